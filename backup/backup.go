@@ -0,0 +1,373 @@
+// Package backup takes differential snapshots of DNS zones through an
+// opusdns.Client, on demand or on a cron-like schedule, and prunes old
+// snapshots according to a retention policy.
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+)
+
+// Snapshot is a point-in-time copy of a zone.
+type Snapshot struct {
+	// ID is the snapshot identifier, derived from TakenAt.
+	ID string `json:"id"`
+
+	// Zone is the zone name.
+	Zone string `json:"zone"`
+
+	// TakenAt is when the snapshot was taken.
+	TakenAt time.Time `json:"taken_at"`
+
+	// UpdatedOn is the zone's UpdatedOn at the time of the snapshot.
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+
+	// Serial is the SOA serial at the time of the snapshot (0 if unknown).
+	Serial uint32 `json:"serial"`
+
+	// Checksum is a digest of the zone's RRsets, used to detect changes when
+	// the serial is unavailable.
+	Checksum string `json:"checksum"`
+
+	// RRSets contains the zone's records.
+	RRSets []models.RRSet `json:"rrsets"`
+
+	// DNSSECStatus, Kind, Transfer and VanityNameserverSetID are the zone's
+	// settings at the time of the snapshot, used to re-create it.
+	DNSSECStatus          models.DNSSECStatus           `json:"dnssec_status,omitempty"`
+	Kind                  models.ZoneKind               `json:"kind,omitempty"`
+	Transfer              *models.ZoneTransferSettings  `json:"transfer,omitempty"`
+	VanityNameserverSetID *models.VanityNameserverSetID `json:"vanity_nameserver_set_id,omitempty"`
+}
+
+// CreateRequest returns the request that re-creates the snapshot's zone with
+// its settings but without records, which are restored separately.
+func (s *Snapshot) CreateRequest() *models.ZoneCreateRequest {
+	return &models.ZoneCreateRequest{
+		Name:                  s.Zone,
+		DNSSECStatus:          s.DNSSECStatus,
+		Kind:                  s.Kind,
+		Transfer:              s.Transfer,
+		VanityNameserverSetID: s.VanityNameserverSetID,
+	}
+}
+
+// Retention controls which snapshots are kept. The newest snapshot of a zone
+// is always kept. Zero values disable the respective limit.
+type Retention struct {
+	// KeepLast keeps at most this many snapshots per zone.
+	KeepLast int
+
+	// MaxAge deletes snapshots older than this.
+	MaxAge time.Duration
+}
+
+// Result summarizes one backup run.
+type Result struct {
+	// Started is when the run started.
+	Started time.Time
+
+	// Saved lists the zones for which a new snapshot was written.
+	Saved []string
+
+	// Unchanged lists the zones skipped because they did not change since their latest snapshot.
+	Unchanged []string
+
+	// Pruned is the number of snapshots deleted by the retention policy.
+	Pruned int
+
+	// Errors maps zone names to the error that prevented their backup.
+	Errors map[string]error
+}
+
+// Err returns the per-zone errors joined into one, or nil if every zone succeeded.
+func (r *Result) Err() error {
+	zones := make([]string, 0, len(r.Errors))
+	for zone := range r.Errors {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	errs := make([]error, len(zones))
+	for i, zone := range zones {
+		errs[i] = fmt.Errorf("%s: %w", zone, r.Errors[zone])
+	}
+	return errors.Join(errs...)
+}
+
+// Option configures Run and Schedule.
+type Option func(*config)
+
+type config struct {
+	retention Retention
+	filter    func(models.Zone) bool
+	listOpts  *models.ListZonesOptions
+	location  *time.Location
+	onResult  func(*Result)
+	onError   func(error)
+	now       func() time.Time
+}
+
+// WithRetention sets the retention policy applied after each run.
+func WithRetention(r Retention) Option {
+	return func(c *config) {
+		c.retention = r
+	}
+}
+
+// WithZoneFilter restricts backups to zones for which fn returns true.
+func WithZoneFilter(fn func(models.Zone) bool) Option {
+	return func(c *config) {
+		c.filter = fn
+	}
+}
+
+// WithListOptions sets the filters used when listing zones (such as a search or tag).
+func WithListOptions(opts *models.ListZonesOptions) Option {
+	return func(c *config) {
+		c.listOpts = opts
+	}
+}
+
+// WithLocation sets the time zone the cron schedule is evaluated in (defaults to time.Local).
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) {
+		c.location = loc
+	}
+}
+
+// WithResultHandler sets a callback invoked with the result of each scheduled run.
+func WithResultHandler(fn func(*Result)) Option {
+	return func(c *config) {
+		c.onResult = fn
+	}
+}
+
+// WithErrorHandler sets a callback invoked when a scheduled run fails or
+// some zones could not be backed up. Schedule keeps going after errors.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{location: time.Local, now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Schedule runs a backup every time the cron expression fires (see ParseCron)
+// until ctx is cancelled. Each run snapshots only zones that changed since
+// their latest stored snapshot and then applies the retention policy.
+// Schedule returns ctx.Err() when stopped, or an error if cronExpr is invalid.
+func Schedule(ctx context.Context, client *opusdns.Client, cronExpr string, store Store, opts ...Option) error {
+	cron, err := ParseCron(cronExpr)
+	if err != nil {
+		return err
+	}
+	cfg := newConfig(opts)
+
+	for {
+		now := cfg.now().In(cfg.location)
+		next := cron.Next(now)
+		if next.IsZero() {
+			return &opusdns.ValidationError{Field: "cron", Message: "schedule never fires", Value: cronExpr}
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		result, err := run(ctx, client, store, cfg)
+		if err == nil {
+			err = result.Err()
+		}
+		if err != nil && cfg.onError != nil && ctx.Err() == nil {
+			cfg.onError(err)
+		}
+		if result != nil && cfg.onResult != nil {
+			cfg.onResult(result)
+		}
+	}
+}
+
+// Run performs a single differential backup of all zones. Zones whose
+// UpdatedOn is not newer than their latest snapshot are skipped without
+// fetching their records; fetched zones are only saved if their SOA serial or
+// records differ. Failures for individual zones are recorded in
+// Result.Errors; the returned error is reserved for failures that stop the
+// whole run, such as listing zones.
+func Run(ctx context.Context, client *opusdns.Client, store Store, opts ...Option) (*Result, error) {
+	return run(ctx, client, store, newConfig(opts))
+}
+
+func run(ctx context.Context, client *opusdns.Client, store Store, cfg *config) (*Result, error) {
+	result := &Result{Started: cfg.now(), Errors: map[string]error{}}
+
+	zones, err := client.DNS.ListZones(ctx, cfg.listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range zones {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if cfg.filter != nil && !cfg.filter(zone) {
+			continue
+		}
+
+		name := strings.TrimSuffix(zone.Name, ".")
+		saved, err := backupZone(ctx, client, store, zone, name, result.Started)
+		if err != nil {
+			result.Errors[name] = err
+			continue
+		}
+		if saved {
+			result.Saved = append(result.Saved, name)
+		} else {
+			result.Unchanged = append(result.Unchanged, name)
+		}
+
+		pruned, err := prune(ctx, store, name, cfg.retention, result.Started)
+		result.Pruned += pruned
+		if err != nil {
+			result.Errors[name] = err
+		}
+	}
+
+	return result, nil
+}
+
+// backupZone snapshots a zone if it changed since its latest snapshot and reports whether it did.
+func backupZone(ctx context.Context, client *opusdns.Client, store Store, listed models.Zone, name string, now time.Time) (bool, error) {
+	latest, err := store.Latest(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && listed.UpdatedOn != nil && latest.UpdatedOn != nil && !listed.UpdatedOn.After(*latest.UpdatedOn) {
+		return false, nil
+	}
+
+	zone, err := client.DNS.GetZone(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	snapshot, err := newSnapshot(zone, name, now)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && (snapshot.Checksum == latest.Checksum || (snapshot.Serial != 0 && snapshot.Serial == latest.Serial)) {
+		return false, nil
+	}
+	if latest != nil && latest.ID == snapshot.ID {
+		return false, fmt.Errorf("backup: snapshot %s already exists", snapshot.ID)
+	}
+
+	if err := store.Save(ctx, snapshot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newSnapshot builds a snapshot of zone taken at now.
+func newSnapshot(zone *models.Zone, name string, now time.Time) (*Snapshot, error) {
+	rrsets := append([]models.RRSet(nil), zone.RRSets...)
+	sort.Slice(rrsets, func(i, j int) bool {
+		if rrsets[i].Name != rrsets[j].Name {
+			return rrsets[i].Name < rrsets[j].Name
+		}
+		return rrsets[i].Type < rrsets[j].Type
+	})
+
+	data, err := json.Marshal(rrsets)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to encode zone %s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+
+	takenAt := now.UTC().Truncate(time.Second)
+	return &Snapshot{
+		ID:        takenAt.Format(snapshotIDLayout),
+		Zone:      name,
+		TakenAt:   takenAt,
+		UpdatedOn: zone.UpdatedOn,
+		Serial:    soaSerial(rrsets),
+		Checksum:  hex.EncodeToString(sum[:]),
+		RRSets:    rrsets,
+
+		DNSSECStatus:          zone.DNSSECStatus,
+		Kind:                  zone.Kind,
+		Transfer:              zone.Transfer,
+		VanityNameserverSetID: zone.VanityNameserverSetID,
+	}, nil
+}
+
+// soaSerial extracts the serial from the apex SOA record, or 0 if there is none.
+func soaSerial(rrsets []models.RRSet) uint32 {
+	for _, rrset := range rrsets {
+		if rrset.Type != models.RRSetTypeSOA || len(rrset.Records) == 0 {
+			continue
+		}
+		// SOA rdata: mname rname serial refresh retry expire minimum
+		fields := strings.Fields(rrset.Records[0].RData)
+		if len(fields) < 3 {
+			return 0
+		}
+		serial, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return 0
+		}
+		return uint32(serial)
+	}
+	return 0
+}
+
+// prune deletes snapshots of zone that fall outside the retention policy.
+func prune(ctx context.Context, store Store, zone string, retention Retention, now time.Time) (int, error) {
+	if retention.KeepLast <= 0 && retention.MaxAge <= 0 {
+		return 0, nil
+	}
+
+	infos, err := store.List(ctx, zone)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for i, info := range infos {
+		if i == 0 {
+			continue
+		}
+		tooMany := retention.KeepLast > 0 && i >= retention.KeepLast
+		tooOld := retention.MaxAge > 0 && now.Sub(info.TakenAt) > retention.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := store.Delete(ctx, zone, info.ID); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+
+	return pruned, nil
+}
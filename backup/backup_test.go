@@ -0,0 +1,190 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zoneServer fakes the zone list and zone detail endpoints.
+type zoneServer struct {
+	mu        sync.Mutex
+	updatedOn time.Time
+	serial    int
+	www       string
+	fetches   int
+}
+
+func (z *zoneServer) set(updatedOn time.Time, serial int, www string) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.updatedOn, z.serial, z.www = updatedOn, serial, www
+}
+
+func (z *zoneServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		z.mu.Lock()
+		defer z.mu.Unlock()
+
+		updatedOn := z.updatedOn
+		switch r.URL.Path {
+		case "/v1/dns":
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: []models.Zone{
+				{Name: "example.com", UpdatedOn: &updatedOn},
+				{Name: "skip.com", UpdatedOn: &updatedOn},
+			}})
+		case "/v1/dns/example.com":
+			z.fetches++
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", DNSSECStatus: models.DNSSECStatusEnabled, UpdatedOn: &updatedOn, RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeSOA, TTL: 3600, Records: []models.RecordData{{RData: fmt.Sprintf("ns1.opusdns.net. hostmaster.example.com. %d 7200 900 1209600 3600", z.serial)}}},
+				{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordData{{RData: z.www}}},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	fake := &zoneServer{}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	day := time.Date(2024, time.January, 1, 3, 0, 0, 0, time.UTC)
+	now := day
+
+	runAt := func(at time.Time) *Result {
+		now = at
+		result, err := Run(ctx, client, store,
+			WithZoneFilter(func(z models.Zone) bool { return z.Name != "skip.com" }),
+			WithRetention(Retention{KeepLast: 2}),
+			func(c *config) { c.now = func() time.Time { return now } },
+		)
+		require.NoError(t, err)
+		require.NoError(t, result.Err())
+		return result
+	}
+
+	fake.set(day, 1, "192.0.2.1")
+	result := runAt(day)
+	assert.Equal(t, []string{"example.com"}, result.Saved)
+	assert.Equal(t, 1, fake.fetches)
+
+	// UpdatedOn unchanged: skipped without fetching records.
+	result = runAt(day.Add(24 * time.Hour))
+	assert.Equal(t, []string{"example.com"}, result.Unchanged)
+	assert.Equal(t, 1, fake.fetches)
+
+	// UpdatedOn bumped but serial and records unchanged: fetched, not saved.
+	fake.set(day.Add(time.Hour), 1, "192.0.2.1")
+	result = runAt(day.Add(48 * time.Hour))
+	assert.Equal(t, []string{"example.com"}, result.Unchanged)
+	assert.Equal(t, 2, fake.fetches)
+
+	// Records changed: saved; older snapshots pruned down to KeepLast.
+	fake.set(day.Add(72*time.Hour), 2, "192.0.2.2")
+	result = runAt(day.Add(72 * time.Hour))
+	assert.Equal(t, []string{"example.com"}, result.Saved)
+
+	fake.set(day.Add(96*time.Hour), 3, "192.0.2.3")
+	result = runAt(day.Add(96 * time.Hour))
+	assert.Equal(t, []string{"example.com"}, result.Saved)
+	assert.Equal(t, 1, result.Pruned)
+
+	infos, err := store.List(ctx, "example.com")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+
+	latest, err := store.Latest(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), latest.Serial)
+	assert.Equal(t, "192.0.2.3", latest.RRSets[1].Records[0].RData)
+	assert.Equal(t, &models.ZoneCreateRequest{Name: "example.com", DNSSECStatus: models.DNSSECStatusEnabled}, latest.CreateRequest())
+
+	skipped, err := store.List(ctx, "skip.com")
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+}
+
+func TestPrune_MaxAge(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, age := range []time.Duration{40 * 24 * time.Hour, 20 * 24 * time.Hour, 10 * 24 * time.Hour} {
+		takenAt := now.Add(-age)
+		require.NoError(t, store.Save(ctx, &Snapshot{ID: takenAt.Format(snapshotIDLayout), Zone: "example.com", TakenAt: takenAt}))
+	}
+
+	pruned, err := prune(ctx, store, "example.com", Retention{MaxAge: 30 * 24 * time.Hour}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	// The newest snapshot survives even when it is past MaxAge.
+	pruned, err = prune(ctx, store, "example.com", Retention{MaxAge: time.Hour}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	infos, err := store.List(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Len(t, infos, 1)
+}
+
+func TestSchedule(t *testing.T) {
+	t.Run("rejects invalid cron", func(t *testing.T) {
+		err := Schedule(context.Background(), nil, "not a cron", NewMemoryStore())
+		assert.True(t, opusdns.IsValidationError(err))
+	})
+
+	t.Run("runs on schedule until cancelled", func(t *testing.T) {
+		fake := &zoneServer{}
+		fake.set(time.Now(), 1, "192.0.2.1")
+		server := httptest.NewServer(fake.handler(t))
+		defer server.Close()
+
+		client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Pretend it is a few milliseconds before the next minute so the first run fires quickly.
+		clock := time.Now().Truncate(time.Minute).Add(time.Minute - 10*time.Millisecond)
+		results := make(chan *Result, 1)
+
+		errc := make(chan error, 1)
+		go func() {
+			errc <- Schedule(ctx, client, "* * * * *", NewMemoryStore(),
+				WithZoneFilter(func(z models.Zone) bool { return z.Name == "example.com" }),
+				WithResultHandler(func(r *Result) {
+					results <- r
+					cancel()
+				}),
+				func(c *config) { c.now = func() time.Time { return clock } },
+			)
+		}()
+
+		select {
+		case result := <-results:
+			assert.Equal(t, []string{"example.com"}, result.Saved)
+		case <-time.After(5 * time.Second):
+			t.Fatal("scheduled run did not fire")
+		}
+		assert.ErrorIs(t, <-errc, context.Canceled)
+	})
+}
@@ -0,0 +1,53 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Next(t *testing.T) {
+	base := time.Date(2024, time.March, 15, 10, 30, 20, 0, time.UTC) // a Friday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.March, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.March, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, time.March, 16, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 2 * * 1-5", time.Date(2024, time.March, 18, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 20th, or Mondays).
+		{"0 0 20 * 1", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)},
+		{"0,30 9-17/4 * * *", time.Date(2024, time.March, 15, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cron.Next(base))
+		})
+	}
+
+	t.Run("impossible date", func(t *testing.T) {
+		cron, err := ParseCron("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, cron.Next(base).IsZero())
+	})
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@sometimes"} {
+		_, err := ParseCron(expr)
+		assert.True(t, opusdns.IsValidationError(err), expr)
+	}
+}
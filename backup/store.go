@@ -0,0 +1,203 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// snapshotIDLayout formats a snapshot's TakenAt as its ID. IDs sort chronologically.
+const snapshotIDLayout = "20060102T150405Z"
+
+// SnapshotInfo identifies a stored snapshot without its records.
+type SnapshotInfo struct {
+	// ID is the snapshot identifier, unique per zone.
+	ID string `json:"id"`
+
+	// Zone is the zone name.
+	Zone string `json:"zone"`
+
+	// TakenAt is when the snapshot was taken.
+	TakenAt time.Time `json:"taken_at"`
+}
+
+// Store persists zone snapshots.
+type Store interface {
+	// Save stores a snapshot.
+	Save(ctx context.Context, snapshot *Snapshot) error
+
+	// Latest returns the most recent snapshot of a zone, or nil if there is none.
+	Latest(ctx context.Context, zone string) (*Snapshot, error)
+
+	// List returns the snapshots of a zone, newest first.
+	List(ctx context.Context, zone string) ([]SnapshotInfo, error)
+
+	// Delete removes a snapshot.
+	Delete(ctx context.Context, zone, id string) error
+}
+
+// DirStore is a Store that keeps each snapshot as a JSON file at
+// <dir>/<zone>/<id>.json.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a DirStore rooted at dir. The directory is created on first save.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Save implements Store.
+func (s *DirStore) Save(_ context.Context, snapshot *Snapshot) error {
+	zoneDir := filepath.Join(s.dir, snapshot.Zone)
+	if err := os.MkdirAll(zoneDir, 0o750); err != nil {
+		return fmt.Errorf("backup: failed to create %s: %w", zoneDir, err)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("backup: failed to encode snapshot: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated snapshot behind.
+	path := filepath.Join(zoneDir, snapshot.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("backup: failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("backup: failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Latest implements Store.
+func (s *DirStore) Latest(ctx context.Context, zone string) (*Snapshot, error) {
+	infos, err := s.List(ctx, zone)
+	if err != nil || len(infos) == 0 {
+		return nil, err
+	}
+	return s.Load(zone, infos[0].ID)
+}
+
+// Load reads a single snapshot.
+func (s *DirStore) Load(zone, id string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, zone, id+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("backup: failed to decode snapshot %s/%s: %w", zone, id, err)
+	}
+	return &snapshot, nil
+}
+
+// List implements Store.
+func (s *DirStore) List(_ context.Context, zone string) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, zone))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to list snapshots: %w", err)
+	}
+
+	var infos []SnapshotInfo
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		takenAt, err := time.Parse(snapshotIDLayout, id)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, SnapshotInfo{ID: id, Zone: zone, TakenAt: takenAt})
+	}
+
+	sortNewestFirst(infos)
+	return infos, nil
+}
+
+// Delete implements Store.
+func (s *DirStore) Delete(_ context.Context, zone, id string) error {
+	if err := os.Remove(filepath.Join(s.dir, zone, id+".json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("backup: failed to delete snapshot: %w", err)
+	}
+	return nil
+}
+
+// MemoryStore is an in-memory Store, useful for tests and short-lived processes.
+type MemoryStore struct {
+	mu        sync.Mutex
+	snapshots map[string]map[string]*Snapshot
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snapshots: map[string]map[string]*Snapshot{}}
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(_ context.Context, snapshot *Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshots[snapshot.Zone] == nil {
+		s.snapshots[snapshot.Zone] = map[string]*Snapshot{}
+	}
+	s.snapshots[snapshot.Zone][snapshot.ID] = snapshot
+	return nil
+}
+
+// Latest implements Store.
+func (s *MemoryStore) Latest(ctx context.Context, zone string) (*Snapshot, error) {
+	infos, _ := s.List(ctx, zone)
+	if len(infos) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshots[zone][infos[0].ID], nil
+}
+
+// List implements Store.
+func (s *MemoryStore) List(_ context.Context, zone string) ([]SnapshotInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]SnapshotInfo, 0, len(s.snapshots[zone]))
+	for id, snapshot := range s.snapshots[zone] {
+		infos = append(infos, SnapshotInfo{ID: id, Zone: zone, TakenAt: snapshot.TakenAt})
+	}
+
+	sortNewestFirst(infos)
+	return infos, nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(_ context.Context, zone, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.snapshots[zone], id)
+	return nil
+}
+
+// sortNewestFirst orders snapshots by TakenAt, newest first.
+func sortNewestFirst(infos []SnapshotInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].TakenAt.After(infos[j].TakenAt)
+	})
+}
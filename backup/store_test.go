@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]Store{
+		"dir":    NewDirStore(t.TempDir()),
+		"memory": NewMemoryStore(),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			latest, err := store.Latest(ctx, "example.com")
+			require.NoError(t, err)
+			assert.Nil(t, latest)
+
+			for i, day := range []int{1, 3, 2} {
+				takenAt := time.Date(2024, time.January, day, 0, 0, 0, 0, time.UTC)
+				require.NoError(t, store.Save(ctx, &Snapshot{
+					ID:      takenAt.Format(snapshotIDLayout),
+					Zone:    "example.com",
+					TakenAt: takenAt,
+					Serial:  uint32(i + 1),
+					RRSets:  []models.RRSet{{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordData{{RData: "192.0.2.1"}}}},
+				}))
+			}
+
+			infos, err := store.List(ctx, "example.com")
+			require.NoError(t, err)
+			require.Len(t, infos, 3)
+			assert.Equal(t, "20240103T000000Z", infos[0].ID)
+			assert.Equal(t, "20240101T000000Z", infos[2].ID)
+
+			latest, err = store.Latest(ctx, "example.com")
+			require.NoError(t, err)
+			require.NotNil(t, latest)
+			assert.Equal(t, uint32(2), latest.Serial)
+			assert.Equal(t, "192.0.2.1", latest.RRSets[0].Records[0].RData)
+
+			require.NoError(t, store.Delete(ctx, "example.com", infos[0].ID))
+			infos, err = store.List(ctx, "example.com")
+			require.NoError(t, err)
+			assert.Len(t, infos, 2)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+// dashboardURL is where users create API keys.
+const dashboardURL = "https://app.opusdns.com"
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in to OpusDNS",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with an API key created in the dashboard",
+	Long: `Open the OpusDNS dashboard in the browser, read the API key created there
+from the terminal (or take it from --api-key), verify it, and store it in a
+profile (see "opusdns config").
+
+The OpusDNS API authenticates with API keys only; it offers no device-code or
+OAuth flow, so there is no token to refresh. The key is stored in the OS
+keychain unless --keychain=false is given, so it never has to be kept in a
+file or shell history.
+
+The profile is named by --profile, or "default". The first profile becomes
+the current one.
+
+Examples:
+  opusdns auth login
+  opusdns --profile sandbox auth login --api-endpoint https://sandbox.opusdns.com`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := profileName
+		if name == "" {
+			name = "default"
+		}
+
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		p := cfg.Profiles[name]
+		if p == nil {
+			p = &profile{}
+		}
+		if cmd.Flags().Changed("api-endpoint") {
+			p.APIEndpoint, _ = cmd.Flags().GetString("api-endpoint")
+		}
+
+		key := apiKey
+		if key == "" {
+			if noBrowser, _ := cmd.Flags().GetBool("no-browser"); noBrowser || openBrowser(dashboardURL) != nil {
+				fmt.Printf("Create an API key at %s\n", dashboardURL)
+			} else {
+				fmt.Printf("• Opened %s in your browser. Create an API key there.\n", dashboardURL)
+			}
+
+			fmt.Fprint(os.Stderr, "API key: ")
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if key = strings.TrimSpace(line); key == "" {
+				return fmt.Errorf("an API key is required: %v", err)
+			}
+		}
+
+		// Verify the key before storing it
+		apiEndpoint = p.APIEndpoint
+		c, err := opusdns.NewClient(clientOptions(key)...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		ctx, cancel := getContext()
+		defer cancel()
+		credential, err := c.Auth.IntrospectAPIKey(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to verify API key: %w", err)
+		}
+
+		useKeychain, _ := cmd.Flags().GetBool("keychain")
+		if useKeychain {
+			if err := keychainSet(name, key); err != nil {
+				return err
+			}
+			p.APIKey = ""
+		} else {
+			if p.Keychain {
+				_ = keychainDelete(name)
+			}
+			p.APIKey = key
+		}
+		p.Keychain = useKeychain
+
+		if cfg.Profiles == nil {
+			cfg.Profiles = map[string]*profile{}
+		}
+		cfg.Profiles[name] = p
+		if cfg.CurrentProfile == "" {
+			cfg.CurrentProfile = name
+		}
+		if err := saveCLIConfig(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Logged in to organization %s as profile '%s'\n", credential.OrganizationID, name)
+		if credential.ExpiresAt != nil {
+			fmt.Printf("! The API key expires on %s; run 'opusdns auth login' again before then.\n", credential.ExpiresAt.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+// openBrowser opens url in the default browser.
+func openBrowser(url string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	default:
+		return exec.Command("xdg-open", url).Start()
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+
+	authCmd.AddCommand(authLoginCmd)
+	authLoginCmd.Flags().String("api-endpoint", "", "API endpoint of the profile")
+	authLoginCmd.Flags().Bool("keychain", runtime.GOOS == "darwin" || runtime.GOOS == "linux", "Store the API key in the OS keychain")
+	authLoginCmd.Flags().Bool("no-browser", false, "Print the dashboard URL instead of opening it")
+}
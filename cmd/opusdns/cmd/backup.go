@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/backup"
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+var zonesBackupCmd = &cobra.Command{
+	Use:   "backup [zone-name]",
+	Short: "Back up zones to a directory",
+	Long: `Save a snapshot of one zone, or of all zones with --all, to a directory.
+
+Each snapshot is written as <dir>/<zone>/<id>.json, holding the zone's
+settings and RRsets, next to a zone file <id>.zone for reading and diffing.
+Snapshot IDs are the UTC time they were taken, so they sort chronologically.
+Zones that did not change since their latest snapshot are skipped.
+
+Examples:
+  opusdns dns backup example.com -d ./backups
+  opusdns dns backup --all -d ./backups`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		dir, _ := cmd.Flags().GetString("dir")
+		if all == (len(args) == 1) {
+			return errors.New("specify either a zone name or --all")
+		}
+
+		var opts []backup.Option
+		if !all {
+			zoneName := strings.TrimSuffix(args[0], ".")
+			opts = append(opts,
+				backup.WithListOptions(&models.ListZonesOptions{Search: zoneName}),
+				backup.WithZoneFilter(func(z models.Zone) bool {
+					return strings.EqualFold(strings.TrimSuffix(z.Name, "."), zoneName)
+				}),
+			)
+		}
+
+		ctx, cancel := getContext()
+		defer cancel()
+
+		store := backup.NewDirStore(dir)
+		result, err := backup.Run(ctx, getClient(), store, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to back up zones: %w", err)
+		}
+		if !all && len(result.Saved)+len(result.Unchanged)+len(result.Errors) == 0 {
+			return fmt.Errorf("zone '%s' not found", args[0])
+		}
+
+		for _, zoneName := range result.Saved {
+			snapshot, err := store.Latest(ctx, zoneName)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(dir, zoneName, snapshot.ID+".zone")
+			if err := writeSnapshotZoneFile(path, snapshot); err != nil {
+				return err
+			}
+			fmt.Printf("✓ %s: saved %s\n", zoneName, filepath.Join(dir, zoneName, snapshot.ID+".json"))
+		}
+		for _, zoneName := range result.Unchanged {
+			fmt.Printf("• %s: unchanged since the latest backup\n", zoneName)
+		}
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("failed to back up %d zone(s): %w", len(result.Errors), err)
+		}
+		return nil
+	},
+}
+
+// writeSnapshotZoneFile writes the records of a snapshot as a zone file at path.
+func writeSnapshotZoneFile(path string, snapshot *backup.Snapshot) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	fmt.Fprintf(f, "; Backup %s of %s taken at %s (serial %d)\n", snapshot.ID, snapshot.Zone, snapshot.TakenAt.Format(time.RFC3339), snapshot.Serial)
+	writeZoneRecords(f, opusdns.RRSetsToRecords(snapshot.RRSets))
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+var zonesRestoreCmd = &cobra.Command{
+	Use:   "restore <zone-name>",
+	Short: "Restore a zone from a backup",
+	Long: `Restore a zone from a snapshot written by "opusdns dns backup". If the
+zone no longer exists it is re-created with the settings from the snapshot.
+The records are then changed to match the snapshot as a single atomic patch.
+
+The SOA record is managed by OpusDNS and is not restored. Use --diff to only
+show what would change.
+
+Examples:
+  opusdns dns restore example.com -f backups/example.com/20240101T030000Z.json --diff
+  opusdns dns restore example.com -f backups/example.com/20240101T030000Z.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zoneName := strings.TrimSuffix(args[0], ".")
+		file, _ := cmd.Flags().GetString("file")
+		diffOnly, _ := cmd.Flags().GetBool("diff")
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		var snapshot backup.Snapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if !strings.EqualFold(strings.TrimSuffix(snapshot.Zone, "."), zoneName) {
+			return fmt.Errorf("%s is a backup of zone '%s', not '%s'", file, snapshot.Zone, zoneName)
+		}
+
+		ctx, cancel := getContext()
+		zone, err := getClient().DNS.GetZone(ctx, zoneName)
+		cancel()
+		missing := opusdns.IsNotFoundError(err)
+		if err != nil && !missing {
+			return fmt.Errorf("failed to get zone: %w", err)
+		}
+
+		var current []models.Record
+		if !missing {
+			current = withoutSOA(opusdns.RRSetsToRecords(zone.RRSets))
+		}
+		ops := opusdns.DiffRecords(current, withoutSOA(opusdns.RRSetsToRecords(snapshot.RRSets)))
+
+		if missing {
+			fmt.Printf("Zone '%s' does not exist and will be created from backup %s.\n", zoneName, snapshot.ID)
+		}
+		if len(ops) == 0 {
+			fmt.Println("• No record changes")
+			if !missing {
+				return nil
+			}
+		} else {
+			fmt.Printf("Changes to zone '%s':\n", zoneName)
+			printRecordOps(ops)
+		}
+		if diffOnly {
+			return nil
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Print("Type 'yes' to restore: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		ctx, cancel = getContext()
+		defer cancel()
+
+		if missing {
+			if _, err := getClient().DNS.CreateZone(ctx, snapshot.CreateRequest()); err != nil {
+				return fmt.Errorf("failed to create zone: %w", err)
+			}
+			fmt.Printf("✓ Zone '%s' created\n", zoneName)
+
+			// Start from the records the API created with the zone, such as the apex NS.
+			zone, err := getClient().DNS.GetZone(ctx, zoneName)
+			if err != nil {
+				return fmt.Errorf("failed to get zone: %w", err)
+			}
+			ops = opusdns.DiffRecords(withoutSOA(opusdns.RRSetsToRecords(zone.RRSets)), withoutSOA(opusdns.RRSetsToRecords(snapshot.RRSets)))
+			if len(ops) == 0 {
+				return nil
+			}
+		}
+
+		allowProtected, _ := cmd.Flags().GetBool("allow-protected")
+		return applyRecordOps(ctx, zoneName, ops, allowProtected)
+	},
+}
+
+// withoutSOA returns records without SOA records.
+func withoutSOA(records []models.Record) []models.Record {
+	var filtered []models.Record
+	for _, r := range records {
+		if r.Type != models.RRSetTypeSOA {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func init() {
+	zonesCmd.AddCommand(zonesBackupCmd)
+	zonesBackupCmd.Flags().Bool("all", false, "Back up all zones")
+	zonesBackupCmd.Flags().StringP("dir", "d", "./backups", "Directory to write backups to")
+
+	zonesCmd.AddCommand(zonesRestoreCmd)
+	zonesRestoreCmd.Flags().StringP("file", "f", "", "Snapshot JSON file to restore (required)")
+	zonesRestoreCmd.Flags().Bool("diff", false, "Only show the changes a restore would make")
+	zonesRestoreCmd.Flags().Bool("force", false, "Restore without confirmation")
+	zonesRestoreCmd.Flags().Bool("allow-protected", false, "Allow changing or removing protected records")
+	_ = zonesRestoreCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/spf13/cobra"
+)
+
+// completionPageSize is the number of candidates fetched for a completion.
+const completionPageSize = 100
+
+// The functions below complete the first argument of a command from the
+// API. Completion is best effort: when no client can be created or the
+// request fails, nothing is suggested.
+
+// completeZoneNames suggests the names of the user's zones.
+func completeZoneNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || initClient() != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := getContext()
+	defer cancel()
+
+	resp, err := getClient().DNS.ListZonesPage(ctx, &models.ListZonesOptions{
+		PageSize:  completionPageSize,
+		SortBy:    models.ZoneSortByName,
+		SortOrder: models.SortAsc,
+		Search:    toComplete,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, z := range resp.Results {
+		if name := strings.TrimSuffix(z.Name, "."); strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDomainNames suggests the names of the user's domains.
+func completeDomainNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || initClient() != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := getContext()
+	defer cancel()
+
+	resp, err := getClient().Domains.ListDomainsPage(ctx, &models.ListDomainsOptions{
+		PageSize:  completionPageSize,
+		SortBy:    models.DomainSortByName,
+		SortOrder: models.SortAsc,
+		Search:    toComplete,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, d := range resp.Results {
+		if strings.HasPrefix(d.Name, toComplete) {
+			names = append(names, d.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContactIDs suggests the IDs of the user's contacts, described by
+// name and email.
+func completeContactIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || initClient() != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := getContext()
+	defer cancel()
+
+	resp, err := getClient().Contacts.ListContactsPage(ctx, &models.ListContactsOptions{
+		PageSize:  completionPageSize,
+		SortBy:    models.ContactSortByLastName,
+		SortOrder: models.SortAsc,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, c := range resp.Results {
+		if id := string(c.ContactID); strings.HasPrefix(id, toComplete) {
+			ids = append(ids, fmt.Sprintf("%s\t%s %s <%s>", id, c.FirstName, c.LastName, c.Email))
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	for _, c := range []*cobra.Command{
+		zonesGetCmd, zonesDeleteCmd, zonesApplyCmd, zonesEditCmd, zonesSummaryCmd,
+		recordsListCmd, recordsUpsertCmd, recordsDeleteCmd, recordsApplyCmd,
+	} {
+		c.ValidArgsFunction = completeZoneNames
+	}
+	for _, c := range []*cobra.Command{
+		domainsGetCmd, domainsRenewCmd, domainsUpdateCmd, domainsCancelTransferCmd, domainsTransferStatusCmd, domainsDelegationCmd,
+	} {
+		c.ValidArgsFunction = completeDomainNames
+	}
+	for _, c := range []*cobra.Command{
+		contactsGetCmd, contactsDeleteCmd, contactsVerifyRequestCmd, contactsVerifyStatusCmd,
+	} {
+		c.ValidArgsFunction = completeContactIDs
+	}
+}
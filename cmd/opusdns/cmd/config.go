@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// keychainService is the service name API keys are stored under in the OS keychain.
+const keychainService = "opusdns"
+
+// cliConfig is the layout of the CLI configuration file.
+type cliConfig struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile is a named set of credentials and settings.
+type profile struct {
+	APIKey      string `yaml:"api_key,omitempty"`
+	APIEndpoint string `yaml:"api_endpoint,omitempty"`
+
+	// Keychain means the API key is stored in the OS keychain instead of the file.
+	Keychain bool `yaml:"keychain,omitempty"`
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI profiles and credentials",
+	Long: `Manage named profiles in the configuration file, such as one per
+environment (prod, sandbox) or organization.
+
+The file is $XDG_CONFIG_HOME/opusdns/config.yaml, or ~/.config/opusdns/config.yaml.
+The API key is taken from --api-key, the profile named by --profile,
+OPUSDNS_API_KEY, or the profile named by OPUSDNS_PROFILE or set as current, in
+that order.
+
+API keys are stored in the file unless --keychain is given, which stores them
+in the macOS keychain (security) or the Secret Service on Linux (secret-tool).`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		if len(cfg.Profiles) == 0 {
+			fmt.Println("No profiles configured. Add one with: opusdns config set <profile> --api-key <key>")
+			return nil
+		}
+
+		current := activeProfileName(cfg)
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			p := cfg.Profiles[name]
+			marker := "•"
+			if name == current {
+				marker = "*"
+			}
+			key := maskAPIKey(p.APIKey)
+			if p.Keychain {
+				key = "(keychain)"
+			}
+			fmt.Printf("  %s %s  %s", marker, name, key)
+			if p.APIEndpoint != "" {
+				fmt.Printf("  %s", p.APIEndpoint)
+			}
+			fmt.Println()
+		}
+		if current != "" {
+			fmt.Println("\n* active profile")
+		}
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <profile>",
+	Short: "Create or update a profile",
+	Long: `Create or update a profile. Without --api-key the key is read from stdin.
+The first profile becomes the current one.
+
+Examples:
+  opusdns config set prod --api-key opk_... --keychain
+  opusdns config set sandbox --api-endpoint https://sandbox.opusdns.com < sandbox-key.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+
+		p := cfg.Profiles[name]
+		if p == nil {
+			p = &profile{}
+		}
+
+		if cmd.Flags().Changed("api-endpoint") {
+			p.APIEndpoint, _ = cmd.Flags().GetString("api-endpoint")
+		}
+
+		useKeychain := p.Keychain
+		if cmd.Flags().Changed("keychain") {
+			useKeychain, _ = cmd.Flags().GetBool("keychain")
+		}
+
+		key, _ := cmd.Flags().GetString("api-key")
+		if key == "" && p.Keychain != useKeychain {
+			// Move the stored key to the new location.
+			key = p.APIKey
+			if p.Keychain {
+				if key, err = keychainGet(name); err != nil {
+					return err
+				}
+			}
+		}
+		if key == "" && p.APIKey == "" && !p.Keychain {
+			fmt.Fprint(os.Stderr, "API key: ")
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if key = strings.TrimSpace(line); key == "" {
+				return fmt.Errorf("an API key is required: %v", err)
+			}
+		}
+
+		if key != "" {
+			if useKeychain {
+				if err := keychainSet(name, key); err != nil {
+					return err
+				}
+				p.APIKey = ""
+			} else {
+				p.APIKey = key
+			}
+		}
+		if p.Keychain && !useKeychain {
+			_ = keychainDelete(name)
+		}
+		p.Keychain = useKeychain
+
+		if cfg.Profiles == nil {
+			cfg.Profiles = map[string]*profile{}
+		}
+		cfg.Profiles[name] = p
+		if cfg.CurrentProfile == "" {
+			cfg.CurrentProfile = name
+		}
+
+		if err := saveCLIConfig(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Profile '%s' saved\n", name)
+		return nil
+	},
+}
+
+var configUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Set the current profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Profiles[args[0]] == nil {
+			return fmt.Errorf("profile '%s' does not exist", args[0])
+		}
+
+		cfg.CurrentProfile = args[0]
+		if err := saveCLIConfig(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Using profile '%s'\n", args[0])
+		return nil
+	},
+}
+
+var configDeleteCmd = &cobra.Command{
+	Use:   "delete <profile>",
+	Short: "Delete a profile and its stored API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		p := cfg.Profiles[name]
+		if p == nil {
+			return fmt.Errorf("profile '%s' does not exist", name)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Are you sure you want to delete profile '%s'?\n", name)
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if p.Keychain {
+			if err := keychainDelete(name); err != nil {
+				fmt.Printf("! Could not remove the API key from the keychain: %v\n", err)
+			}
+		}
+		delete(cfg.Profiles, name)
+		if cfg.CurrentProfile == name {
+			cfg.CurrentProfile = ""
+		}
+
+		if err := saveCLIConfig(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Profile '%s' deleted\n", name)
+		return nil
+	},
+}
+
+// cliConfigPath returns the path of the configuration file.
+func cliConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "opusdns", "config.yaml"), nil
+}
+
+// loadCLIConfig reads the configuration file. A missing file is an empty configuration.
+func loadCLIConfig() (*cliConfig, error) {
+	path, err := cliConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &cliConfig{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// saveCLIConfig writes the configuration file, readable only by the user.
+func saveCLIConfig(cfg *cliConfig) error {
+	path, err := cliConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to format config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// activeProfileName returns the profile selected by --profile,
+// OPUSDNS_PROFILE or the configuration file, in that order.
+func activeProfileName(cfg *cliConfig) string {
+	if profileName != "" {
+		return profileName
+	}
+	if name := os.Getenv("OPUSDNS_PROFILE"); name != "" {
+		return name
+	}
+	return cfg.CurrentProfile
+}
+
+// resolveProfile returns the active profile with its API key filled in from
+// the keychain if needed, or nil if no profile is selected.
+func resolveProfile() (*profile, error) {
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+	name := activeProfileName(cfg)
+	if name == "" {
+		return nil, nil
+	}
+
+	p := cfg.Profiles[name]
+	if p == nil {
+		return nil, fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if p.Keychain {
+		if p.APIKey, err = keychainGet(name); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// keychainSet stores the API key of a profile in the OS keychain.
+func keychainSet(name, key string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("security", "add-generic-password", "-U", "-s", keychainService, "-a", name, "-w", key)
+	case "linux":
+		c = exec.Command("secret-tool", "store", "--label=OpusDNS API key ("+name+")", "service", keychainService, "profile", name)
+		c.Stdin = strings.NewReader(key)
+	default:
+		return fmt.Errorf("keychain storage is not supported on %s", runtime.GOOS)
+	}
+	if out, err := c.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return fmt.Errorf("failed to store API key in keychain: %w", err)
+	}
+	return nil
+}
+
+// keychainGet reads the API key of a profile from the OS keychain.
+func keychainGet(name string) (string, error) {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", name, "-w")
+	case "linux":
+		c = exec.Command("secret-tool", "lookup", "service", keychainService, "profile", name)
+	default:
+		return "", fmt.Errorf("keychain storage is not supported on %s", runtime.GOOS)
+	}
+	out, err := c.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key of profile '%s' from keychain: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// keychainDelete removes the API key of a profile from the OS keychain.
+func keychainDelete(name string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", name)
+	case "linux":
+		c = exec.Command("secret-tool", "clear", "service", keychainService, "profile", name)
+	default:
+		return fmt.Errorf("keychain storage is not supported on %s", runtime.GOOS)
+	}
+	return c.Run()
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+
+	configCmd.AddCommand(configListCmd)
+
+	configCmd.AddCommand(configSetCmd)
+	configSetCmd.Flags().String("api-key", "", "API key of the profile (read from stdin if omitted)")
+	configSetCmd.Flags().String("api-endpoint", "", "API endpoint of the profile")
+	configSetCmd.Flags().Bool("keychain", false, "Store the API key in the OS keychain")
+
+	configCmd.AddCommand(configUseCmd)
+
+	configCmd.AddCommand(configDeleteCmd)
+	configDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/opusdns/opusdns-go-client/models"
 	"github.com/spf13/cobra"
@@ -11,7 +12,7 @@ import (
 var contactsCmd = &cobra.Command{
 	Use:   "contacts",
 	Short: "Manage contacts",
-	Long:  `List, create, delete, and verify contacts for domain registrations.`,
+	Long:  `List, create, delete, import, export, and verify contacts for domain registrations.`,
 }
 
 var contactsListCmd = &cobra.Command{
@@ -84,7 +85,8 @@ var contactsGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get contact: %w", err)
 		}
 
-		data, err := json.MarshalIndent(contact, "", "  ")
+		maskPII, _ := cmd.Flags().GetBool("mask-pii")
+		data, err := json.MarshalIndent(contact.WithJSONMasking(maskPII), "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to format contact: %w", err)
 		}
@@ -198,6 +200,95 @@ var contactsDeleteCmd = &cobra.Command{
 	},
 }
 
+var contactsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create contacts from a CSV file",
+	Long: `Create contacts from a CSV file with a header row.
+
+Required columns: first_name, last_name, email, phone, street, city, postal_code, country
+Optional columns: org, title, fax, state, disclose
+
+The file is validated before any contact is created. Contacts that the API
+rejects are reported individually and do not stop the import.
+
+Examples:
+  opusdns contacts import -f contacts.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		file, _ := cmd.Flags().GetString("file")
+
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+
+		result, err := getClient().Contacts.ImportCSV(ctx, f)
+		if err != nil && result == nil {
+			return fmt.Errorf("failed to import contacts: %w", err)
+		}
+
+		for _, contact := range result.Created {
+			fmt.Printf("  ✓ %s: %s <%s>\n", contact.ContactID, contact.FullName(), contact.Email)
+		}
+		for _, failure := range result.Failed {
+			fmt.Printf("  ✗ row %d (%s): %v\n", failure.Index+2, failure.Request.Email, failure.Err)
+		}
+
+		fmt.Printf("\nImported %d contact(s), %d failed.\n", len(result.Created), len(result.Failed))
+		if err != nil {
+			return fmt.Errorf("import interrupted: %w", err)
+		}
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("%d contact(s) failed to import", len(result.Failed))
+		}
+		return nil
+	},
+}
+
+var contactsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export contacts to CSV",
+	Long: `Export all contacts as CSV, to stdout or to a file.
+
+Examples:
+  opusdns contacts export > contacts.csv
+  opusdns contacts export -o contacts.csv --country DE`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		output, _ := cmd.Flags().GetString("output")
+		country, _ := cmd.Flags().GetString("country")
+
+		opts := &models.ListContactsOptions{}
+		if country != "" {
+			opts.Country = country
+		}
+
+		out := os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+			defer f.Close() //nolint:errcheck
+			out = f
+		}
+
+		if err := getClient().Contacts.ExportCSV(ctx, out, opts); err != nil {
+			return fmt.Errorf("failed to export contacts: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Contacts exported to %s\n", output)
+		}
+		return nil
+	},
+}
+
 var contactsVerifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Manage contact verification",
@@ -285,6 +376,7 @@ func init() {
 
 	// Get subcommand
 	contactsCmd.AddCommand(contactsGetCmd)
+	contactsGetCmd.Flags().Bool("mask-pii", false, "Partially mask the email, last name, phone and address")
 
 	// Create subcommand
 	contactsCmd.AddCommand(contactsCreateCmd)
@@ -314,6 +406,15 @@ func init() {
 	contactsCmd.AddCommand(contactsDeleteCmd)
 	contactsDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 
+	// Import/export subcommands
+	contactsCmd.AddCommand(contactsImportCmd)
+	contactsImportCmd.Flags().StringP("file", "f", "", "Path to the CSV file (required)")
+	_ = contactsImportCmd.MarkFlagRequired("file")
+
+	contactsCmd.AddCommand(contactsExportCmd)
+	contactsExportCmd.Flags().StringP("output", "o", "", "Write CSV to this file instead of stdout")
+	contactsExportCmd.Flags().String("country", "", "Filter by country code (e.g., US, DE)")
+
 	// Verify subcommands
 	contactsCmd.AddCommand(contactsVerifyCmd)
 	contactsVerifyCmd.AddCommand(contactsVerifyRequestCmd)
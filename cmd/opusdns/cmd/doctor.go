@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+// Thresholds for doctor warnings.
+const (
+	doctorMaxClockSkew    = 30 * time.Second
+	doctorSlowLatency     = 2 * time.Second
+	doctorLowRateHeadroom = 0.1
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose connectivity and configuration problems",
+	Long: `Check that the API endpoint is reachable, the API key is accepted, the local
+clock agrees with the server, and the rate limit has headroom, and report the
+endpoint latency. Include the output when contacting support.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		samples, _ := cmd.Flags().GetInt("samples")
+		if samples < 1 {
+			samples = 1
+		}
+
+		c := getClient()
+		httpClient := c.HTTPClient()
+		path := httpClient.BuildPath("users", "me")
+
+		fmt.Printf("Endpoint:  %s\n", c.Config.APIEndpoint)
+		fmt.Printf("API key:   %s\n\n", maskAPIKey(c.Config.APIKey))
+
+		failed := 0
+		fail := func(format string, args ...interface{}) {
+			failed++
+			fmt.Printf("✗ "+format+"\n", args...)
+		}
+
+		var resp *opusdns.Response
+		var latencies []time.Duration
+		for i := 0; i < samples; i++ {
+			start := time.Now()
+			r, err := httpClient.Get(ctx, path, nil)
+			elapsed := time.Since(start)
+			if err != nil {
+				fail("Connectivity: %v", err)
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+			resp = r
+			latencies = append(latencies, elapsed)
+
+			// Compare clocks on the first response, correcting for half the round trip.
+			if i == 0 {
+				checkClockSkew(resp, start.Add(elapsed/2))
+			}
+		}
+		fmt.Printf("✓ Connectivity: %s reachable\n", c.Config.APIEndpoint)
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized:
+			fail("Authentication: API key rejected (HTTP 401)")
+		case resp.StatusCode == http.StatusForbidden:
+			fail("Authentication: access denied (HTTP 403) - check the organization's IP restrictions")
+		case resp.StatusCode >= 400:
+			fail("Authentication: unexpected HTTP %d", resp.StatusCode)
+		default:
+			fmt.Printf("✓ Authentication: API key accepted\n")
+		}
+
+		checkRateLimit(resp)
+		printLatency(latencies)
+
+		if failed > 0 {
+			return fmt.Errorf("%d check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// checkClockSkew compares the server's Date header with the local time at which it was sent.
+func checkClockSkew(resp *opusdns.Response, local time.Time) {
+	serverTime, err := http.ParseTime(resp.Headers.Get("Date"))
+	if err != nil {
+		fmt.Printf("• Clock skew: server did not report its time\n")
+		return
+	}
+
+	// The Date header has one-second resolution.
+	skew := local.Sub(serverTime).Truncate(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > doctorMaxClockSkew {
+		fmt.Printf("! Clock skew: local clock differs from the server by %v - timestamps and date filters may be off\n", skew)
+		return
+	}
+	fmt.Printf("✓ Clock skew: within %v\n", skew+time.Second)
+}
+
+// checkRateLimit reports the remaining rate limit from the response headers.
+func checkRateLimit(resp *opusdns.Response) {
+	remaining, errRemaining := strconv.Atoi(resp.Headers.Get("X-RateLimit-Remaining"))
+	limit, errLimit := strconv.Atoi(resp.Headers.Get("X-RateLimit-Limit"))
+	if errRemaining != nil || errLimit != nil || limit <= 0 {
+		fmt.Printf("• Rate limit: not reported by the server\n")
+		return
+	}
+
+	if float64(remaining)/float64(limit) < doctorLowRateHeadroom {
+		fmt.Printf("! Rate limit: only %d of %d requests remaining\n", remaining, limit)
+		return
+	}
+	fmt.Printf("✓ Rate limit: %d of %d requests remaining\n", remaining, limit)
+}
+
+// printLatency reports the minimum, average and maximum request latency.
+func printLatency(latencies []time.Duration) {
+	minimum, maximum, total := latencies[0], latencies[0], time.Duration(0)
+	for _, l := range latencies {
+		minimum = min(minimum, l)
+		maximum = max(maximum, l)
+		total += l
+	}
+	avg := (total / time.Duration(len(latencies))).Round(time.Millisecond)
+
+	marker := "✓"
+	if avg > doctorSlowLatency {
+		marker = "!"
+	}
+	fmt.Printf("%s Latency: avg %v (min %v, max %v over %d request(s))\n",
+		marker, avg, minimum.Round(time.Millisecond), maximum.Round(time.Millisecond), len(latencies))
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().Int("samples", 3, "Number of requests used to measure latency")
+}
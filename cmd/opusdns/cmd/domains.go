@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/portfolio"
 	"github.com/spf13/cobra"
 )
 
@@ -23,14 +30,22 @@ var domainsListCmd = &cobra.Command{
 
 		search, _ := cmd.Flags().GetString("search")
 		tld, _ := cmd.Flags().GetString("tld")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		matchAll, _ := cmd.Flags().GetBool("match-all")
 
-		opts := &models.ListDomainsOptions{}
+		opts := &models.ListDomainsOptions{
+			Tags:    tags,
+			Include: []models.DomainIncludeField{models.DomainIncludeTags},
+		}
 		if search != "" {
 			opts.Search = search
 		}
 		if tld != "" {
 			opts.TLD = tld
 		}
+		if matchAll {
+			opts.TagMode = models.TagFilterModeMatchAll
+		}
 
 		domains, err := getClient().Domains.ListDomains(ctx, opts)
 		if err != nil {
@@ -45,14 +60,21 @@ var domainsListCmd = &cobra.Command{
 		fmt.Printf("Found %d domain(s):\n\n", len(domains))
 		for _, domain := range domains {
 			expiresOn := "N/A"
-			if domain.ExpiresOn != nil {
-				expiresOn = domain.ExpiresOn.Format("2006-01-02")
+			if date, ok := domain.ExpiryDate(); ok {
+				expiresOn = date.String() + " UTC"
+				if models.CrossesMidnight(*domain.ExpiresOn, time.Local) {
+					expiresOn += fmt.Sprintf(", %s local", domain.ExpiresOn.Local().Format("2006-01-02 15:04"))
+				}
 			}
 			renewMode := string(domain.RenewalMode)
 			if renewMode == "" {
 				renewMode = "unknown"
 			}
-			fmt.Printf("  • %s (expires: %s, renewal: %s)\n", domain.Name, expiresOn, renewMode)
+			fmt.Printf("  • %s (expires: %s, renewal: %s)", domain.Name, expiresOn, renewMode)
+			if len(domain.Tags) > 0 {
+				fmt.Printf(" [%s]", strings.Join(models.TagLabels(domain.Tags), ", "))
+			}
+			fmt.Println()
 		}
 
 		return nil
@@ -269,6 +291,337 @@ var domainsCancelTransferCmd = &cobra.Command{
 	},
 }
 
+var domainsTransferStatusCmd = &cobra.Command{
+	Use:   "transfer-status <domain-name>",
+	Short: "Show the progress of an inbound domain transfer",
+	Long: `Show the registry statuses of a domain and the events of its inbound
+transfer. With --watch, keep polling for new transfer events until the
+transfer succeeds, fails, or is cancelled.
+
+Examples:
+  opusdns domains transfer-status example.com
+  opusdns domains transfer-status example.com --watch --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx, cancel := getContext()
+		domain, err := getClient().Domains.GetDomain(ctx, args[0])
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to get domain: %w", err)
+		}
+		opts := models.EventsForObject(models.EventObjectTypeDomain, string(domain.DomainID))
+		opts.Type = models.EventTypeInboundTransfer
+		events, err := getClient().Events.ListEvents(ctx, opts)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to list transfer events: %w", err)
+		}
+
+		if !asJSON {
+			fmt.Printf("Domain:    %s\n", domain.Name)
+			fmt.Printf("Statuses:  %s\n", strings.Join(domain.RegistryStatuses, ", "))
+			fmt.Println()
+			if len(events) == 0 {
+				fmt.Println("No transfer events yet.")
+			}
+		}
+		for _, event := range events {
+			printEvent(event, asJSON)
+		}
+
+		if !watch || (len(events) > 0 && transferFinished(events[len(events)-1])) {
+			return nil
+		}
+
+		watchCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		since := time.Now()
+		if len(events) > 0 && events[len(events)-1].CreatedOn != nil {
+			since = *events[len(events)-1].CreatedOn
+		}
+		err = watchEvents(watchCtx, opts, since, interval, func(event models.Event) bool {
+			printEvent(event, asJSON)
+			return transferFinished(event)
+		})
+		if err != nil && watchCtx.Err() == nil {
+			return err
+		}
+		return nil
+	},
+}
+
+// transferFinished reports whether event ends a transfer.
+func transferFinished(event models.Event) bool {
+	if event.Subtype == nil {
+		return false
+	}
+	switch *event.Subtype {
+	case models.EventSubtypeSuccess, models.EventSubtypeFailure, models.EventSubtypeCanceled:
+		return true
+	}
+	return false
+}
+
+var domainsDelegationCmd = &cobra.Command{
+	Use:   "delegation <domain-name>",
+	Short: "Check a domain's nameserver delegation",
+	Long: `Compare the registry nameservers of a domain with the NS records served by
+those nameservers and with the OpusDNS-hosted zone, and report mismatches,
+lame delegations, and missing glue.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		report, err := getClient().Domains.CheckDelegation(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to check delegation: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(report)
+		}
+
+		fmt.Printf("Registry nameservers: %s\n", strings.Join(report.RegistryNameservers, ", "))
+		if report.Hosted {
+			fmt.Printf("Zone nameservers:     %s\n", strings.Join(report.ZoneNameservers, ", "))
+		}
+		fmt.Println()
+
+		if report.OK() {
+			fmt.Printf("✓ Delegation of '%s' is consistent\n", report.Domain)
+			return nil
+		}
+
+		fmt.Printf("Found %d issue(s):\n\n", len(report.Issues))
+		for _, issue := range report.Issues {
+			if issue.Nameserver != "" {
+				fmt.Printf("  • [%s] %s: %s\n", issue.Kind, issue.Nameserver, issue.Message)
+			} else {
+				fmt.Printf("  • [%s] %s\n", issue.Kind, issue.Message)
+			}
+		}
+		return nil
+	},
+}
+
+var domainsTagCmd = &cobra.Command{
+	Use:   "tag <domain-name> [label...]",
+	Short: "Set the tags of a domain",
+	Long: `Replace the tags of a domain with the given labels, creating tags that do
+not exist yet. Without labels, all tags are removed.
+
+Examples:
+  opusdns domains tag example.com brand-a production
+  opusdns domains list --tag brand-a --tag production --match-all`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		if err := getClient().Domains.SetTags(ctx, args[0], args[1:]); err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+
+		if len(args) == 1 {
+			fmt.Printf("✓ Tags of '%s' removed\n", args[0])
+		} else {
+			fmt.Printf("✓ '%s' tagged: %s\n", args[0], strings.Join(args[1:], ", "))
+		}
+		return nil
+	},
+}
+
+var domainsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Export a portfolio report",
+	Long: `Export one row per domain with its expiry, auto-renew and transfer lock
+settings, nameservers, DNS hosting and DNSSEC status, and registrant, joined
+from the domain, DNS zone and contact data of the account.
+
+Examples:
+  opusdns domains report > portfolio.csv
+  opusdns domains report --format xlsx -o portfolio.xlsx
+  opusdns domains report --format json --tld com
+  opusdns domains report --tag client-x -o client-x.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		tld, _ := cmd.Flags().GetString("tld")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+
+		switch portfolio.ReportFormat(format) {
+		case portfolio.ReportFormatCSV, portfolio.ReportFormatJSON:
+		case portfolio.ReportFormatXLSX:
+			if output == "" && !stdoutRedirected() {
+				return fmt.Errorf("--format xlsx writes a binary file; use --output or redirect stdout")
+			}
+		default:
+			return fmt.Errorf("invalid --format %q: must be csv, xlsx or json", format)
+		}
+
+		ctx, cancel := getContext()
+		defer cancel()
+
+		opts := &models.ListDomainsOptions{Tags: tags}
+		if tld != "" {
+			opts.TLD = tld
+		}
+
+		rows, err := portfolio.BuildReport(ctx, getClient(), opts)
+		if err != nil {
+			return fmt.Errorf("failed to build report: %w", err)
+		}
+
+		out := os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+			defer f.Close() //nolint:errcheck
+			out = f
+		}
+
+		if err := portfolio.WriteReport(out, rows, portfolio.ReportFormat(format)); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Report of %d domain(s) written to %s\n", len(rows), output)
+		}
+		return nil
+	},
+}
+
+// stdoutRedirected reports whether stdout is a file or pipe rather than a terminal.
+func stdoutRedirected() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice == 0
+}
+
+var domainsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch the portfolio for expiring domains",
+	Long: `Periodically check the domain portfolio and print an alert for every domain
+that expires within the given window, has expired, or was renewed. Each alert
+is printed once. Durations accept a "d" suffix for days.
+
+With --auto-renew, domains inside the window that are not set to auto-renew
+are renewed for --period years.
+
+Examples:
+  opusdns domains watch --expiring-within 30d
+  opusdns domains watch --expiring-within 14d --interval 6h --tld com
+  opusdns domains watch --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		withinFlag, _ := cmd.Flags().GetString("expiring-within")
+		intervalFlag, _ := cmd.Flags().GetString("interval")
+		once, _ := cmd.Flags().GetBool("once")
+		autoRenew, _ := cmd.Flags().GetBool("auto-renew")
+		period, _ := cmd.Flags().GetInt("period")
+		tld, _ := cmd.Flags().GetString("tld")
+
+		within, err := parseDayDuration(withinFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --expiring-within: %w", err)
+		}
+		interval, err := parseDayDuration(intervalFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --interval: %w", err)
+		}
+
+		opts := []portfolio.Option{
+			portfolio.WithExpiringWithin(within),
+			portfolio.WithInterval(interval),
+			portfolio.WithAlertHandler(printAlert),
+			portfolio.WithErrorHandler(func(err error) {
+				fmt.Fprintf(os.Stderr, "check failed: %v\n", err)
+			}),
+		}
+		if tld != "" {
+			opts = append(opts, portfolio.WithListOptions(&models.ListDomainsOptions{TLD: tld}))
+		}
+		if autoRenew {
+			opts = append(opts, portfolio.WithRenewPolicy(portfolio.RenewPolicy{Period: period}))
+		}
+
+		monitor := portfolio.NewMonitor(getClient(), opts...)
+
+		if once {
+			ctx, cancel := getContext()
+			defer cancel()
+
+			alerts, err := monitor.Check(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to check domains: %w", err)
+			}
+			if len(alerts) == 0 {
+				fmt.Println("No expiring domains found.")
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Watching for domains expiring within %s (every %s, Ctrl+C to stop)...\n", withinFlag, intervalFlag)
+		if err := monitor.Run(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		return nil
+	},
+}
+
+// printAlert prints a single portfolio alert.
+func printAlert(alert portfolio.Alert) {
+	expires := models.RegistryDate(alert.ExpiresOn).String()
+	days := alert.DaysLeft
+
+	switch alert.Kind {
+	case portfolio.AlertExpired:
+		fmt.Printf("  • %s expired on %s\n", alert.Domain.Name, expires)
+	case portfolio.AlertExpiring:
+		fmt.Printf("  • %s expires on %s (in %d day(s), not set to auto-renew)\n", alert.Domain.Name, expires, days)
+	case portfolio.AlertExpiringAutoRenew:
+		fmt.Printf("  • %s expires on %s (in %d day(s), auto-renew)\n", alert.Domain.Name, expires, days)
+	case portfolio.AlertRenewed:
+		newExpiry := "N/A"
+		if alert.Domain.ExpiresOn != nil {
+			newExpiry = models.RegistryDate(*alert.Domain.ExpiresOn).String()
+		}
+		fmt.Printf("✓ %s renewed (new expiration date: %s)\n", alert.Domain.Name, newExpiry)
+	case portfolio.AlertRenewFailed:
+		fmt.Printf("  • %s could not be renewed: %v\n", alert.Domain.Name, alert.Err)
+	}
+}
+
+// parseDayDuration parses a duration that may use a "d" suffix for days (e.g. "30d").
+func parseDayDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("expected a positive number of days, got %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("expected a positive duration, got %q", s)
+	}
+	return d, nil
+}
+
 func init() {
 	rootCmd.AddCommand(domainsCmd)
 
@@ -276,6 +629,11 @@ func init() {
 	domainsCmd.AddCommand(domainsListCmd)
 	domainsListCmd.Flags().String("search", "", "Search domains by name")
 	domainsListCmd.Flags().String("tld", "", "Filter by TLD")
+	domainsListCmd.Flags().StringSlice("tag", nil, "Filter by tag label (repeatable)")
+	domainsListCmd.Flags().Bool("match-all", false, "Require all --tag labels instead of any")
+
+	// Tag subcommand
+	domainsCmd.AddCommand(domainsTagCmd)
 
 	// Get subcommand
 	domainsCmd.AddCommand(domainsGetCmd)
@@ -298,4 +656,30 @@ func init() {
 	// Cancel transfer subcommand
 	domainsCmd.AddCommand(domainsCancelTransferCmd)
 	domainsCancelTransferCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	// Transfer status subcommand
+	domainsCmd.AddCommand(domainsTransferStatusCmd)
+	domainsTransferStatusCmd.Flags().Bool("watch", false, "Poll until the transfer finishes")
+	domainsTransferStatusCmd.Flags().Duration("interval", 30*time.Second, "Polling interval for --watch")
+	domainsTransferStatusCmd.Flags().Bool("json", false, "Print events as JSON lines")
+
+	// Delegation subcommand
+	domainsCmd.AddCommand(domainsDelegationCmd)
+	domainsDelegationCmd.Flags().Bool("json", false, "Print the report as JSON")
+
+	// Report subcommand
+	domainsCmd.AddCommand(domainsReportCmd)
+	domainsReportCmd.Flags().String("format", "csv", "Output format: csv, xlsx or json")
+	domainsReportCmd.Flags().StringP("output", "o", "", "Write the report to this file instead of stdout")
+	domainsReportCmd.Flags().String("tld", "", "Only report domains with this TLD")
+	domainsReportCmd.Flags().StringSlice("tag", nil, "Only report domains with this tag label (repeatable)")
+
+	// Watch subcommand
+	domainsCmd.AddCommand(domainsWatchCmd)
+	domainsWatchCmd.Flags().String("expiring-within", "30d", "Alert on domains expiring within this window (e.g. 30d, 72h)")
+	domainsWatchCmd.Flags().String("interval", "1h", "Time between checks (e.g. 1h, 1d)")
+	domainsWatchCmd.Flags().Bool("once", false, "Check once and exit")
+	domainsWatchCmd.Flags().Bool("auto-renew", false, "Renew expiring domains that are not set to auto-renew")
+	domainsWatchCmd.Flags().Int("period", 1, "Renewal period in years for --auto-renew")
+	domainsWatchCmd.Flags().String("tld", "", "Only watch domains with this TLD")
 }
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+var emailForwardsCmd = &cobra.Command{
+	Use:   "email-forwards",
+	Short: "Manage email forwarding",
+	Long:  `List, create, and delete email forwarding for hostnames, manage their aliases, and inspect delivery logs.`,
+}
+
+var emailForwardsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List email forwards",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		search, _ := cmd.Flags().GetString("search")
+
+		forwards, err := getClient().EmailForwards.ListEmailForwards(ctx, &models.ListEmailForwardsOptions{Search: search})
+		if err != nil {
+			return fmt.Errorf("failed to list email forwards: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(forwards)
+		}
+
+		if len(forwards) == 0 {
+			fmt.Println("No email forwards found.")
+			return nil
+		}
+
+		fmt.Printf("Found %d email forward(s):\n\n", len(forwards))
+		for _, f := range forwards {
+			status := "disabled"
+			if f.Enabled {
+				status = "enabled"
+			}
+			fmt.Printf("  • %s (%s, %d alias(es))\n", f.Hostname, status, len(f.Aliases))
+			for _, a := range f.Aliases {
+				fmt.Printf("      %s → %s\n", aliasLabel(a), strings.Join(a.ForwardTo, ", "))
+			}
+		}
+
+		return nil
+	},
+}
+
+var emailForwardsCreateCmd = &cobra.Command{
+	Use:   "create <hostname>",
+	Short: "Enable email forwarding for a hostname",
+	Long: `Create email forwarding for a hostname, optionally with initial aliases.
+
+Examples:
+  opusdns email-forwards create example.com
+  opusdns email-forwards create example.com --alias info=team@example.org --alias 'sales=a@example.org,b@example.org'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		aliasFlags, _ := cmd.Flags().GetStringArray("alias")
+		disabled, _ := cmd.Flags().GetBool("disabled")
+
+		req := &models.EmailForwardCreateRequest{Hostname: args[0]}
+		if disabled {
+			req.Enabled = models.BoolPtr(false)
+		}
+		for _, value := range aliasFlags {
+			alias, dests, ok := strings.Cut(value, "=")
+			if !ok || alias == "" || dests == "" {
+				return fmt.Errorf("invalid --alias %q: expected ALIAS=ADDRESS[,ADDRESS...]", value)
+			}
+			req.Aliases = append(req.Aliases, models.EmailForwardAliasCreate{Alias: alias, ForwardTo: splitAddresses(dests)})
+		}
+
+		forward, err := getClient().EmailForwards.CreateEmailForward(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create email forward: %w", err)
+		}
+
+		fmt.Printf("✓ Email forwarding for '%s' created successfully!\n", forward.Hostname)
+		return nil
+	},
+}
+
+var emailForwardsDeleteCmd = &cobra.Command{
+	Use:   "delete <hostname>",
+	Short: "Delete email forwarding for a hostname",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		hostname := args[0]
+
+		forward, err := getClient().EmailForwards.GetEmailForwardByHostname(ctx, hostname)
+		if err != nil {
+			return fmt.Errorf("failed to find email forward: %w", err)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Are you sure you want to delete email forwarding for '%s' and its %d alias(es)?\n", hostname, len(forward.Aliases))
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if err := getClient().EmailForwards.DeleteEmailForward(ctx, forward.EmailForwardID); err != nil {
+			return fmt.Errorf("failed to delete email forward: %w", err)
+		}
+
+		fmt.Printf("✓ Email forwarding for '%s' deleted successfully!\n", hostname)
+		return nil
+	},
+}
+
+var emailForwardsAliasesCmd = &cobra.Command{
+	Use:   "aliases",
+	Short: "Manage the aliases of an email forward",
+}
+
+var emailForwardsAliasesAddCmd = &cobra.Command{
+	Use:   "add <hostname> <alias> <address>...",
+	Short: "Add an alias forwarding to one or more addresses",
+	Long: `Add an alias to a hostname's email forwarding. Use '*' for a catch-all alias.
+
+Examples:
+  opusdns email-forwards aliases add example.com info team@example.org
+  opusdns email-forwards aliases add example.com '*' inbox@example.org`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		forward, err := getClient().EmailForwards.GetEmailForwardByHostname(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find email forward: %w", err)
+		}
+
+		alias, err := getClient().EmailForwards.CreateAlias(ctx, forward.EmailForwardID, &models.EmailForwardAliasCreate{
+			Alias:     args[1],
+			ForwardTo: args[2:],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add alias: %w", err)
+		}
+
+		fmt.Printf("✓ Alias %s@%s → %s added\n", alias.Alias, forward.Hostname, strings.Join(alias.ForwardTo, ", "))
+		return nil
+	},
+}
+
+var emailForwardsAliasesUpdateCmd = &cobra.Command{
+	Use:   "update <hostname> <alias> <address>...",
+	Short: "Replace the addresses an alias forwards to",
+	Args:  cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		forward, existing, err := findAlias(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		alias, err := getClient().EmailForwards.UpdateAlias(ctx, forward.EmailForwardID, existing.EmailForwardAliasID, &models.EmailForwardAliasUpdate{
+			ForwardTo: args[2:],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update alias: %w", err)
+		}
+
+		fmt.Printf("✓ Alias %s@%s → %s updated\n", alias.Alias, forward.Hostname, strings.Join(alias.ForwardTo, ", "))
+		return nil
+	},
+}
+
+var emailForwardsAliasesRemoveCmd = &cobra.Command{
+	Use:   "remove <hostname> <alias>",
+	Short: "Remove an alias",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		forward, existing, err := findAlias(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		if err := getClient().EmailForwards.DeleteAlias(ctx, forward.EmailForwardID, existing.EmailForwardAliasID); err != nil {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+
+		fmt.Printf("✓ Alias %s@%s removed\n", existing.Alias, forward.Hostname)
+		return nil
+	},
+}
+
+var emailForwardsLogsCmd = &cobra.Command{
+	Use:   "logs <hostname>",
+	Short: "Show delivery logs of an email forward",
+	Long: `Show the most recent delivery logs of a hostname's email forwarding.
+
+--status filters by final status: QUEUED, DELIVERED, REFUSED, SOFT-BOUNCE or
+HARD-BOUNCE. --since accepts a duration such as 12h or 7d.
+
+Examples:
+  opusdns email-forwards logs example.com --status HARD-BOUNCE --since 7d
+  opusdns email-forwards logs example.com --limit 200 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		status, _ := cmd.Flags().GetString("status")
+		since, _ := cmd.Flags().GetString("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if limit <= 0 || limit > opusdns.MaxPageSize {
+			return fmt.Errorf("invalid --limit %d: must be between 1 and %d", limit, opusdns.MaxPageSize)
+		}
+
+		opts := &models.ListEmailForwardLogsOptions{
+			PageSize:  limit,
+			SortBy:    models.EmailForwardLogSortByCreatedOn,
+			SortOrder: models.SortDesc,
+		}
+		if status != "" {
+			opts.Status = models.EmailForwardLogStatus(strings.ToUpper(status))
+			switch opts.Status {
+			case models.EmailForwardLogStatusQueued, models.EmailForwardLogStatusDelivered, models.EmailForwardLogStatusRefused,
+				models.EmailForwardLogStatusSoftBounce, models.EmailForwardLogStatusHardBounce:
+			default:
+				return fmt.Errorf("invalid --status %q: must be QUEUED, DELIVERED, REFUSED, SOFT-BOUNCE or HARD-BOUNCE", status)
+			}
+		}
+		if since != "" {
+			d, err := parseDayDuration(since)
+			if err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			start := time.Now().Add(-d)
+			opts.StartTime = &start
+		}
+
+		forward, err := getClient().EmailForwards.GetEmailForwardByHostname(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find email forward: %w", err)
+		}
+
+		resp, err := getClient().Events.ListEmailForwardLogsPage(ctx, forward.EmailForwardID, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list logs: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(resp.Results)
+		}
+
+		if len(resp.Results) == 0 {
+			fmt.Println("No logs found.")
+			return nil
+		}
+
+		for _, l := range resp.Results {
+			fmt.Printf("%s  %-11s  %s → %s", l.CreatedOn.Format(time.RFC3339), l.FinalStatus, l.SenderEmail, l.RecipientEmail)
+			if l.ForwardEmail != "" {
+				fmt.Printf(" → %s", l.ForwardEmail)
+			}
+			fmt.Println()
+			if l.Subject != "" {
+				fmt.Printf("    subject: %s\n", l.Subject)
+			}
+			if n := len(l.Events); n > 0 && l.FinalStatus != models.EmailForwardLogStatusDelivered {
+				last := l.Events[n-1]
+				fmt.Printf("    %d %s\n", last.Code, last.Message)
+			}
+		}
+		if resp.Pagination.HasNextPage {
+			fmt.Printf("\n! Showing the %d most recent logs; use --limit to see more.\n", len(resp.Results))
+		}
+
+		return nil
+	},
+}
+
+// findAlias looks up an email forward by hostname and one of its aliases by name.
+func findAlias(ctx context.Context, hostname, name string) (*models.EmailForward, *models.EmailForwardAlias, error) {
+	forward, err := getClient().EmailForwards.GetEmailForwardByHostname(ctx, hostname)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find email forward: %w", err)
+	}
+
+	for i := range forward.Aliases {
+		if strings.EqualFold(forward.Aliases[i].Alias, name) {
+			return forward, &forward.Aliases[i], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("alias %q not found on %s", name, forward.Hostname)
+}
+
+// aliasLabel returns the alias name for display, marking the catch-all.
+func aliasLabel(a models.EmailForwardAlias) string {
+	if a.IsCatchAll() {
+		return "* (catch-all)"
+	}
+	return a.Alias
+}
+
+// splitAddresses splits a comma-separated list of email addresses.
+func splitAddresses(s string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(s, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func init() {
+	rootCmd.AddCommand(emailForwardsCmd)
+
+	// List subcommand
+	emailForwardsCmd.AddCommand(emailForwardsListCmd)
+	emailForwardsListCmd.Flags().String("search", "", "Search email forwards by hostname")
+	emailForwardsListCmd.Flags().Bool("json", false, "Output as JSON")
+
+	// Create subcommand
+	emailForwardsCmd.AddCommand(emailForwardsCreateCmd)
+	emailForwardsCreateCmd.Flags().StringArray("alias", nil, "Initial alias as ALIAS=ADDRESS[,ADDRESS...] (repeatable)")
+	emailForwardsCreateCmd.Flags().Bool("disabled", false, "Create the forward disabled")
+
+	// Delete subcommand
+	emailForwardsCmd.AddCommand(emailForwardsDeleteCmd)
+	emailForwardsDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	// Aliases subcommands
+	emailForwardsCmd.AddCommand(emailForwardsAliasesCmd)
+	emailForwardsAliasesCmd.AddCommand(emailForwardsAliasesAddCmd)
+	emailForwardsAliasesCmd.AddCommand(emailForwardsAliasesUpdateCmd)
+	emailForwardsAliasesCmd.AddCommand(emailForwardsAliasesRemoveCmd)
+
+	// Logs subcommand
+	emailForwardsCmd.AddCommand(emailForwardsLogsCmd)
+	emailForwardsLogsCmd.Flags().String("status", "", "Filter by final delivery status (e.g., HARD-BOUNCE)")
+	emailForwardsLogsCmd.Flags().String("since", "", "Only logs from this long ago (e.g., 24h, 7d)")
+	emailForwardsLogsCmd.Flags().Int("limit", 50, "Maximum number of logs to show")
+	emailForwardsLogsCmd.Flags().Bool("json", false, "Output as JSON")
+}
@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Work with account events",
+	Long:  `Export or watch events such as registrations, renewals and transfers.`,
+}
+
+var eventsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export events to CSV or JSON lines",
+	Long: `Export all events matching the filters, oldest first, to stdout or a file.
+Events are fetched page by page and written as they arrive, so large
+extracts such as a yearly audit do not need to fit in memory.
+
+--since and --until accept a date (2024-01-01) or an RFC 3339 timestamp. A
+date given to --until includes that whole day.
+
+Examples:
+  opusdns events export --type INBOUND_TRANSFER --since 2024-01-01 --until 2024-12-31 --output csv --file events.csv
+  opusdns events export --since 2024-06-01 --output jsonl > events.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventType, _ := cmd.Flags().GetString("type")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		format, _ := cmd.Flags().GetString("output")
+		file, _ := cmd.Flags().GetString("file")
+
+		opts := &opusdns.EventExportOptions{
+			Type:   models.EventType(strings.ToUpper(eventType)),
+			Format: opusdns.EventExportFormat(format),
+		}
+
+		var err error
+		if sinceFlag != "" {
+			if opts.Since, _, err = parseDateFlag(sinceFlag); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+		if untilFlag != "" {
+			var dateOnly bool
+			if opts.Until, dateOnly, err = parseDateFlag(untilFlag); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+			if dateOnly {
+				opts.Until = opts.Until.AddDate(0, 0, 1)
+			}
+		}
+
+		out := os.Stdout
+		if file != "" {
+			f, err := os.Create(file)
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+			defer f.Close() //nolint:errcheck
+			out = f
+
+			opts.Progress = func(n int) {
+				fmt.Fprintf(os.Stderr, "\r• Exported %d event(s)...", n)
+			}
+		}
+
+		// An export can outlast the per-request --timeout, so only stop on Ctrl+C.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		n, err := getClient().Events.Export(ctx, out, opts)
+		if file != "" {
+			fmt.Fprintln(os.Stderr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export events: %w", err)
+		}
+
+		if file != "" {
+			fmt.Printf("✓ Exported %d event(s) to %s\n", n, file)
+		}
+		return nil
+	},
+}
+
+var eventsExportLogsCmd = &cobra.Command{
+	Use:   "export-logs",
+	Short: "Export the audit log for a SIEM",
+	Long: `Export the audit log (changes to domains, contacts, zones and billing
+transactions) as NDJSON or ArcSight CEF, oldest first, for ingestion into
+Splunk, Elastic or another SIEM.
+
+With --state-file the export resumes after the last entry of the previous
+run and records its new position, so running the command from cron ships
+every entry exactly once. --since is ignored once the state file exists.
+
+Examples:
+  opusdns events export-logs --format cef --since 2024-01-01 > audit.cef
+  opusdns events export-logs --state-file /var/lib/opusdns/audit.state >> /var/log/opusdns/audit.ndjson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		format, _ := cmd.Flags().GetString("format")
+		stateFile, _ := cmd.Flags().GetString("state-file")
+		objectType, _ := cmd.Flags().GetString("object-type")
+
+		opts := &opusdns.LogExportOptions{ObjectType: models.EventObjectType(strings.ToUpper(objectType))}
+
+		var err error
+		if sinceFlag != "" {
+			if opts.Since, _, err = parseDateFlag(sinceFlag); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+		if untilFlag != "" {
+			var dateOnly bool
+			if opts.Until, dateOnly, err = parseDateFlag(untilFlag); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+			if dateOnly {
+				opts.Until = opts.Until.AddDate(0, 0, 1)
+			}
+		}
+		if stateFile != "" {
+			data, err := os.ReadFile(stateFile)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read state file: %w", err)
+			}
+			opts.ResumeToken = strings.TrimSpace(string(data))
+		}
+
+		// An export can outlast the per-request --timeout, so only stop on Ctrl+C.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		result, err := getClient().Events.ExportLogs(ctx, opts, os.Stdout, opusdns.LogExportFormat(format))
+
+		// Record the progress even when the export failed part way
+		if stateFile != "" && result != nil && result.ResumeToken != "" {
+			if werr := os.WriteFile(stateFile, []byte(result.ResumeToken+"\n"), 0o600); werr != nil {
+				return fmt.Errorf("failed to write state file: %w", werr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export audit log: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "✓ Exported %d audit log record(s)\n", result.Exported)
+		return nil
+	},
+}
+
+var eventsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Tail new events",
+	Long: `Poll for events created after the command starts (or after --since) and
+print each one as it arrives, until Ctrl+C. Successes are shown in green and
+failures in red when stdout is a terminal and NO_COLOR is not set.
+
+With --json every event is printed as one JSON line, for piping into other
+tools.
+
+Examples:
+  opusdns events watch
+  opusdns events watch --type INBOUND_TRANSFER --interval 30s
+  opusdns events watch --object-type DOMAIN --object-id domain_01h... --json | jq .`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventType, _ := cmd.Flags().GetString("type")
+		objectType, _ := cmd.Flags().GetString("object-type")
+		objectID, _ := cmd.Flags().GetString("object-id")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		opts := &models.ListEventsOptions{
+			Type:       models.EventType(strings.ToUpper(eventType)),
+			ObjectType: models.EventObjectType(strings.ToUpper(objectType)),
+			ObjectID:   objectID,
+		}
+		since := time.Now()
+		if sinceFlag != "" {
+			var err error
+			if since, _, err = parseDateFlag(sinceFlag); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if !asJSON {
+			fmt.Fprintf(os.Stderr, "Watching for events (every %s, Ctrl+C to stop)...\n", interval)
+		}
+		err := watchEvents(ctx, opts, since, interval, func(event models.Event) bool {
+			printEvent(event, asJSON)
+			return false
+		})
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		return nil
+	},
+}
+
+// watchEvents polls for events matching opts created after since, oldest
+// first, and passes each to handle once until handle returns true or ctx is
+// done. Failed polls are reported and retried at the next interval.
+func watchEvents(ctx context.Context, opts *models.ListEventsOptions, since time.Time, interval time.Duration, handle func(models.Event) bool) error {
+	// seen holds the events created at the cursor, which the next poll
+	// returns again because the filter is inclusive.
+	seen := map[models.EventID]bool{}
+	cursor := since
+
+	for {
+		page := *opts
+		page.SortBy = models.EventSortByCreatedOn
+		page.SortOrder = models.SortAsc
+		page.CreatedAfter = models.TimePtr(cursor)
+		page.Page = 1
+
+		for {
+			reqCtx, cancel := context.WithTimeout(ctx, timeout)
+			resp, err := getClient().Events.ListEventsPage(reqCtx, &page)
+			cancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				fmt.Fprintf(os.Stderr, "! Failed to list events: %v\n", err)
+				break
+			}
+
+			for _, event := range resp.Results {
+				if seen[event.EventID] || event.CreatedOn == nil || event.CreatedOn.Before(cursor) {
+					continue
+				}
+				if event.CreatedOn.After(cursor) {
+					cursor = *event.CreatedOn
+					seen = map[models.EventID]bool{}
+				}
+				seen[event.EventID] = true
+				if handle(event) {
+					return nil
+				}
+			}
+			if !resp.Pagination.HasNextPage {
+				break
+			}
+			page.Page++
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ANSI colors for event output.
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// useColor reports whether output to stdout should be colorized.
+func useColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// printEvent prints one event as a line of text, or as a JSON line.
+func printEvent(event models.Event, asJSON bool) {
+	if asJSON {
+		data, err := json.Marshal(event)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "! Failed to format event %s: %v\n", event.EventID, err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	created := "-"
+	if event.CreatedOn != nil {
+		created = event.CreatedOn.Local().Format("2006-01-02 15:04:05")
+	}
+	kind := "-"
+	if event.Type != nil {
+		kind = string(*event.Type)
+	}
+	color := ""
+	if event.Subtype != nil {
+		kind += "/" + string(*event.Subtype)
+		switch *event.Subtype {
+		case models.EventSubtypeSuccess:
+			color = colorGreen
+		case models.EventSubtypeFailure:
+			color = colorRed
+		case models.EventSubtypeCanceled:
+			color = colorYellow
+		}
+	}
+	object := string(event.ObjectType)
+	if event.ObjectID != nil {
+		object += " " + *event.ObjectID
+	}
+
+	line := fmt.Sprintf("%s  %-30s  %s  %s", created, kind, object, event.EventData.Message)
+	if event.EventData.Error != nil {
+		line += fmt.Sprintf(" (%s)", event.EventData.Error.Detail)
+	}
+	if color != "" && useColor() {
+		line = color + line + colorReset
+	}
+	fmt.Println(line)
+}
+
+// parseDateFlag parses a date (2006-01-02) or RFC 3339 timestamp and reports
+// whether the value was a date only.
+func parseDateFlag(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected YYYY-MM-DD or RFC 3339, got %q", value)
+	}
+	return t, false, nil
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	eventsCmd.AddCommand(eventsExportCmd)
+	eventsExportCmd.Flags().String("type", "", "Filter by event type (e.g., REGISTRATION, INBOUND_TRANSFER)")
+	eventsExportCmd.Flags().String("since", "", "Only events created on or after this date")
+	eventsExportCmd.Flags().String("until", "", "Only events created on or before this date")
+	eventsExportCmd.Flags().String("output", "csv", "Output format: csv or jsonl")
+	eventsExportCmd.Flags().String("file", "", "Write to this file instead of stdout")
+
+	eventsCmd.AddCommand(eventsExportLogsCmd)
+	eventsExportLogsCmd.Flags().String("object-type", "", "Filter by object type (e.g., DOMAIN, CONTACT)")
+	eventsExportLogsCmd.Flags().String("since", "", "Only entries created on or after this date")
+	eventsExportLogsCmd.Flags().String("until", "", "Only entries created on or before this date")
+	eventsExportLogsCmd.Flags().String("format", "ndjson", "Output format: ndjson or cef")
+	eventsExportLogsCmd.Flags().String("state-file", "", "Resume from and record the export position in this file")
+
+	eventsCmd.AddCommand(eventsWatchCmd)
+	eventsWatchCmd.Flags().String("type", "", "Filter by event type (e.g., REGISTRATION, INBOUND_TRANSFER)")
+	eventsWatchCmd.Flags().String("object-type", "", "Filter by object type (e.g., DOMAIN, CONTACT)")
+	eventsWatchCmd.Flags().String("object-id", "", "Filter by object ID")
+	eventsWatchCmd.Flags().String("since", "", "Also show events created on or after this date")
+	eventsWatchCmd.Flags().Duration("interval", 10*time.Second, "Polling interval")
+	eventsWatchCmd.Flags().Bool("json", false, "Print events as JSON lines")
+}
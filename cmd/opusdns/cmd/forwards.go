@@ -0,0 +1,474 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/spf13/cobra"
+)
+
+var forwardsCmd = &cobra.Command{
+	Use:   "forwards",
+	Short: "Manage domain forwards",
+	Long:  `List, create, update, and delete HTTP(S) domain forwards (redirects).`,
+}
+
+var forwardsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List domain forwards",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		search, _ := cmd.Flags().GetString("search")
+
+		forwards, err := getClient().DomainForwards.ListDomainForwards(ctx, &models.ListDomainForwardsOptions{Search: search})
+		if err != nil {
+			return fmt.Errorf("failed to list domain forwards: %w", err)
+		}
+
+		if len(forwards) == 0 {
+			fmt.Println("No domain forwards found.")
+			return nil
+		}
+
+		fmt.Printf("Found %d domain forward(s):\n\n", len(forwards))
+		for _, f := range forwards {
+			status := "disabled"
+			if f.Enabled {
+				status = "enabled"
+			}
+			fmt.Printf("  • %s (%s)\n", f.Hostname, status)
+		}
+
+		return nil
+	},
+}
+
+var forwardsGetCmd = &cobra.Command{
+	Use:   "get <hostname>",
+	Short: "Get details of a domain forward",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		forward, err := getClient().DomainForwards.GetDomainForward(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get domain forward: %w", err)
+		}
+
+		return printJSON(forward)
+	},
+}
+
+var forwardsCreateCmd = &cobra.Command{
+	Use:   "create <hostname>",
+	Short: "Create a domain forward with a single redirect",
+	Long: `Create a domain forward that redirects a hostname to a target.
+
+Examples:
+  opusdns forwards create example.com --target-hostname www.example.com
+  opusdns forwards create old.example.com --protocol http --target-hostname new.example.com --target-path /landing --code 302`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		protocol, _ := cmd.Flags().GetString("protocol")
+		path, _ := cmd.Flags().GetString("path")
+
+		redirect, err := redirectFromFlags(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		set := &models.DomainForwardProtocolSetRequest{Redirects: []models.HttpRedirectRequest{redirect}}
+		req := &models.DomainForwardCreateRequest{Hostname: args[0], Enabled: true}
+		switch models.HttpProtocol(protocol) {
+		case models.HttpProtocolHTTP:
+			req.HTTP = set
+		case models.HttpProtocolHTTPS:
+			req.HTTPS = set
+		default:
+			return fmt.Errorf("invalid protocol %q: must be http or https", protocol)
+		}
+
+		forward, err := getClient().DomainForwards.CreateDomainForward(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create domain forward: %w", err)
+		}
+
+		fmt.Printf("✓ Domain forward for '%s' created successfully!\n\n", forward.Hostname)
+		return printJSON(forward)
+	},
+}
+
+var forwardsWildcardCmd = &cobra.Command{
+	Use:   "wildcard <hostname>",
+	Short: "Create or update a wildcard redirect",
+	Long: `Create or update a redirect matching a subdomain pattern below a hostname.
+
+Examples:
+  opusdns forwards wildcard example.com --subdomain '*' --target-hostname www.example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		protocol, _ := cmd.Flags().GetString("protocol")
+		path, _ := cmd.Flags().GetString("path")
+		subdomain, _ := cmd.Flags().GetString("subdomain")
+
+		redirect, err := redirectFromFlags(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		err = getClient().DomainForwards.CreateWildcardRedirect(ctx, args[0], models.HttpProtocol(protocol), &models.WildcardHttpRedirectRequest{
+			RequestPath:      redirect.RequestPath,
+			RequestSubdomain: subdomain,
+			TargetProtocol:   redirect.TargetProtocol,
+			TargetHostname:   redirect.TargetHostname,
+			TargetPath:       redirect.TargetPath,
+			RedirectCode:     redirect.RedirectCode,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create wildcard redirect: %w", err)
+		}
+
+		fmt.Printf("✓ Wildcard redirect '%s.%s' → %s created successfully!\n", subdomain, args[0], redirect.TargetHostname)
+		return nil
+	},
+}
+
+var forwardsReplaceCmd = &cobra.Command{
+	Use:   "replace <hostname> <http|https>",
+	Short: "Replace all redirects of one protocol from a JSON file",
+	Long: `Replace the redirects of one protocol of a hostname with the redirects in a
+JSON file ({"redirects": [{"request_path", "target_protocol", "target_hostname",
+"target_path", "redirect_code"}]}).
+
+Examples:
+  opusdns forwards replace example.com https -f redirects.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		hostname, protocol := args[0], args[1]
+
+		var req models.DomainForwardProtocolSetRequest
+		if err := readJSONFile(cmd, &req); err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Are you sure you want to replace all %s redirects of '%s' with %d redirect(s)?\n", protocol, hostname, len(req.Redirects))
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		forward, err := getClient().DomainForwards.UpdateDomainForwardConfig(ctx, hostname, models.HttpProtocol(protocol), &req)
+		if err != nil {
+			return fmt.Errorf("failed to replace redirects: %w", err)
+		}
+
+		fmt.Printf("✓ Replaced %s redirects of '%s'\n\n", protocol, hostname)
+		return printJSON(forward)
+	},
+}
+
+var forwardsPatchCmd = &cobra.Command{
+	Use:   "patch",
+	Short: "Apply upsert/remove redirect operations from a JSON file",
+	Long: `Apply a batch of redirect operations across hostnames from a JSON file
+({"ops": [{"op": "upsert"|"remove", "redirect": {...}}]}).
+
+Examples:
+  opusdns forwards patch -f ops.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		var req models.DomainForwardPatchOps
+		if err := readJSONFile(cmd, &req); err != nil {
+			return err
+		}
+
+		if err := getClient().DomainForwards.PatchRedirects(ctx, &req); err != nil {
+			return fmt.Errorf("failed to patch redirects: %w", err)
+		}
+
+		fmt.Printf("✓ Applied %d redirect operation(s)\n", len(req.Ops))
+		return nil
+	},
+}
+
+var forwardsDeleteCmd = &cobra.Command{
+	Use:   "delete <hostname>",
+	Short: "Delete a domain forward",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		hostname := args[0]
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Are you sure you want to delete the domain forward for '%s'?\n", hostname)
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if err := getClient().DomainForwards.DeleteDomainForward(ctx, hostname); err != nil {
+			return fmt.Errorf("failed to delete domain forward: %w", err)
+		}
+
+		fmt.Printf("✓ Domain forward for '%s' deleted successfully!\n", hostname)
+		return nil
+	},
+}
+
+var forwardsEnableCmd = &cobra.Command{
+	Use:   "enable <hostname>",
+	Short: "Enable a domain forward",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		if err := getClient().DomainForwards.EnableDomainForward(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to enable domain forward: %w", err)
+		}
+
+		fmt.Printf("✓ Domain forward for '%s' enabled\n", args[0])
+		return nil
+	},
+}
+
+var forwardsDisableCmd = &cobra.Command{
+	Use:   "disable <hostname>",
+	Short: "Disable a domain forward",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		if err := getClient().DomainForwards.DisableDomainForward(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to disable domain forward: %w", err)
+		}
+
+		fmt.Printf("✓ Domain forward for '%s' disabled\n", args[0])
+		return nil
+	},
+}
+
+var forwardsStatsCmd = &cobra.Command{
+	Use:   "stats <hostname>",
+	Short: "Show visit statistics for a domain forward",
+	Long: `Show visits, top countries, browsers, platforms, referrers and status codes
+for a domain forward over a time range (1h, 1d, 7d, 30d or 1y).
+
+Examples:
+  opusdns forwards stats example.com --since 7d
+  opusdns forwards stats example.com --since 30d --exclude-bots --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		hostname := args[0]
+		since, _ := cmd.Flags().GetString("since")
+		top, _ := cmd.Flags().GetInt("top")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		timeRange := models.TimeRange(since)
+		switch timeRange {
+		case models.TimeRange1H, models.TimeRange1D, models.TimeRange7D, models.TimeRange30D, models.TimeRange1Y:
+		default:
+			return fmt.Errorf("invalid --since %q: must be 1h, 1d, 7d, 30d or 1y", since)
+		}
+
+		opts := &models.DomainForwardMetricsOptions{Hostname: hostname, TimeRange: timeRange}
+		if cmd.Flags().Changed("exclude-bots") {
+			excludeBots, _ := cmd.Flags().GetBool("exclude-bots")
+			opts.ExcludeBots = &excludeBots
+		}
+
+		forwards := getClient().DomainForwards
+		var stats struct {
+			Metrics     *models.DomainForwardMetrics                 `json:"metrics"`
+			Geo         *models.DomainForwardGeoStatsResponse        `json:"geo"`
+			Browsers    *models.DomainForwardBrowserStatsResponse    `json:"browsers"`
+			Platforms   *models.DomainForwardPlatformStatsResponse   `json:"platforms"`
+			Referrers   *models.DomainForwardReferrerStatsResponse   `json:"referrers"`
+			StatusCodes *models.DomainForwardStatusCodeStatsResponse `json:"status_codes"`
+		}
+
+		var err error
+		if stats.Metrics, err = forwards.GetMetrics(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get metrics: %w", err)
+		}
+		if stats.Geo, err = forwards.GetGeoStats(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get geo stats: %w", err)
+		}
+		if stats.Browsers, err = forwards.GetBrowserStats(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get browser stats: %w", err)
+		}
+		if stats.Platforms, err = forwards.GetPlatformStats(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get platform stats: %w", err)
+		}
+		if stats.Referrers, err = forwards.GetReferrerStats(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get referrer stats: %w", err)
+		}
+		if stats.StatusCodes, err = forwards.GetStatusCodeStats(ctx, opts); err != nil {
+			return fmt.Errorf("failed to get status code stats: %w", err)
+		}
+
+		if asJSON {
+			return printJSON(stats)
+		}
+
+		fmt.Printf("Statistics for '%s' (last %s):\n\n", hostname, timeRange)
+		fmt.Printf("  Total visits:  %d\n", stats.Metrics.TotalVisits)
+		fmt.Printf("  Unique visits: %d\n", stats.Metrics.UniqueVisits)
+
+		printStatsSection("Countries", top, len(stats.Geo.Results), func(i int) (string, int) {
+			return stats.Geo.Results[i].Key, stats.Geo.Results[i].Total
+		})
+		printStatsSection("Browsers", top, len(stats.Browsers.Results), func(i int) (string, int) {
+			return stats.Browsers.Results[i].Key, stats.Browsers.Results[i].Total
+		})
+		printStatsSection("Platforms", top, len(stats.Platforms.Results), func(i int) (string, int) {
+			return stats.Platforms.Results[i].Key, stats.Platforms.Results[i].Total
+		})
+		printStatsSection("Referrers", top, len(stats.Referrers.Results), func(i int) (string, int) {
+			return stats.Referrers.Results[i].Key, stats.Referrers.Results[i].Total
+		})
+		printStatsSection("Status codes", top, len(stats.StatusCodes.Results), func(i int) (string, int) {
+			return stats.StatusCodes.Results[i].Key, stats.StatusCodes.Results[i].Total
+		})
+
+		return nil
+	},
+}
+
+// printStatsSection prints up to top entries of a stats breakdown.
+func printStatsSection(title string, top, n int, entry func(i int) (string, int)) {
+	if n == 0 {
+		return
+	}
+	if top > 0 && n > top {
+		n = top
+	}
+
+	fmt.Printf("\n%s:\n", title)
+	for i := 0; i < n; i++ {
+		key, total := entry(i)
+		if key == "" {
+			key = "(unknown)"
+		}
+		fmt.Printf("  • %-30s %d\n", key, total)
+	}
+}
+
+// redirectFromFlags builds a redirect from the shared --target-* and --code flags.
+func redirectFromFlags(cmd *cobra.Command, path string) (models.HttpRedirectRequest, error) {
+	targetProtocol, _ := cmd.Flags().GetString("target-protocol")
+	targetHostname, _ := cmd.Flags().GetString("target-hostname")
+	targetPath, _ := cmd.Flags().GetString("target-path")
+	code, _ := cmd.Flags().GetInt("code")
+
+	switch models.RedirectCode(code) {
+	case models.RedirectCodePermanent, models.RedirectCodeTemporary,
+		models.RedirectCodeTemporaryRedirect, models.RedirectCodePermanentRedirect:
+	default:
+		return models.HttpRedirectRequest{}, fmt.Errorf("invalid redirect code %d: must be 301, 302, 307 or 308", code)
+	}
+
+	return models.HttpRedirectRequest{
+		RequestPath:    path,
+		TargetProtocol: models.HttpProtocol(targetProtocol),
+		TargetHostname: targetHostname,
+		TargetPath:     targetPath,
+		RedirectCode:   models.RedirectCode(code),
+	}, nil
+}
+
+// readJSONFile decodes the file named by the --file flag into v.
+func readJSONFile(cmd *cobra.Command, v interface{}) error {
+	file, _ := cmd.Flags().GetString("file")
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return nil
+}
+
+// addRedirectFlags registers the flags read by redirectFromFlags.
+func addRedirectFlags(cmd *cobra.Command) {
+	cmd.Flags().String("protocol", "https", "Source protocol (http or https)")
+	cmd.Flags().String("path", "/", "Source path to match")
+	cmd.Flags().String("target-protocol", "https", "Target protocol (http or https)")
+	cmd.Flags().String("target-hostname", "", "Target hostname (required)")
+	cmd.Flags().String("target-path", "/", "Target path")
+	cmd.Flags().Int("code", int(models.RedirectCodePermanent), "Redirect status code (301, 302, 307 or 308)")
+	_ = cmd.MarkFlagRequired("target-hostname")
+}
+
+func init() {
+	rootCmd.AddCommand(forwardsCmd)
+
+	forwardsCmd.AddCommand(forwardsListCmd)
+	forwardsListCmd.Flags().String("search", "", "Filter by hostname")
+
+	forwardsCmd.AddCommand(forwardsGetCmd)
+
+	forwardsCmd.AddCommand(forwardsCreateCmd)
+	addRedirectFlags(forwardsCreateCmd)
+
+	forwardsCmd.AddCommand(forwardsWildcardCmd)
+	addRedirectFlags(forwardsWildcardCmd)
+	forwardsWildcardCmd.Flags().String("subdomain", "*", "Subdomain pattern to match")
+
+	forwardsCmd.AddCommand(forwardsReplaceCmd)
+	forwardsReplaceCmd.Flags().StringP("file", "f", "", "JSON file with the redirects (required)")
+	forwardsReplaceCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	_ = forwardsReplaceCmd.MarkFlagRequired("file")
+
+	forwardsCmd.AddCommand(forwardsPatchCmd)
+	forwardsPatchCmd.Flags().StringP("file", "f", "", "JSON file with the operations (required)")
+	_ = forwardsPatchCmd.MarkFlagRequired("file")
+
+	forwardsCmd.AddCommand(forwardsDeleteCmd)
+	forwardsDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	forwardsCmd.AddCommand(forwardsStatsCmd)
+	forwardsStatsCmd.Flags().String("since", "7d", "Time range: 1h, 1d, 7d, 30d or 1y")
+	forwardsStatsCmd.Flags().Int("top", 5, "Number of entries to show per breakdown (0 for all)")
+	forwardsStatsCmd.Flags().Bool("exclude-bots", false, "Exclude bot traffic")
+	forwardsStatsCmd.Flags().Bool("json", false, "Print raw statistics as JSON")
+
+	forwardsCmd.AddCommand(forwardsEnableCmd)
+	forwardsCmd.AddCommand(forwardsDisableCmd)
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage API keys",
+	Long:  `List, create, revoke, and rotate the organization's API keys.`,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		status, _ := cmd.Flags().GetString("status")
+		keys, err := getClient().APIKeys.ListAPIKeys(ctx, &models.ListAPIKeysOptions{
+			Status: models.OrganizationCredentialStatus(status),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(keys)
+		}
+
+		if len(keys) == 0 {
+			fmt.Println("No API keys found.")
+			return nil
+		}
+
+		// Mark the key this CLI is using, if the API tells us which it is.
+		var current models.OrganizationCredentialID
+		if self, err := getClient().Auth.IntrospectAPIKey(ctx); err == nil {
+			current = self.APIKeyID
+		}
+
+		fmt.Printf("Found %d API key(s):\n\n", len(keys))
+		for _, key := range keys {
+			marker := "•"
+			if key.APIKeyID == current {
+				marker = "*"
+			}
+			fmt.Printf("  %s %s (%s)\n", marker, keyLabel(key), key.Status)
+			if key.Role != nil {
+				fmt.Printf("      role: %s\n", *key.Role)
+			}
+			if key.ExpiresAt != nil {
+				fmt.Printf("      expires: %s\n", key.ExpiresAt.Format("2006-01-02"))
+			}
+			if key.LastUsedOn != nil {
+				fmt.Printf("      last used: %s\n", key.LastUsedOn.Format(time.RFC3339))
+			}
+		}
+		if current != "" {
+			fmt.Println("\n* key used by this CLI")
+		}
+
+		return nil
+	},
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key",
+	Long: `Create an API key. The secret is printed once and cannot be retrieved
+again, so store it right away.
+
+Examples:
+  opusdns keys create --name ci-deploy --role dns-admin --expires-in 90d`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		req, err := keyCreateRequest(cmd)
+		if err != nil {
+			return err
+		}
+
+		created, err := getClient().APIKeys.CreateAPIKey(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		fmt.Printf("✓ Created API key %s\n\n", keyLabel(created.OrganizationCredential))
+		printKeySecret(created.APIKey)
+		return nil
+	},
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <key-id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		keyID := models.OrganizationCredentialID(args[0])
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			if self, err := getClient().Auth.IntrospectAPIKey(ctx); err == nil && self.APIKeyID == keyID {
+				fmt.Println("! This is the key the CLI is using; later commands will fail until you switch keys.")
+			}
+			fmt.Printf("Are you sure you want to revoke API key '%s'?\n", keyID)
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if err := getClient().APIKeys.RevokeAPIKey(ctx, keyID); err != nil {
+			return fmt.Errorf("failed to revoke API key: %w", err)
+		}
+
+		fmt.Printf("✓ API key '%s' revoked\n", keyID)
+		return nil
+	},
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate [key-id]",
+	Short: "Replace an API key with a new one",
+	Long: `Rotate an API key (by default the one the CLI is using):
+
+  1. create a new key with the same name, description and role
+  2. print the new secret once
+  3. verify that the new key works
+  4. revoke the old key, after you confirm that the new one is stored
+
+If verification fails the old key is left untouched.
+
+Examples:
+  opusdns keys rotate
+  opusdns keys rotate key_01h45ytscbebyvny4gc8cr8ma2 --expires-in 90d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		var old *models.OrganizationCredential
+		var err error
+		if len(args) == 1 {
+			old, err = getClient().APIKeys.GetAPIKey(ctx, models.OrganizationCredentialID(args[0]))
+		} else {
+			old, err = getClient().Auth.IntrospectAPIKey(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get API key: %w", err)
+		}
+
+		req, err := keyCreateRequest(cmd)
+		if err != nil {
+			return err
+		}
+		if req.APIKeyName == nil {
+			req.APIKeyName = old.APIKeyName
+		}
+		if req.APIKeyDescription == nil {
+			req.APIKeyDescription = old.APIKeyDescription
+		}
+		if req.Role == nil {
+			req.Role = old.Role
+		}
+
+		fmt.Printf("Rotating API key %s\n\n", keyLabel(*old))
+
+		created, err := getClient().APIKeys.CreateAPIKey(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+		fmt.Printf("✓ Created API key %s\n\n", keyLabel(created.OrganizationCredential))
+		printKeySecret(created.APIKey)
+
+		newClient, err := opusdns.NewClient(clientOptions(created.APIKey)...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		self, err := newClient.Auth.IntrospectAPIKey(ctx)
+		if err != nil || self.APIKeyID != created.APIKeyID {
+			if err == nil {
+				err = fmt.Errorf("API reports key %s", self.APIKeyID)
+			}
+			fmt.Printf("! The old key %s was not revoked; revoke the new key %s if you do not keep it.\n", old.APIKeyID, created.APIKeyID)
+			return fmt.Errorf("failed to verify new API key: %w", err)
+		}
+		fmt.Println("✓ Verified the new key")
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("\nStore the new key, then revoke the old key %s.\n", old.APIKeyID)
+			fmt.Print("Type 'yes' to revoke it: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Printf("Old key kept. Revoke it later with: opusdns keys revoke %s\n", old.APIKeyID)
+				return nil
+			}
+		}
+
+		// The prompt may have outlasted the first context.
+		ctx, cancel = getContext()
+		defer cancel()
+
+		// Revoke with the new key, which also works when the old one is the
+		// key this CLI was started with.
+		if err := newClient.APIKeys.RevokeAPIKey(ctx, old.APIKeyID); err != nil {
+			return fmt.Errorf("failed to revoke old API key: %w", err)
+		}
+
+		fmt.Printf("✓ Revoked old key %s\n", old.APIKeyID)
+		return nil
+	},
+}
+
+// keyCreateRequest builds an API key create request from the command's flags.
+// Unset flags are left nil.
+func keyCreateRequest(cmd *cobra.Command) (*models.APIKeyCreateRequest, error) {
+	req := &models.APIKeyCreateRequest{}
+
+	if cmd.Flags().Changed("name") {
+		name, _ := cmd.Flags().GetString("name")
+		req.APIKeyName = &name
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		req.APIKeyDescription = &description
+	}
+	if cmd.Flags().Changed("role") {
+		role, _ := cmd.Flags().GetString("role")
+		req.Role = &role
+	}
+	if expiresIn, _ := cmd.Flags().GetString("expires-in"); expiresIn != "" {
+		d, err := parseDayDuration(expiresIn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --expires-in: %w", err)
+		}
+		expiresAt := time.Now().Add(d).UTC()
+		req.ExpiresAt = &expiresAt
+	}
+
+	return req, nil
+}
+
+// keyLabel returns the name and ID of an API key.
+func keyLabel(key models.OrganizationCredential) string {
+	if key.APIKeyName != nil && *key.APIKeyName != "" {
+		return fmt.Sprintf("%s [%s]", *key.APIKeyName, key.APIKeyID)
+	}
+	return string(key.APIKeyID)
+}
+
+// printKeySecret prints a newly created API key secret.
+func printKeySecret(secret string) {
+	fmt.Printf("  %s\n\n", secret)
+	fmt.Println("! This is the only time the key is shown. Store it in your secret manager now.")
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+
+	// List subcommand
+	keysCmd.AddCommand(keysListCmd)
+	keysListCmd.Flags().String("status", "", "Filter by status (active, expired, revoked)")
+	keysListCmd.Flags().Bool("json", false, "Output as JSON")
+
+	// Create and rotate subcommands share the key attribute flags
+	for _, c := range []*cobra.Command{keysCreateCmd, keysRotateCmd} {
+		keysCmd.AddCommand(c)
+		c.Flags().String("name", "", "Key name")
+		c.Flags().String("description", "", "Key description")
+		c.Flags().String("role", "", "Role to bind the key to")
+		c.Flags().String("expires-in", "", "Expire the key after this duration (e.g. 90d)")
+	}
+	keysRotateCmd.Flags().BoolP("force", "f", false, "Revoke the old key without confirmation")
+
+	// Revoke subcommand
+	keysCmd.AddCommand(keysRevokeCmd)
+	keysRevokeCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage the organization",
+	Long:  `Inspect settings of the organization the API key belongs to.`,
+}
+
+var orgIPRestrictionsCmd = &cobra.Command{
+	Use:   "ip-restrictions",
+	Short: "Inspect IP restrictions on API access",
+}
+
+var orgIPRestrictionsTestCmd = &cobra.Command{
+	Use:   "test <ip>",
+	Short: "Check whether an IP address may access the API",
+	Long: `Check whether API requests from an IP address would be allowed under the
+organization's current IP restrictions. Exits with an error if they would be
+rejected, so it can gate deployments from new addresses.`,
+	Example: `  opusdns org ip-restrictions test 203.0.113.9`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		result, err := getClient().Organizations.TestIPRestriction(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to test IP restrictions: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			if err := printJSON(result); err != nil {
+				return err
+			}
+		} else {
+			switch {
+			case !result.Restricted:
+				fmt.Printf("%s is allowed: no IP restrictions are configured\n", result.IP)
+			case result.Allowed:
+				fmt.Printf("%s is allowed by:\n", result.IP)
+				for _, r := range result.Matches {
+					fmt.Printf("  • %s\n", r.IPNetwork)
+				}
+			default:
+				fmt.Printf("%s is not in any allowed network\n", result.IP)
+			}
+			for _, r := range result.Unparsable {
+				fmt.Printf("Warning: could not evaluate restriction %d (%q)\n", r.IPRestrictionID, r.IPNetwork)
+			}
+		}
+
+		if !result.Allowed {
+			return fmt.Errorf("requests from %s would be rejected", result.IP)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.AddCommand(orgIPRestrictionsCmd)
+	orgIPRestrictionsCmd.AddCommand(orgIPRestrictionsTestCmd)
+
+	orgIPRestrictionsTestCmd.Flags().Bool("json", false, "Print the result as JSON")
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var recordsCmd = &cobra.Command{
+	Use:   "records",
+	Short: "Manage the records of a zone",
+	Long:  `List, upsert, and delete individual records, or apply a declarative record file.`,
+}
+
+var recordsListCmd = &cobra.Command{
+	Use:   "list <zone-name>",
+	Short: "List the records of a zone",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		zoneName := strings.TrimSuffix(args[0], ".")
+		rrtype, _ := cmd.Flags().GetString("type")
+		name, _ := cmd.Flags().GetString("name")
+
+		zone, err := getClient().DNS.GetZone(ctx, zoneName)
+		if err != nil {
+			return fmt.Errorf("failed to get zone: %w", err)
+		}
+
+		var records []models.Record
+		for _, r := range opusdns.RRSetsToRecords(zone.RRSets) {
+			if rrtype != "" && !strings.EqualFold(string(r.Type), rrtype) {
+				continue
+			}
+			if name != "" && recordName(r.Name) != recordName(name) {
+				continue
+			}
+			records = append(records, r)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(records)
+		}
+
+		if len(records) == 0 {
+			fmt.Println("No records found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTTL\tTYPE\tRDATA")
+		for _, r := range records {
+			rdata := r.RData
+			if r.Protected {
+				rdata += " (protected)"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", recordName(r.Name), r.TTL, r.Type, rdata)
+		}
+		return w.Flush()
+	},
+}
+
+var recordsUpsertCmd = &cobra.Command{
+	Use:   "upsert <zone-name> <name> <type> <rdata>",
+	Short: "Create or update a record",
+	Long: `Create a record, or update the TTL of an existing one. Use "@" for the
+zone apex.
+
+Examples:
+  opusdns dns records upsert example.com www A 192.0.2.10 --ttl 300
+  opusdns dns records upsert example.com @ TXT "v=spf1 include:_spf.example.net -all"`,
+	Args: cobra.ExactArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		zoneName := strings.TrimSuffix(args[0], ".")
+		ttl, _ := cmd.Flags().GetInt("ttl")
+		record := models.Record{
+			Name:  recordName(args[1]),
+			Type:  models.RRSetType(strings.ToUpper(args[2])),
+			TTL:   ttl,
+			RData: args[3],
+		}
+
+		if err := getClient().DNS.UpsertRecord(ctx, zoneName, record); err != nil {
+			return fmt.Errorf("failed to upsert record: %w", err)
+		}
+
+		fmt.Printf("✓ Upserted %s %d %s %s in zone '%s'\n", record.Name, record.TTL, record.Type, record.RData, zoneName)
+		return nil
+	},
+}
+
+var recordsDeleteCmd = &cobra.Command{
+	Use:   "delete <zone-name> <name> <type> [rdata]",
+	Short: "Delete a record or a whole RRset",
+	Long: `Delete the record with the given rdata, or every record of the name and
+type if rdata is omitted.
+
+Examples:
+  opusdns dns records delete example.com www A 192.0.2.10
+  opusdns dns records delete example.com old CNAME`,
+	Args: cobra.RangeArgs(3, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zoneName := strings.TrimSuffix(args[0], ".")
+		name := recordName(args[1])
+		rrtype := models.RRSetType(strings.ToUpper(args[2]))
+
+		ctx, cancel := getContext()
+		zone, err := getClient().DNS.GetZone(ctx, zoneName)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get zone: %w", err)
+		}
+
+		var ops []models.RecordOperation
+		for _, r := range opusdns.RRSetsToRecords(zone.RRSets) {
+			if recordName(r.Name) != name || r.Type != rrtype {
+				continue
+			}
+			if len(args) == 4 && r.RData != args[3] {
+				continue
+			}
+			ops = append(ops, models.RecordOperation{Op: models.RecordOpRemove, Record: r})
+		}
+		if len(ops) == 0 {
+			return fmt.Errorf("no matching %s record for '%s' in zone '%s'", rrtype, name, zoneName)
+		}
+
+		fmt.Printf("Changes to zone '%s':\n", zoneName)
+		printRecordOps(ops)
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		ctx, cancel = getContext()
+		defer cancel()
+
+		allowProtected, _ := cmd.Flags().GetBool("allow-protected")
+		return applyRecordOps(ctx, zoneName, ops, allowProtected)
+	},
+}
+
+var recordsApplyCmd = &cobra.Command{
+	Use:   "apply <zone-name>",
+	Short: "Apply a declarative record file",
+	Long: `Make the RRsets listed in a YAML (or JSON) file match it exactly. Records
+of those names and types that are not in the file are removed; other RRsets
+are left alone unless --prune is given, which removes every RRset not in the
+file except the apex SOA and NS records.
+
+The file lists records:
+
+  records:
+    - name: www
+      type: A
+      ttl: 300
+      rdata: 192.0.2.10
+    - name: "@"
+      type: MX
+      ttl: 3600
+      rdata: 10 mail.example.com.
+
+A missing ttl defaults to 3600. The changes are shown and applied as one
+atomic patch after confirmation.
+
+Examples:
+  opusdns dns records apply example.com -f records.yaml
+  opusdns dns records apply example.com -f records.yaml --prune --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zoneName := strings.TrimSuffix(args[0], ".")
+		file, _ := cmd.Flags().GetString("file")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		desired, err := readRecordFile(file)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := getContext()
+		zone, err := getClient().DNS.GetZone(ctx, zoneName)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get zone: %w", err)
+		}
+
+		listed := map[string]bool{}
+		for _, r := range desired {
+			listed[rrsetKey(r)] = true
+		}
+		var current []models.Record
+		for _, r := range opusdns.RRSetsToRecords(zone.RRSets) {
+			switch {
+			case listed[rrsetKey(r)]:
+				current = append(current, r)
+			case prune && !(recordName(r.Name) == "@" && (r.Type == models.RRSetTypeSOA || r.Type == models.RRSetTypeNS)):
+				current = append(current, r)
+			}
+		}
+
+		ops := opusdns.DiffRecords(current, desired)
+		if len(ops) == 0 {
+			fmt.Println("• No changes")
+			return nil
+		}
+
+		fmt.Printf("Changes to zone '%s':\n", zoneName)
+		printRecordOps(ops)
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Print("Type 'yes' to apply: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		ctx, cancel = getContext()
+		defer cancel()
+
+		allowProtected, _ := cmd.Flags().GetBool("allow-protected")
+		return applyRecordOps(ctx, zoneName, ops, allowProtected)
+	},
+}
+
+// recordFile is the layout of the file read by "dns records apply".
+type recordFile struct {
+	Records []struct {
+		Name  string `yaml:"name"`
+		Type  string `yaml:"type"`
+		TTL   int    `yaml:"ttl"`
+		RData string `yaml:"rdata"`
+	} `yaml:"records"`
+}
+
+// readRecordFile reads the records of a record file.
+func readRecordFile(path string) ([]models.Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var f recordFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	records := make([]models.Record, 0, len(f.Records))
+	for i, r := range f.Records {
+		if r.Type == "" || r.RData == "" {
+			return nil, fmt.Errorf("%s: record %d: type and rdata are required", path, i+1)
+		}
+		ttl := r.TTL
+		if ttl == 0 {
+			ttl = 3600
+		}
+		records = append(records, models.Record{
+			Name:  recordName(r.Name),
+			Type:  models.RRSetType(strings.ToUpper(r.Type)),
+			TTL:   ttl,
+			RData: r.RData,
+		})
+	}
+	return records, nil
+}
+
+// recordName normalizes a record name for comparison: lowercase, with "@"
+// for the apex.
+func recordName(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return strings.ToLower(name)
+}
+
+// rrsetKey returns the name and type identifying a record's RRset.
+func rrsetKey(r models.Record) string {
+	return recordName(r.Name) + " " + strings.ToUpper(string(r.Type))
+}
+
+func init() {
+	zonesCmd.AddCommand(recordsCmd)
+
+	// List subcommand
+	recordsCmd.AddCommand(recordsListCmd)
+	recordsListCmd.Flags().String("type", "", "Only records of this type")
+	recordsListCmd.Flags().String("name", "", "Only records with this name")
+	recordsListCmd.Flags().Bool("json", false, "Output as JSON")
+
+	// Upsert subcommand
+	recordsCmd.AddCommand(recordsUpsertCmd)
+	recordsUpsertCmd.Flags().Int("ttl", 3600, "Record TTL in seconds")
+
+	// Delete subcommand
+	recordsCmd.AddCommand(recordsDeleteCmd)
+	recordsDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	recordsDeleteCmd.Flags().Bool("allow-protected", false, "Allow removing protected records")
+
+	// Apply subcommand
+	recordsCmd.AddCommand(recordsApplyCmd)
+	recordsApplyCmd.Flags().StringP("file", "f", "", "YAML or JSON record file (required)")
+	recordsApplyCmd.Flags().Bool("prune", false, "Also remove RRsets not in the file")
+	recordsApplyCmd.Flags().Bool("force", false, "Apply changes without confirmation")
+	recordsApplyCmd.Flags().Bool("allow-protected", false, "Allow changing or removing protected records")
+	_ = recordsApplyCmd.MarkFlagRequired("file")
+}
@@ -12,10 +12,12 @@ import (
 )
 
 var (
-	apiKey  string
-	debug   bool
-	timeout time.Duration
-	client  *opusdns.Client
+	apiKey      string
+	apiEndpoint string
+	profileName string
+	debug       bool
+	timeout     time.Duration
+	client      *opusdns.Client
 
 	// Version information (set by main.go)
 	version = "dev"
@@ -29,32 +31,22 @@ var rootCmd = &cobra.Command{
 	Long: `OpusDNS CLI is an interactive command-line tool for managing
 your DNS zones, domains, contacts, and more through the OpusDNS API.
 
-Set your API key via the OPUSDNS_API_KEY environment variable or use the --api-key flag.`,
+Set your API key via the OPUSDNS_API_KEY environment variable, the --api-key
+flag, or a profile (see "opusdns config").`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip client initialization for help and version commands
-		if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "version" {
+		// Skip client initialization for help, version, completion, config and
+		// login commands; completion functions initialize the client themselves
+		if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "version" || cmd.Name() == cobra.ShellCompRequestCmd {
 			return nil
 		}
-
-		// Get API key from flag or environment
-		if apiKey == "" {
-			apiKey = os.Getenv("OPUSDNS_API_KEY")
-		}
-		if apiKey == "" {
-			return fmt.Errorf("API key is required. Set OPUSDNS_API_KEY or use --api-key flag")
+		if cmd == configCmd || cmd.Parent() == configCmd || cmd == authLoginCmd {
+			return nil
 		}
-
-		// Create client
-		var err error
-		client, err = opusdns.NewClient(
-			opusdns.WithAPIKey(apiKey),
-			opusdns.WithDebug(debug),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+		if cmd.HasParent() && cmd.Parent().Name() == "completion" {
+			return nil
 		}
 
-		return nil
+		return initClient()
 	},
 }
 
@@ -71,6 +63,7 @@ func SetVersion(v, c, d string) {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "OpusDNS API key (or set OPUSDNS_API_KEY)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to use (or set OPUSDNS_PROFILE)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Request timeout")
 
@@ -86,11 +79,52 @@ func init() {
 	})
 }
 
+// initClient creates the client used by getClient.
+func initClient() error {
+	// Get API key from the flag, an explicit --profile, the environment,
+	// or OPUSDNS_PROFILE and the current profile, in that order
+	if apiKey == "" && profileName == "" {
+		apiKey = os.Getenv("OPUSDNS_API_KEY")
+	}
+	if apiKey == "" {
+		p, err := resolveProfile()
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			apiKey = p.APIKey
+			apiEndpoint = p.APIEndpoint
+		}
+	}
+	if apiKey == "" {
+		return fmt.Errorf("API key is required. Set OPUSDNS_API_KEY, use --api-key or configure a profile with 'opusdns config set'")
+	}
+
+	// Create client
+	var err error
+	client, err = opusdns.NewClient(clientOptions(apiKey)...)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return nil
+}
+
 // getContext returns a context with the configured timeout
 func getContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), timeout)
 }
 
+// clientOptions returns the options for a client using key and the
+// settings of the active profile.
+func clientOptions(key string) []opusdns.Option {
+	opts := []opusdns.Option{opusdns.WithAPIKey(key), opusdns.WithDebug(debug)}
+	if apiEndpoint != "" {
+		opts = append(opts, opusdns.WithAPIEndpoint(apiEndpoint))
+	}
+	return opts
+}
+
 // getClient returns the initialized client
 func getClient() *opusdns.Client {
 	return client
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the OpusDNS platform status",
+	Long: `Show the overall platform status, unresolved incidents and maintenance
+windows in progress or scheduled.
+
+With --check the command exits with an error if bulk operations should be
+deferred: the platform is degraded, an incident is unresolved, or a
+maintenance window is in progress or starts within --within.
+
+Examples:
+  opusdns status
+  opusdns status --check --within 2h && ./bulk-update.sh`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		status, err := getClient().Status.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get status: %w", err)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			if err := printJSON(status); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("Status: %s\n", status.Status)
+			if status.Description != "" {
+				fmt.Printf("        %s\n", status.Description)
+			}
+
+			if len(status.Incidents) > 0 {
+				fmt.Printf("\nIncidents (%d):\n", len(status.Incidents))
+				for _, i := range status.Incidents {
+					fmt.Printf("  • %s [%s, %s impact]\n", i.Name, i.Status, i.Impact)
+					if i.Message != "" {
+						fmt.Printf("    %s\n", i.Message)
+					}
+				}
+			}
+
+			if len(status.Maintenances) > 0 {
+				fmt.Printf("\nMaintenance (%d):\n", len(status.Maintenances))
+				for _, m := range status.Maintenances {
+					fmt.Printf("  • %s [%s] %s – %s\n", m.Name, m.Status,
+						m.StartsOn.Local().Format("2006-01-02 15:04"), m.EndsOn.Local().Format("2006-01-02 15:04 MST"))
+				}
+			}
+		}
+
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			within, _ := cmd.Flags().GetDuration("within")
+			if reason := status.DeferReason(time.Now(), within); reason != "" {
+				return fmt.Errorf("defer bulk operations: %s", reason)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().Bool("json", false, "Print the status as JSON")
+	statusCmd.Flags().Bool("check", false, "Exit with an error if bulk operations should be deferred")
+	statusCmd.Flags().Duration("within", time.Hour, "With --check, also defer for maintenance starting within this time")
+}
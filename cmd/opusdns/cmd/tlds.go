@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/spf13/cobra"
+)
+
+var tldsCmd = &cobra.Command{
+	Use:   "tlds",
+	Short: "Inspect TLDs and pricing",
+	Long:  `Inspect TLD information and upcoming TLD price changes.`,
+}
+
+var tldsPriceChangesCmd = &cobra.Command{
+	Use:   "price-changes",
+	Short: "List upcoming TLD price changes",
+	Long: `List upcoming TLD price changes with their effective date and the old and new
+price per action, for renewal planning.
+
+Examples:
+  opusdns tlds price-changes
+  opusdns tlds price-changes --tld com --tld io --action renew`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		tlds, _ := cmd.Flags().GetStringArray("tld")
+		actions, _ := cmd.Flags().GetStringArray("action")
+
+		opts := &models.ListTLDPriceChangesOptions{TLDs: tlds}
+		for _, action := range actions {
+			opts.Actions = append(opts.Actions, models.BillingTransactionAction(action))
+		}
+
+		changes, err := getClient().TLDs.ListPriceChanges(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list price changes: %w", err)
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("No upcoming price changes found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TLD\tACTION\tOLD PRICE\tNEW PRICE\tCURRENCY\tEFFECTIVE")
+		for _, change := range changes {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				change.TLD,
+				change.Action,
+				formatPrice(change.Currency, change.OldPrice),
+				formatPrice(change.Currency, change.NewPrice),
+				change.Currency,
+				change.EffectiveDate.Format("2006-01-02"),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+// formatPrice renders an amount with the decimals and symbol of its currency,
+// "N/A" if it is missing, or the raw amount if it cannot be parsed.
+func formatPrice(currency models.Currency, amount string) string {
+	if strings.TrimSpace(amount) == "" {
+		return "N/A"
+	}
+	formatted, err := currency.FormatAmount(amount)
+	if err != nil {
+		return amount
+	}
+	return formatted
+}
+
+func init() {
+	rootCmd.AddCommand(tldsCmd)
+
+	tldsCmd.AddCommand(tldsPriceChangesCmd)
+	tldsPriceChangesCmd.Flags().StringArray("tld", nil, "Filter by TLD (repeatable)")
+	tldsPriceChangesCmd.Flags().StringArray("action", nil, "Filter by action, e.g. create, renew, transfer (repeatable)")
+}
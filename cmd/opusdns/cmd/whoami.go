@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/spf13/cobra"
+)
+
+// whoamiInfo is the JSON output of the whoami command.
+type whoamiInfo struct {
+	User           models.User            `json:"user"`
+	Organization   *models.Organization   `json:"organization,omitempty"`
+	APIKey         string                 `json:"api_key"`
+	Permissions    []models.Permission    `json:"permissions"`
+	IPRestrictions []models.IPRestriction `json:"ip_restrictions"`
+	Unavailable    map[string]string      `json:"unavailable,omitempty"`
+}
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the identity behind the API key",
+	Long: `Show the user and organization the API key belongs to, its effective
+permissions, and whether the organization restricts API access by IP address.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		identity, err := getClient().Identity(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get identity: %w", err)
+		}
+
+		info := whoamiInfo{
+			User:        identity.User,
+			APIKey:      maskAPIKey(getClient().Config.APIKey),
+			Permissions: identity.Permissions,
+			Unavailable: map[string]string{},
+		}
+
+		// The organization and IP restrictions need extra permissions; report
+		// them as unavailable rather than failing the whole command.
+		if org, err := getClient().Organizations.GetOrganization(ctx, identity.OrganizationID); err != nil {
+			info.Unavailable["organization"] = err.Error()
+		} else {
+			info.Organization = org
+		}
+		if restrictions, err := getClient().Organizations.ListIPRestrictions(ctx); err != nil {
+			info.Unavailable["ip_restrictions"] = err.Error()
+		} else {
+			info.IPRestrictions = restrictions.Results
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(info)
+		}
+
+		user := info.User
+		fmt.Printf("User:          %s (%s)\n", user.Username, user.UserID)
+		if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+			fmt.Printf("Name:          %s\n", name)
+		}
+		fmt.Printf("Email:         %s\n", user.Email)
+		if info.Organization != nil {
+			fmt.Printf("Organization:  %s (%s)\n", info.Organization.Name, identity.OrganizationID)
+		} else {
+			fmt.Printf("Organization:  %s\n", identity.OrganizationID)
+		}
+		fmt.Printf("API key:       %s\n", info.APIKey)
+
+		fmt.Printf("\nPermissions (%d):\n", len(info.Permissions))
+		for _, p := range info.Permissions {
+			fmt.Printf("  • %s\n", p)
+		}
+
+		fmt.Printf("\nIP restrictions: ")
+		switch reason, unavailable := info.Unavailable["ip_restrictions"]; {
+		case unavailable:
+			fmt.Printf("unknown (%s)\n", reason)
+		case len(info.IPRestrictions) == 0:
+			fmt.Printf("none (API access allowed from any IP)\n")
+		default:
+			fmt.Printf("%d network(s) allowed\n", len(info.IPRestrictions))
+			for _, r := range info.IPRestrictions {
+				fmt.Printf("  • %s\n", r.IPNetwork)
+			}
+		}
+
+		return nil
+	},
+}
+
+// maskAPIKey shows only the start of an API key.
+func maskAPIKey(key string) string {
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:8] + strings.Repeat("*", 8)
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+	whoamiCmd.Flags().Bool("json", false, "Print the identity as JSON")
+}
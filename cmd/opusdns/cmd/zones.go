@@ -1,17 +1,30 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
 	"github.com/spf13/cobra"
 )
 
 var zonesCmd = &cobra.Command{
-	Use:   "zones",
-	Short: "Manage DNS zones",
-	Long:  `List, create, get, and delete DNS zones.`,
+	Use:     "zones",
+	Aliases: []string{"dns"},
+	Short:   "Manage DNS zones",
+	Long:    `List, create, get, and delete DNS zones.`,
 }
 
 var zonesListCmd = &cobra.Command{
@@ -22,10 +35,18 @@ var zonesListCmd = &cobra.Command{
 		defer cancel()
 
 		search, _ := cmd.Flags().GetString("search")
-		opts := &models.ListZonesOptions{}
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		matchAll, _ := cmd.Flags().GetBool("match-all")
+		opts := &models.ListZonesOptions{
+			Tags:    tags,
+			Include: []models.ZoneIncludeField{models.ZoneIncludeTags},
+		}
 		if search != "" {
 			opts.Search = search
 		}
+		if matchAll {
+			opts.TagMode = models.TagFilterModeMatchAll
+		}
 
 		zones, err := getClient().DNS.ListZones(ctx, opts)
 		if err != nil {
@@ -43,7 +64,11 @@ var zonesListCmd = &cobra.Command{
 			if dnssec == "" {
 				dnssec = "unknown"
 			}
-			fmt.Printf("  • %s (DNSSEC: %s)\n", zone.Name, dnssec)
+			fmt.Printf("  • %s (DNSSEC: %s)", zone.Name, dnssec)
+			if len(zone.Tags) > 0 {
+				fmt.Printf(" [%s]", strings.Join(models.TagLabels(zone.Tags), ", "))
+			}
+			fmt.Println()
 		}
 
 		return nil
@@ -104,6 +129,29 @@ var zonesCreateCmd = &cobra.Command{
 	},
 }
 
+var zonesTagCmd = &cobra.Command{
+	Use:   "tag <zone-name> [label...]",
+	Short: "Set the tags of a DNS zone",
+	Long: `Replace the tags of a zone with the given labels, creating tags that do
+not exist yet. Without labels, all tags are removed.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		if err := getClient().DNS.SetZoneTags(ctx, args[0], args[1:]); err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+
+		if len(args) == 1 {
+			fmt.Printf("✓ Tags of zone '%s' removed\n", args[0])
+		} else {
+			fmt.Printf("✓ Zone '%s' tagged: %s\n", args[0], strings.Join(args[1:], ", "))
+		}
+		return nil
+	},
+}
+
 var zonesDeleteCmd = &cobra.Command{
 	Use:   "delete <zone-name>",
 	Short: "Delete a DNS zone",
@@ -136,12 +184,365 @@ var zonesDeleteCmd = &cobra.Command{
 	},
 }
 
+var zonesApplyCmd = &cobra.Command{
+	Use:   "apply <zone-name>",
+	Short: "Replace a zone's RRsets from a JSON definition",
+	Long: `Replace all RRsets of a zone with the RRsets in a JSON file (a list of
+{"name", "type", "ttl", "records": [{"rdata"}]} objects).
+
+Names and record values may reference variables such as {{ .Env }} or
+{{ .LoadBalancerIP }}, supplied with --var. Referencing a variable that is not
+supplied is an error and nothing is changed, so one definition can serve
+several environments.
+
+Examples:
+  opusdns zones apply example.com -f zone.json --var Env=staging --var LoadBalancerIP=192.0.2.10`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		zoneName := args[0]
+		file, _ := cmd.Flags().GetString("file")
+		vars, _ := cmd.Flags().GetStringToString("var")
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		var rrsets []models.RRSetCreate
+		if err := json.Unmarshal(data, &rrsets); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		// Render before confirming so variable errors surface without a prompt.
+		rendered, err := opusdns.RenderRRSets(rrsets, vars)
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Are you sure you want to replace all RRsets of zone '%s' with %d RRset(s)?\n", zoneName, len(rendered))
+			fmt.Print("Type 'yes' to confirm: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if err := getClient().DNS.PutRRSets(ctx, zoneName, rendered); err != nil {
+			return fmt.Errorf("failed to apply zone: %w", err)
+		}
+
+		fmt.Printf("✓ Applied %d RRset(s) to zone '%s'\n", len(rendered), zoneName)
+		return nil
+	},
+}
+
+var zonesEditCmd = &cobra.Command{
+	Use:   "edit <zone-name>",
+	Short: "Edit a zone's records in your editor",
+	Long: `Export the records of a zone to a temporary zone file, open it in $VISUAL
+or $EDITOR (vi if neither is set) and apply the differences after you save
+and confirm them.
+
+Each line holds one record as "name ttl [IN] type rdata"; lines starting
+with ";" or "#" are ignored. Deleting a line removes the record. The changes
+are applied as a single atomic patch where the API supports it.
+
+Examples:
+  opusdns dns edit example.com
+  EDITOR="code --wait" opusdns dns edit example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zoneName := strings.TrimSuffix(args[0], ".")
+
+		ctx, cancel := getContext()
+		zone, err := getClient().DNS.GetZone(ctx, zoneName)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get zone: %w", err)
+		}
+		current := opusdns.RRSetsToRecords(zone.RRSets)
+
+		file, err := os.CreateTemp("", "opusdns-"+zoneName+"-*.zone")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		path := file.Name()
+		writeZoneFile(file, zoneName, current)
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+
+		if err := runEditor(path); err != nil {
+			_ = os.Remove(path)
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		desired, err := parseZoneFile(string(data))
+		if err != nil {
+			// Keep the file so the edits are not lost.
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		_ = os.Remove(path)
+
+		ops := opusdns.DiffRecords(current, desired)
+		if len(ops) == 0 {
+			fmt.Println("• No changes")
+			return nil
+		}
+
+		fmt.Printf("Changes to zone '%s':\n", zoneName)
+		printRecordOps(ops)
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Print("Type 'yes' to apply: ")
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		ctx, cancel = getContext()
+		defer cancel()
+
+		allowProtected, _ := cmd.Flags().GetBool("allow-protected")
+		return applyRecordOps(ctx, zoneName, ops, allowProtected)
+	},
+}
+
+// printRecordOps prints record operations as +/- lines.
+func printRecordOps(ops []models.RecordOperation) {
+	for _, op := range ops {
+		sign := "+"
+		if op.Op == models.RecordOpRemove {
+			sign = "-"
+		}
+		r := op.Record
+		fmt.Printf("  %s %s %d %s %s\n", sign, r.Name, r.TTL, r.Type, r.RData)
+	}
+}
+
+// applyRecordOps applies ops to a zone as one atomic patch and reports the
+// operations the API rejected.
+func applyRecordOps(ctx context.Context, zoneName string, ops []models.RecordOperation, allowProtected bool) error {
+	result, err := getClient().DNS.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{
+		Ops:            ops,
+		Atomic:         true,
+		AllowProtected: allowProtected,
+	})
+	var batchErr *opusdns.BatchError
+	if errors.As(err, &batchErr) {
+		for _, failed := range batchErr.Failed {
+			r := failed.Operation.Record
+			fmt.Printf("  ✗ %s %s %s %s: %s\n", failed.Operation.Op, r.Name, r.Type, r.RData, failed.Message)
+		}
+		if batchErr.Applied {
+			fmt.Printf("! The other %d change(s) were applied\n", len(batchErr.PassedOps()))
+		}
+		if opusdns.IsRecordProtectedError(err) && !allowProtected {
+			fmt.Println("! Protected records can only be changed with --allow-protected")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+
+	fmt.Printf("✓ Applied %d change(s) to zone '%s'\n", len(ops), zoneName)
+	if !result.Atomic {
+		fmt.Println("! The API did not apply the changes atomically")
+	}
+	return nil
+}
+
+// writeZoneFile writes records as zone file lines with a short header.
+func writeZoneFile(f *os.File, zoneName string, records []models.Record) {
+	fmt.Fprintf(f, "; Records of %s. Lines are \"name ttl type rdata\"; delete a line to remove\n", zoneName)
+	fmt.Fprintln(f, "; a record. Save and exit to review the changes before they are applied.")
+	writeZoneRecords(f, records)
+}
+
+// writeZoneRecords writes records as aligned "name ttl type rdata" lines.
+func writeZoneRecords(out io.Writer, records []models.Record) {
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	for _, r := range records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", name, r.TTL, r.Type, r.RData)
+	}
+	_ = w.Flush()
+}
+
+// parseZoneFile parses the lines written by writeZoneFile.
+func parseZoneFile(text string) ([]models.Record, error) {
+	var records []models.Record
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, rest := cutField(line)
+		ttlField, rest := cutField(rest)
+		typeField, rdata := cutField(rest)
+		if strings.EqualFold(typeField, "IN") {
+			typeField, rdata = cutField(rdata)
+		}
+		if rdata == "" {
+			return nil, fmt.Errorf("line %d: expected \"name ttl type rdata\"", n)
+		}
+		ttl, err := strconv.Atoi(ttlField)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("line %d: invalid TTL %q", n, ttlField)
+		}
+
+		records = append(records, models.Record{
+			Name:  name,
+			Type:  models.RRSetType(strings.ToUpper(typeField)),
+			TTL:   ttl,
+			RData: rdata,
+		})
+	}
+
+	return records, scanner.Err()
+}
+
+// cutField splits the first whitespace-separated field off s.
+func cutField(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := strings.Fields(editor)
+	c := exec.Command(args[0], append(args[1:], path)...) //nolint:gosec
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
+
+var zonesSummaryCmd = &cobra.Command{
+	Use:   "summary [zone-name]",
+	Short: "Show DNS statistics for the account or a single zone",
+	Long: `Without arguments, show the number of zones and their DNSSEC states.
+With a zone name, show that zone's record counts by type, DNSSEC state and
+last-modified time.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getContext()
+		defer cancel()
+
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if len(args) == 1 {
+			stats, err := getClient().DNS.GetZoneStats(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get zone statistics: %w", err)
+			}
+			if asJSON {
+				return printJSON(stats)
+			}
+			printZoneStats(stats)
+			return nil
+		}
+
+		summary, err := getClient().DNS.GetSummary(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get zone summary: %w", err)
+		}
+		if asJSON {
+			return printJSON(summary)
+		}
+
+		fmt.Printf("Zones: %d\n", summary.TotalZones)
+		if len(summary.ZonesByDNSSEC) > 0 {
+			fmt.Println("\nBy DNSSEC status:")
+			statuses := make([]string, 0, len(summary.ZonesByDNSSEC))
+			for status := range summary.ZonesByDNSSEC {
+				statuses = append(statuses, string(status))
+			}
+			sort.Strings(statuses)
+			for _, status := range statuses {
+				fmt.Printf("  • %-10s %d\n", status, summary.ZonesByDNSSEC[models.DNSSECStatus(status)])
+			}
+		}
+
+		return nil
+	},
+}
+
+// printZoneStats prints the statistics of a single zone.
+func printZoneStats(stats *models.ZoneStats) {
+	dnssec := string(stats.DNSSECStatus)
+	if dnssec == "" {
+		dnssec = "unknown"
+	}
+
+	fmt.Printf("Zone:          %s\n", stats.Zone)
+	fmt.Printf("DNSSEC:        %s\n", dnssec)
+	if stats.CreatedOn != nil {
+		fmt.Printf("Created:       %s\n", stats.CreatedOn.Format(time.RFC3339))
+	}
+	if stats.UpdatedOn != nil {
+		fmt.Printf("Last modified: %s\n", stats.UpdatedOn.Format(time.RFC3339))
+	}
+	fmt.Printf("RRsets:        %d\n", stats.RRSets)
+	fmt.Printf("Records:       %d\n", stats.Records)
+
+	if len(stats.RecordsByType) > 0 {
+		fmt.Println("\nRecords by type:")
+		types := make([]string, 0, len(stats.RecordsByType))
+		for t := range stats.RecordsByType {
+			types = append(types, string(t))
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			fmt.Printf("  • %-6s %d\n", t, stats.RecordsByType[models.RRSetType(t)])
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(zonesCmd)
 
 	// List subcommand
 	zonesCmd.AddCommand(zonesListCmd)
 	zonesListCmd.Flags().String("search", "", "Search zones by name")
+	zonesListCmd.Flags().StringSlice("tag", nil, "Filter by tag label (repeatable)")
+	zonesListCmd.Flags().Bool("match-all", false, "Require all --tag labels instead of any")
+
+	// Tag subcommand
+	zonesCmd.AddCommand(zonesTagCmd)
 
 	// Get subcommand
 	zonesCmd.AddCommand(zonesGetCmd)
@@ -152,4 +553,20 @@ func init() {
 	// Delete subcommand
 	zonesCmd.AddCommand(zonesDeleteCmd)
 	zonesDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	// Apply subcommand
+	zonesCmd.AddCommand(zonesApplyCmd)
+	zonesApplyCmd.Flags().StringP("file", "f", "", "JSON file with the RRsets to apply (required)")
+	zonesApplyCmd.Flags().StringToString("var", nil, "Template variable as KEY=VALUE (repeatable)")
+	zonesApplyCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	_ = zonesApplyCmd.MarkFlagRequired("file")
+
+	// Edit subcommand
+	zonesCmd.AddCommand(zonesEditCmd)
+	zonesEditCmd.Flags().Bool("force", false, "Apply changes without confirmation")
+	zonesEditCmd.Flags().Bool("allow-protected", false, "Allow changing or removing protected records")
+
+	// Summary subcommand
+	zonesCmd.AddCommand(zonesSummaryCmd)
+	zonesSummaryCmd.Flags().Bool("json", false, "Output as JSON")
 }
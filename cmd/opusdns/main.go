@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/cmd/opusdns/cmd"
 	"github.com/opusdns/opusdns-go-client/opusdns"
@@ -14,9 +15,22 @@ var (
 	date   = "unknown"
 )
 
+// exitTempFail is the exit status for failures that are worth retrying later
+// (EX_TEMPFAIL), such as a platform maintenance window.
+const exitTempFail = 75
+
 func main() {
 	cmd.SetVersion(opusdns.Version, commit, date)
 	if err := cmd.Execute(); err != nil {
+		if m, ok := opusdns.IsMaintenanceError(err); ok {
+			// Make clear the platform, not the user's setup, is the cause
+			window := "a maintenance window"
+			if !m.Until.IsZero() {
+				window += " until " + m.Until.Local().Format(time.RFC1123)
+			}
+			fmt.Fprintf(os.Stderr, "! OpusDNS is in %s. Your configuration is fine; try again later.\n", window)
+			os.Exit(exitTempFail)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,162 @@
+// Package metrics exposes the health of a domain portfolio as Prometheus
+// metrics, gathered through an opusdns.Client on every scrape.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultTimeout is the default time limit for gathering the metrics of one
+// scrape.
+const DefaultTimeout = 30 * time.Second
+
+const namespace = "opusdns"
+
+var (
+	upDesc = prometheus.NewDesc(namespace+"_up",
+		"Whether the last scrape gathered every metric from the OpusDNS API.", nil, nil)
+	domainExpiringDesc = prometheus.NewDesc(namespace+"_domains_expiring_in_days",
+		"Calendar days until the domain expires in the registry's timezone (negative once expired).",
+		[]string{"domain", "renewal_mode"}, nil)
+	zonesDesc = prometheus.NewDesc(namespace+"_zones_total",
+		"Number of DNS zones.", nil, nil)
+	dnssecDesc = prometheus.NewDesc(namespace+"_dnssec_enabled_total",
+		"Number of DNS zones with DNSSEC enabled.", nil, nil)
+	transferLockDesc = prometheus.NewDesc(namespace+"_transfer_lock_disabled_total",
+		"Number of domains without a transfer lock.", nil, nil)
+	walletBalanceDesc = prometheus.NewDesc(namespace+"_wallet_balance",
+		"Balance of the account wallet.", []string{"currency"}, nil)
+)
+
+// WalletBalanceFunc returns the balance of the account wallet.
+type WalletBalanceFunc func(ctx context.Context) (float64, models.Currency, error)
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithTimeout sets the time limit for gathering the metrics of one scrape.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Collector) {
+		c.timeout = d
+	}
+}
+
+// WithWalletBalance reports the wallet balance returned by fn. The API does
+// not expose the balance, so opusdns_wallet_balance is only reported with
+// this option, for example from a billing system.
+func WithWalletBalance(fn WalletBalanceFunc) Option {
+	return func(c *Collector) {
+		c.walletBalance = fn
+	}
+}
+
+// WithErrorHandler sets a function called with the errors of each scrape.
+// Failed metrics are left out of the scrape and opusdns_up is 0.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *Collector) {
+		c.onError = fn
+	}
+}
+
+// Collector is a prometheus.Collector reporting the health of a domain
+// portfolio. Every scrape lists all domains and zones, so scrape intervals
+// of a few minutes or more are recommended for large portfolios.
+type Collector struct {
+	client        *opusdns.Client
+	timeout       time.Duration
+	walletBalance WalletBalanceFunc
+	onError       func(error)
+	now           func() time.Time
+
+	// mu serializes scrapes, so concurrent scrapes do not multiply API calls
+	mu sync.Mutex
+}
+
+// NewCollector creates a collector for the client's portfolio. Register it
+// with a prometheus.Registerer to expose the metrics.
+func NewCollector(client *opusdns.Client, opts ...Option) *Collector {
+	c := &Collector{
+		client:  client,
+		timeout: DefaultTimeout,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- upDesc
+	ch <- domainExpiringDesc
+	ch <- zonesDesc
+	ch <- dnssecDesc
+	ch <- transferLockDesc
+	if c.walletBalance != nil {
+		ch <- walletBalanceDesc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	up := 1.0
+	fail := func(err error) {
+		up = 0
+		if c.onError != nil {
+			c.onError(err)
+		}
+	}
+
+	if domains, err := c.client.Domains.ListDomains(ctx, nil); err != nil {
+		fail(err)
+	} else {
+		today := models.RegistryDate(c.now())
+		unlocked := 0
+		for _, d := range domains {
+			if !d.TransferLock {
+				unlocked++
+			}
+			if d.ExpiresOn != nil {
+				days := today.DaysUntil(models.RegistryDate(*d.ExpiresOn))
+				ch <- prometheus.MustNewConstMetric(domainExpiringDesc, prometheus.GaugeValue, float64(days), d.Name, string(d.RenewalMode))
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(transferLockDesc, prometheus.GaugeValue, float64(unlocked))
+	}
+
+	if zones, err := c.client.DNS.ListZones(ctx, nil); err != nil {
+		fail(err)
+	} else {
+		signed := 0
+		for _, z := range zones {
+			if z.DNSSECStatus == models.DNSSECStatusEnabled {
+				signed++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(zonesDesc, prometheus.GaugeValue, float64(len(zones)))
+		ch <- prometheus.MustNewConstMetric(dnssecDesc, prometheus.GaugeValue, float64(signed))
+	}
+
+	if c.walletBalance != nil {
+		if balance, currency, err := c.walletBalance(ctx); err != nil {
+			fail(err)
+		} else {
+			ch <- prometheus.MustNewConstMetric(walletBalanceDesc, prometheus.GaugeValue, balance, string(currency))
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up)
+}
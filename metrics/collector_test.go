@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCollector(t *testing.T, handler http.HandlerFunc, opts ...Option) *Collector {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL), opusdns.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	c := NewCollector(client, opts...)
+	c.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestCollector(t *testing.T) {
+	expires := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	expired := time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("reports portfolio health", func(t *testing.T) {
+		c := newTestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/domains":
+				_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+					{Name: "example.com", ExpiresOn: &expires, TransferLock: true, RenewalMode: models.RenewalModeRenew},
+					{Name: "example.org", ExpiresOn: &expired, RenewalMode: models.RenewalModeExpire},
+				}})
+			case "/v1/dns":
+				_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: []models.Zone{
+					{Name: "example.com", DNSSECStatus: models.DNSSECStatusEnabled},
+					{Name: "example.org", DNSSECStatus: models.DNSSECStatusDisabled},
+					{Name: "example.net"},
+				}})
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}, WithWalletBalance(func(context.Context) (float64, models.Currency, error) {
+			return 125.5, models.CurrencyEUR, nil
+		}))
+
+		err := testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP opusdns_dnssec_enabled_total Number of DNS zones with DNSSEC enabled.
+# TYPE opusdns_dnssec_enabled_total gauge
+opusdns_dnssec_enabled_total 1
+# HELP opusdns_domains_expiring_in_days Calendar days until the domain expires in the registry's timezone (negative once expired).
+# TYPE opusdns_domains_expiring_in_days gauge
+opusdns_domains_expiring_in_days{domain="example.com",renewal_mode="renew"} 30
+opusdns_domains_expiring_in_days{domain="example.org",renewal_mode="expire"} -2
+# HELP opusdns_transfer_lock_disabled_total Number of domains without a transfer lock.
+# TYPE opusdns_transfer_lock_disabled_total gauge
+opusdns_transfer_lock_disabled_total 1
+# HELP opusdns_up Whether the last scrape gathered every metric from the OpusDNS API.
+# TYPE opusdns_up gauge
+opusdns_up 1
+# HELP opusdns_wallet_balance Balance of the account wallet.
+# TYPE opusdns_wallet_balance gauge
+opusdns_wallet_balance{currency="EUR"} 125.5
+# HELP opusdns_zones_total Number of DNS zones.
+# TYPE opusdns_zones_total gauge
+opusdns_zones_total 3
+`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports failures through opusdns_up", func(t *testing.T) {
+		var errs []error
+		c := newTestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/dns" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{})
+		}, WithErrorHandler(func(err error) { errs = append(errs, err) }),
+			WithWalletBalance(func(context.Context) (float64, models.Currency, error) {
+				return 0, "", errors.New("billing unavailable")
+			}))
+
+		err := testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP opusdns_up Whether the last scrape gathered every metric from the OpusDNS API.
+# TYPE opusdns_up gauge
+opusdns_up 0
+`), "opusdns_up", "opusdns_zones_total", "opusdns_wallet_balance")
+		assert.NoError(t, err)
+		assert.Len(t, errs, 2)
+	})
+}
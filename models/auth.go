@@ -51,3 +51,98 @@ type OrganizationCredential struct {
 	// LastUsedOn is when the API key was last used.
 	LastUsedOn *time.Time `json:"last_used_on,omitempty"`
 }
+
+// OrganizationCredentialListResponse represents the paginated response when listing API keys.
+type OrganizationCredentialListResponse struct {
+	Results    []OrganizationCredential `json:"results"`
+	Pagination Pagination               `json:"pagination"`
+}
+
+// ListAPIKeysOptions contains options for listing API keys.
+type ListAPIKeysOptions struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// PageSize is the number of results per page.
+	PageSize int
+
+	// Status filters by API key status.
+	Status OrganizationCredentialStatus
+}
+
+// APIKeyCreateRequest represents a request to create an API key.
+type APIKeyCreateRequest struct {
+	// APIKeyName is the optional name of the API key.
+	APIKeyName *string `json:"api_key_name,omitempty"`
+
+	// APIKeyDescription is the optional description of the API key.
+	APIKeyDescription *string `json:"api_key_description,omitempty"`
+
+	// ExpiresAt is when the API key expires; nil keeps it valid until revoked.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Role is the role to bind the API key to, by built-in role name or custom
+	// role label. Nil uses the organization's default.
+	Role *string `json:"role,omitempty"`
+}
+
+// APIKeyCreated is the response to creating an API key. The secret is only
+// returned here; it cannot be retrieved again.
+type APIKeyCreated struct {
+	OrganizationCredential
+
+	// APIKey is the secret key (format: opk_...).
+	APIKey string `json:"api_key"`
+}
+
+// TokenType is the kind of credential a token is.
+type TokenType string
+
+const (
+	// TokenTypeAPIKey is an organization API key.
+	TokenTypeAPIKey TokenType = "api_key"
+
+	// TokenTypeAccessToken is a user access token issued at sign-in.
+	TokenTypeAccessToken TokenType = "access_token"
+)
+
+// TokenIntrospectionRequest represents a request to introspect a token.
+type TokenIntrospectionRequest struct {
+	// Token is the API key or access token to inspect.
+	Token string `json:"token"`
+}
+
+// TokenIntrospection describes a token, following RFC 7662. Inactive tokens
+// (unknown, expired or revoked) only report Active.
+type TokenIntrospection struct {
+	// Active reports whether the token is currently valid.
+	Active bool `json:"active"`
+
+	// TokenType is the kind of token.
+	TokenType TokenType `json:"token_type,omitempty"`
+
+	// OrganizationID is the organization the token belongs to.
+	OrganizationID OrganizationID `json:"organization_id,omitempty"`
+
+	// UserID is the user an access token was issued to.
+	UserID *UserID `json:"user_id,omitempty"`
+
+	// SessionID is the session an access token belongs to; revoke it with
+	// UsersService.RevokeSession.
+	SessionID *UserSessionID `json:"session_id,omitempty"`
+
+	// APIKeyID identifies an API key; revoke it with APIKeysService.RevokeAPIKey.
+	APIKeyID *OrganizationCredentialID `json:"api_key_id,omitempty"`
+
+	// Role is the role the token acts with, if any.
+	Role *string `json:"role,omitempty"`
+
+	// IssuedAt is when the token was issued.
+	IssuedAt *time.Time `json:"issued_at,omitempty"`
+
+	// ExpiresAt is when the token expires, if it does.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// LastUsedOn is when the token was last used.
+	LastUsedOn *time.Time `json:"last_used_on,omitempty"`
+}
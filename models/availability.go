@@ -122,7 +122,7 @@ type PremiumPricingAction struct {
 	Price string `json:"price"`
 
 	// Currency is the ISO 4217 currency code.
-	Currency string `json:"currency"`
+	Currency Currency `json:"currency"`
 }
 
 // DomainSuggestion represents a suggested domain name (DomainSearchSuggestionWithPrice).
@@ -149,7 +149,7 @@ type DomainSearchSuggestionPriceData struct {
 	Amount *string `json:"amount"`
 
 	// Currency is the currency code.
-	Currency string `json:"currency"`
+	Currency Currency `json:"currency"`
 
 	// Period is the registration/renewal period.
 	Period DomainPeriod `json:"period"`
@@ -148,14 +148,6 @@ func (t TypeID) IsEmpty() bool {
 	return t == ""
 }
 
-// Currency represents a currency code.
-type Currency string
-
-const (
-	CurrencyEUR Currency = "EUR"
-	CurrencyUSD Currency = "USD"
-)
-
 // DomainNameParts represents the parts of a domain name.
 type DomainNameParts struct {
 	// Domain is the second-level domain (e.g., "example" in "example.com").
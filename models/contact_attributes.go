@@ -90,6 +90,27 @@ type ContactAttributeLink struct {
 	CreatedOn *time.Time `json:"created_on,omitempty"`
 }
 
+// ContactRegistryAttributes represents the TLD-specific registry attributes set on a contact.
+type ContactRegistryAttributes struct {
+	// ContactID is the contact the attributes belong to.
+	ContactID ContactID `json:"contact_id"`
+
+	// TLD is the TLD the attributes apply to.
+	TLD string `json:"tld"`
+
+	// Attributes maps registry attribute keys to their values.
+	Attributes map[RegistryHandleAttributeType]string `json:"attributes"`
+
+	// UpdatedOn is when the attributes were last updated.
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+}
+
+// ContactRegistryAttributesUpdateRequest is the request body for setting a contact's registry attributes.
+type ContactRegistryAttributesUpdateRequest struct {
+	// Attributes maps registry attribute keys to their values. It replaces the existing set.
+	Attributes map[RegistryHandleAttributeType]string `json:"attributes"`
+}
+
 // ContactVerificationClaim is a claim type that can be verified for a contact.
 type ContactVerificationClaim string
 
@@ -1,7 +1,12 @@
 // Package models contains all the data types for the OpusDNS API.
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+)
 
 // ContactID is a TypeID for contacts.
 type ContactID = TypeID
@@ -113,6 +118,9 @@ type Contact struct {
 	// Disclose indicates whether contact information should be publicly disclosed.
 	Disclose bool `json:"disclose"`
 
+	// DiscloseFields overrides Disclose for individual fields (optional).
+	DiscloseFields *ContactDiscloseFields `json:"disclose_fields,omitempty"`
+
 	// CreatedOn is when the contact was created.
 	CreatedOn *time.Time `json:"created_on,omitempty"`
 
@@ -121,6 +129,9 @@ type Contact struct {
 
 	// DeletedOn is when the contact was deleted.
 	DeletedOn *time.Time `json:"deleted_on,omitempty"`
+
+	// maskJSON makes MarshalJSON encode the Masked form; see WithJSONMasking.
+	maskJSON bool
 }
 
 // FullName returns the contact's full name.
@@ -131,6 +142,89 @@ func (c *Contact) FullName() string {
 	return c.FirstName + " " + c.LastName
 }
 
+// WithJSONMasking returns a copy of the contact whose JSON encoding is its
+// Masked form when enabled is true, so tools that log or render contacts can
+// opt in per value. The setting belongs to the copy only: other contacts, and
+// request bodies built from them, keep encoding the real values.
+func (c Contact) WithJSONMasking(enabled bool) Contact {
+	c.maskJSON = enabled
+	return c
+}
+
+// MarshalJSON encodes the contact, masked if it was returned by
+// WithJSONMasking(true).
+func (c Contact) MarshalJSON() ([]byte, error) {
+	type plain Contact
+	if c.maskJSON {
+		return json.Marshal(plain(c.Masked()))
+	}
+	return json.Marshal(plain(c))
+}
+
+// Masked returns a copy of the contact with personal data partially masked,
+// suitable for logs and low-privilege UIs. The email local part, last name and
+// postal code keep only their first character, phone and fax numbers keep only
+// their last two digits, and the street address is removed. Identifiers,
+// first name, organization, city, state and country are left intact.
+func (c *Contact) Masked() Contact {
+	masked := *c
+	masked.Email = maskEmail(c.Email)
+	masked.LastName = maskKeepPrefix(c.LastName, 1)
+	masked.Phone = maskDigits(c.Phone, 2)
+	masked.Street = ""
+	masked.PostalCode = maskKeepPrefix(c.PostalCode, 1)
+	if c.Fax != nil {
+		fax := maskDigits(*c.Fax, 2)
+		masked.Fax = &fax
+	}
+	return masked
+}
+
+// maskEmail masks the local part of an email address (john@example.com -> j***@example.com).
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return maskKeepPrefix(email, 1)
+	}
+	return maskKeepPrefix(email[:at], 1) + email[at:]
+}
+
+// maskKeepPrefix keeps the first n runes of s and replaces the rest with "***".
+func maskKeepPrefix(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	if len(runes) <= n {
+		return string(runes[:1]) + "***"
+	}
+	return string(runes[:n]) + "***"
+}
+
+// maskDigits replaces every digit except the last n with '*', keeping separators.
+func maskDigits(s string, n int) string {
+	total := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			total++
+		}
+	}
+
+	var b strings.Builder
+	seen := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			seen++
+			if seen <= total-n {
+				b.WriteRune('*')
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // ContactListResponse represents the paginated response when listing contacts.
 type ContactListResponse struct {
 	// Results contains the list of contacts for the current page.
@@ -180,6 +274,72 @@ type ContactCreateRequest struct {
 
 	// Disclose indicates whether contact information should be publicly disclosed.
 	Disclose bool `json:"disclose"`
+
+	// DiscloseFields overrides Disclose for individual fields (optional).
+	DiscloseFields *ContactDiscloseFields `json:"disclose_fields,omitempty"`
+}
+
+// ContactDiscloseField names a group of contact data that can be disclosed individually.
+type ContactDiscloseField string
+
+const (
+	ContactDiscloseName    ContactDiscloseField = "name"
+	ContactDiscloseOrg     ContactDiscloseField = "org"
+	ContactDiscloseEmail   ContactDiscloseField = "email"
+	ContactDisclosePhone   ContactDiscloseField = "phone"
+	ContactDiscloseAddress ContactDiscloseField = "address"
+)
+
+// ContactDiscloseFields controls public (WHOIS/RDAP) disclosure per field.
+// A nil field inherits the contact-level Disclose setting.
+type ContactDiscloseFields struct {
+	// Name controls disclosure of the first and last name.
+	Name *bool `json:"name,omitempty"`
+
+	// Org controls disclosure of the organization.
+	Org *bool `json:"org,omitempty"`
+
+	// Email controls disclosure of the email address.
+	Email *bool `json:"email,omitempty"`
+
+	// Phone controls disclosure of the phone and fax numbers.
+	Phone *bool `json:"phone,omitempty"`
+
+	// Address controls disclosure of the postal address.
+	Address *bool `json:"address,omitempty"`
+}
+
+// ContactDisclosureUpdateRequest represents a request to update a contact's per-field disclosure.
+type ContactDisclosureUpdateRequest struct {
+	// Disclose optionally updates the contact-level default.
+	Disclose *bool `json:"disclose,omitempty"`
+
+	// DiscloseFields contains the per-field overrides to apply.
+	DiscloseFields ContactDiscloseFields `json:"disclose_fields"`
+}
+
+// IsDisclosed reports whether the given field is publicly disclosed, applying the
+// per-field override when set and falling back to Disclose otherwise.
+func (c *Contact) IsDisclosed(field ContactDiscloseField) bool {
+	if c.DiscloseFields != nil {
+		var override *bool
+		switch field {
+		case ContactDiscloseName:
+			override = c.DiscloseFields.Name
+		case ContactDiscloseOrg:
+			override = c.DiscloseFields.Org
+		case ContactDiscloseEmail:
+			override = c.DiscloseFields.Email
+		case ContactDisclosePhone:
+			override = c.DiscloseFields.Phone
+		case ContactDiscloseAddress:
+			override = c.DiscloseFields.Address
+		}
+		if override != nil {
+			return *override
+		}
+	}
+	return c.Disclose
 }
 
 // VerificationType is how the verification token is retrieved.
@@ -275,6 +435,9 @@ type ListContactsOptions struct {
 	// Country filters by country code.
 	Country string
 
+	// PostalCode filters by postal code.
+	PostalCode string
+
 	// Verified filters by verification status.
 	Verified *bool
 
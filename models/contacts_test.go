@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContact() Contact {
+	fax := "+49.301234567"
+	return Contact{
+		ContactID:  "contact_01h45ytscbebyvny4gc8cr8ma2",
+		FirstName:  "John",
+		LastName:   "Doe",
+		Email:      "john.doe@example.com",
+		Phone:      "+1.2125551234",
+		Fax:        &fax,
+		Street:     "123 Main St",
+		City:       "New York",
+		PostalCode: "10001",
+		Country:    "US",
+	}
+}
+
+func TestContact_Masked(t *testing.T) {
+	contact := testContact()
+	masked := contact.Masked()
+
+	assert.Equal(t, "j***@example.com", masked.Email)
+	assert.Equal(t, "D***", masked.LastName)
+	assert.Equal(t, "+*.********34", masked.Phone)
+	require.NotNil(t, masked.Fax)
+	assert.Equal(t, "+**.*******67", *masked.Fax)
+	assert.Empty(t, masked.Street)
+	assert.Equal(t, "1***", masked.PostalCode)
+
+	assert.Equal(t, contact.ContactID, masked.ContactID)
+	assert.Equal(t, "John", masked.FirstName)
+	assert.Equal(t, "New York", masked.City)
+	assert.Equal(t, "US", masked.Country)
+
+	assert.Equal(t, "john.doe@example.com", contact.Email, "the original is unchanged")
+	assert.Equal(t, "+49.301234567", *contact.Fax)
+}
+
+func TestContact_Masked_EdgeCases(t *testing.T) {
+	masked := (&Contact{Email: "nobody", LastName: "X", Phone: "12"}).Masked()
+	assert.Equal(t, "n***", masked.Email)
+	assert.Equal(t, "X***", masked.LastName)
+	assert.Equal(t, "12", masked.Phone)
+	assert.Nil(t, masked.Fax)
+}
+
+func TestContact_WithJSONMasking(t *testing.T) {
+	contact := testContact()
+
+	data, err := json.Marshal(contact)
+	require.NoError(t, err)
+	var decoded Contact
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "john.doe@example.com", decoded.Email, "contacts encode real values by default")
+	assert.Equal(t, "123 Main St", decoded.Street)
+
+	masked := contact.WithJSONMasking(true)
+	data, err = json.Marshal(&masked)
+	require.NoError(t, err)
+	decoded = Contact{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, contact.Masked(), decoded)
+	assert.NotContains(t, string(data), "maskJSON")
+
+	data, err = json.Marshal([]Contact{contact, masked})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"email":"john.doe@example.com"`, "masking applies to the copy only")
+	assert.Contains(t, string(data), `"email":"j***@example.com"`)
+
+	data, err = json.Marshal(masked.WithJSONMasking(false))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"email":"john.doe@example.com"`)
+}
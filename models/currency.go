@@ -0,0 +1,181 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+const (
+	CurrencyEUR Currency = "EUR"
+	CurrencyUSD Currency = "USD"
+	CurrencyGBP Currency = "GBP"
+	CurrencyCHF Currency = "CHF"
+	CurrencySEK Currency = "SEK"
+	CurrencyNOK Currency = "NOK"
+	CurrencyDKK Currency = "DKK"
+	CurrencyPLN Currency = "PLN"
+	CurrencyCAD Currency = "CAD"
+	CurrencyAUD Currency = "AUD"
+	CurrencyJPY Currency = "JPY"
+)
+
+// currencyInfo holds the display details of a currency.
+type currencyInfo struct {
+	symbol   string
+	decimals int
+	// prefix places the symbol before the amount ("€10.00") instead of after
+	// it ("10.00 kr").
+	prefix bool
+}
+
+var currencies = map[Currency]currencyInfo{
+	CurrencyEUR: {symbol: "€", decimals: 2, prefix: true},
+	CurrencyUSD: {symbol: "$", decimals: 2, prefix: true},
+	CurrencyGBP: {symbol: "£", decimals: 2, prefix: true},
+	CurrencyCHF: {symbol: "CHF", decimals: 2, prefix: true},
+	CurrencySEK: {symbol: "kr", decimals: 2},
+	CurrencyNOK: {symbol: "kr", decimals: 2},
+	CurrencyDKK: {symbol: "kr.", decimals: 2},
+	CurrencyPLN: {symbol: "zł", decimals: 2},
+	CurrencyCAD: {symbol: "CA$", decimals: 2, prefix: true},
+	CurrencyAUD: {symbol: "A$", decimals: 2, prefix: true},
+	CurrencyJPY: {symbol: "¥", decimals: 0, prefix: true},
+}
+
+// ParseCurrency parses a currency code case-insensitively. It returns an
+// error for codes the platform does not use.
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.IsValid() {
+		return "", fmt.Errorf("unsupported currency %q", code)
+	}
+	return c, nil
+}
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsValid reports whether c is one of the currencies the platform uses.
+func (c Currency) IsValid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Symbol returns the currency symbol, or the code for unknown currencies.
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok {
+		return info.symbol
+	}
+	return string(c)
+}
+
+// Decimals returns the number of minor unit digits of the currency (0 for
+// JPY, 2 for most others). Unknown currencies are assumed to have 2.
+func (c Currency) Decimals() int {
+	if info, ok := currencies[c]; ok {
+		return info.decimals
+	}
+	return 2
+}
+
+// RoundAmount rounds a decimal amount as returned by the API (e.g. "12.5")
+// to the currency's minor units, half away from zero, without going through
+// floating point: "12.5" becomes "12.50" in EUR and "1234.5" becomes "1235"
+// in JPY. Empty amounts and amounts without digits are errors.
+func (c Currency) RoundAmount(amount string) (string, error) {
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return "", fmt.Errorf("invalid amount %q", amount)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	decimals := c.Decimals()
+	roundUp := len(frac) > decimals && frac[decimals] >= '5'
+	if len(frac) > decimals {
+		frac = frac[:decimals]
+	}
+	frac += strings.Repeat("0", decimals-len(frac))
+
+	digits := []byte(whole + frac)
+	if roundUp {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i < 0 {
+			digits = append([]byte{'1'}, digits...)
+		} else {
+			digits[i]++
+		}
+	}
+
+	whole = strings.TrimLeft(string(digits[:len(digits)-decimals]), "0")
+	if whole == "" {
+		whole = "0"
+	}
+	result := whole
+	if decimals > 0 {
+		result += "." + string(digits[len(digits)-decimals:])
+	}
+	if negative && strings.Trim(result, "0.") != "" {
+		result = "-" + result
+	}
+	return result, nil
+}
+
+// FormatAmount rounds amount with RoundAmount and adds the currency symbol,
+// e.g. "€12.50", "¥1235" or "99.00 kr".
+func (c Currency) FormatAmount(amount string) (string, error) {
+	rounded, err := c.RoundAmount(amount)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if strings.HasPrefix(rounded, "-") {
+		sign, rounded = "-", rounded[1:]
+	}
+
+	info, ok := currencies[c]
+	switch {
+	case !ok:
+		return sign + rounded + " " + string(c), nil
+	case info.prefix && len(info.symbol) > 1 && isLetters(info.symbol):
+		return sign + info.symbol + " " + rounded, nil
+	case info.prefix:
+		return sign + info.symbol + rounded, nil
+	default:
+		return sign + rounded + " " + info.symbol, nil
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency_RoundAmount(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		amount   string
+		want     string
+	}{
+		{CurrencyEUR, "12.5", "12.50"},
+		{CurrencyEUR, "12", "12.00"},
+		{CurrencyEUR, " 0012.344 ", "12.34"},
+		{CurrencyEUR, "12.345", "12.35"},
+		{CurrencyEUR, "9.995", "10.00"},
+		{CurrencyEUR, "999.999", "1000.00"},
+		{CurrencyEUR, ".5", "0.50"},
+		{CurrencyEUR, "5.", "5.00"},
+		{CurrencyEUR, "+7.1", "7.10"},
+		{CurrencyEUR, "-12.345", "-12.35"},
+		{CurrencyEUR, "-0.005", "-0.01"},
+		{CurrencyEUR, "-0.004", "0.00"},
+		{CurrencyEUR, "-0", "0.00"},
+		{CurrencyJPY, "1234.5", "1235"},
+		{CurrencyJPY, "1234.49", "1234"},
+		{CurrencyJPY, "999.5", "1000"},
+		{CurrencyJPY, "-0.4", "0"},
+		{Currency("XYZ"), "1.005", "1.01"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.currency)+" "+tc.amount, func(t *testing.T) {
+			got, err := tc.currency.RoundAmount(tc.amount)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCurrency_RoundAmount_Invalid(t *testing.T) {
+	for _, amount := range []string{"", " ", "-", "+", ".", "-.", "--1", "+-1", "1.2.3", "1,50", "abc", "1e3", "€5"} {
+		_, err := CurrencyEUR.RoundAmount(amount)
+		assert.Error(t, err, "amount %q", amount)
+	}
+}
+
+func TestCurrency_FormatAmount(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		amount   string
+		want     string
+	}{
+		{CurrencyEUR, "12.5", "€12.50"},
+		{CurrencyUSD, "-3", "-$3.00"},
+		{CurrencyGBP, "9.995", "£10.00"},
+		{CurrencyCHF, "12.5", "CHF 12.50"},
+		{CurrencyCAD, "1", "CA$1.00"},
+		{CurrencySEK, "99", "99.00 kr"},
+		{CurrencyDKK, "-5.5", "-5.50 kr."},
+		{CurrencyPLN, "0.004", "0.00 zł"},
+		{CurrencyJPY, "1234.5", "¥1235"},
+		{Currency("XYZ"), "2", "2.00 XYZ"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.currency)+" "+tc.amount, func(t *testing.T) {
+			got, err := tc.currency.FormatAmount(tc.amount)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := CurrencyEUR.FormatAmount("")
+	assert.Error(t, err, "a missing price is not formatted as zero")
+}
+
+func TestParseCurrency(t *testing.T) {
+	c, err := ParseCurrency(" eur ")
+	require.NoError(t, err)
+	assert.Equal(t, CurrencyEUR, c)
+	assert.Equal(t, 0, CurrencyJPY.Decimals())
+	assert.Equal(t, "€", CurrencyEUR.Symbol())
+
+	_, err = ParseCurrency("BTC")
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Registries and the billing system both work in UTC: a domain whose
+// ExpiresOn is 2026-03-01T00:30:00Z expires on March 1st, even though that is
+// still February 28th in New York. Comparing dates after converting them to
+// local time, or rounding durations down to whole days, can therefore be off
+// by one day. The helpers below compare calendar dates in the canonical
+// timezone instead.
+
+// Date is a calendar day without a time of day or timezone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar day of t in loc.
+func DateOf(t time.Time, loc *time.Location) Date {
+	y, m, d := t.In(loc).Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// RegistryDate returns the calendar day of t in the registries' timezone
+// (UTC), the day on which an expiry at t takes effect.
+func RegistryDate(t time.Time) Date {
+	return DateOf(t, time.UTC)
+}
+
+// BillingDate returns the calendar day of t in the billing timezone (UTC),
+// the day on which a payment due at t becomes due.
+func BillingDate(t time.Time) Date {
+	return DateOf(t, time.UTC)
+}
+
+// ParseDate parses a date in "2006-01-02" format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return DateOf(t, time.UTC), nil
+}
+
+// String returns the date in "2006-01-02" format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time returns midnight at the start of d in loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.DaysUntil(other) > 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.DaysUntil(other) < 0
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time(time.UTC).AddDate(0, 0, n), time.UTC)
+}
+
+// DaysUntil returns the number of calendar days from d to other, negative
+// if other is before d.
+func (d Date) DaysUntil(other Date) int {
+	return int(other.Time(time.UTC).Sub(d.Time(time.UTC)).Hours() / 24)
+}
+
+// CrossesMidnight reports whether t falls on a different calendar day in loc
+// than in UTC. For such timestamps, formatting or comparing the date in loc
+// (e.g. time.Local) gives a day other than the registry or billing date.
+func CrossesMidnight(t time.Time, loc *time.Location) bool {
+	return DateOf(t, loc) != DateOf(t, time.UTC)
+}
+
+// ExpiryDate returns the registry date on which the domain expires, and false
+// if the expiry is unknown.
+func (d *Domain) ExpiryDate() (Date, bool) {
+	if d.ExpiresOn == nil {
+		return Date{}, false
+	}
+	return RegistryDate(*d.ExpiresOn), true
+}
+
+// DaysUntilExpiry returns the number of calendar days from now until the
+// domain's expiry date in the registry's timezone: 0 on the day it expires,
+// negative once expired. It returns false if the expiry is unknown.
+func (d *Domain) DaysUntilExpiry(now time.Time) (int, bool) {
+	expires, ok := d.ExpiryDate()
+	if !ok {
+		return 0, false
+	}
+	return RegistryDate(now).DaysUntil(expires), true
+}
+
+// DueDate returns the billing date on which payment of the invoice is due,
+// and false if the invoice has no due date.
+func (i *Invoice) DueDate() (Date, bool) {
+	if i.PaymentDueDate == nil {
+		return Date{}, false
+	}
+	return BillingDate(*i.PaymentDueDate), true
+}
+
+// DaysUntilDue returns the number of calendar days from now until the
+// invoice's due date in the billing timezone: 0 on the due date, negative
+// once overdue. It returns false if the invoice has no due date.
+func (i *Invoice) DaysUntilDue(now time.Time) (int, bool) {
+	due, ok := i.DueDate()
+	if !ok {
+		return 0, false
+	}
+	return BillingDate(now).DaysUntil(due), true
+}
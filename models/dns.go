@@ -1,7 +1,10 @@
 // Package models contains all the data types for the OpusDNS API.
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // DNSSECStatus represents the DNSSEC status of a zone.
 type DNSSECStatus string
@@ -18,27 +21,29 @@ const (
 type RRSetType string
 
 const (
-	RRSetTypeA      RRSetType = "A"
-	RRSetTypeAAAA   RRSetType = "AAAA"
-	RRSetTypeALIAS  RRSetType = "ALIAS"
-	RRSetTypeCAA    RRSetType = "CAA"
-	RRSetTypeCERT   RRSetType = "CERT"
-	RRSetTypeCNAME  RRSetType = "CNAME"
-	RRSetTypeDNSKEY RRSetType = "DNSKEY"
-	RRSetTypeDS     RRSetType = "DS"
-	RRSetTypeHTTPS  RRSetType = "HTTPS"
-	RRSetTypeMX     RRSetType = "MX"
-	RRSetTypeNAPTR  RRSetType = "NAPTR"
-	RRSetTypeNS     RRSetType = "NS"
-	RRSetTypePTR    RRSetType = "PTR"
-	RRSetTypeTXT    RRSetType = "TXT"
-	RRSetTypeSOA    RRSetType = "SOA"
-	RRSetTypeSSHFP  RRSetType = "SSHFP"
-	RRSetTypeSRV    RRSetType = "SRV"
-	RRSetTypeSVCB   RRSetType = "SVCB"
-	RRSetTypeSMIMEA RRSetType = "SMIMEA"
-	RRSetTypeTLSA   RRSetType = "TLSA"
-	RRSetTypeURI    RRSetType = "URI"
+	RRSetTypeA       RRSetType = "A"
+	RRSetTypeAAAA    RRSetType = "AAAA"
+	RRSetTypeALIAS   RRSetType = "ALIAS"
+	RRSetTypeCAA     RRSetType = "CAA"
+	RRSetTypeCDNSKEY RRSetType = "CDNSKEY"
+	RRSetTypeCDS     RRSetType = "CDS"
+	RRSetTypeCERT    RRSetType = "CERT"
+	RRSetTypeCNAME   RRSetType = "CNAME"
+	RRSetTypeDNSKEY  RRSetType = "DNSKEY"
+	RRSetTypeDS      RRSetType = "DS"
+	RRSetTypeHTTPS   RRSetType = "HTTPS"
+	RRSetTypeMX      RRSetType = "MX"
+	RRSetTypeNAPTR   RRSetType = "NAPTR"
+	RRSetTypeNS      RRSetType = "NS"
+	RRSetTypePTR     RRSetType = "PTR"
+	RRSetTypeTXT     RRSetType = "TXT"
+	RRSetTypeSOA     RRSetType = "SOA"
+	RRSetTypeSSHFP   RRSetType = "SSHFP"
+	RRSetTypeSRV     RRSetType = "SRV"
+	RRSetTypeSVCB    RRSetType = "SVCB"
+	RRSetTypeSMIMEA  RRSetType = "SMIMEA"
+	RRSetTypeTLSA    RRSetType = "TLSA"
+	RRSetTypeURI     RRSetType = "URI"
 )
 
 // DnsProtectedReason describes why a DNS RRset or record is protected from modification.
@@ -89,6 +94,18 @@ type Zone struct {
 	// zone, or nil when the zone uses the system default nameservers.
 	VanityNameserverSetID *VanityNameserverSetID `json:"vanity_nameserver_set_id,omitempty"`
 
+	// Kind is whether the zone is served from OpusDNS records (primary) or
+	// transferred from external primaries (secondary). Empty means primary.
+	Kind ZoneKind `json:"kind,omitempty"`
+
+	// Transfer holds the zone transfer settings of a secondary zone.
+	Transfer *ZoneTransferSettings `json:"transfer,omitempty"`
+
+	// Serial is the zone's SOA serial, which grows with every change. Pass it
+	// as RecordPatchRequest.ExpectedSerial to only apply a patch to this
+	// version of the zone.
+	Serial int64 `json:"serial,omitempty"`
+
 	// RRSets contains the resource record sets for this zone.
 	// This field is populated when fetching a single zone with records.
 	RRSets []RRSet `json:"rrsets,omitempty"`
@@ -121,6 +138,48 @@ type ZoneSummary struct {
 	ZonesByDNSSEC map[DNSSECStatus]int `json:"zones_by_dnssec,omitempty"`
 }
 
+// ZoneStats holds statistics about a single zone.
+type ZoneStats struct {
+	// Zone is the zone name.
+	Zone string `json:"zone"`
+
+	// DNSSECStatus is the zone's DNSSEC status.
+	DNSSECStatus DNSSECStatus `json:"dnssec_status,omitempty"`
+
+	// RRSets is the number of RRsets in the zone.
+	RRSets int `json:"rrsets"`
+
+	// Records is the number of records in the zone.
+	Records int `json:"records"`
+
+	// RecordsByType is the number of records per record type.
+	RecordsByType map[RRSetType]int `json:"records_by_type"`
+
+	// CreatedOn is when the zone was created.
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+
+	// UpdatedOn is when the zone was last modified.
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+}
+
+// Stats computes statistics from the zone's RRsets and metadata. The zone
+// must have been fetched with its RRsets for the counts to be meaningful.
+func (z *Zone) Stats() ZoneStats {
+	stats := ZoneStats{
+		Zone:          z.Name,
+		DNSSECStatus:  z.DNSSECStatus,
+		RRSets:        len(z.RRSets),
+		RecordsByType: map[RRSetType]int{},
+		CreatedOn:     z.CreatedOn,
+		UpdatedOn:     z.UpdatedOn,
+	}
+	for _, rrset := range z.RRSets {
+		stats.Records += len(rrset.Records)
+		stats.RecordsByType[rrset.Type] += len(rrset.Records)
+	}
+	return stats
+}
+
 // ZoneCreateRequest represents the request body for creating a new zone.
 type ZoneCreateRequest struct {
 	// Name is the domain name for the new zone (e.g., "example.com").
@@ -136,6 +195,60 @@ type ZoneCreateRequest struct {
 	// given vanity NS set. When nil, the org's default active set (if any) is
 	// used; otherwise the system default nameservers are used.
 	VanityNameserverSetID *VanityNameserverSetID `json:"vanity_nameserver_set_id,omitempty"`
+
+	// Kind selects a primary (default) or secondary zone.
+	Kind ZoneKind `json:"kind,omitempty"`
+
+	// Transfer configures where a secondary zone is transferred from.
+	// Required for secondary zones and not allowed for primary zones.
+	Transfer *ZoneTransferSettings `json:"transfer,omitempty"`
+}
+
+// ZoneKind represents whether a zone is primary or secondary.
+type ZoneKind string
+
+const (
+	// ZoneKindPrimary zones are served from records managed in OpusDNS.
+	ZoneKindPrimary ZoneKind = "primary"
+
+	// ZoneKindSecondary zones are transferred (AXFR/IXFR) from external primaries.
+	ZoneKindSecondary ZoneKind = "secondary"
+)
+
+// TSIGAlgorithm represents a TSIG key algorithm.
+type TSIGAlgorithm string
+
+const (
+	TSIGAlgorithmHMACSHA256 TSIGAlgorithm = "hmac-sha256"
+	TSIGAlgorithmHMACSHA384 TSIGAlgorithm = "hmac-sha384"
+	TSIGAlgorithmHMACSHA512 TSIGAlgorithm = "hmac-sha512"
+)
+
+// TSIGKey is a shared key authenticating zone transfers.
+type TSIGKey struct {
+	// Name is the key name, as configured on the primary server.
+	Name string `json:"name"`
+
+	// Algorithm is the HMAC algorithm of the key.
+	Algorithm TSIGAlgorithm `json:"algorithm"`
+
+	// Secret is the base64-encoded key. The API does not return it.
+	Secret string `json:"secret,omitempty"`
+}
+
+// ZoneTransferSettings configures how a secondary zone is transferred.
+type ZoneTransferSettings struct {
+	// Primaries are the IP addresses of the primary servers to transfer from.
+	Primaries []string `json:"primaries"`
+
+	// TSIGKey authenticates the transfers (optional).
+	TSIGKey *TSIGKey `json:"tsig_key,omitempty"`
+
+	// LastTransferOn is when the zone was last transferred successfully (read-only).
+	LastTransferOn *time.Time `json:"last_transfer_on,omitempty"`
+
+	// LastSerial is the SOA serial of the last transfer (read-only).
+	LastSerial *uint32 `json:"last_serial,omitempty"`
 }
 
 // RRSet represents a resource record set (multiple records with same name/type).
@@ -210,6 +323,73 @@ type Record struct {
 	Protected bool `json:"protected,omitempty"`
 }
 
+// TXT returns the logical value of a TXT record.
+func (r Record) TXT() TXTData {
+	return ParseTXTData(r.RData)
+}
+
+// TXT returns the logical value of a TXT record.
+func (r RecordData) TXT() TXTData {
+	return ParseTXTData(r.RData)
+}
+
+// maxTXTStringLength is the maximum length of one TXT character string.
+const maxTXTStringLength = 255
+
+// TXTData is the logical value of a TXT record. On the wire, values longer
+// than 255 bytes are split into several quoted character strings, which
+// TXTData hides: ParseTXTData joins them and RData splits them again.
+type TXTData string
+
+// ParseTXTData joins the quoted character strings of TXT record data,
+// unescaping quotes and backslashes. Unquoted data is returned as is.
+func ParseTXTData(rdata string) TXTData {
+	rdata = strings.TrimSpace(rdata)
+	if !strings.HasPrefix(rdata, `"`) {
+		return TXTData(rdata)
+	}
+
+	var b strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range rdata {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			b.WriteRune(r)
+		}
+	}
+	return TXTData(b.String())
+}
+
+// RData formats the value as TXT record data: quoted character strings of at
+// most 255 bytes each, with quotes and backslashes escaped.
+func (t TXTData) RData() string {
+	value := string(t)
+	var chunks []string
+	for len(value) > maxTXTStringLength {
+		chunks = append(chunks, value[:maxTXTStringLength])
+		value = value[maxTXTStringLength:]
+	}
+	chunks = append(chunks, value)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for i, chunk := range chunks {
+		chunks[i] = `"` + escaper.Replace(chunk) + `"`
+	}
+	return strings.Join(chunks, " ")
+}
+
+// String returns the logical value.
+func (t TXTData) String() string {
+	return string(t)
+}
+
 // RecordPatchOp represents an operation for patching records.
 type RecordPatchOp string
 
@@ -234,6 +414,53 @@ type RecordOperation struct {
 type RecordPatchRequest struct {
 	// Ops is the list of operations to perform.
 	Ops []RecordOperation `json:"ops"`
+
+	// Atomic asks the API to apply the operations in a single transaction, so
+	// either all of them take effect or none do. Check RecordPatchResult.Atomic
+	// to see whether the API honored the request.
+	Atomic bool `json:"atomic,omitempty"`
+
+	// AllowProtected lets the operations change or remove protected records.
+	// The API only honors it for API keys permitted to override protection.
+	AllowProtected bool `json:"allow_protected,omitempty"`
+
+	// ExpectedSerial, if set, makes the patch conditional: it is only applied
+	// if the zone's serial (Zone.Serial) still has this value, so concurrent
+	// writers cannot silently overwrite each other's changes. It is sent as
+	// the If-Match header.
+	ExpectedSerial int64 `json:"-"`
+}
+
+// RecordPatchResult is the outcome of a record patch request.
+type RecordPatchResult struct {
+	// Atomic reports whether the API confirmed that the operations were
+	// applied as a single transaction. It is false if the API does not
+	// support transactions, even if atomicity was requested.
+	Atomic bool `json:"atomic"`
+
+	// ChangesetID identifies the changeset the API recorded for the patch, if
+	// it reports one.
+	ChangesetID string `json:"changeset_id,omitempty"`
+
+	// Rejected lists the operations the API did not apply, e.g. because they
+	// target protected records.
+	Rejected []RecordOperationError `json:"rejected,omitempty"`
+}
+
+// RecordOperationError describes an operation of a record patch request that
+// the API rejected.
+type RecordOperationError struct {
+	// Index is the position of the operation in the request's Ops.
+	Index int `json:"index"`
+
+	// ErrorCode is the API error code (e.g., "record_protected").
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// Message is the human-readable reason.
+	Message string `json:"message,omitempty"`
+
+	// Operation is the rejected operation, filled in from the request.
+	Operation RecordOperation `json:"-"`
 }
 
 // RRSetPatch represents an RRset used in patch operations.
@@ -387,6 +614,10 @@ type ListZonesOptions struct {
 	// TagIDs filters by tag IDs. Multiple values are sent as repeated tag_ids params.
 	TagIDs []TagID
 
+	// Tags filters by tag labels. The client resolves them to tag IDs and
+	// combines them with TagIDs; an unknown label is an error.
+	Tags []string
+
 	// TagMode controls whether any or all tag IDs must match.
 	TagMode TagFilterMode
 
@@ -416,6 +647,12 @@ type ListZonesOptions struct {
 
 	// Include requests additional response data.
 	Include []ZoneIncludeField
+
+	// MaxPages and MaxItems override Config.MaxPages and Config.MaxItems for
+	// ListZones when positive, and disable the limit when negative. They
+	// are ignored by single-page calls.
+	MaxPages int
+	MaxItems int
 }
 
 // ZoneIncludeField represents optional zone response expansions.
@@ -430,4 +667,155 @@ const (
 type GetZoneOptions struct {
 	// Include requests additional response data.
 	Include []ZoneIncludeField
+
+	// IncludeRRSets controls whether the zone's RRsets are returned. Set it to
+	// false to fetch only the zone metadata; nil uses the API default (included).
+	IncludeRRSets *bool
+
+	// Types limits the returned RRsets to these record types.
+	Types []RRSetType
+
+	// NameFilter limits the returned RRsets to this relative name ("@" for the
+	// apex). A trailing "*" matches names starting with the rest, for example
+	// "_acme-challenge*".
+	NameFilter string
+}
+
+// MatchesRRSet reports whether an RRset passes the Types and NameFilter options.
+func (o *GetZoneOptions) MatchesRRSet(rrset RRSet) bool {
+	if o == nil {
+		return true
+	}
+	if len(o.Types) > 0 {
+		found := false
+		for _, t := range o.Types {
+			if strings.EqualFold(string(t), string(rrset.Type)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if o.NameFilter == "" {
+		return true
+	}
+
+	name := strings.ToLower(rrset.Name)
+	if name == "" {
+		name = "@"
+	}
+	filter := strings.ToLower(o.NameFilter)
+	if prefix, ok := strings.CutSuffix(filter, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == filter
+}
+
+// ListRRSetsOptions contains filters for listing the RRsets of a zone.
+type ListRRSetsOptions struct {
+	// NamePrefix limits the results to RRsets whose relative name starts with
+	// this prefix (case-insensitive), for example "_acme-challenge".
+	NamePrefix string
+
+	// Types limits the results to these record types.
+	Types []RRSetType
+
+	// MinTTL and MaxTTL limit the results to RRsets within this TTL range
+	// (inclusive). Zero means no bound.
+	MinTTL int
+	MaxTTL int
+
+	// Search limits the results to RRsets whose name or record data contains
+	// this string (case-insensitive).
+	Search string
+}
+
+// Matches reports whether an RRset passes all filters.
+func (o *ListRRSetsOptions) Matches(rrset RRSet) bool {
+	if o == nil {
+		return true
+	}
+	zoneOpts := GetZoneOptions{Types: o.Types}
+	if o.NamePrefix != "" {
+		zoneOpts.NameFilter = o.NamePrefix + "*"
+	}
+	if !zoneOpts.MatchesRRSet(rrset) {
+		return false
+	}
+	if o.MinTTL > 0 && rrset.TTL < o.MinTTL {
+		return false
+	}
+	if o.MaxTTL > 0 && rrset.TTL > o.MaxTTL {
+		return false
+	}
+	if o.Search == "" {
+		return true
+	}
+
+	search := strings.ToLower(o.Search)
+	if strings.Contains(strings.ToLower(rrset.Name), search) {
+		return true
+	}
+	for _, record := range rrset.Records {
+		if strings.Contains(strings.ToLower(record.RData), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// DNSQueryStatsOptions contains options for DNS query analytics.
+type DNSQueryStatsOptions struct {
+	// TimeRange is the period to report on (default: the API's default range).
+	TimeRange TimeRange
+
+	// Limit is the maximum number of top record names to return (optional).
+	Limit int
+}
+
+// DNSQueryMetrics represents aggregate query counts for a zone.
+type DNSQueryMetrics struct {
+	// TotalQueries is the number of queries answered for the zone.
+	TotalQueries int `json:"total_queries"`
+
+	// QueriesPerSecond is the average query rate over the time range.
+	QueriesPerSecond float64 `json:"queries_per_second,omitempty"`
+}
+
+// DNSQueryNameBucket represents the queries for one record name.
+type DNSQueryNameBucket struct {
+	// Key is the queried name relative to the zone.
+	Key string `json:"key"`
+
+	// Total is the number of queries for this name.
+	Total int `json:"total"`
+}
+
+// DNSResponseCodeBucket represents the responses with one DNS response code.
+type DNSResponseCodeBucket struct {
+	// Key is the response code (e.g., "NOERROR", "NXDOMAIN", "SERVFAIL").
+	Key string `json:"key"`
+
+	// Total is the number of responses with this code.
+	Total int `json:"total"`
+}
+
+// DNSQueryStats combines the query analytics of a zone.
+type DNSQueryStats struct {
+	// Zone is the zone name.
+	Zone string `json:"zone"`
+
+	// Metrics contains the aggregate query counts.
+	Metrics DNSQueryMetrics `json:"metrics"`
+
+	// TimeSeries contains the queries over time.
+	TimeSeries []TimeSeriesBucket `json:"time_series"`
+
+	// TopNames contains the most queried record names, most queried first.
+	TopNames []DNSQueryNameBucket `json:"top_names"`
+
+	// ResponseCodes contains the responses by DNS response code.
+	ResponseCodes []DNSResponseCodeBucket `json:"response_codes"`
 }
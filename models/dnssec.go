@@ -0,0 +1,185 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DNSSEC digest types used for DS records.
+const (
+	DNSSECDigestSHA1   DNSSECDigestType = 1
+	DNSSECDigestSHA256 DNSSECDigestType = 2
+	DNSSECDigestSHA384 DNSSECDigestType = 4
+)
+
+// dnskeyFlagSEP is the Secure Entry Point flag set on key signing keys.
+const dnskeyFlagSEP = 0x0001
+
+// ParseDNSKEYRecord parses DNSKEY (or CDNSKEY) presentation rdata such as
+// "257 3 13 mdsswUyr3DPW...". Whitespace inside the key is ignored.
+func ParseDNSKEYRecord(rdata string) (DNSKEYRecord, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return DNSKEYRecord{}, fmt.Errorf("invalid DNSKEY rdata %q", rdata)
+	}
+
+	var nums [3]int
+	for i := range nums {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return DNSKEYRecord{}, fmt.Errorf("invalid DNSKEY rdata %q: %w", rdata, err)
+		}
+		nums[i] = n
+	}
+
+	return DNSKEYRecord{
+		Flags:     nums[0],
+		Protocol:  nums[1],
+		Algorithm: nums[2],
+		PublicKey: strings.Join(fields[3:], ""),
+	}, nil
+}
+
+// IsKSK reports whether the key has the Secure Entry Point flag, marking it
+// as a key signing key whose DS belongs at the parent.
+func (k DNSKEYRecord) IsKSK() bool {
+	return k.Flags&dnskeyFlagSEP != 0
+}
+
+// wire returns the DNSKEY rdata in wire format.
+func (k DNSKEYRecord) wire() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNSKEY public key: %w", err)
+	}
+	rdata := []byte{byte(k.Flags >> 8), byte(k.Flags), byte(k.Protocol), byte(k.Algorithm)}
+	return append(rdata, key...), nil
+}
+
+// KeyTag returns the key tag of the key (RFC 4034, Appendix B).
+func (k DNSKEYRecord) KeyTag() (int, error) {
+	rdata, err := k.wire()
+	if err != nil {
+		return 0, err
+	}
+
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return int(ac & 0xFFFF), nil
+}
+
+// DS returns the SHA-256 DS record of the key for the zone owner (RFC 4509).
+func (k DNSKEYRecord) DS(owner string) (DSRecord, error) {
+	rdata, err := k.wire()
+	if err != nil {
+		return DSRecord{}, err
+	}
+	tag, err := k.KeyTag()
+	if err != nil {
+		return DSRecord{}, err
+	}
+	name, err := wireName(owner)
+	if err != nil {
+		return DSRecord{}, err
+	}
+
+	sum := sha256.Sum256(append(name, rdata...))
+	return DSRecord{
+		KeyTag:     tag,
+		Algorithm:  k.Algorithm,
+		DigestType: int(DNSSECDigestSHA256),
+		Digest:     strings.ToUpper(hex.EncodeToString(sum[:])),
+	}, nil
+}
+
+// ParseDSRecord parses DS (or CDS) presentation rdata such as "2371 13 2 1F98...".
+// Whitespace inside the digest is ignored.
+func ParseDSRecord(rdata string) (DSRecord, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return DSRecord{}, fmt.Errorf("invalid DS rdata %q", rdata)
+	}
+
+	var nums [3]int
+	for i := range nums {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return DSRecord{}, fmt.Errorf("invalid DS rdata %q: %w", rdata, err)
+		}
+		nums[i] = n
+	}
+
+	return DSRecord{
+		KeyTag:     nums[0],
+		Algorithm:  nums[1],
+		DigestType: nums[2],
+		Digest:     strings.ToUpper(strings.Join(fields[3:], "")),
+	}, nil
+}
+
+// IsDeleteSignal reports whether r is the CDS record "0 0 0 00", which asks
+// the parent to remove the DS records (RFC 8078).
+func (r DSRecord) IsDeleteSignal() bool {
+	return r.KeyTag == 0 && r.Algorithm == 0 && r.DigestType == 0 && r.Digest == "00"
+}
+
+// IsDeleteSignal reports whether k is the CDNSKEY record "0 3 0 AA==", which
+// asks the parent to remove the DS records (RFC 8078).
+func (k DNSKEYRecord) IsDeleteSignal() bool {
+	return k.Flags == 0 && k.Protocol == 3 && k.Algorithm == 0 && k.PublicKey == "AA=="
+}
+
+// Equal reports whether r and other are the same DS record. Digests are
+// compared case-insensitively.
+func (r DSRecord) Equal(other DSRecord) bool {
+	return r.KeyTag == other.KeyTag &&
+		r.Algorithm == other.Algorithm &&
+		r.DigestType == other.DigestType &&
+		strings.EqualFold(r.Digest, other.Digest)
+}
+
+// String returns the DS record in presentation format.
+func (r DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+}
+
+// DNSSECData returns the record as DNSSEC data for DomainsService.PutDNSSEC.
+func (r DSRecord) DNSSECData() DomainDNSSECDataCreate {
+	keyTag := r.KeyTag
+	digest := r.Digest
+	digestType := DNSSECDigestType(r.DigestType)
+	return DomainDNSSECDataCreate{
+		RecordType: DNSSECRecordTypeDSData,
+		Algorithm:  DNSSECAlgorithm(r.Algorithm),
+		KeyTag:     &keyTag,
+		Digest:     &digest,
+		DigestType: &digestType,
+	}
+}
+
+// wireName returns a domain name in canonical (lower-case) wire format.
+func wireName(name string) ([]byte, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	var wire []byte
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if label == "" || len(label) > 63 {
+				return nil, fmt.Errorf("invalid domain name %q", name)
+			}
+			wire = append(wire, byte(len(label)))
+			wire = append(wire, label...)
+		}
+	}
+	return append(wire, 0), nil
+}
@@ -300,6 +300,33 @@ type HttpRedirectRemove struct {
 	RequestSubdomain *string `json:"request_subdomain,omitempty"`
 }
 
+// HttpRedirectUpsert represents a redirect to create or update in a patch operation.
+type HttpRedirectUpsert struct {
+	// RequestProtocol is the source protocol.
+	RequestProtocol HttpProtocol `json:"request_protocol"`
+
+	// RequestHostname is the source hostname.
+	RequestHostname string `json:"request_hostname"`
+
+	// RequestPath is the source path.
+	RequestPath string `json:"request_path"`
+
+	// RequestSubdomain is the optional subdomain pattern (e.g., "*" for a wildcard redirect).
+	RequestSubdomain *string `json:"request_subdomain,omitempty"`
+
+	// TargetProtocol is the destination protocol.
+	TargetProtocol HttpProtocol `json:"target_protocol"`
+
+	// TargetHostname is the destination hostname.
+	TargetHostname string `json:"target_hostname"`
+
+	// TargetPath is the destination path.
+	TargetPath string `json:"target_path"`
+
+	// RedirectCode is the HTTP redirect status code.
+	RedirectCode RedirectCode `json:"redirect_code"`
+}
+
 // ListDomainForwardsOptions contains options for listing domain forwards.
 type ListDomainForwardsOptions struct {
 	// Page is the page number to retrieve (1-indexed).
@@ -411,6 +411,10 @@ type ListDomainsOptions struct {
 	// TagIDs filters by tag IDs. Multiple values are sent as repeated tag_ids params.
 	TagIDs []TagID
 
+	// Tags filters by tag labels. The client resolves them to tag IDs and
+	// combines them with TagIDs; an unknown label is an error.
+	Tags []string
+
 	// TagMode controls whether any or all tag IDs must match.
 	TagMode TagFilterMode
 
@@ -476,6 +480,12 @@ type ListDomainsOptions struct {
 
 	// Status filters by domain status.
 	Status DomainStatus
+
+	// MaxPages and MaxItems override Config.MaxPages and Config.MaxItems for
+	// ListDomains when positive, and disable the limit when negative. They
+	// are ignored by single-page calls.
+	MaxPages int
+	MaxItems int
 }
 
 // DomainIncludeField represents optional domain response expansions.
@@ -169,6 +169,36 @@ type ListEmailForwardsOptions struct {
 	Enabled *bool
 }
 
+// ListEmailForwardLogsOptions contains options for listing email forward logs.
+type ListEmailForwardLogsOptions struct {
+	// Page is the page number to retrieve (1-indexed).
+	Page int
+
+	// PageSize is the number of items per page.
+	PageSize int
+
+	// SortBy is the field to sort by.
+	SortBy EmailForwardLogSortField
+
+	// SortOrder is the sort direction.
+	SortOrder SortOrder
+
+	// Status filters by final delivery status.
+	Status EmailForwardLogStatus
+
+	// SenderEmail filters by sender address.
+	SenderEmail string
+
+	// RecipientEmail filters by recipient address.
+	RecipientEmail string
+
+	// StartTime filters logs created at or after this time.
+	StartTime *time.Time
+
+	// EndTime filters logs created before this time.
+	EndTime *time.Time
+}
+
 // EmailForwardMetricsOptions contains options for email-forward metrics.
 type EmailForwardMetricsOptions struct {
 	StartTime *time.Time
@@ -215,6 +215,12 @@ type ListEventsOptions struct {
 
 	// ObjectID filters by object ID.
 	ObjectID string
+
+	// CreatedAfter filters events created after this time.
+	CreatedAfter *time.Time
+
+	// CreatedBefore filters events created before this time.
+	CreatedBefore *time.Time
 }
 
 // ObjectEventType represents the action recorded in an object log entry.
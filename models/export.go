@@ -0,0 +1,151 @@
+package models
+
+// ResourceSetVersion is the version of the ResourceSet file format written by
+// ExportService.Export.
+const ResourceSetVersion = 1
+
+// ResourceSet is a stable, file-friendly representation of an account's
+// resources, as written by ExportService.Export and read by
+// ExportService.Apply. Resources are sorted and carry no server-side
+// timestamps, so exports of an unchanged account are identical and diff
+// cleanly. Every resource has an ImportID suitable for `terraform import`.
+type ResourceSet struct {
+	// Version is the file format version (ResourceSetVersion).
+	Version int `json:"version" yaml:"version"`
+
+	// Zones are the DNS zones and their records.
+	Zones []ZoneResource `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// Domains are the registered domains.
+	Domains []DomainResource `json:"domains,omitempty" yaml:"domains,omitempty"`
+
+	// DomainForwards are the HTTP(S) redirects by hostname.
+	DomainForwards []DomainForwardResource `json:"domain_forwards,omitempty" yaml:"domain_forwards,omitempty"`
+
+	// EmailForwards are the email forwarding configurations by hostname.
+	EmailForwards []EmailForwardResource `json:"email_forwards,omitempty" yaml:"email_forwards,omitempty"`
+
+	// Contacts are the registrant, admin, tech and billing contacts.
+	Contacts []ContactResource `json:"contacts,omitempty" yaml:"contacts,omitempty"`
+}
+
+// ZoneResource is a DNS zone with its records. The SOA record is managed by
+// the platform and not included.
+type ZoneResource struct {
+	// ImportID is the zone name.
+	ImportID string `json:"import_id" yaml:"import_id"`
+
+	// Name is the zone name, without a trailing dot.
+	Name string `json:"name" yaml:"name"`
+
+	// Records are the zone's records, sorted by name, type and data.
+	Records []RecordResource `json:"records,omitempty" yaml:"records,omitempty"`
+}
+
+// RecordResource is a single DNS record of a ZoneResource.
+type RecordResource struct {
+	// Name is the record name relative to the zone, "@" for the apex.
+	Name string `json:"name" yaml:"name"`
+
+	// Type is the record type.
+	Type RRSetType `json:"type" yaml:"type"`
+
+	// TTL is the time-to-live in seconds.
+	TTL int `json:"ttl" yaml:"ttl"`
+
+	// RData is the record data.
+	RData string `json:"rdata" yaml:"rdata"`
+}
+
+// DomainResource is the configurable state of a registered domain.
+type DomainResource struct {
+	// ImportID is the domain ID.
+	ImportID string `json:"import_id" yaml:"import_id"`
+
+	// Name is the domain name.
+	Name string `json:"name" yaml:"name"`
+
+	// RenewalMode is the renewal mode (renew or expire).
+	RenewalMode RenewalMode `json:"renewal_mode,omitempty" yaml:"renewal_mode,omitempty"`
+
+	// Nameservers are the delegated nameserver hostnames.
+	Nameservers []string `json:"nameservers,omitempty" yaml:"nameservers,omitempty"`
+
+	// Contacts maps contact types to the import IDs of ContactResources.
+	Contacts map[DomainContactType]string `json:"contacts,omitempty" yaml:"contacts,omitempty"`
+}
+
+// DomainForwardResource is the redirect configuration of a hostname.
+type DomainForwardResource struct {
+	// ImportID is the hostname.
+	ImportID string `json:"import_id" yaml:"import_id"`
+
+	// Hostname is the forwarded hostname.
+	Hostname string `json:"hostname" yaml:"hostname"`
+
+	// Enabled reports whether forwarding is active.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// HTTP and HTTPS are the redirects per request protocol.
+	HTTP  []RedirectResource `json:"http,omitempty" yaml:"http,omitempty"`
+	HTTPS []RedirectResource `json:"https,omitempty" yaml:"https,omitempty"`
+}
+
+// RedirectResource is a single redirect of a DomainForwardResource.
+type RedirectResource struct {
+	// RequestPath is the path matched on the forwarded hostname.
+	RequestPath string `json:"request_path" yaml:"request_path"`
+
+	// TargetProtocol, TargetHostname and TargetPath form the redirect target.
+	TargetProtocol HttpProtocol `json:"target_protocol" yaml:"target_protocol"`
+	TargetHostname string       `json:"target_hostname" yaml:"target_hostname"`
+	TargetPath     string       `json:"target_path" yaml:"target_path"`
+
+	// RedirectCode is the HTTP status code of the redirect.
+	RedirectCode RedirectCode `json:"redirect_code" yaml:"redirect_code"`
+}
+
+// EmailForwardResource is the email forwarding configuration of a hostname.
+type EmailForwardResource struct {
+	// ImportID is the email forward ID.
+	ImportID string `json:"import_id" yaml:"import_id"`
+
+	// Hostname is the domain receiving the email.
+	Hostname string `json:"hostname" yaml:"hostname"`
+
+	// Enabled reports whether forwarding is active.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Aliases are the forwarded addresses, sorted by alias.
+	Aliases []EmailAliasResource `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+}
+
+// EmailAliasResource is a single alias of an EmailForwardResource.
+type EmailAliasResource struct {
+	// Alias is the local part of the address.
+	Alias string `json:"alias" yaml:"alias"`
+
+	// ForwardTo are the destination addresses.
+	ForwardTo []string `json:"forward_to" yaml:"forward_to"`
+}
+
+// ContactResource is a contact. Contacts cannot be changed once created, so
+// Apply creates missing contacts but never updates existing ones.
+type ContactResource struct {
+	// ImportID is the contact ID.
+	ImportID string `json:"import_id" yaml:"import_id"`
+
+	FirstName  string  `json:"first_name" yaml:"first_name"`
+	LastName   string  `json:"last_name" yaml:"last_name"`
+	Org        *string `json:"org,omitempty" yaml:"org,omitempty"`
+	Title      *string `json:"title,omitempty" yaml:"title,omitempty"`
+	Email      string  `json:"email" yaml:"email"`
+	Phone      string  `json:"phone" yaml:"phone"`
+	Fax        *string `json:"fax,omitempty" yaml:"fax,omitempty"`
+	Street     string  `json:"street" yaml:"street"`
+	City       string  `json:"city" yaml:"city"`
+	State      *string `json:"state,omitempty" yaml:"state,omitempty"`
+	PostalCode string  `json:"postal_code" yaml:"postal_code"`
+	Country    string  `json:"country" yaml:"country"`
+	Disclose   bool    `json:"disclose" yaml:"disclose"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// The constructors below return list options pre-populated for common
+// filters. Times are computed from the current time in UTC and truncated to
+// whole seconds, matching the RFC 3339 precision the API filters on. The
+// returned options can be adjusted further before use.
+
+// timeNow is the clock behind the constructors, replaced in tests.
+var timeNow = time.Now
+
+// utcNow returns the current time in UTC without sub-second precision.
+func utcNow() time.Time {
+	return timeNow().UTC().Truncate(time.Second)
+}
+
+// ZonesCreatedWithin returns options listing the zones created in the last d.
+func ZonesCreatedWithin(d time.Duration) *ListZonesOptions {
+	return &ListZonesOptions{CreatedAfter: TimePtr(utcNow().Add(-d))}
+}
+
+// ZonesUpdatedWithin returns options listing the zones updated in the last d.
+func ZonesUpdatedWithin(d time.Duration) *ListZonesOptions {
+	return &ListZonesOptions{UpdatedAfter: TimePtr(utcNow().Add(-d))}
+}
+
+// DomainsExpiringWithin returns options listing the domains that have not
+// expired yet but expire within d, soonest first.
+func DomainsExpiringWithin(d time.Duration) *ListDomainsOptions {
+	now := utcNow()
+	return &ListDomainsOptions{
+		SortBy:        DomainSortByExpiresOn,
+		SortOrder:     SortAsc,
+		ExpiresAfter:  TimePtr(now),
+		ExpiresBefore: TimePtr(now.Add(d)),
+	}
+}
+
+// DomainsCreatedWithin returns options listing the domains created in the last d.
+func DomainsCreatedWithin(d time.Duration) *ListDomainsOptions {
+	return &ListDomainsOptions{CreatedAfter: TimePtr(utcNow().Add(-d))}
+}
+
+// EventsForObject returns options listing the events of one object, oldest
+// first.
+func EventsForObject(objectType EventObjectType, objectID string) *ListEventsOptions {
+	return &ListEventsOptions{
+		SortBy:     EventSortByCreatedOn,
+		SortOrder:  SortAsc,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+	}
+}
+
+// EventsCreatedWithin returns options listing the events created in the last
+// d, oldest first.
+func EventsCreatedWithin(d time.Duration) *ListEventsOptions {
+	return &ListEventsOptions{
+		SortBy:       EventSortByCreatedOn,
+		SortOrder:    SortAsc,
+		CreatedAfter: TimePtr(utcNow().Add(-d)),
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTimeNow(t *testing.T, now time.Time) {
+	t.Helper()
+	previous := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = previous })
+}
+
+func TestFilterConstructors(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	useTimeNow(t, time.Date(2026, 10, 15, 14, 30, 45, 987654321, berlin))
+	now := time.Date(2026, 10, 15, 12, 30, 45, 0, time.UTC)
+
+	zones := ZonesCreatedWithin(24 * time.Hour)
+	require.NotNil(t, zones.CreatedAfter)
+	assert.Equal(t, now.Add(-24*time.Hour), *zones.CreatedAfter)
+	assert.Equal(t, time.UTC, zones.CreatedAfter.Location())
+
+	zones = ZonesUpdatedWithin(time.Hour)
+	require.NotNil(t, zones.UpdatedAfter)
+	assert.Equal(t, now.Add(-time.Hour), *zones.UpdatedAfter)
+	assert.Nil(t, zones.CreatedAfter)
+
+	domains := DomainsExpiringWithin(30 * 24 * time.Hour)
+	assert.Equal(t, DomainSortByExpiresOn, domains.SortBy)
+	assert.Equal(t, SortAsc, domains.SortOrder)
+	assert.Equal(t, now, *domains.ExpiresAfter)
+	assert.Equal(t, now.Add(30*24*time.Hour), *domains.ExpiresBefore)
+
+	domains = DomainsCreatedWithin(7 * 24 * time.Hour)
+	assert.Equal(t, now.Add(-7*24*time.Hour), *domains.CreatedAfter)
+
+	events := EventsForObject(EventObjectTypeDomain, "domain_01h45")
+	assert.Equal(t, EventSortByCreatedOn, events.SortBy)
+	assert.Equal(t, SortAsc, events.SortOrder)
+	assert.Equal(t, EventObjectTypeDomain, events.ObjectType)
+	assert.Equal(t, "domain_01h45", events.ObjectID)
+	assert.Nil(t, events.CreatedAfter)
+
+	events = EventsCreatedWithin(time.Hour)
+	assert.Equal(t, EventSortByCreatedOn, events.SortBy)
+	assert.Equal(t, now.Add(-time.Hour), *events.CreatedAfter)
+}
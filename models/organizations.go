@@ -1,7 +1,10 @@
 // Package models contains all the data types for the OpusDNS API.
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // OrganizationID is a TypeID for organizations.
 type OrganizationID = TypeID
@@ -565,3 +568,28 @@ type InvoiceListResponse struct {
 	// Pagination contains the pagination metadata.
 	Pagination Pagination `json:"pagination"`
 }
+
+// PaymentConfirmRequest represents a request to confirm payment for an
+// operation that returned 402 Payment Required.
+type PaymentConfirmRequest struct {
+	// ContinuationToken is the token from the 402 response.
+	ContinuationToken string `json:"continuation_token"`
+}
+
+// PaymentConfirmation represents the outcome of a confirmed payment.
+type PaymentConfirmation struct {
+	// Status is the status of the resulting transaction.
+	Status BillingTransactionStatus `json:"status"`
+
+	// BillingTransactionID is the transaction charged for the operation.
+	BillingTransactionID *BillingTransactionID `json:"billing_transaction_id,omitempty"`
+
+	// Amount is the amount charged.
+	Amount string `json:"amount,omitempty"`
+
+	// Currency is the currency code.
+	Currency Currency `json:"currency,omitempty"`
+
+	// Result is the response of the continued operation, if any.
+	Result json.RawMessage `json:"result,omitempty"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlatformStatusIndicator is the overall health of the OpusDNS platform.
+type PlatformStatusIndicator string
+
+const (
+	PlatformStatusOperational      PlatformStatusIndicator = "operational"
+	PlatformStatusDegraded         PlatformStatusIndicator = "degraded_performance"
+	PlatformStatusPartialOutage    PlatformStatusIndicator = "partial_outage"
+	PlatformStatusMajorOutage      PlatformStatusIndicator = "major_outage"
+	PlatformStatusUnderMaintenance PlatformStatusIndicator = "under_maintenance"
+)
+
+// IsOperational reports whether the platform is fully operational.
+func (s PlatformStatusIndicator) IsOperational() bool {
+	return s == PlatformStatusOperational
+}
+
+// IncidentStatus is the progress of an incident.
+type IncidentStatus string
+
+const (
+	IncidentStatusInvestigating IncidentStatus = "investigating"
+	IncidentStatusIdentified    IncidentStatus = "identified"
+	IncidentStatusMonitoring    IncidentStatus = "monitoring"
+	IncidentStatusResolved      IncidentStatus = "resolved"
+)
+
+// IncidentImpact is how severely an incident affects the platform.
+type IncidentImpact string
+
+const (
+	IncidentImpactNone     IncidentImpact = "none"
+	IncidentImpactMinor    IncidentImpact = "minor"
+	IncidentImpactMajor    IncidentImpact = "major"
+	IncidentImpactCritical IncidentImpact = "critical"
+)
+
+// Incident is an unplanned disruption reported on the status page.
+type Incident struct {
+	// IncidentID is the unique identifier of the incident.
+	IncidentID string `json:"incident_id"`
+
+	// Name is the title of the incident.
+	Name string `json:"name"`
+
+	// Status is the progress of the incident.
+	Status IncidentStatus `json:"status"`
+
+	// Impact is how severely the incident affects the platform.
+	Impact IncidentImpact `json:"impact"`
+
+	// Components are the affected parts of the platform, such as "api" or "dns".
+	Components []string `json:"components,omitempty"`
+
+	// Message is the latest update posted for the incident.
+	Message string `json:"message,omitempty"`
+
+	// URL links to the incident on the status page.
+	URL string `json:"url,omitempty"`
+
+	// StartedOn is when the incident started.
+	StartedOn *time.Time `json:"started_on,omitempty"`
+
+	// ResolvedOn is when the incident was resolved, if it was.
+	ResolvedOn *time.Time `json:"resolved_on,omitempty"`
+}
+
+// MaintenanceStatus is the progress of a maintenance window.
+type MaintenanceStatus string
+
+const (
+	MaintenanceStatusScheduled  MaintenanceStatus = "scheduled"
+	MaintenanceStatusInProgress MaintenanceStatus = "in_progress"
+	MaintenanceStatusCompleted  MaintenanceStatus = "completed"
+)
+
+// MaintenanceWindow is planned maintenance announced on the status page.
+type MaintenanceWindow struct {
+	// MaintenanceID is the unique identifier of the maintenance.
+	MaintenanceID string `json:"maintenance_id"`
+
+	// Name is the title of the maintenance.
+	Name string `json:"name"`
+
+	// Description describes the work and its expected effects.
+	Description string `json:"description,omitempty"`
+
+	// Status is the progress of the maintenance.
+	Status MaintenanceStatus `json:"status"`
+
+	// Components are the affected parts of the platform, such as "api" or "dns".
+	Components []string `json:"components,omitempty"`
+
+	// URL links to the maintenance on the status page.
+	URL string `json:"url,omitempty"`
+
+	// StartsOn is when the maintenance window starts.
+	StartsOn time.Time `json:"starts_on"`
+
+	// EndsOn is when the maintenance window is planned to end.
+	EndsOn time.Time `json:"ends_on"`
+}
+
+// Overlaps reports whether the window overlaps the period from start to end.
+// Completed windows never overlap.
+func (m MaintenanceWindow) Overlaps(start, end time.Time) bool {
+	if m.Status == MaintenanceStatusCompleted {
+		return false
+	}
+	return m.StartsOn.Before(end) && m.EndsOn.After(start)
+}
+
+// PlatformStatus is the current state of the OpusDNS platform.
+type PlatformStatus struct {
+	// Status is the overall health of the platform.
+	Status PlatformStatusIndicator `json:"status"`
+
+	// Description summarizes the status, such as "All systems operational".
+	Description string `json:"description,omitempty"`
+
+	// Incidents are the unresolved incidents.
+	Incidents []Incident `json:"incidents"`
+
+	// Maintenances are the maintenance windows in progress or scheduled.
+	Maintenances []MaintenanceWindow `json:"scheduled_maintenances"`
+
+	// UpdatedOn is when the status was last updated.
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+}
+
+// DeferReason reports why bulk operations should be postponed at now: the
+// platform is not fully operational, an incident with impact is unresolved,
+// or a maintenance window is in progress or starts within margin. It returns
+// "" if it is a good time to proceed.
+func (s *PlatformStatus) DeferReason(now time.Time, margin time.Duration) string {
+	for _, m := range s.Maintenances {
+		if m.Overlaps(now, now.Add(margin)) {
+			if m.StartsOn.After(now) {
+				return fmt.Sprintf("maintenance %q starts at %s", m.Name, m.StartsOn.Format(time.RFC3339))
+			}
+			return fmt.Sprintf("maintenance %q is in progress until %s", m.Name, m.EndsOn.Format(time.RFC3339))
+		}
+	}
+	for _, i := range s.Incidents {
+		if i.Status != IncidentStatusResolved && i.Impact != IncidentImpactNone {
+			return fmt.Sprintf("incident %q is %s", i.Name, i.Status)
+		}
+	}
+	if s.Status != "" && !s.Status.IsOperational() {
+		return fmt.Sprintf("platform status is %s", s.Status)
+	}
+	return ""
+}
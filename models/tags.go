@@ -123,3 +123,12 @@ type ObjectTagChangesResponse struct {
 	Removed    int      `json:"removed"`
 	Unresolved []string `json:"unresolved,omitempty"`
 }
+
+// TagLabels returns the labels of tags, in order.
+func TagLabels(tags []TagEnriched) []string {
+	labels := make([]string, len(tags))
+	for i, t := range tags {
+		labels[i] = t.Label
+	}
+	return labels
+}
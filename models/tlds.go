@@ -46,6 +46,11 @@ type TLD struct {
 	// DNSSECSupported indicates if DNSSEC is supported.
 	DNSSECSupported bool `json:"dnssec_supported,omitempty"`
 
+	// CDSScanningSupported indicates if the registry scans the CDS and
+	// CDNSKEY records of delegated zones (RFC 7344, RFC 8078) and updates
+	// their DS records itself.
+	CDSScanningSupported bool `json:"cds_scanning_supported,omitempty"`
+
 	// MinRegistrationPeriod is the minimum registration period in years.
 	MinRegistrationPeriod int `json:"min_registration_period,omitempty"`
 
@@ -109,6 +114,26 @@ type TLDPricing struct {
 	PremiumPricing bool `json:"premium_pricing,omitempty"`
 }
 
+// TLDPrice is the price of one action for a TLD, as returned by
+// TLDsService.ComparePricing.
+type TLDPrice struct {
+	// TLD is the TLD name without the leading dot (e.g., "com").
+	TLD string `json:"tld"`
+
+	// Action is the priced action.
+	Action BillingTransactionAction `json:"action"`
+
+	// Price is the standard price, or empty if the TLD has no price for the action.
+	Price string `json:"price,omitempty"`
+
+	// Currency is the currency code of Price.
+	Currency Currency `json:"currency,omitempty"`
+
+	// Premium indicates the TLD has premium pricing tiers, so individual names
+	// may cost more than Price.
+	Premium bool `json:"premium"`
+}
+
 // TLDRestrictions contains registration restrictions for a TLD.
 type TLDRestrictions struct {
 	// LocalPresenceRequired indicates if a local address is required.
@@ -292,3 +317,57 @@ type TLDPhase struct {
 	// Requirements describes any special requirements for this phase.
 	Requirements *string `json:"requirements,omitempty"`
 }
+
+// TLDPriceChange represents an upcoming price change for a single TLD action.
+type TLDPriceChange struct {
+	// TLD is the TLD name without the leading dot (e.g., "com").
+	TLD string `json:"tld"`
+
+	// Action is the product action whose price changes (create, renew, transfer, ...).
+	Action BillingTransactionAction `json:"action"`
+
+	// OldPrice is the price in effect before the change.
+	OldPrice string `json:"old_price"`
+
+	// NewPrice is the price in effect from EffectiveDate onwards.
+	NewPrice string `json:"new_price"`
+
+	// Currency is the currency code for both prices.
+	Currency Currency `json:"currency"`
+
+	// Period is the pricing period the prices apply to (e.g., 1 year).
+	Period *PricingPeriod `json:"period,omitempty"`
+
+	// EffectiveDate is when the new price takes effect.
+	EffectiveDate time.Time `json:"effective_date"`
+}
+
+// TLDPriceChangeListResponse represents the paginated response when listing TLD price changes.
+type TLDPriceChangeListResponse struct {
+	// Results contains the list of price changes for the current page.
+	Results []TLDPriceChange `json:"results"`
+
+	// Pagination contains the pagination metadata.
+	Pagination Pagination `json:"pagination"`
+}
+
+// ListTLDPriceChangesOptions contains options for listing TLD price changes.
+type ListTLDPriceChangesOptions struct {
+	// Page is the page number to retrieve (1-indexed).
+	Page int
+
+	// PageSize is the number of price changes per page.
+	PageSize int
+
+	// TLDs filters by TLD name (repeatable, matches any).
+	TLDs []string
+
+	// Actions filters by product action (repeatable, matches any).
+	Actions []BillingTransactionAction
+
+	// EffectiveAfter filters price changes taking effect after this time.
+	EffectiveAfter *time.Time
+
+	// EffectiveBefore filters price changes taking effect before this time.
+	EffectiveBefore *time.Time
+}
@@ -155,6 +155,65 @@ type UserUpdateRequest struct {
 	UserAttributes []UserAttributeBase `json:"user_attributes,omitempty"`
 }
 
+// UserSessionID is a TypeID for user sessions.
+type UserSessionID = TypeID
+
+// UserSession represents an active sign-in session of a user.
+type UserSession struct {
+	// SessionID is the unique identifier for the session.
+	SessionID UserSessionID `json:"session_id"`
+
+	// UserID is the user the session belongs to.
+	UserID UserID `json:"user_id"`
+
+	// IPAddress is the address the session was last used from.
+	IPAddress *string `json:"ip_address,omitempty"`
+
+	// UserAgent is the user agent of the client that opened the session.
+	UserAgent *string `json:"user_agent,omitempty"`
+
+	// CreatedOn is when the user signed in.
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+
+	// LastActiveOn is when the session was last used.
+	LastActiveOn *time.Time `json:"last_active_on,omitempty"`
+
+	// ExpiresOn is when the session expires.
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+}
+
+// UserSessionListResponse represents the response when listing user sessions.
+type UserSessionListResponse struct {
+	// Results contains the sessions.
+	Results []UserSession `json:"results"`
+}
+
+// TwoFactorEnrollment is returned when two-factor authentication is enabled
+// for a user. It stays pending until confirmed with a code generated from it.
+type TwoFactorEnrollment struct {
+	// Secret is the base32-encoded TOTP secret.
+	Secret string `json:"secret"`
+
+	// ProvisioningURI is the otpauth:// URI authenticator apps import,
+	// usually rendered as a QR code.
+	ProvisioningURI string `json:"provisioning_uri"`
+
+	// QRCode is the provisioning URI as a PNG QR code in a data: URI, if
+	// provided.
+	QRCode *string `json:"qr_code,omitempty"`
+
+	// RecoveryCodes are single-use codes for signing in without the
+	// authenticator. They are only returned once.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// TwoFactorConfirmRequest represents a request to confirm a pending
+// two-factor enrollment.
+type TwoFactorConfirmRequest struct {
+	// Code is a current one-time code from the authenticator.
+	Code string `json:"code"`
+}
+
 // Permission is a user permission identifier, in "resource:scope" form
 // (e.g. "domains:read").
 type Permission string
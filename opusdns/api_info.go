@@ -0,0 +1,119 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// APIInfo describes the API the client talks to.
+type APIInfo struct {
+	// Title is the name of the API.
+	Title string
+
+	// Release is the API's release, such as "1.42.0".
+	Release string
+
+	// Versions are the API versions offered, such as "v1", oldest first.
+	Versions []string
+
+	// DeprecatedVersions are the offered versions whose operations are all
+	// marked deprecated.
+	DeprecatedVersions []string
+
+	// ClientVersion is the version the client is configured for.
+	ClientVersion string
+}
+
+// Supports reports whether the API offers version.
+func (i *APIInfo) Supports(version string) bool {
+	return slices.ContainsFunc(i.Versions, func(v string) bool { return sameAPIVersion(v, version) })
+}
+
+// Latest returns the newest version offered, or "" if none.
+func (i *APIInfo) Latest() string {
+	if len(i.Versions) == 0 {
+		return ""
+	}
+	return i.Versions[len(i.Versions)-1]
+}
+
+// versionPathPattern matches the version prefix of an API path.
+var versionPathPattern = regexp.MustCompile(`^/(v\d+)/`)
+
+// openAPIDocument is the part of the API's OpenAPI document read by APIInfo.
+type openAPIDocument struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+// httpMethods are the keys of an OpenAPI path item holding operations.
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// APIInfo returns the API's release and the versions it offers, read from
+// its OpenAPI document. Compare Latest and ClientVersion, or check
+// DeprecatedVersions, to learn of a new major version before the one in use
+// is retired.
+func (c *Client) APIInfo(ctx context.Context) (*APIInfo, error) {
+	resp, err := c.http.Get(ctx, "/openapi.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc openAPIDocument
+	if err := c.http.DecodeResponse(resp, &doc); err != nil {
+		return nil, fmt.Errorf("opusdns: failed to read API description: %w", err)
+	}
+
+	info := &APIInfo{
+		Title:         doc.Info.Title,
+		Release:       doc.Info.Version,
+		ClientVersion: c.Config.APIVersion,
+	}
+
+	// A version is deprecated if all of its operations are marked deprecated,
+	// that is, if none of the HTTP operations under its /vN/ paths is active.
+	active := map[string]bool{}
+	for path, operations := range doc.Paths {
+		m := versionPathPattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		version := m[1]
+		if _, ok := active[version]; !ok {
+			active[version] = false
+		}
+		for method, raw := range operations {
+			if !slices.Contains(httpMethods, method) {
+				continue
+			}
+			var op struct {
+				Deprecated bool `json:"deprecated"`
+			}
+			if err := json.Unmarshal(raw, &op); err == nil && !op.Deprecated {
+				active[version] = true
+			}
+		}
+	}
+	for version, isActive := range active {
+		info.Versions = append(info.Versions, version)
+		if !isActive {
+			info.DeprecatedVersions = append(info.DeprecatedVersions, version)
+		}
+	}
+
+	byNumber := func(a, b string) int {
+		x, _ := strconv.Atoi(a[1:])
+		y, _ := strconv.Atoi(b[1:])
+		return x - y
+	}
+	slices.SortFunc(info.Versions, byNumber)
+	slices.SortFunc(info.DeprecatedVersions, byNumber)
+	return info, nil
+}
@@ -0,0 +1,44 @@
+package opusdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_APIInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/openapi.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"openapi": "3.1.0",
+			"info": {"title": "OpusDNS API", "version": "1.42.0"},
+			"paths": {
+				"/v1/dns": {"get": {"deprecated": true}, "parameters": []},
+				"/v1/domains": {"get": {"deprecated": true}, "post": {"deprecated": true}},
+				"/v10/dns": {"get": {}},
+				"/v2/dns": {"summary": "Zones", "get": {}, "post": {"deprecated": true}},
+				"/health": {"get": {}}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	info, err := client.APIInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "OpusDNS API", info.Title)
+	assert.Equal(t, "1.42.0", info.Release)
+	assert.Equal(t, []string{"v1", "v2", "v10"}, info.Versions)
+	assert.Equal(t, []string{"v1"}, info.DeprecatedVersions)
+	assert.Equal(t, "v1", info.ClientVersion)
+	assert.Equal(t, "v10", info.Latest())
+	assert.True(t, info.Supports("2"))
+	assert.False(t, info.Supports("v3"))
+}
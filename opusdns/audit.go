@@ -0,0 +1,236 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Audit results recorded in AuditEntry.Result.
+const (
+	AuditResultOK     = "ok"
+	AuditResultError  = "error"
+	AuditResultDenied = "denied"
+	AuditResultDryRun = "dry_run"
+)
+
+// auditRedactedValue replaces secret values in audited request bodies.
+const auditRedactedValue = "[REDACTED]"
+
+// auditRedactedFields are request body fields whose values are never
+// written to the audit journal.
+var auditRedactedFields = map[string]bool{
+	"api_key":            true,
+	"auth_code":          true,
+	"code":               true,
+	"continuation_token": true,
+	"password":           true,
+	"secret":             true,
+	"token":              true,
+	"tsig_key":           true,
+}
+
+// AuditEntry is one line of the audit journal written to Config.AuditWriter.
+type AuditEntry struct {
+	// Time is when the call started.
+	Time time.Time `json:"time"`
+
+	// Actor is the ID of the API key the call was made with, or a masked
+	// form of the key if its ID could not be looked up.
+	Actor string `json:"actor"`
+
+	// Method and Path identify the request.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Request is the JSON request body with secrets such as auth codes
+	// and passwords redacted, or nil if the request has none.
+	Request json.RawMessage `json:"request,omitempty"`
+
+	// Result is AuditResultOK, AuditResultError, AuditResultDenied (by a
+	// policy) or AuditResultDryRun.
+	Result string `json:"result"`
+
+	// StatusCode is the HTTP status of the last response, if one arrived.
+	StatusCode int `json:"status_code,omitempty"`
+
+	// RequestID is the API's request ID of the last response, if any.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Error describes why the call failed.
+	Error string `json:"error,omitempty"`
+
+	// DurationMS is how long the call took, including retries.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// auditJournal serializes entries to the audit writer and caches the actor.
+type auditJournal struct {
+	w  io.Writer
+	mu sync.Mutex // serializes writes to w
+
+	actorMu sync.Mutex // guards actor
+	actor   string
+}
+
+// auditable reports whether req is recorded in the audit journal: mutating
+// requests that are not marked ReadOnly.
+func (c *HTTPClient) auditable(req *Request) bool {
+	if c.audit == nil || req.ReadOnly {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// recordAudit writes the journal entry for a finished mutating call. Write
+// errors are logged rather than failing the call, which already happened.
+func (c *HTTPClient) recordAudit(ctx context.Context, req *Request, start time.Time, resp *Response, err error) {
+	entry := AuditEntry{
+		Time:       start.UTC(),
+		Actor:      c.auditActor(ctx),
+		Method:     req.Method,
+		Path:       req.Path,
+		Request:    redactAuditBody(req.Body),
+		Result:     AuditResultOK,
+		DurationMS: c.clock.Now().Sub(start).Milliseconds(),
+	}
+
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		entry.RequestID = resp.Headers.Get("X-Request-ID")
+		if resp.StatusCode >= 400 && err == nil {
+			err = NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
+		}
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		entry.StatusCode = apiErr.StatusCode
+		if entry.RequestID == "" {
+			entry.RequestID = apiErr.RequestID
+		}
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrPolicyViolation):
+		entry.Result = AuditResultDenied
+		entry.Error = err.Error()
+	case errors.Is(err, ErrDryRun):
+		entry.Result = AuditResultDryRun
+	default:
+		entry.Result = AuditResultError
+		entry.Error = err.Error()
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		c.logf(ctx, "Audit: %v", marshalErr)
+		return
+	}
+	c.audit.mu.Lock()
+	defer c.audit.mu.Unlock()
+	if _, writeErr := c.audit.w.Write(append(line, '\n')); writeErr != nil {
+		c.logf(ctx, "Audit: %v", writeErr)
+	}
+}
+
+// auditActor returns the ID of the client's API key, introspecting it on
+// first use. The lookup runs without holding a lock, so concurrent calls are
+// not serialized behind it. If the key may not introspect itself (401 or
+// 403), the masked key is used for the client's lifetime so a missing
+// permission does not add a request to every call; other failures use the
+// masked key for this entry only and the lookup is retried on the next call.
+func (c *HTTPClient) auditActor(ctx context.Context) string {
+	c.audit.actorMu.Lock()
+	actor := c.audit.actor
+	c.audit.actorMu.Unlock()
+	if actor != "" {
+		return actor
+	}
+
+	actor, err := c.lookupAPIKeyID(context.WithoutCancel(ctx))
+	if err != nil {
+		c.logf(ctx, "Audit: looking up the API key ID: %v", err)
+		if !IsUnauthorizedError(err) && !IsForbiddenError(err) {
+			return maskAPIKey(c.config.APIKey)
+		}
+	}
+	if actor == "" {
+		actor = maskAPIKey(c.config.APIKey)
+	}
+
+	c.audit.actorMu.Lock()
+	defer c.audit.actorMu.Unlock()
+	if c.audit.actor == "" {
+		c.audit.actor = actor
+	}
+	return c.audit.actor
+}
+
+// lookupAPIKeyID introspects the client's API key and returns its ID.
+func (c *HTTPClient) lookupAPIKeyID(ctx context.Context) (string, error) {
+	resp, err := c.do(ctx, &Request{Method: http.MethodGet, Path: c.BuildPath("auth", "client_credentials", "introspect")})
+	if err != nil {
+		return "", err
+	}
+	var cred models.OrganizationCredential
+	if err := c.DecodeResponse(resp, &cred); err != nil {
+		return "", err
+	}
+	return string(cred.APIKeyID), nil
+}
+
+// maskAPIKey keeps the prefix and the last four characters of key.
+func maskAPIKey(key string) string {
+	if len(key) <= 8 {
+		return "opk_****"
+	}
+	return key[:4] + "****" + key[len(key)-4:]
+}
+
+// redactAuditBody returns the JSON form of body with secret fields replaced.
+func redactAuditBody(body interface{}) json.RawMessage {
+	if body == nil {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redactAuditValue(decoded))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactAuditValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if auditRedactedFields[key] && child != nil {
+				v[key] = auditRedactedValue
+				continue
+			}
+			v[key] = redactAuditValue(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactAuditValue(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,227 @@
+package opusdns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditEntries(t *testing.T, buf *bytes.Buffer) []AuditEntry {
+	t.Helper()
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditWriter(t *testing.T) {
+	introspections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/auth/client_credentials/introspect":
+			introspections++
+			_ = json.NewEncoder(w).Encode(models.OrganizationCredential{APIKeyID: "key_01h45"})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/domains/transfer":
+			w.Header().Set("X-Request-ID", "req_1")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com"})
+		case r.Method == http.MethodDelete:
+			w.Header().Set("X-Request-ID", "req_2")
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "zone not found"})
+		default:
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com"})
+		}
+	}))
+	defer server.Close()
+
+	var journal bytes.Buffer
+	client, err := NewClient(
+		WithAPIKey("opk_test_secret_1234"),
+		WithAPIEndpoint(server.URL),
+		WithAuditWriter(&journal),
+		WithPolicy(DenyZoneDeletion(func(zone string) bool { return zone == "prod.example.com" })),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.Domains.TransferDomain(ctx, &models.DomainTransferRequest{Name: "example.com", AuthCode: "s3cr3t"})
+	require.NoError(t, err)
+	_, err = client.DNS.GetZone(ctx, "example.com")
+	require.NoError(t, err)
+	err = client.DNS.DeleteZone(ctx, "missing.com")
+	assert.True(t, IsNotFoundError(err))
+	err = client.DNS.DeleteZone(ctx, "prod.example.com")
+	_, denied := IsPolicyViolationError(err)
+	assert.True(t, denied)
+	err = client.DNS.DeleteZone(ContextWithDryRun(ctx, true), "example.com")
+	assert.True(t, IsDryRunError(err))
+
+	assert.NotContains(t, journal.String(), "s3cr3t")
+	entries := readAuditEntries(t, &journal)
+	require.Len(t, entries, 4, "reads are not recorded")
+	assert.Equal(t, 1, introspections)
+
+	transfer := entries[0]
+	assert.Equal(t, "key_01h45", transfer.Actor)
+	assert.Equal(t, http.MethodPost, transfer.Method)
+	assert.Equal(t, "/v1/domains/transfer", transfer.Path)
+	assert.Equal(t, AuditResultOK, transfer.Result)
+	assert.Equal(t, http.StatusCreated, transfer.StatusCode)
+	assert.Equal(t, "req_1", transfer.RequestID)
+	assert.False(t, transfer.Time.IsZero())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(transfer.Request, &body))
+	assert.Equal(t, "example.com", body["name"])
+	assert.Equal(t, "[REDACTED]", body["auth_code"])
+
+	assert.Equal(t, AuditResultError, entries[1].Result)
+	assert.Equal(t, http.StatusNotFound, entries[1].StatusCode)
+	assert.Equal(t, "req_2", entries[1].RequestID)
+	assert.Contains(t, entries[1].Error, "zone not found")
+
+	assert.Equal(t, AuditResultDenied, entries[2].Result)
+	assert.Contains(t, entries[2].Error, "protected")
+	assert.Zero(t, entries[2].StatusCode)
+
+	assert.Equal(t, AuditResultDryRun, entries[3].Result)
+	assert.Empty(t, entries[3].Error)
+}
+
+func TestAuditWriter_RedactsOneTimeCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/client_credentials/introspect" {
+			_ = json.NewEncoder(w).Encode(models.OrganizationCredential{APIKeyID: "key_01h45"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.PaymentConfirmation{})
+	}))
+	defer server.Close()
+
+	var journal bytes.Buffer
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithAuditWriter(&journal))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.Users.ConfirmTwoFactor(ctx, "user_01h45", "123 456"))
+	_, err = client.Organizations.ConfirmPayment(ctx, "ct_s3cr3t")
+	require.NoError(t, err)
+
+	assert.NotContains(t, journal.String(), "123456")
+	assert.NotContains(t, journal.String(), "ct_s3cr3t")
+	entries := readAuditEntries(t, &journal)
+	require.Len(t, entries, 2)
+
+	var confirm, payment map[string]interface{}
+	require.NoError(t, json.Unmarshal(entries[0].Request, &confirm))
+	assert.Equal(t, "/v1/users/user_01h45/two-factor/confirm", entries[0].Path)
+	assert.Equal(t, "[REDACTED]", confirm["code"])
+	require.NoError(t, json.Unmarshal(entries[1].Request, &payment))
+	assert.Equal(t, "[REDACTED]", payment["continuation_token"])
+}
+
+func TestAuditWriter_ActorFallback(t *testing.T) {
+	introspections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/client_credentials/introspect" {
+			introspections++
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var journal bytes.Buffer
+	client, err := NewClient(WithAPIKey("opk_test_secret_1234"), WithAPIEndpoint(server.URL), WithAuditWriter(&journal))
+	require.NoError(t, err)
+
+	require.NoError(t, client.DNS.DeleteZone(context.Background(), "a.example.com"))
+	require.NoError(t, client.DNS.DeleteZone(context.Background(), "b.example.com"))
+
+	entries := readAuditEntries(t, &journal)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "opk_****1234", entries[0].Actor)
+	assert.Equal(t, "opk_****1234", entries[1].Actor)
+	assert.Equal(t, 1, introspections, "a failed lookup is not repeated")
+	assert.NotContains(t, journal.String(), "secret")
+}
+
+func TestAuditWriter_ActorRetriedAfterTransientError(t *testing.T) {
+	introspections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/client_credentials/introspect" {
+			introspections++
+			if introspections == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.OrganizationCredential{APIKeyID: "key_01h45"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var journal bytes.Buffer
+	client, err := NewClient(WithAPIKey("opk_test_secret_1234"), WithAPIEndpoint(server.URL), WithAuditWriter(&journal), WithMaxRetries(0))
+	require.NoError(t, err)
+
+	for _, zone := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		require.NoError(t, client.DNS.DeleteZone(context.Background(), zone))
+	}
+
+	entries := readAuditEntries(t, &journal)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "opk_****1234", entries[0].Actor)
+	assert.Equal(t, "key_01h45", entries[1].Actor)
+	assert.Equal(t, "key_01h45", entries[2].Actor)
+	assert.Equal(t, 2, introspections, "a transient failure is retried, a success is cached")
+}
+
+func TestAuditWriter_ActorLookupNotUnderLock(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/client_credentials/introspect" {
+			close(started)
+			<-release
+			_ = json.NewEncoder(w).Encode(models.OrganizationCredential{APIKeyID: "key_01h45"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var journal bytes.Buffer
+	client, err := NewClient(WithAPIKey("opk_test_secret_1234"), WithAPIEndpoint(server.URL), WithAuditWriter(&journal))
+	require.NoError(t, err)
+
+	go func() { _ = client.DNS.DeleteZone(context.Background(), "slow.example.com") }()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the API key was not introspected")
+	}
+
+	// While the lookup hangs, the journal and the actor cache stay usable
+	// by other calls.
+	for _, mu := range []*sync.Mutex{&client.http.audit.actorMu, &client.http.audit.mu} {
+		require.True(t, mu.TryLock())
+		mu.Unlock()
+	}
+}
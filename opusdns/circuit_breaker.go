@@ -0,0 +1,106 @@
+package opusdns
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is used when CircuitBreakerThreshold is set
+// without a cooldown.
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// retryGuard holds the retry budget and circuit breaker shared by all calls
+// on an HTTPClient. A nil *retryGuard allows everything.
+type retryGuard struct {
+	ratio     float64
+	burst     float64
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	tokens    float64
+	failures  int
+	openUntil time.Time
+}
+
+// newRetryGuard returns the guard for config, or nil if neither a retry
+// budget nor a circuit breaker is configured.
+func newRetryGuard(config *Config, clock Clock) *retryGuard {
+	if config.RetryBudgetRatio <= 0 && config.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+
+	g := &retryGuard{
+		ratio:     config.RetryBudgetRatio,
+		burst:     float64(config.RetryBudgetBurst),
+		threshold: config.CircuitBreakerThreshold,
+		cooldown:  config.CircuitBreakerCooldown,
+		now:       clock.Now,
+	}
+	if g.cooldown == 0 {
+		g.cooldown = defaultCircuitBreakerCooldown
+	}
+	g.tokens = g.burst
+	return g
+}
+
+// allow reports whether an attempt may be sent. The first attempt of a call
+// adds ratio to the budget and each retry spends one; lastErr is the error
+// of the previous attempt. Once the cooldown of an open circuit has passed,
+// one attempt is let through as a probe and the circuit stays open for
+// another cooldown unless it succeeds.
+func (g *retryGuard) allow(attempt int, lastErr error) error {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.threshold > 0 && !g.openUntil.IsZero() {
+		now := g.now()
+		if now.Before(g.openUntil) {
+			if lastErr != nil {
+				return fmt.Errorf("%w until %s: %w", ErrCircuitOpen, g.openUntil.Format(time.RFC3339), lastErr)
+			}
+			return fmt.Errorf("%w until %s", ErrCircuitOpen, g.openUntil.Format(time.RFC3339))
+		}
+		g.openUntil = now.Add(g.cooldown)
+	}
+
+	if g.ratio > 0 {
+		if attempt == 0 {
+			g.tokens = min(g.tokens+g.ratio, max(g.burst, 1))
+		} else {
+			if g.tokens < 1 {
+				return fmt.Errorf("opusdns: retry budget exhausted: %w", lastErr)
+			}
+			g.tokens--
+		}
+	}
+	return nil
+}
+
+// record counts the outcome of an attempt: transient failures (network
+// errors, 429 and 5xx) open the circuit once threshold of them follow each
+// other, and any other response closes it.
+func (g *retryGuard) record(failed bool) {
+	if g == nil || g.threshold <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !failed {
+		g.failures = 0
+		g.openUntil = time.Time{}
+		return
+	}
+	g.failures++
+	if g.failures >= g.threshold {
+		g.openUntil = g.now().Add(g.cooldown)
+	}
+}
@@ -0,0 +1,87 @@
+package opusdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryGuard_Budget(t *testing.T) {
+	g := newRetryGuard(&Config{RetryBudgetRatio: 0.5, RetryBudgetBurst: 1}, systemClock{})
+	lastErr := errors.New("boom")
+
+	// The burst allows one retry up front
+	require.NoError(t, g.allow(0, nil))
+	require.NoError(t, g.allow(1, lastErr))
+	err := g.allow(1, lastErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, lastErr)
+
+	// Two requests earn another retry
+	require.NoError(t, g.allow(0, nil))
+	require.NoError(t, g.allow(0, nil))
+	require.NoError(t, g.allow(1, lastErr))
+	assert.Error(t, g.allow(1, lastErr))
+}
+
+func TestRetryGuard_CircuitBreaker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := newRetryGuard(&Config{CircuitBreakerThreshold: 2, CircuitBreakerCooldown: time.Minute}, systemClock{})
+	g.now = func() time.Time { return now }
+
+	g.record(true)
+	require.NoError(t, g.allow(0, nil))
+	g.record(true)
+	assert.True(t, IsCircuitOpenError(g.allow(0, nil)))
+
+	// After the cooldown one probe goes through; its failure reopens the circuit
+	now = now.Add(time.Minute)
+	require.NoError(t, g.allow(0, nil))
+	assert.True(t, IsCircuitOpenError(g.allow(0, nil)))
+	g.record(true)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, g.allow(0, nil))
+	g.record(false)
+	assert.NoError(t, g.allow(0, nil))
+
+	assert.Nil(t, newRetryGuard(NewConfig(), systemClock{}))
+}
+
+func TestHTTPClient_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL),
+		WithRetryWait(time.Millisecond, time.Millisecond), WithCircuitBreaker(3, time.Hour))
+	require.NoError(t, err)
+
+	_, err = client.DNS.GetZone(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.True(t, IsCircuitOpenError(err))
+	assert.True(t, IsRetryableError(err))
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Other calls fail without a request
+	_, err = client.DNS.GetZone(context.Background(), "example.org")
+	assert.True(t, IsCircuitOpenError(err))
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestConfig_ValidateRetryGuard(t *testing.T) {
+	_, err := NewClient(WithAPIKey("opk_test"), WithRetryBudget(-1, 0))
+	assert.Error(t, err)
+	_, err = NewClient(WithAPIKey("opk_test"), WithCircuitBreaker(-1, 0))
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 // Package opusdns provides a Go client library for the OpusDNS API.
 package opusdns
 
+import "sync"
+
 // Client is the high-level OpusDNS API client.
 // It provides access to all API services through dedicated service objects.
 type Client struct {
@@ -10,53 +12,68 @@ type Client struct {
 	// http is the underlying HTTP client.
 	http *HTTPClient
 
+	// identityMu guards identity.
+	identityMu sync.Mutex
+
+	// identity caches the result of Identity.
+	identity *Identity
+
 	// DNS provides access to DNS zone and record management.
-	DNS *DNSService
+	DNS DNSAPI
 
 	// Domains provides access to domain registration and management.
-	Domains *DomainsService
+	Domains DomainsAPI
 
 	// Contacts provides access to contact management.
-	Contacts *ContactsService
+	Contacts ContactsAPI
 
 	// EmailForwards provides access to email forwarding configuration.
-	EmailForwards *EmailForwardsService
+	EmailForwards EmailForwardsAPI
 
 	// DomainForwards provides access to domain/URL forwarding configuration.
-	DomainForwards *DomainForwardsService
+	DomainForwards DomainForwardsAPI
 
 	// TLDs provides access to TLD information and portfolio.
-	TLDs *TLDsService
+	TLDs TLDsAPI
 
 	// Availability provides access to domain availability checking.
-	Availability *AvailabilityService
+	Availability AvailabilityAPI
 
 	// Organizations provides access to organization management.
-	Organizations *OrganizationsService
+	Organizations OrganizationsAPI
 
 	// Users provides access to user management.
-	Users *UsersService
+	Users UsersAPI
 
 	// Auth provides access to authentication-related operations.
-	Auth *AuthService
+	Auth AuthAPI
+
+	// APIKeys provides access to API key management.
+	APIKeys APIKeysAPI
 
 	// VanityNameservers provides access to vanity nameserver set management.
-	VanityNameservers *VanityNameserversService
+	VanityNameservers VanityNameserversAPI
 
 	// Hosts provides access to host object management.
-	Hosts *HostsService
+	Hosts HostsAPI
 
 	// Events provides access to event and audit log data.
-	Events *EventsService
+	Events EventsAPI
 
 	// Jobs provides access to async job batch management.
-	Jobs *JobsService
+	Jobs JobsAPI
 
 	// Reports provides access to report generation and download.
-	Reports *ReportsService
+	Reports ReportsAPI
 
 	// Tags provides access to tag management.
-	Tags *TagsService
+	Tags TagsAPI
+
+	// Export serializes resources to files and provisions them from files.
+	Export ExportAPI
+
+	// Status provides access to platform status, incidents and maintenance windows.
+	Status StatusAPI
 }
 
 // NewClient creates a new OpusDNS client with the given options.
@@ -95,12 +112,15 @@ func NewClient(opts ...Option) (*Client, error) {
 	client.Organizations = &OrganizationsService{client: client}
 	client.Users = &UsersService{client: client}
 	client.Auth = &AuthService{client: client}
+	client.APIKeys = &APIKeysService{client: client}
 	client.VanityNameservers = &VanityNameserversService{client: client}
 	client.Hosts = &HostsService{client: client}
 	client.Events = &EventsService{client: client}
 	client.Jobs = &JobsService{client: client}
 	client.Reports = &ReportsService{client: client}
 	client.Tags = &TagsService{client: client}
+	client.Export = &ExportService{client: client}
+	client.Status = &StatusService{client: client}
 
 	return client, nil
 }
@@ -132,12 +152,15 @@ func NewClientWithConfig(config *Config) (*Client, error) {
 	client.Organizations = &OrganizationsService{client: client}
 	client.Users = &UsersService{client: client}
 	client.Auth = &AuthService{client: client}
+	client.APIKeys = &APIKeysService{client: client}
 	client.VanityNameservers = &VanityNameserversService{client: client}
 	client.Hosts = &HostsService{client: client}
 	client.Events = &EventsService{client: client}
 	client.Jobs = &JobsService{client: client}
 	client.Reports = &ReportsService{client: client}
 	client.Tags = &TagsService{client: client}
+	client.Export = &ExportService{client: client}
+	client.Status = &StatusService{client: client}
 
 	return client, nil
 }
@@ -2,9 +2,15 @@ package opusdns
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +19,18 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// newTestClient starts a test server running handler, closed when the test
+// ends, and returns a client pointed at it.
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(append([]Option{WithAPIKey("opk_test"), WithAPIEndpoint(server.URL)}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	t.Run("creates client with API key", func(t *testing.T) {
 		client, err := NewClient(WithAPIKey("opk_test"))
@@ -68,6 +86,95 @@ func TestNewClient(t *testing.T) {
 	})
 }
 
+func TestNewTransport(t *testing.T) {
+	t.Run("tunes the default transport", func(t *testing.T) {
+		transport, ok := newTransport(NewConfig(WithMaxConnsPerHost(8), WithKeepAlive(time.Minute, 0))).(*http.Transport)
+		require.True(t, ok)
+
+		assert.Equal(t, 8, transport.MaxConnsPerHost)
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+		assert.NotNil(t, transport.Proxy)
+		assert.True(t, transport.ForceAttemptHTTP2)
+		assert.Nil(t, transport.TLSNextProto)
+	})
+
+	t.Run("disables HTTP/2", func(t *testing.T) {
+		transport := newTransport(NewConfig(WithHTTP2(false))).(*http.Transport)
+
+		assert.False(t, transport.ForceAttemptHTTP2)
+		assert.NotNil(t, transport.TLSNextProto)
+		assert.Empty(t, transport.TLSNextProto)
+	})
+
+	t.Run("uses a custom transport", func(t *testing.T) {
+		var used bool
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			used = true
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+		})
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithTransport(rt))
+		require.NoError(t, err)
+		_, err = client.http.Get(context.Background(), "/v1/dns", nil)
+		require.NoError(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("sends requests through the proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer proxy.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint("http://api.opusdns.invalid"), WithProxy(proxy.URL))
+		require.NoError(t, err)
+		_, err = client.http.Get(context.Background(), "/v1/dns", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://api.opusdns.invalid/v1/dns", proxied)
+	})
+
+	t.Run("uses custom root CAs and client certificates", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		server.StartTLS()
+		defer server.Close()
+
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0),
+			WithRootCAs(pool), WithClientCertificate(server.TLS.Certificates[0]))
+		require.NoError(t, err)
+		_, err = client.http.Get(context.Background(), "/v1/dns", nil)
+		require.NoError(t, err)
+
+		withoutCert, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0), WithRootCAs(pool))
+		require.NoError(t, err)
+		_, err = withoutCert.http.Get(context.Background(), "/v1/dns", nil)
+		assert.Error(t, err)
+
+		untrusted, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0))
+		require.NoError(t, err)
+		_, err = untrusted.http.Get(context.Background(), "/v1/dns", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		_, err := NewClient(WithAPIKey("opk_test"), WithMaxConnsPerHost(-1))
+		assert.Error(t, err)
+		_, err = NewClient(WithAPIKey("opk_test"), WithProxy("proxy:3128"))
+		assert.Error(t, err)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
 func TestRetryLogic(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -128,6 +235,38 @@ func TestAPIError(t *testing.T) {
 	})
 }
 
+func TestDecodeError(t *testing.T) {
+	page := "<html><body>" + strings.Repeat("Bad Gateway ", 100) + "</body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Request-ID", "req_1")
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.DNS.GetZone(context.Background(), "example.com")
+	require.Error(t, err)
+
+	var decodeErr *DecodeError
+	require.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, http.StatusOK, decodeErr.StatusCode)
+	assert.Equal(t, "text/html", decodeErr.ContentType)
+	assert.Equal(t, "req_1", decodeErr.RequestID)
+	assert.Equal(t, page, string(decodeErr.Body))
+
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+
+	msg := err.Error()
+	assert.Contains(t, msg, "status 200, content-type text/html")
+	assert.Contains(t, msg, "<html><body>Bad Gateway")
+	assert.True(t, strings.HasSuffix(msg, "..."))
+	assert.Less(t, len(msg), len(page))
+}
+
 func TestContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
@@ -148,3 +287,199 @@ func TestContextCancellation(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestOverallTimeout(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithMaxRetries(10),
+		WithRetryWait(50*time.Millisecond, 50*time.Millisecond),
+		WithOverallTimeout(120*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.DNS.ListZones(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Less(t, attempts, 11)
+
+	t.Run("caller cancellation is not reported as overall timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.DNS.ListZones(ctx, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, ErrTimeout)
+	})
+}
+
+func TestDeadlineWouldExceed(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithMaxRetries(3),
+		WithRetryWait(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	start := time.Now()
+	_, err = client.DNS.ListZones(ctx, nil)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, attempts)
+
+	assert.True(t, IsDeadlineWouldExceedError(err))
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	var wouldExceed *DeadlineWouldExceedError
+	require.ErrorAs(t, err, &wouldExceed)
+	assert.Equal(t, "rate limit", wouldExceed.Reason)
+	assert.Equal(t, deadline, wouldExceed.Deadline)
+	assert.WithinDuration(t, start.Add(time.Minute), wouldExceed.NextAttempt, 2*time.Second)
+}
+
+func TestHTTPClient_Probe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodOptions, r.Method)
+
+		switch r.URL.Path {
+		case "/v1/dns":
+			w.Header().Set("Allow", "GET, POST, options")
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/legacy":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "/v1/private":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	httpClient := client.HTTPClient()
+
+	t.Run("parses allow header", func(t *testing.T) {
+		caps, err := httpClient.Probe(context.Background(), httpClient.BuildPath("dns"))
+
+		require.NoError(t, err)
+		assert.True(t, caps.Exists)
+		assert.Equal(t, []string{"GET", "POST", "OPTIONS"}, caps.Methods)
+		assert.True(t, caps.Allows("post"))
+		assert.False(t, caps.Allows(http.MethodDelete))
+	})
+
+	t.Run("reports missing endpoint", func(t *testing.T) {
+		caps, err := httpClient.Probe(context.Background(), httpClient.BuildPath("missing"))
+
+		require.NoError(t, err)
+		assert.False(t, caps.Exists)
+	})
+
+	t.Run("treats 405 as existing", func(t *testing.T) {
+		caps, err := httpClient.Probe(context.Background(), httpClient.BuildPath("legacy"))
+
+		require.NoError(t, err)
+		assert.True(t, caps.Exists)
+		assert.Empty(t, caps.Methods)
+	})
+
+	t.Run("returns API errors", func(t *testing.T) {
+		_, err := httpClient.Probe(context.Background(), httpClient.BuildPath("private"))
+
+		require.Error(t, err)
+		assert.True(t, IsForbiddenError(err))
+	})
+}
+
+// recordingLogger collects debug output.
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+type loggerKey struct{}
+
+func TestDebugLogging(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-ID", "req_abc")
+		_, _ = w.Write([]byte(`{"results":[],"pagination":{}}`))
+	}))
+	defer server.Close()
+
+	global := &recordingLogger{}
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithRetryWait(time.Millisecond, time.Millisecond),
+		WithDebug(true),
+		WithLogger(global),
+		WithContextLogger(func(ctx context.Context) Logger {
+			logger, _ := ctx.Value(loggerKey{}).(Logger)
+			return logger
+		}),
+	)
+	require.NoError(t, err)
+
+	scoped := &recordingLogger{}
+	ctx := context.WithValue(context.Background(), loggerKey{}, scoped)
+	_, err = client.DNS.ListZonesPage(ctx, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, global.lines)
+	require.NotEmpty(t, scoped.lines)
+
+	prefix := regexp.MustCompile(`^\[opusdns\] call=([0-9a-f]{8}) attempt=(\d) elapsed=\S+ `)
+	callID := ""
+	for _, line := range scoped.lines {
+		m := prefix.FindStringSubmatch(line)
+		require.NotNil(t, m, line)
+		if callID == "" {
+			callID = m[1]
+		}
+		assert.Equal(t, callID, m[1], "all lines of a call share its ID")
+	}
+	assert.Contains(t, scoped.lines[0], "attempt=1")
+	last := scoped.lines[len(scoped.lines)-1]
+	assert.Contains(t, last, "attempt=2")
+	assert.Contains(t, last, "request_id: req_abc")
+
+	// Without a context logger the configured Logger is used.
+	_, err = client.DNS.ListZonesPage(context.Background(), nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, global.lines)
+}
@@ -0,0 +1,23 @@
+package opusdns
+
+import "time"
+
+// Clock is the source of time for retry backoff, rate-limit and maintenance
+// waits, the circuit breaker, deadline checks and DNSSEC polling. Tests can supply a clock
+// whose After fires at once, so retry behavior runs instantly and waits can
+// be asserted exactly. With such a clock, context deadlines are compared
+// with Clock.Now.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
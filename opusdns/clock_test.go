@@ -0,0 +1,82 @@
+package opusdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances instantly through every wait and records it.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// advance moves the clock forward by d without recording a wait.
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestWithClock(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 2:
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"name": "example.com"}`))
+		}
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithRetryWait(time.Hour, time.Hour),
+		WithClock(clock),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	zone, err := client.DNS.GetZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 3, attempts)
+
+	// Backoff, then the Retry-After wait, then backoff again.
+	require.Len(t, clock.waits, 3)
+	assert.InDelta(t, float64(time.Hour), float64(clock.waits[0]), float64(12*time.Minute))
+	assert.Equal(t, 120*time.Second, clock.waits[1])
+	assert.InDelta(t, float64(time.Hour), float64(clock.waits[2]), float64(12*time.Minute))
+}
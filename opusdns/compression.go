@@ -0,0 +1,63 @@
+package opusdns
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipData compresses a request body.
+func gzipData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressBody replaces the body of a gzip-encoded response with its
+// decompressed content and drops the headers describing the encoding, as
+// http.Transport does when it negotiates compression itself.
+func decompressBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	switch {
+	case errors.Is(err, io.EOF):
+		// Empty body, such as for HEAD requests
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{http.NoBody, resp.Body}
+	case err != nil:
+		return err
+	default:
+		resp.Body = &gzipReadCloser{Reader: zr, body: resp.Body}
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipReadCloser reads a decompressed response body and closes the
+// underlying one.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r *gzipReadCloser) Close() error {
+	return errors.Join(r.Reader.Close(), r.body.Close())
+}
@@ -0,0 +1,99 @@
+package opusdns
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	gzipJSON := func(t *testing.T, v interface{}) []byte {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		data, err = gzipData(data)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("decompresses responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(gzipJSON(t, map[string]string{"name": "example.com"}))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		resp, err := client.http.Get(context.Background(), "/v1/dns/example.com", nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"example.com"}`, string(resp.Body))
+		assert.Empty(t, resp.Headers.Get("Content-Encoding"))
+
+		body, err := client.http.Stream(context.Background(), &Request{Method: http.MethodGet, Path: "/v1/dns/example.com"})
+		require.NoError(t, err)
+		defer body.Close() //nolint:errcheck
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"example.com"}`, string(data))
+	})
+
+	t.Run("compresses large request bodies", func(t *testing.T) {
+		var encodings []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encodings = append(encodings, r.Header.Get("Content-Encoding"))
+			var body io.Reader = r.Body
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				zr, err := gzip.NewReader(r.Body)
+				require.NoError(t, err)
+				body = zr
+			}
+			var v map[string]string
+			assert.NoError(t, json.NewDecoder(body).Decode(&v))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithCompression(true, 100))
+		require.NoError(t, err)
+
+		_, err = client.http.Post(context.Background(), "/v1/dns", map[string]string{"name": "example.com"})
+		require.NoError(t, err)
+		_, err = client.http.Post(context.Background(), "/v1/dns", map[string]string{"name": strings.Repeat("a", 200)})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"", "gzip"}, encodings)
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Accept-Encoding"))
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithCompression(false, 0))
+		require.NoError(t, err)
+
+		_, err = client.http.Get(context.Background(), "/v1/dns", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("handles empty bodies", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Content-Encoding": {"gzip"}}, Body: io.NopCloser(bytes.NewReader(nil))}
+		require.NoError(t, decompressBody(resp))
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+}
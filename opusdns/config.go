@@ -2,7 +2,12 @@
 package opusdns
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 )
@@ -20,6 +25,16 @@ const (
 	// DefaultTimeout is the default HTTP client timeout.
 	DefaultTimeout = 30 * time.Second
 
+	// DefaultListConcurrency is the default number of pages fetched in parallel.
+	DefaultListConcurrency = 1
+
+	// DefaultMaxPages is the default number of pages ListZones and ListDomains fetch at most.
+	DefaultMaxPages = 10000
+
+	// DefaultMaxEmailForwardAliases is the default per-hostname alias limit
+	// EmailForwardsService.CreateAlias checks before sending a request.
+	DefaultMaxEmailForwardAliases = 100
+
 	// DefaultMaxRetries is the default number of retries for transient failures.
 	DefaultMaxRetries = 3
 
@@ -29,6 +44,16 @@ const (
 	// DefaultRetryWaitMax is the maximum wait time between retries.
 	DefaultRetryWaitMax = 30 * time.Second
 
+	// DefaultMaxIdleConnsPerHost is the default number of idle connections
+	// kept open to the API.
+	DefaultMaxIdleConnsPerHost = 100
+
+	// DefaultIdleConnTimeout is how long idle connections are kept open by default.
+	DefaultIdleConnTimeout = 90 * time.Second
+
+	// DefaultTCPKeepAlive is the default interval of TCP keep-alive probes.
+	DefaultTCPKeepAlive = 30 * time.Second
+
 	// DefaultPageSize is the default page size for paginated requests.
 	DefaultPageSize = 100
 
@@ -76,10 +101,52 @@ type Config struct {
 	// Default: 60
 	TTL int
 
-	// HTTPTimeout is the timeout for HTTP requests.
+	// HTTPTimeout is the timeout for each attempt of an HTTP request.
+	// ContextWithRequestTimeout overrides it per call. It is not applied to
+	// a custom HTTPClient, which brings its own Timeout.
 	// Default: 30s
 	HTTPTimeout time.Duration
 
+	// OverallTimeout bounds a whole API call, including retries, backoff and
+	// rate-limit waits. HTTPTimeout still applies to each individual attempt.
+	// ContextWithOverallTimeout overrides it per call.
+	// Default: 0 (no overall limit)
+	OverallTimeout time.Duration
+
+	// ListConcurrency is the number of pages automatic pagination (such as
+	// DNSService.ListZones) fetches in parallel once the first page has
+	// revealed the total. Results keep their page order.
+	// Default: 1 (pages are fetched one at a time)
+	ListConcurrency int
+
+	// ZoneCacheTTL is how long DNSService.FindZone remembers that a name is
+	// not a zone when it has to probe, saving a request per label on
+	// repeated lookups.
+	// Zones created through the client are picked up immediately.
+	// Default: 0 (no caching)
+	ZoneCacheTTL time.Duration
+
+	// MaxPages is the number of pages DNSService.ListZones and
+	// DomainsService.ListDomains fetch at most before failing with
+	// ErrTooManyResults. Other List* methods that page through results
+	// are not bounded by it. Zero disables the limit.
+	// Default: 10000
+	MaxPages int
+
+	// MaxItems is the number of results DNSService.ListZones and
+	// DomainsService.ListDomains return at most before failing with
+	// ErrTooManyResults. Zero disables the limit.
+	// Default: 0 (no limit)
+	MaxItems int
+
+	// MaxEmailForwardAliases is the number of aliases per hostname
+	// EmailForwardsService.CreateAlias allows before failing with a
+	// ValidationError instead of sending the request. The API does not report
+	// an account's limit, so set this to match it, or to zero to leave the
+	// check to the API.
+	// Default: 100
+	MaxEmailForwardAliases int
+
 	// MaxRetries is the maximum number of retries for transient failures (429, 5xx).
 	// Set to 0 to disable retries.
 	// Default: 3
@@ -93,11 +160,116 @@ type Config struct {
 	// Default: 30s
 	RetryWaitMax time.Duration
 
+	// RetryBudgetRatio limits retries, across all goroutines sharing the
+	// client, to this fraction of requests, such as 0.1 for one retry per ten
+	// requests, plus RetryBudgetBurst. Once the budget is spent, calls fail
+	// with their last error instead of retrying, so a throttled or failing
+	// API does not receive a multiple of the normal load.
+	// Default: 0 (retries are not limited)
+	RetryBudgetRatio float64
+
+	// RetryBudgetBurst is the number of retries allowed beyond
+	// RetryBudgetRatio, such as right after the client is created. It is
+	// also the most the budget saves up while requests succeed (at least one
+	// retry).
+	// Default: 0
+	RetryBudgetBurst int
+
+	// CircuitBreakerThreshold opens the circuit after this many consecutive
+	// transient failures (429, 5xx or network errors) across all goroutines
+	// sharing the client. While it is open, requests fail immediately with an
+	// error matching ErrCircuitOpen; after CircuitBreakerCooldown one request
+	// is let through, and its success closes the circuit.
+	// Default: 0 (no circuit breaker)
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long an open circuit fails requests
+	// before trying one again.
+	// Default: 30s when CircuitBreakerThreshold is set
+	CircuitBreakerCooldown time.Duration
+
+	// MaintenanceMaxWait makes requests wait out maintenance windows that
+	// end within this duration and then retry, instead of failing with a
+	// *MaintenanceError. Only windows with an advertised end are waited for.
+	// Default: 0 (never wait)
+	MaintenanceMaxWait time.Duration
+
 	// HTTPClient allows providing a custom HTTP client.
 	// If nil, a default client with the configured timeout will be used.
 	// Use this to configure custom transport settings, proxies, etc.
 	HTTPClient *http.Client
 
+	// Transport is the RoundTripper of the default HTTP client, for example
+	// to add instrumentation. It replaces the transport built from the
+	// connection settings below. Ignored when HTTPClient is set.
+	// Default: nil (an *http.Transport with proxy support from the environment)
+	Transport http.RoundTripper
+
+	// MaxConnsPerHost limits the connections to the API, counting those in
+	// use, idle and being dialed. Requests beyond it wait for a connection.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: 0 (no limit)
+	MaxConnsPerHost int
+
+	// MaxIdleConnsPerHost is the number of idle connections kept open to the
+	// API for reuse. Bulk workloads running many goroutines should keep it at
+	// least as high as their concurrency.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: 100
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: 90s
+	IdleConnTimeout time.Duration
+
+	// TCPKeepAlive is the interval of TCP keep-alive probes on connections to
+	// the API; negative disables them.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: 30s
+	TCPKeepAlive time.Duration
+
+	// DisableHTTP2 restricts connections to HTTP/1.1. HTTP/2 multiplexes
+	// concurrent requests over one connection, so disabling it spreads them
+	// over up to MaxConnsPerHost connections instead.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: false (HTTP/2 is used when the API offers it)
+	DisableHTTP2 bool
+
+	// ProxyURL is the proxy for requests to the API, such as
+	// "http://proxy.internal:3128".
+	// Ignored when HTTPClient or Transport is set.
+	// Default: "" (HTTPS_PROXY, HTTP_PROXY and NO_PROXY from the environment)
+	ProxyURL string
+
+	// RootCAs verifies the API's certificate, for example to reach a private
+	// gateway with an internal certificate authority.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: nil (the system roots)
+	RootCAs *x509.CertPool
+
+	// ClientCertificates are presented to servers requesting mutual TLS.
+	// Ignored when HTTPClient or Transport is set.
+	// Default: none
+	ClientCertificates []tls.Certificate
+
+	// DisableCompression stops asking the API for gzip-compressed responses.
+	// Compressed responses are decompressed transparently, which for large
+	// zone exports and listings saves most of the transfer.
+	// Default: false (responses are compressed)
+	DisableCompression bool
+
+	// CompressRequestsOver gzip-compresses request bodies of at least this
+	// many bytes, such as large batch record patches.
+	// Default: 0 (request bodies are sent uncompressed)
+	CompressRequestsOver int
+
+	// RequestSigningSecret, if set, signs every request with HMAC-SHA256 in
+	// addition to the API key, for deployments behind gateways requiring
+	// signed requests. See RequestSignature for what is signed.
+	// Default: "" (requests are not signed)
+	RequestSigningSecret string
+
 	// UserAgent is the user agent string to use for API requests.
 	// Default: opusdns-go-client/1.0.0
 	UserAgent string
@@ -109,6 +281,49 @@ type Config struct {
 	// Logger is the logger to use for debug output.
 	// If nil, logs will be written to stdout.
 	Logger Logger
+
+	// ContextLogger optionally returns the logger for a request's context,
+	// such as a request-scoped logger carrying the caller's trace IDs. If it
+	// returns nil, Logger is used.
+	ContextLogger func(ctx context.Context) Logger
+
+	// DeprecationHandler, if set, is called for every response carrying a
+	// Deprecation or Sunset header, or an API-Version header naming another
+	// version than APIVersion. Notices are also written to the debug log.
+	DeprecationHandler func(Deprecation)
+
+	// DryRun stops mutating requests from being sent; they fail with a
+	// *DryRunError describing the request instead. ContextWithDryRun
+	// overrides it per call.
+	// Default: false
+	DryRun bool
+
+	// Policies are guardrails consulted, in order, before every mutating
+	// request, including in dry-run mode. The first policy that rejects a
+	// request stops it with a *PolicyViolationError.
+	// Default: none
+	Policies []Policy
+
+	// AuditWriter, if set, receives a journal of every mutating call as
+	// NDJSON: one AuditEntry per line with the time, the API key ID, the
+	// request with secrets redacted, and the result. Policy rejections and
+	// dry runs are recorded too. Writes are serialized.
+	// Default: nil
+	AuditWriter io.Writer
+
+	// StrictDecoding checks every decoded response for fields the models do
+	// not cover and for non-optional fields the API did not send, and logs a
+	// warning for each, once per type and field, even without Debug. Use it
+	// in CI to detect API schema changes.
+	// Default: false
+	StrictDecoding bool
+
+	// Clock is the source of time for retry backoff, rate-limit and
+	// maintenance waits, the circuit breaker, request signing timestamps,
+	// zone lock leases, the zone cache and the polling of long-running
+	// helpers. Tests can supply a fake clock to run retries instantly.
+	// Default: the system clock
+	Clock Clock
 }
 
 // Logger is the interface for logging debug messages.
@@ -154,6 +369,47 @@ func WithHTTPTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithOverallTimeout limits the total time of an API call across all retries,
+// backoff and rate-limit waits. Zero disables the limit.
+func WithOverallTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.OverallTimeout = timeout
+	}
+}
+
+// WithListConcurrency sets how many pages automatic pagination fetches in parallel.
+func WithListConcurrency(n int) Option {
+	return func(c *Config) {
+		c.ListConcurrency = n
+	}
+}
+
+// WithZoneCache makes FindZone and FindZoneForFQDN remember for ttl which
+// names are not zones when they probe. Zero disables the cache.
+func WithZoneCache(ttl time.Duration) Option {
+	return func(c *Config) {
+		c.ZoneCacheTTL = ttl
+	}
+}
+
+// WithPaginationLimits sets how many pages and results ListZones and
+// ListDomains fetch at most before failing with ErrTooManyResults. Zero
+// disables a limit.
+func WithPaginationLimits(maxPages, maxItems int) Option {
+	return func(c *Config) {
+		c.MaxPages = maxPages
+		c.MaxItems = maxItems
+	}
+}
+
+// WithMaxEmailForwardAliases sets the per-hostname alias limit CreateAlias
+// checks locally. Zero leaves the check to the API.
+func WithMaxEmailForwardAliases(n int) Option {
+	return func(c *Config) {
+		c.MaxEmailForwardAliases = n
+	}
+}
+
 // WithMaxRetries sets the maximum number of retries.
 func WithMaxRetries(retries int) Option {
 	return func(c *Config) {
@@ -169,6 +425,31 @@ func WithRetryWait(min, max time.Duration) Option {
 	}
 }
 
+// WithRetryBudget limits retries across goroutines to ratio of the requests
+// plus burst.
+func WithRetryBudget(ratio float64, burst int) Option {
+	return func(c *Config) {
+		c.RetryBudgetRatio = ratio
+		c.RetryBudgetBurst = burst
+	}
+}
+
+// WithCircuitBreaker fails requests fast for cooldown after threshold
+// consecutive transient failures.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitBreakerThreshold = threshold
+		c.CircuitBreakerCooldown = cooldown
+	}
+}
+
+// WithMaintenanceWait makes requests wait out maintenance windows ending within max.
+func WithMaintenanceWait(max time.Duration) Option {
+	return func(c *Config) {
+		c.MaintenanceMaxWait = max
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Config) {
@@ -176,6 +457,84 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the RoundTripper of the default HTTP client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = transport
+	}
+}
+
+// WithMaxConnsPerHost limits the number of connections to the API.
+func WithMaxConnsPerHost(n int) Option {
+	return func(c *Config) {
+		c.MaxConnsPerHost = n
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the number of idle connections kept open to the API.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Config) {
+		c.MaxIdleConnsPerHost = n
+	}
+}
+
+// WithKeepAlive sets how long idle connections are kept open and the
+// interval of TCP keep-alive probes (negative disables them).
+func WithKeepAlive(idleTimeout, tcpKeepAlive time.Duration) Option {
+	return func(c *Config) {
+		c.IdleConnTimeout = idleTimeout
+		c.TCPKeepAlive = tcpKeepAlive
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2.
+func WithHTTP2(enabled bool) Option {
+	return func(c *Config) {
+		c.DisableHTTP2 = !enabled
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL.
+func WithProxy(proxyURL string) Option {
+	return func(c *Config) {
+		c.ProxyURL = proxyURL
+	}
+}
+
+// WithRootCAs sets the certificate authorities that verify the API's certificate.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Config) {
+		c.RootCAs = pool
+	}
+}
+
+// WithClientCertificate adds a client certificate for mutual TLS.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *Config) {
+		c.ClientCertificates = append(c.ClientCertificates, cert)
+	}
+}
+
+// WithCompression enables or disables gzip-compressed responses and, if
+// requestThreshold is positive, compresses request bodies of at least
+// requestThreshold bytes.
+func WithCompression(enabled bool, requestThreshold int) Option {
+	return func(c *Config) {
+		c.DisableCompression = !enabled
+		c.CompressRequestsOver = requestThreshold
+	}
+}
+
+// WithRequestSigner signs every request with HMAC-SHA256 keyed with secret,
+// setting the X-Signature and X-Signature-Timestamp headers. The timestamp
+// follows the API's clock as reported in response Date headers, and a request
+// rejected because the local clock was off is signed again once.
+func WithRequestSigner(secret string) Option {
+	return func(c *Config) {
+		c.RequestSigningSecret = secret
+	}
+}
+
 // WithUserAgent sets a custom user agent string.
 func WithUserAgent(userAgent string) Option {
 	return func(c *Config) {
@@ -197,18 +556,86 @@ func WithLogger(logger Logger) Option {
 	}
 }
 
+// WithContextLogger sets a function that extracts a logger from a request's
+// context, so debug output is written to the caller's request-scoped logger.
+func WithContextLogger(fn func(ctx context.Context) Logger) Option {
+	return func(c *Config) {
+		c.ContextLogger = fn
+	}
+}
+
+// WithDeprecationHandler sets a callback invoked with the deprecation notices
+// the API attaches to responses, so endpoints scheduled for removal can be
+// detected before they break. The handler is called for every such response
+// and must be safe for concurrent use.
+func WithDeprecationHandler(fn func(Deprecation)) Option {
+	return func(c *Config) {
+		c.DeprecationHandler = fn
+	}
+}
+
+// WithDryRun enables dry-run mode, in which mutating calls return a
+// *DryRunError with the request they would have sent instead of sending it.
+func WithDryRun(dryRun bool) Option {
+	return func(c *Config) {
+		c.DryRun = dryRun
+	}
+}
+
+// WithPolicy adds a guardrail consulted before every mutating request, such
+// as DenyZoneDeletion, MinTTL or RequireTransferLock. It may be given more
+// than once; the policies run in order.
+func WithPolicy(p Policy) Option {
+	return func(c *Config) {
+		c.Policies = append(c.Policies, p)
+	}
+}
+
+// WithAuditWriter records every mutating call as a line of NDJSON in w, for
+// change-management trails kept independently of the API's own events.
+func WithAuditWriter(w io.Writer) Option {
+	return func(c *Config) {
+		c.AuditWriter = w
+	}
+}
+
+// WithStrictDecoding enables warnings about response fields the models do
+// not cover and about missing fields.
+func WithStrictDecoding(enabled bool) Option {
+	return func(c *Config) {
+		c.StrictDecoding = enabled
+	}
+}
+
+// WithClock sets the clock used for retry backoff, rate-limit waits, the
+// circuit breaker, signing timestamps, zone lock leases, the zone cache and
+// polling helpers, so tests can simulate time.
+func WithClock(clock Clock) Option {
+	return func(c *Config) {
+		c.Clock = clock
+	}
+}
+
 // NewConfig creates a new Config with default values.
 // Optionally applies the provided functional options.
 func NewConfig(opts ...Option) *Config {
 	cfg := &Config{
-		APIEndpoint:  DefaultAPIEndpoint,
-		APIVersion:   DefaultAPIVersion,
-		TTL:          DefaultTTL,
-		HTTPTimeout:  DefaultTimeout,
-		MaxRetries:   DefaultMaxRetries,
-		RetryWaitMin: DefaultRetryWaitMin,
-		RetryWaitMax: DefaultRetryWaitMax,
-		UserAgent:    GetUserAgent(),
+		APIEndpoint:     DefaultAPIEndpoint,
+		APIVersion:      DefaultAPIVersion,
+		TTL:             DefaultTTL,
+		HTTPTimeout:     DefaultTimeout,
+		MaxRetries:      DefaultMaxRetries,
+		ListConcurrency: DefaultListConcurrency,
+		MaxPages:        DefaultMaxPages,
+		RetryWaitMin:    DefaultRetryWaitMin,
+		RetryWaitMax:    DefaultRetryWaitMax,
+		UserAgent:       GetUserAgent(),
+
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		TCPKeepAlive:        DefaultTCPKeepAlive,
+
+		MaxEmailForwardAliases: DefaultMaxEmailForwardAliases,
 	}
 
 	// Apply environment variables
@@ -256,6 +683,24 @@ func (c *Config) Validate() error {
 	if c.HTTPTimeout < 0 {
 		return &ConfigError{Field: "HTTPTimeout", Message: "HTTP timeout must be non-negative"}
 	}
+	if c.OverallTimeout < 0 {
+		return &ConfigError{Field: "OverallTimeout", Message: "overall timeout must be non-negative"}
+	}
+	if c.ListConcurrency < 0 {
+		return &ConfigError{Field: "ListConcurrency", Message: "ListConcurrency must be non-negative"}
+	}
+	if c.ZoneCacheTTL < 0 {
+		return &ConfigError{Field: "ZoneCacheTTL", Message: "ZoneCacheTTL must be non-negative"}
+	}
+	if c.MaxPages < 0 {
+		return &ConfigError{Field: "MaxPages", Message: "MaxPages must be non-negative"}
+	}
+	if c.MaxItems < 0 {
+		return &ConfigError{Field: "MaxItems", Message: "MaxItems must be non-negative"}
+	}
+	if c.MaxEmailForwardAliases < 0 {
+		return &ConfigError{Field: "MaxEmailForwardAliases", Message: "MaxEmailForwardAliases must be non-negative"}
+	}
 	if c.MaxRetries < 0 {
 		return &ConfigError{Field: "MaxRetries", Message: "MaxRetries must be non-negative"}
 	}
@@ -265,6 +710,29 @@ func (c *Config) Validate() error {
 	if c.RetryWaitMax < 0 {
 		return &ConfigError{Field: "RetryWaitMax", Message: "RetryWaitMax must be non-negative"}
 	}
+	if c.MaxConnsPerHost < 0 {
+		return &ConfigError{Field: "MaxConnsPerHost", Message: "MaxConnsPerHost must be non-negative"}
+	}
+	if c.MaxIdleConnsPerHost < 0 {
+		return &ConfigError{Field: "MaxIdleConnsPerHost", Message: "MaxIdleConnsPerHost must be non-negative"}
+	}
+	if c.IdleConnTimeout < 0 {
+		return &ConfigError{Field: "IdleConnTimeout", Message: "IdleConnTimeout must be non-negative"}
+	}
+	if c.ProxyURL != "" {
+		if u, err := url.Parse(c.ProxyURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return &ConfigError{Field: "ProxyURL", Message: "proxy URL must be absolute, such as http://proxy:3128"}
+		}
+	}
+	if c.CompressRequestsOver < 0 {
+		return &ConfigError{Field: "CompressRequestsOver", Message: "CompressRequestsOver must be non-negative"}
+	}
+	if c.RetryBudgetRatio < 0 || c.RetryBudgetBurst < 0 {
+		return &ConfigError{Field: "RetryBudgetRatio", Message: "retry budget must be non-negative"}
+	}
+	if c.CircuitBreakerThreshold < 0 || c.CircuitBreakerCooldown < 0 {
+		return &ConfigError{Field: "CircuitBreakerThreshold", Message: "circuit breaker settings must be non-negative"}
+	}
 	if c.RetryWaitMin > c.RetryWaitMax {
 		return &ConfigError{Field: "RetryWaitMin", Message: "RetryWaitMin must not exceed RetryWaitMax"}
 	}
@@ -0,0 +1,184 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// contactCSVHeader is the column layout written by WriteContactsCSV.
+// ReadContactsCSV matches columns by header name, so extra columns (such as
+// contact_id from an export) are ignored and the order may differ.
+var contactCSVHeader = []string{
+	"contact_id",
+	"first_name",
+	"last_name",
+	"org",
+	"title",
+	"email",
+	"phone",
+	"fax",
+	"street",
+	"city",
+	"state",
+	"postal_code",
+	"country",
+	"disclose",
+}
+
+// contactCSVRequired lists the columns that must be present and non-empty on import.
+var contactCSVRequired = []string{
+	"first_name",
+	"last_name",
+	"email",
+	"phone",
+	"street",
+	"city",
+	"postal_code",
+	"country",
+}
+
+// WriteContactsCSV writes contacts as CSV with a header row.
+func WriteContactsCSV(w io.Writer, contacts []models.Contact) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(contactCSVHeader); err != nil {
+		return err
+	}
+
+	for _, c := range contacts {
+		row := []string{
+			string(c.ContactID),
+			c.FirstName,
+			c.LastName,
+			models.Deref(c.Org),
+			models.Deref(c.Title),
+			c.Email,
+			c.Phone,
+			models.Deref(c.Fax),
+			c.Street,
+			c.City,
+			models.Deref(c.State),
+			c.PostalCode,
+			c.Country,
+			strconv.FormatBool(c.Disclose),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadContactsCSV parses CSV with a header row into contact create requests.
+// Empty optional columns (org, title, fax, state) are left nil, and an empty
+// disclose column defaults to false. Rows missing a required value are
+// reported as a *ValidationError naming the offending row and column.
+func ReadContactsCSV(r io.Reader) ([]models.ContactCreateRequest, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &ValidationError{Field: "csv", Message: "missing header row"}
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range contactCSVRequired {
+		if _, ok := columns[name]; !ok {
+			return nil, &ValidationError{Field: "csv", Message: fmt.Sprintf("missing required column %q", name)}
+		}
+	}
+
+	var reqs []models.ContactCreateRequest
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		optional := func(name string) *string {
+			if v := get(name); v != "" {
+				return &v
+			}
+			return nil
+		}
+
+		for _, name := range contactCSVRequired {
+			if get(name) == "" {
+				return nil, &ValidationError{Field: name, Message: fmt.Sprintf("row %d: value is required", line)}
+			}
+		}
+
+		var disclose bool
+		if v := get("disclose"); v != "" {
+			disclose, err = strconv.ParseBool(v)
+			if err != nil {
+				return nil, &ValidationError{Field: "disclose", Message: fmt.Sprintf("row %d: must be true or false", line), Value: v}
+			}
+		}
+
+		reqs = append(reqs, models.ContactCreateRequest{
+			FirstName:  get("first_name"),
+			LastName:   get("last_name"),
+			Org:        optional("org"),
+			Title:      optional("title"),
+			Email:      get("email"),
+			Phone:      get("phone"),
+			Fax:        optional("fax"),
+			Street:     get("street"),
+			City:       get("city"),
+			State:      optional("state"),
+			PostalCode: get("postal_code"),
+			Country:    get("country"),
+			Disclose:   disclose,
+		})
+	}
+
+	return reqs, nil
+}
+
+// ExportCSV lists contacts matching opts (with automatic pagination) and writes them to w as CSV.
+func (s *ContactsService) ExportCSV(ctx context.Context, w io.Writer, opts *models.ListContactsOptions) error {
+	contacts, err := s.ListContacts(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return WriteContactsCSV(w, contacts)
+}
+
+// ImportCSV parses contacts from CSV and creates them with BulkCreate.
+// The whole file is validated before any contact is created, so a malformed
+// row never leaves a partial import behind.
+func (s *ContactsService) ImportCSV(ctx context.Context, r io.Reader) (*ContactBulkCreateResult, error) {
+	reqs, err := ReadContactsCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.BulkCreate(ctx, reqs)
+}
@@ -0,0 +1,92 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactsCSV_RoundTrip(t *testing.T) {
+	contacts := []models.Contact{
+		{
+			ContactID:  "contact_1",
+			FirstName:  "John",
+			LastName:   "Doe",
+			Org:        models.StringPtr("Acme, Inc."),
+			Email:      "john@example.com",
+			Phone:      "+1.2125551234",
+			Street:     "123 Main St",
+			City:       "New York",
+			PostalCode: "10001",
+			Country:    "US",
+			Disclose:   true,
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteContactsCSV(&buf, contacts))
+
+	reqs, err := ReadContactsCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "John", reqs[0].FirstName)
+	require.NotNil(t, reqs[0].Org)
+	assert.Equal(t, "Acme, Inc.", *reqs[0].Org)
+	assert.Nil(t, reqs[0].Fax)
+	assert.Nil(t, reqs[0].State)
+	assert.True(t, reqs[0].Disclose)
+}
+
+func TestContactsCSV_ReadValidation(t *testing.T) {
+	t.Run("missing column", func(t *testing.T) {
+		_, err := ReadContactsCSV(strings.NewReader("first_name,last_name\nJohn,Doe\n"))
+
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), "email")
+	})
+
+	t.Run("missing value reports row", func(t *testing.T) {
+		data := "first_name,last_name,email,phone,street,city,postal_code,country\n" +
+			"John,Doe,john@example.com,+1.2125551234,1 Main St,NYC,10001,US\n" +
+			"Jane,Doe,,+1.2125551234,1 Main St,NYC,10001,US\n"
+
+		_, err := ReadContactsCSV(strings.NewReader(data))
+
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), "row 3")
+	})
+}
+
+func TestContactsService_ImportCSV(t *testing.T) {
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/contacts", r.URL.Path)
+		created++
+		_ = json.NewEncoder(w).Encode(models.Contact{ContactID: "contact_1"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	data := "email,first_name,last_name,phone,street,city,postal_code,country\n" +
+		"john@example.com,John,Doe,+1.2125551234,1 Main St,NYC,10001,US\n"
+
+	result, err := client.Contacts.ImportCSV(context.Background(), strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Failed)
+	assert.Equal(t, 1, created)
+}
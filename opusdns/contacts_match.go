@@ -0,0 +1,165 @@
+package opusdns
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Weights used by ScoreContactMatch. They sum to 1 so a perfect match scores 1.
+const (
+	contactMatchWeightEmail   = 0.4
+	contactMatchWeightName    = 0.2
+	contactMatchWeightAddress = 0.3
+	contactMatchWeightPhone   = 0.1
+)
+
+// Field names reported in ContactMatch.MatchedFields.
+const (
+	ContactMatchFieldEmail   = "email"
+	ContactMatchFieldName    = "name"
+	ContactMatchFieldAddress = "address"
+	ContactMatchFieldPhone   = "phone"
+)
+
+// ContactMatch is an existing contact that resembles a contact create request.
+type ContactMatch struct {
+	// Contact is the existing contact.
+	Contact models.Contact
+
+	// Score is the match confidence between 0 and 1 (1 means every compared field matched).
+	Score float64
+
+	// MatchedFields lists which fields matched (see the ContactMatchField constants).
+	MatchedFields []string
+}
+
+// FindMatching searches existing contacts that resemble req, so automation can
+// reuse an existing contact handle instead of creating a duplicate. Candidates
+// are fetched by email, by name and by postal code and country, scored with
+// ScoreContactMatch, and returned in descending score order. Contacts that
+// match on no field are omitted. The email is sent as given (trimmed), since
+// the API may compare it case-sensitively; scoring ignores case.
+func (s *ContactsService) FindMatching(ctx context.Context, req models.ContactCreateRequest) ([]ContactMatch, error) {
+	candidates := map[models.ContactID]models.Contact{}
+	var order []models.ContactID
+
+	collect := func(opts *models.ListContactsOptions) error {
+		contacts, err := s.ListContacts(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, c := range contacts {
+			if _, ok := candidates[c.ContactID]; !ok {
+				order = append(order, c.ContactID)
+			}
+			candidates[c.ContactID] = c
+		}
+		return nil
+	}
+
+	if email := strings.TrimSpace(req.Email); email != "" {
+		if err := collect(&models.ListContactsOptions{Email: email}); err != nil {
+			return nil, err
+		}
+	}
+	if req.FirstName != "" && req.LastName != "" {
+		if err := collect(&models.ListContactsOptions{
+			FirstName: strings.TrimSpace(req.FirstName),
+			LastName:  strings.TrimSpace(req.LastName),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	postalCode, country := strings.TrimSpace(req.PostalCode), strings.TrimSpace(req.Country)
+	if postalCode != "" && country != "" {
+		if err := collect(&models.ListContactsOptions{PostalCode: postalCode, Country: country}); err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []ContactMatch
+	for _, id := range order {
+		contact := candidates[id]
+		score, fields := ScoreContactMatch(&contact, &req)
+		if score == 0 {
+			continue
+		}
+		matches = append(matches, ContactMatch{Contact: contact, Score: score, MatchedFields: fields})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches, nil
+}
+
+// ScoreContactMatch compares an existing contact with a create request after
+// normalizing case, whitespace and phone punctuation. It returns a score
+// between 0 and 1 and the list of matching fields. The address only matches
+// when street, postal code and country all agree.
+func ScoreContactMatch(contact *models.Contact, req *models.ContactCreateRequest) (float64, []string) {
+	var score float64
+	var fields []string
+
+	if e := normalizeEmail(req.Email); e != "" && e == normalizeEmail(contact.Email) {
+		score += contactMatchWeightEmail
+		fields = append(fields, ContactMatchFieldEmail)
+	}
+
+	reqName := normalizeText(req.FirstName + " " + req.LastName)
+	if reqName != "" && reqName == normalizeText(contact.FirstName+" "+contact.LastName) {
+		score += contactMatchWeightName
+		fields = append(fields, ContactMatchFieldName)
+	}
+
+	reqStreet := normalizeText(req.Street)
+	if reqStreet != "" &&
+		reqStreet == normalizeText(contact.Street) &&
+		normalizeText(req.PostalCode) == normalizeText(contact.PostalCode) &&
+		strings.EqualFold(strings.TrimSpace(req.Country), strings.TrimSpace(contact.Country)) {
+		score += contactMatchWeightAddress
+		fields = append(fields, ContactMatchFieldAddress)
+	}
+
+	if p := normalizePhone(req.Phone); p != "" && p == normalizePhone(contact.Phone) {
+		score += contactMatchWeightPhone
+		fields = append(fields, ContactMatchFieldPhone)
+	}
+
+	return score, fields
+}
+
+// normalizeEmail lower-cases and trims an email address.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeText lower-cases s, drops punctuation and collapses whitespace.
+func normalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// normalizePhone keeps only the digits of a phone number.
+func normalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
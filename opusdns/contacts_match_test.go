@@ -0,0 +1,103 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreContactMatch(t *testing.T) {
+	contact := &models.Contact{
+		FirstName:  "John",
+		LastName:   "Doe",
+		Email:      "John.Doe@Example.com",
+		Phone:      "+1.212-555-1234",
+		Street:     "123 Main St.",
+		PostalCode: "10001",
+		Country:    "us",
+	}
+
+	t.Run("full match", func(t *testing.T) {
+		score, fields := ScoreContactMatch(contact, &models.ContactCreateRequest{
+			FirstName:  " john ",
+			LastName:   "DOE",
+			Email:      "john.doe@example.com",
+			Phone:      "+1.2125551234",
+			Street:     "123  main st",
+			PostalCode: "10001",
+			Country:    "US",
+		})
+
+		assert.InDelta(t, 1.0, score, 0.0001)
+		assert.Equal(t, []string{ContactMatchFieldEmail, ContactMatchFieldName, ContactMatchFieldAddress, ContactMatchFieldPhone}, fields)
+	})
+
+	t.Run("address requires postal code and country", func(t *testing.T) {
+		score, fields := ScoreContactMatch(contact, &models.ContactCreateRequest{
+			Street:     "123 Main St",
+			PostalCode: "99999",
+			Country:    "US",
+		})
+
+		assert.Zero(t, score)
+		assert.Empty(t, fields)
+	})
+}
+
+func TestContactsService_FindMatching(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1/contacts", r.URL.Path)
+		query := r.URL.Query()
+		queries = append(queries, query.Encode())
+
+		var results []models.Contact
+		switch {
+		case query.Get("email") != "":
+			results = []models.Contact{
+				{ContactID: "contact_email", FirstName: "Johnny", LastName: "D", Email: "john@example.com"},
+			}
+		case query.Get("postal_code") != "":
+			results = []models.Contact{
+				{ContactID: "contact_address", FirstName: "Jane", LastName: "Roe", Street: "1 Main St.", PostalCode: "10001", Country: "US"},
+				{ContactID: "contact_neighbour", FirstName: "Max", LastName: "Poe", Street: "7 Side St", PostalCode: "10001", Country: "US"},
+			}
+		default:
+			results = []models.Contact{
+				{ContactID: "contact_full", FirstName: "John", LastName: "Doe", Email: "john@example.com", Street: "1 Main St", PostalCode: "10001", Country: "US"},
+				{ContactID: "contact_email", FirstName: "Johnny", LastName: "D", Email: "john@example.com"},
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(models.ContactListResponse{Results: results})
+	}))
+
+	matches, err := client.Contacts.FindMatching(context.Background(), models.ContactCreateRequest{
+		FirstName:  "John",
+		LastName:   "Doe",
+		Email:      "John@Example.com",
+		Street:     "1 Main St",
+		PostalCode: "10001",
+		Country:    "US",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"email=John%40Example.com&page=1&page_size=100",
+		"first_name=John&last_name=Doe&page=1&page_size=100",
+		"country=US&page=1&page_size=100&postal_code=10001",
+	}, queries)
+	require.Len(t, matches, 3)
+	assert.Equal(t, models.ContactID("contact_full"), matches[0].Contact.ContactID)
+	assert.InDelta(t, 0.9, matches[0].Score, 0.0001)
+	assert.Equal(t, models.ContactID("contact_email"), matches[1].Contact.ContactID)
+	assert.Equal(t, []string{ContactMatchFieldEmail}, matches[1].MatchedFields)
+	assert.Equal(t, models.ContactID("contact_address"), matches[2].Contact.ContactID)
+	assert.Equal(t, []string{ContactMatchFieldAddress}, matches[2].MatchedFields)
+}
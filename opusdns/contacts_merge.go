@@ -0,0 +1,292 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// ContactMatcher reports whether two contacts agree on some property. It is
+// used by ContactsService.FindDuplicates to decide which contacts are duplicates.
+type ContactMatcher func(a, b *models.Contact) bool
+
+// Matchers for ContactsService.FindDuplicates. They normalize case,
+// whitespace and punctuation like ScoreContactMatch, and never match on
+// empty values.
+var (
+	// MatchContactEmail matches contacts with the same email address.
+	MatchContactEmail ContactMatcher = func(a, b *models.Contact) bool {
+		e := normalizeEmail(a.Email)
+		return e != "" && e == normalizeEmail(b.Email)
+	}
+
+	// MatchContactName matches contacts with the same first and last name.
+	MatchContactName ContactMatcher = func(a, b *models.Contact) bool {
+		n := normalizeText(a.FirstName + " " + a.LastName)
+		return n != "" && n == normalizeText(b.FirstName+" "+b.LastName)
+	}
+
+	// MatchContactAddress matches contacts whose street, postal code and country all agree.
+	MatchContactAddress ContactMatcher = func(a, b *models.Contact) bool {
+		street := normalizeText(a.Street)
+		return street != "" &&
+			street == normalizeText(b.Street) &&
+			normalizeText(a.PostalCode) == normalizeText(b.PostalCode) &&
+			strings.EqualFold(strings.TrimSpace(a.Country), strings.TrimSpace(b.Country))
+	}
+
+	// MatchContactPhone matches contacts with the same phone number digits.
+	MatchContactPhone ContactMatcher = func(a, b *models.Contact) bool {
+		p := normalizePhone(a.Phone)
+		return p != "" && p == normalizePhone(b.Phone)
+	}
+)
+
+// ContactDuplicateGroup is a set of contacts considered duplicates of each other.
+type ContactDuplicateGroup struct {
+	// Survivor is the suggested contact to keep: the oldest in the group.
+	Survivor models.Contact
+
+	// Duplicates are the other contacts in the group, oldest first.
+	Duplicates []models.Contact
+}
+
+// DuplicateIDs returns the IDs of the group's duplicates, for ContactsService.Merge.
+func (g *ContactDuplicateGroup) DuplicateIDs() []models.ContactID {
+	ids := make([]models.ContactID, len(g.Duplicates))
+	for i, c := range g.Duplicates {
+		ids[i] = c.ContactID
+	}
+	return ids
+}
+
+// FindDuplicates lists all contacts and groups those that are duplicates of
+// each other. Two contacts are duplicates when every matcher agrees; with no
+// matchers, MatchContactEmail and MatchContactName are used. Grouping is
+// transitive, so a group may contain contacts that only match through a
+// third one. Groups are returned in listing order of their survivors.
+func (s *ContactsService) FindDuplicates(ctx context.Context, matchers ...ContactMatcher) ([]ContactDuplicateGroup, error) {
+	if len(matchers) == 0 {
+		matchers = []ContactMatcher{MatchContactEmail, MatchContactName}
+	}
+
+	contacts, err := s.ListContacts(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Union-find over the contact indexes.
+	parent := make([]int, len(contacts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range contacts {
+		for j := i + 1; j < len(contacts); j++ {
+			if find(i) == find(j) || !matchesAll(matchers, &contacts[i], &contacts[j]) {
+				continue
+			}
+			parent[find(j)] = find(i)
+		}
+	}
+
+	members := map[int][]models.Contact{}
+	var roots []int
+	for i := range contacts {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], contacts[i])
+	}
+
+	var groups []ContactDuplicateGroup
+	for _, root := range roots {
+		group := members[root]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			a, b := group[i].CreatedOn, group[j].CreatedOn
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return a.Before(*b)
+		})
+		groups = append(groups, ContactDuplicateGroup{Survivor: group[0], Duplicates: group[1:]})
+	}
+
+	return groups, nil
+}
+
+// matchesAll reports whether every matcher agrees on a and b.
+func matchesAll(matchers []ContactMatcher, a, b *models.Contact) bool {
+	for _, match := range matchers {
+		if !match(a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContactMergeOptions configures ContactsService.Merge.
+type ContactMergeOptions struct {
+	// DryRun reports the domain changes and deletions without making them.
+	DryRun bool
+}
+
+// ContactMergeReport describes the changes made, or planned in a dry run, by
+// ContactsService.Merge.
+type ContactMergeReport struct {
+	// SurvivorID is the contact the duplicates were merged into.
+	SurvivorID models.ContactID
+
+	// DryRun is true if no changes were made.
+	DryRun bool
+
+	// Domains lists the domains whose contacts were re-pointed to the survivor.
+	Domains []ContactMergeDomain
+
+	// Deleted lists the duplicate contacts that were deleted (or would be, in
+	// a dry run).
+	Deleted []models.ContactID
+}
+
+// ContactMergeDomain is a domain whose contacts are re-pointed by a merge.
+type ContactMergeDomain struct {
+	// Domain is the domain name.
+	Domain string
+
+	// Contacts holds the new handles for each contact type that changed.
+	Contacts map[models.DomainContactType][]models.ContactHandle
+
+	// Replaced lists the duplicate contacts the domain referenced.
+	Replaced []models.ContactID
+}
+
+// Merge replaces duplicate contacts with a survivor: every domain referencing
+// a duplicate is updated to reference the survivor instead, then the
+// duplicates are deleted. Domains whose listing carries no contacts are
+// fetched individually. With DryRun set, the report describes the planned
+// changes and nothing is modified.
+//
+// Merge stops at the first failure and returns the report of the changes made
+// so far; duplicates are only deleted once every domain has been updated. It
+// also requires the domains:manage permission for the domain updates.
+func (s *ContactsService) Merge(ctx context.Context, survivorID models.ContactID, duplicateIDs []models.ContactID, opts *ContactMergeOptions) (*ContactMergeReport, error) {
+	if survivorID == "" {
+		return nil, &ValidationError{Field: "survivorID", Message: "survivor contact ID is required"}
+	}
+	if len(duplicateIDs) == 0 {
+		return nil, &ValidationError{Field: "duplicateIDs", Message: "at least one duplicate contact ID is required"}
+	}
+	duplicates := make(map[models.ContactID]bool, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		if id == survivorID {
+			return nil, &ValidationError{Field: "duplicateIDs", Message: "must not contain the survivor", Value: id}
+		}
+		duplicates[id] = true
+	}
+
+	o := ContactMergeOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if _, err := s.GetContact(ctx, survivorID); err != nil {
+		return nil, err
+	}
+
+	domains, err := s.client.Domains.ListDomains(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ContactMergeReport{SurvivorID: survivorID, DryRun: o.DryRun}
+	for _, domain := range domains {
+		contacts := domain.Contacts
+		if len(contacts) == 0 {
+			full, err := s.client.Domains.GetDomain(ctx, domain.Name)
+			if err != nil {
+				return report, err
+			}
+			contacts = full.Contacts
+		}
+
+		change := planContactMerge(domain.Name, contacts, survivorID, duplicates)
+		if change == nil {
+			continue
+		}
+		if !o.DryRun {
+			if _, err := s.client.Domains.UpdateDomain(ctx, domain.Name, &models.DomainUpdateRequest{Contacts: change.Contacts}); err != nil {
+				return report, fmt.Errorf("opusdns: failed to update contacts of %s: %w", domain.Name, err)
+			}
+		}
+		report.Domains = append(report.Domains, *change)
+	}
+
+	for _, id := range duplicateIDs {
+		if !o.DryRun {
+			if err := s.DeleteContact(ctx, id); err != nil {
+				return report, fmt.Errorf("opusdns: failed to delete contact %s: %w", id, err)
+			}
+		}
+		report.Deleted = append(report.Deleted, id)
+	}
+
+	return report, nil
+}
+
+// planContactMerge returns the contact changes that replace duplicates with
+// the survivor on a domain, or nil if the domain references no duplicate.
+func planContactMerge(domain string, contacts []models.DomainContact, survivorID models.ContactID, duplicates map[models.ContactID]bool) *ContactMergeDomain {
+	byType := map[models.DomainContactType][]models.ContactID{}
+	var types []models.DomainContactType
+	for _, c := range contacts {
+		if _, ok := byType[c.ContactType]; !ok {
+			types = append(types, c.ContactType)
+		}
+		byType[c.ContactType] = append(byType[c.ContactType], c.ContactID)
+	}
+
+	change := &ContactMergeDomain{Domain: domain, Contacts: map[models.DomainContactType][]models.ContactHandle{}}
+	replaced := map[models.ContactID]bool{}
+	for _, t := range types {
+		changed := false
+		seen := map[models.ContactID]bool{}
+		var handles []models.ContactHandle
+		for _, id := range byType[t] {
+			if duplicates[id] {
+				if !replaced[id] {
+					replaced[id] = true
+					change.Replaced = append(change.Replaced, id)
+				}
+				id = survivorID
+				changed = true
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			handles = append(handles, models.ContactHandle{ContactID: id})
+		}
+		if changed {
+			change.Contacts[t] = handles
+		}
+	}
+
+	if len(change.Replaced) == 0 {
+		return nil
+	}
+	return change
+}
@@ -0,0 +1,141 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactsService_FindDuplicates(t *testing.T) {
+	older := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/contacts", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(models.ContactListResponse{
+			Results: []models.Contact{
+				{ContactID: "contact_a", FirstName: "John", LastName: "Doe", Email: "john@example.com", CreatedOn: &newer},
+				{ContactID: "contact_b", FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"},
+				{ContactID: "contact_c", FirstName: "john", LastName: "DOE", Email: " John@Example.com ", CreatedOn: &older},
+				{ContactID: "contact_d", FirstName: "John", LastName: "Doe", Email: "other@example.com"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	groups, err := client.Contacts.FindDuplicates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, models.ContactID("contact_c"), groups[0].Survivor.ContactID)
+	assert.Equal(t, []models.ContactID{"contact_a"}, groups[0].DuplicateIDs())
+
+	groups, err = client.Contacts.FindDuplicates(context.Background(), MatchContactName)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, models.ContactID("contact_c"), groups[0].Survivor.ContactID)
+	assert.Equal(t, []models.ContactID{"contact_a", "contact_d"}, groups[0].DuplicateIDs())
+}
+
+func TestContactsService_Merge(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updates = map[string]models.DomainUpdateRequest{}
+		deleted []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v1/contacts/contact_keep":
+			_ = json.NewEncoder(w).Encode(models.Contact{ContactID: "contact_keep"})
+		case r.Method == "GET" && r.URL.Path == "/v1/domains":
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{
+				Results: []models.Domain{
+					{Name: "one.com", Contacts: []models.DomainContact{
+						{ContactID: "contact_dup", ContactType: models.DomainContactTypeRegistrant},
+						{ContactID: "contact_keep", ContactType: models.DomainContactTypeAdmin},
+						{ContactID: "contact_dup", ContactType: models.DomainContactTypeAdmin},
+						{ContactID: "contact_other", ContactType: models.DomainContactTypeTech},
+					}},
+					{Name: "two.com"},
+					{Name: "three.com", Contacts: []models.DomainContact{
+						{ContactID: "contact_other", ContactType: models.DomainContactTypeRegistrant},
+					}},
+				},
+			})
+		case r.Method == "GET" && r.URL.Path == "/v1/domains/two.com":
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "two.com", Contacts: []models.DomainContact{
+				{ContactID: "contact_dup2", ContactType: models.DomainContactTypeBilling},
+			}})
+		case r.Method == "PATCH":
+			var req models.DomainUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			updates[r.URL.Path] = req
+			_ = json.NewEncoder(w).Encode(models.Domain{})
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	dups := []models.ContactID{"contact_dup", "contact_dup2"}
+
+	t.Run("dry run", func(t *testing.T) {
+		report, err := client.Contacts.Merge(context.Background(), "contact_keep", dups, &ContactMergeOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Domains, 2)
+		assert.Equal(t, "one.com", report.Domains[0].Domain)
+		assert.Equal(t, "two.com", report.Domains[1].Domain)
+		assert.Equal(t, dups, report.Deleted)
+		assert.Empty(t, updates)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		report, err := client.Contacts.Merge(context.Background(), "contact_keep", dups, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Domains, 2)
+
+		one := updates["/v1/domains/one.com"]
+		assert.Equal(t, map[models.DomainContactType][]models.ContactHandle{
+			models.DomainContactTypeRegistrant: {{ContactID: "contact_keep"}},
+			models.DomainContactTypeAdmin:      {{ContactID: "contact_keep"}},
+		}, one.Contacts)
+		assert.Equal(t, []models.ContactID{"contact_dup"}, report.Domains[0].Replaced)
+
+		two := updates["/v1/domains/two.com"]
+		assert.Equal(t, []models.ContactHandle{{ContactID: "contact_keep"}}, two.Contacts[models.DomainContactTypeBilling])
+		assert.NotContains(t, updates, "/v1/domains/three.com")
+
+		assert.Equal(t, []string{"/v1/contacts/contact_dup", "/v1/contacts/contact_dup2"}, deleted)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := client.Contacts.Merge(context.Background(), "contact_keep", nil, nil)
+		assert.True(t, IsValidationError(err))
+
+		_, err = client.Contacts.Merge(context.Background(), "contact_keep", []models.ContactID{"contact_keep"}, nil)
+		assert.True(t, IsValidationError(err))
+	})
+}
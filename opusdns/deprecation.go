@@ -0,0 +1,118 @@
+package opusdns
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecation is a deprecation notice the API attached to a response through
+// the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, or through an
+// API-Version header naming another version than the client requested.
+type Deprecation struct {
+	// Method and Path identify the request that received the notice.
+	Method string
+	Path   string
+
+	// Deprecated is true if the endpoint is deprecated or has a sunset date,
+	// and false for notices only about the API version.
+	Deprecated bool
+
+	// APIVersion is the API version that served the request, from the
+	// API-Version header, if the API reported one.
+	APIVersion string
+
+	// RequestedVersion is the API version the client is configured for,
+	// set when it differs from APIVersion.
+	RequestedVersion string
+
+	// Date is when the endpoint was or will be deprecated, if the API gave one.
+	Date *time.Time
+
+	// Sunset is when the endpoint is scheduled to stop working, if announced.
+	Sunset *time.Time
+
+	// Link is the URL of the deprecation or sunset policy, if provided.
+	Link string
+}
+
+// linkRelPattern matches a Link header entry with a deprecation or sunset relation.
+var linkRelPattern = regexp.MustCompile(`<([^>]+)>[^,]*;\s*rel="?(?:deprecation|sunset)"?`)
+
+// parseDeprecation extracts a deprecation notice from response headers. It
+// returns nil if the response carries neither a Deprecation nor a Sunset
+// header and its API-Version, if any, matches the requested version.
+func parseDeprecation(method, path, requestedVersion string, headers http.Header) *Deprecation {
+	deprecation := strings.TrimSpace(headers.Get("Deprecation"))
+	sunset := strings.TrimSpace(headers.Get("Sunset"))
+	apiVersion := strings.TrimSpace(headers.Get("API-Version"))
+	deprecated := (deprecation != "" && deprecation != "false") || sunset != ""
+	mismatch := apiVersion != "" && !sameAPIVersion(apiVersion, requestedVersion)
+	if !deprecated && !mismatch {
+		return nil
+	}
+
+	d := &Deprecation{Method: method, Path: path, Deprecated: deprecated, APIVersion: apiVersion}
+	if mismatch {
+		d.RequestedVersion = requestedVersion
+	}
+	switch {
+	case strings.HasPrefix(deprecation, "@"):
+		// RFC 9745 structured date: seconds since the epoch.
+		if secs, err := strconv.ParseInt(deprecation[1:], 10, 64); err == nil {
+			t := time.Unix(secs, 0).UTC()
+			d.Date = &t
+		}
+	case deprecation != "" && deprecation != "true":
+		// Earlier drafts used an HTTP date.
+		if t, err := http.ParseTime(deprecation); err == nil {
+			d.Date = &t
+		}
+	}
+	if t, err := http.ParseTime(sunset); err == nil {
+		d.Sunset = &t
+	}
+	for _, link := range headers.Values("Link") {
+		if m := linkRelPattern.FindStringSubmatch(link); m != nil {
+			d.Link = m[1]
+			break
+		}
+	}
+
+	return d
+}
+
+// sameAPIVersion compares versions ignoring case and a "v" prefix, so "v1"
+// matches "1".
+func sameAPIVersion(a, b string) bool {
+	trim := func(v string) string { return strings.TrimPrefix(strings.ToLower(v), "v") }
+	return trim(a) == trim(b)
+}
+
+// String describes the notice for log output.
+func (d *Deprecation) String() string {
+	var parts []string
+	if d.Deprecated {
+		msg := "deprecated endpoint " + d.Method + " " + d.Path
+		if d.Date != nil {
+			msg += " (deprecated " + d.Date.Format(time.RFC3339) + ")"
+		}
+		parts = append(parts, msg)
+		if d.Sunset != nil {
+			parts = append(parts, "sunset "+d.Sunset.Format(time.RFC3339))
+		}
+	}
+	if d.RequestedVersion != "" {
+		msg := "served by API version " + d.APIVersion + " instead of " + d.RequestedVersion
+		if !d.Deprecated {
+			msg = d.Method + " " + d.Path + " " + msg
+		}
+		parts = append(parts, msg)
+	}
+	if d.Link != "" {
+		parts = append(parts, "see "+d.Link)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,149 @@
+package opusdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	sunset := time.Date(2026, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    *Deprecation
+	}{
+		{
+			name:    "no headers",
+			headers: http.Header{},
+		},
+		{
+			name:    "explicitly not deprecated",
+			headers: http.Header{"Deprecation": {"false"}},
+		},
+		{
+			name:    "boolean",
+			headers: http.Header{"Deprecation": {"true"}},
+			want:    &Deprecation{Method: "GET", Path: "/v1/dns", Deprecated: true},
+		},
+		{
+			name: "structured date with sunset and link",
+			headers: http.Header{
+				"Deprecation": {"@1798761599"},
+				"Sunset":      {sunset.Format(http.TimeFormat)},
+				"Link":        {`<https://api.opusdns.com/docs>; rel="alternate", <https://opusdns.com/deprecations/dns>; rel="deprecation"`},
+			},
+			want: &Deprecation{
+				Method:     "GET",
+				Path:       "/v1/dns",
+				Deprecated: true,
+				Date:       &sunset,
+				Sunset:     &sunset,
+				Link:       "https://opusdns.com/deprecations/dns",
+			},
+		},
+		{
+			name:    "http date",
+			headers: http.Header{"Deprecation": {sunset.Format(http.TimeFormat)}},
+			want:    &Deprecation{Method: "GET", Path: "/v1/dns", Deprecated: true, Date: &sunset},
+		},
+		{
+			name:    "sunset only",
+			headers: http.Header{"Sunset": {sunset.Format(http.TimeFormat)}},
+			want:    &Deprecation{Method: "GET", Path: "/v1/dns", Deprecated: true, Sunset: &sunset},
+		},
+		{
+			name:    "matching API version",
+			headers: http.Header{"Api-Version": {"1"}},
+		},
+		{
+			name:    "other API version",
+			headers: http.Header{"Api-Version": {"v2"}},
+			want:    &Deprecation{Method: "GET", Path: "/v1/dns", APIVersion: "v2", RequestedVersion: "v1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDeprecation("GET", "/v1/dns", "v1", tt.headers)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.Method, got.Method)
+			assert.Equal(t, tt.want.Path, got.Path)
+			assert.Equal(t, tt.want.Link, got.Link)
+			assert.Equal(t, tt.want.Deprecated, got.Deprecated)
+			assert.Equal(t, tt.want.APIVersion, got.APIVersion)
+			assert.Equal(t, tt.want.RequestedVersion, got.RequestedVersion)
+			if tt.want.Date == nil {
+				assert.Nil(t, got.Date)
+			} else {
+				require.NotNil(t, got.Date)
+				assert.True(t, tt.want.Date.Equal(*got.Date))
+			}
+			if tt.want.Sunset == nil {
+				assert.Nil(t, got.Sunset)
+			} else {
+				require.NotNil(t, got.Sunset)
+				assert.True(t, tt.want.Sunset.Equal(*got.Sunset))
+			}
+		})
+	}
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Thu, 31 Dec 2026 23:59:59 GMT")
+		_, _ = w.Write([]byte(`{"results":[],"pagination":{}}`))
+	}))
+	defer server.Close()
+
+	var notices []Deprecation
+	logger := &recordingLogger{}
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithDebug(true),
+		WithLogger(logger),
+		WithDeprecationHandler(func(d Deprecation) {
+			notices = append(notices, d)
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.DNS.ListZonesPage(context.Background(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, notices, 1)
+	assert.Equal(t, "GET", notices[0].Method)
+	assert.Equal(t, "/v1/dns", notices[0].Path)
+	require.NotNil(t, notices[0].Sunset)
+
+	found := false
+	for _, line := range logger.lines {
+		if strings.Contains(line, "Warning: deprecated endpoint GET /v1/dns, sunset 2026-12-31T23:59:59Z") {
+			found = true
+		}
+	}
+	assert.True(t, found, "deprecation not logged: %v", logger.lines)
+}
+
+func TestDeprecation_String(t *testing.T) {
+	sunset := time.Date(2026, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+	d := Deprecation{Method: "GET", Path: "/v1/dns", Deprecated: true, Sunset: &sunset, APIVersion: "v2", RequestedVersion: "v1", Link: "https://opusdns.com/deprecations"}
+	assert.Equal(t, "deprecated endpoint GET /v1/dns, sunset 2026-12-31T23:59:59Z, served by API version v2 instead of v1, see https://opusdns.com/deprecations", d.String())
+
+	d = Deprecation{Method: "GET", Path: "/v1/dns", APIVersion: "v2", RequestedVersion: "v1"}
+	assert.Equal(t, "GET /v1/dns served by API version v2 instead of v1", d.String())
+}
@@ -0,0 +1,55 @@
+package opusdns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// UpsertAlias points name in a zone at target, creating or updating a CNAME
+// record, or an ALIAS record at the zone apex where a CNAME is not allowed
+// next to the SOA and NS records. name is "@" (or "") for the apex or a name
+// relative to the zone; a fully qualified name ending in a dot also works.
+// target is made fully qualified if it lacks the trailing dot.
+func (s *DNSService) UpsertAlias(ctx context.Context, zoneName, name, target string, ttl int) error {
+	target = strings.TrimSpace(target)
+	if target == "" || target == "." {
+		return &ValidationError{Field: "target", Message: "target is required"}
+	}
+	if !strings.HasSuffix(target, ".") {
+		target += "."
+	}
+
+	rrtype := models.RRSetTypeCNAME
+	if isApexName(name, zoneName) {
+		name, rrtype = "@", models.RRSetTypeALIAS
+	}
+
+	return s.UpsertRecord(ctx, zoneName, models.Record{Name: name, Type: rrtype, TTL: ttl, RData: target})
+}
+
+// validateApexCNAME rejects a CNAME at the zone apex, which resolvers would
+// let shadow the SOA and NS records, and suggests an ALIAS record instead.
+func validateApexCNAME(field, name string, rrtype models.RRSetType, zoneName string) error {
+	if rrtype != models.RRSetTypeCNAME || !isApexName(name, zoneName) {
+		return nil
+	}
+	return &ValidationError{
+		Field:   field,
+		Message: "CNAME is not allowed at the zone apex; use an ALIAS record instead (see DNSService.UpsertAlias)",
+		Value:   name,
+	}
+}
+
+// isApexName reports whether the record name refers to the apex of zoneName:
+// "@", "" or the zone name itself, fully qualified.
+func isApexName(name, zoneName string) bool {
+	if name == "" || name == "@" {
+		return true
+	}
+	if !strings.HasSuffix(name, ".") {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSuffix(name, "."), strings.TrimSuffix(zoneName, "."))
+}
@@ -0,0 +1,77 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSService_UpsertAlias(t *testing.T) {
+	var got []models.Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/dns/example.com/records", r.URL.Path)
+
+		var req models.RecordPatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(t, req.Ops, 1)
+		got = append(got, req.Ops[0].Record)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.DNS.UpsertAlias(ctx, "example.com", "www", "lb.example.net", 300))
+	require.NoError(t, client.DNS.UpsertAlias(ctx, "example.com", "@", "lb.example.net.", 300))
+	require.NoError(t, client.DNS.UpsertAlias(ctx, "example.com", "Example.COM.", "lb.example.net.", 300))
+
+	assert.Equal(t, []models.Record{
+		{Name: "www", Type: models.RRSetTypeCNAME, TTL: 300, RData: "lb.example.net."},
+		{Name: "@", Type: models.RRSetTypeALIAS, TTL: 300, RData: "lb.example.net."},
+		{Name: "@", Type: models.RRSetTypeALIAS, TTL: 300, RData: "lb.example.net."},
+	}, got)
+
+	err = client.DNS.UpsertAlias(ctx, "example.com", "www", "", 300)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestDNSService_ApexCNAMERejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = client.DNS.UpsertRecord(ctx, "example.com", models.Record{Name: "@", Type: models.RRSetTypeCNAME, TTL: 300, RData: "lb.example.net."})
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, "ops[0].record.name", valErr.Field)
+	assert.Contains(t, valErr.Message, "ALIAS")
+
+	err = client.DNS.PatchRRSets(ctx, "example.com", []models.RRSetPatchOp{
+		{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "example.com.", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordCreate{{RData: "lb.example.net."}}}},
+	})
+	assert.True(t, IsValidationError(err))
+
+	err = client.DNS.PutRRSets(ctx, "example.com", []models.RRSetCreate{
+		{Name: "", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordCreate{{RData: "lb.example.net."}}},
+	})
+	assert.True(t, IsValidationError(err))
+
+	_, err = client.DNS.CreateZone(ctx, &models.ZoneCreateRequest{Name: "example.com", RRSets: []models.RRSetCreate{
+		{Name: "@", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordCreate{{RData: "lb.example.net."}}},
+	}})
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,355 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat is a DNS-as-code format written by ExportConfig.
+type ConfigFormat string
+
+const (
+	// ConfigFormatOctoDNS writes one octoDNS YamlProvider file per zone,
+	// named "<zone>.yaml".
+	ConfigFormatOctoDNS ConfigFormat = "octodns"
+
+	// ConfigFormatDNSControl writes a single "dnsconfig.js" with one D()
+	// block per zone, using the registrar REG_NONE and the DNS provider
+	// DSP_OPUSDNS (the "opusdns" entry of creds.json).
+	ConfigFormatDNSControl ConfigFormat = "dnscontrol"
+)
+
+// ConfigFile is a file written by ExportConfig.
+type ConfigFile struct {
+	// Name is the file name.
+	Name string
+
+	// Data is the file content.
+	Data []byte
+}
+
+// ExportConfig converts all zones into configuration files for octoDNS or
+// DNSControl, so zones managed as code in those tools can be moved to OpusDNS
+// without translating records by hand.
+//
+// SOA and DNSKEY records are managed by the platform and left out. Records
+// of types the tool does not support are listed in a comment at the top of
+// the zone so they are not lost silently. DNSControl manages apex NS records
+// through the DNS provider, so they are left out of its configuration.
+func (s *DNSService) ExportConfig(ctx context.Context, format ConfigFormat) ([]ConfigFile, error) {
+	if format != ConfigFormatOctoDNS && format != ConfigFormatDNSControl {
+		return nil, &ValidationError{Field: "format", Message: "format must be octodns or dnscontrol", Value: format}
+	}
+
+	listed, err := s.ListZones(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opusdns: failed to list zones: %w", err)
+	}
+	zones := make([]*models.Zone, 0, len(listed))
+	for _, z := range listed {
+		zone, err := s.GetZone(ctx, z.Name)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to get zone %s: %w", z.Name, err)
+		}
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+
+	if format == ConfigFormatDNSControl {
+		return []ConfigFile{{Name: "dnsconfig.js", Data: dnsControlConfig(zones)}}, nil
+	}
+
+	files := make([]ConfigFile, 0, len(zones))
+	for _, zone := range zones {
+		data, err := octoDNSConfig(zone)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to encode zone %s: %w", zone.Name, err)
+		}
+		files = append(files, ConfigFile{Name: strings.TrimSuffix(zone.Name, ".") + ".yaml", Data: data})
+	}
+	return files, nil
+}
+
+// configRRSets returns the exported RRsets of zone with relative names ("@"
+// for the apex), sorted by name and type, with their values sorted.
+func configRRSets(zone *models.Zone) []models.RRSet {
+	zoneName := strings.TrimSuffix(zone.Name, ".")
+
+	var rrsets []models.RRSet
+	for _, rrset := range zone.RRSets {
+		if rrset.Type == models.RRSetTypeSOA || rrset.Type == models.RRSetTypeDNSKEY {
+			continue
+		}
+		name := strings.TrimSuffix(rrset.Name, ".")
+		switch {
+		case name == "" || strings.EqualFold(name, zoneName):
+			name = "@"
+		case strings.HasSuffix(rrset.Name, "."):
+			name = strings.TrimSuffix(name, "."+zoneName)
+		}
+		rrset.Name = strings.ToLower(name)
+		rrset.Records = append([]models.RecordData(nil), rrset.Records...)
+		sort.Slice(rrset.Records, func(i, j int) bool { return rrset.Records[i].RData < rrset.Records[j].RData })
+		rrsets = append(rrsets, rrset)
+	}
+	sort.Slice(rrsets, func(i, j int) bool {
+		if rrsets[i].Name != rrsets[j].Name {
+			return rrsets[i].Name < rrsets[j].Name
+		}
+		return rrsets[i].Type < rrsets[j].Type
+	})
+	return rrsets
+}
+
+// rdataFieldCounts is the number of fields of the record data of types
+// exported field by field; the last field takes the rest of the data.
+var rdataFieldCounts = map[models.RRSetType]int{
+	models.RRSetTypeCAA:   3,
+	models.RRSetTypeDS:    4,
+	models.RRSetTypeHTTPS: 3,
+	models.RRSetTypeMX:    2,
+	models.RRSetTypeNAPTR: 6,
+	models.RRSetTypeSRV:   4,
+	models.RRSetTypeSSHFP: 3,
+	models.RRSetTypeSVCB:  3,
+	models.RRSetTypeTLSA:  4,
+	models.RRSetTypeURI:   3,
+}
+
+// rdataFields splits record data into the fields of rrtype, unquoting quoted
+// fields. TXT data is joined into its logical value.
+func rdataFields(rrtype models.RRSetType, rdata string) []string {
+	if rrtype == models.RRSetTypeTXT {
+		return []string{models.ParseTXTData(rdata).String()}
+	}
+	n := rdataFieldCounts[rrtype]
+	if n == 0 {
+		return []string{strings.TrimSpace(rdata)}
+	}
+
+	fields := make([]string, 0, n)
+	rest := strings.TrimSpace(rdata)
+	for len(fields) < n-1 && rest != "" {
+		var field string
+		if strings.HasPrefix(rest, `"`) {
+			end := 1
+			for end < len(rest) && (rest[end] != '"' || rest[end-1] == '\\') {
+				end++
+			}
+			field, rest = unquoteField(rest[:min(end+1, len(rest))]), rest[min(end+1, len(rest)):]
+		} else {
+			field, rest, _ = strings.Cut(rest, " ")
+		}
+		fields = append(fields, field)
+		rest = strings.TrimSpace(rest)
+	}
+	fields = append(fields, unquoteField(rest))
+	for len(fields) < n {
+		fields = append(fields, "")
+	}
+	return fields
+}
+
+// unquoteField removes the quotes of a quoted record data field.
+func unquoteField(field string) string {
+	if len(field) < 2 || !strings.HasPrefix(field, `"`) || !strings.HasSuffix(field, `"`) {
+		return field
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(field[1 : len(field)-1])
+}
+
+// number returns field as an int if it is numeric.
+func number(field string) any {
+	if n, err := strconv.Atoi(field); err == nil {
+		return n
+	}
+	return field
+}
+
+// octoDNSValue converts record data to an octoDNS value. It returns false for
+// types octoDNS does not support.
+func octoDNSValue(rrtype models.RRSetType, rdata string) (any, bool) {
+	f := rdataFields(rrtype, rdata)
+	switch rrtype {
+	case models.RRSetTypeA, models.RRSetTypeAAAA, models.RRSetTypeALIAS, models.RRSetTypeCNAME,
+		models.RRSetTypeNS, models.RRSetTypePTR:
+		return f[0], true
+	case models.RRSetTypeTXT:
+		return strings.ReplaceAll(f[0], ";", `\;`), true
+	case models.RRSetTypeMX:
+		return map[string]any{"preference": number(f[0]), "exchange": f[1]}, true
+	case models.RRSetTypeSRV:
+		return map[string]any{"priority": number(f[0]), "weight": number(f[1]), "port": number(f[2]), "target": f[3]}, true
+	case models.RRSetTypeCAA:
+		return map[string]any{"flags": number(f[0]), "tag": f[1], "value": f[2]}, true
+	case models.RRSetTypeSSHFP:
+		return map[string]any{"algorithm": number(f[0]), "fingerprint_type": number(f[1]), "fingerprint": f[2]}, true
+	case models.RRSetTypeTLSA:
+		return map[string]any{
+			"certificate_usage": number(f[0]), "selector": number(f[1]), "matching_type": number(f[2]),
+			"certificate_association_data": strings.ReplaceAll(f[3], " ", ""),
+		}, true
+	case models.RRSetTypeDS:
+		return map[string]any{"key_tag": number(f[0]), "algorithm": number(f[1]), "digest_type": number(f[2]), "digest": strings.ReplaceAll(f[3], " ", "")}, true
+	case models.RRSetTypeNAPTR:
+		return map[string]any{
+			"order": number(f[0]), "preference": number(f[1]), "flags": f[2],
+			"service": f[3], "regexp": f[4], "replacement": f[5],
+		}, true
+	case models.RRSetTypeSVCB, models.RRSetTypeHTTPS:
+		params := map[string]any{}
+		for _, p := range strings.Fields(f[2]) {
+			key, value, _ := strings.Cut(p, "=")
+			params[key] = unquoteField(value)
+		}
+		return map[string]any{"svcpriority": number(f[0]), "targetname": f[1], "svcparams": params}, true
+	}
+	return nil, false
+}
+
+// octoDNSConfig converts a zone to an octoDNS YamlProvider file.
+func octoDNSConfig(zone *models.Zone) ([]byte, error) {
+	records := map[string][]map[string]any{}
+	var unsupported []string
+	for _, rrset := range configRRSets(zone) {
+		record := map[string]any{"type": string(rrset.Type), "ttl": rrset.TTL}
+		var values []any
+		for _, r := range rrset.Records {
+			value, ok := octoDNSValue(rrset.Type, r.RData)
+			if !ok {
+				unsupported = append(unsupported, fmt.Sprintf("%s %d %s %s", rrset.Name, rrset.TTL, rrset.Type, r.RData))
+				continue
+			}
+			values = append(values, value)
+		}
+		switch {
+		case len(values) == 0:
+			continue
+		case len(values) == 1 && (rrset.Type == models.RRSetTypeCNAME || rrset.Type == models.RRSetTypeALIAS || rrset.Type == models.RRSetTypePTR):
+			record["value"] = values[0]
+		default:
+			record["values"] = values
+		}
+
+		name := rrset.Name
+		if name == "@" {
+			name = ""
+		}
+		records[name] = append(records[name], record)
+	}
+
+	// A name with a single record is written as a mapping, like octoDNS does
+	doc := make(map[string]any, len(records))
+	for name, list := range records {
+		if len(list) == 1 {
+			doc[name] = list[0]
+		} else {
+			doc[name] = list
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, line := range unsupported {
+		fmt.Fprintf(&buf, "# Not supported by octoDNS: %s\n", line)
+	}
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// dnsControlArgs converts record data to the arguments of a DNSControl
+// record function. It returns false for types DNSControl does not support.
+func dnsControlArgs(rrtype models.RRSetType, rdata string) ([]any, bool) {
+	f := rdataFields(rrtype, rdata)
+	switch rrtype {
+	case models.RRSetTypeA, models.RRSetTypeAAAA, models.RRSetTypeALIAS, models.RRSetTypeCNAME,
+		models.RRSetTypeNS, models.RRSetTypePTR, models.RRSetTypeTXT:
+		return []any{f[0]}, true
+	case models.RRSetTypeMX:
+		return []any{number(f[0]), f[1]}, true
+	case models.RRSetTypeCAA:
+		args := []any{f[1], f[2]}
+		if f[0] == "128" {
+			args = append(args, dnsControlIdent("CAA_CRITICAL"))
+		}
+		return args, true
+	case models.RRSetTypeSRV, models.RRSetTypeSSHFP, models.RRSetTypeTLSA, models.RRSetTypeDS, models.RRSetTypeNAPTR,
+		models.RRSetTypeSVCB, models.RRSetTypeHTTPS:
+		args := make([]any, len(f))
+		for i, field := range f {
+			args[i] = number(field)
+		}
+		return args, true
+	}
+	return nil, false
+}
+
+// dnsControlIdent is a JavaScript identifier in DNSControl arguments.
+type dnsControlIdent string
+
+// dnsControlConfig converts zones to a DNSControl dnsconfig.js.
+func dnsControlConfig(zones []*models.Zone) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("var REG_NONE = NewRegistrar(\"none\");\n")
+	buf.WriteString("var DSP_OPUSDNS = NewDnsProvider(\"opusdns\");\n")
+
+	for _, zone := range zones {
+		var lines, unsupported []string
+		for _, rrset := range configRRSets(zone) {
+			if rrset.Type == models.RRSetTypeNS && rrset.Name == "@" {
+				continue
+			}
+			for _, r := range rrset.Records {
+				args, ok := dnsControlArgs(rrset.Type, r.RData)
+				if !ok {
+					unsupported = append(unsupported, fmt.Sprintf("%s %d %s %s", rrset.Name, rrset.TTL, rrset.Type, r.RData))
+					continue
+				}
+				call := []string{jsValue(rrset.Name)}
+				for _, arg := range args {
+					call = append(call, jsValue(arg))
+				}
+				call = append(call, fmt.Sprintf("TTL(%d)", rrset.TTL))
+				lines = append(lines, fmt.Sprintf("%s(%s)", rrset.Type, strings.Join(call, ", ")))
+			}
+		}
+
+		buf.WriteString("\n")
+		for _, line := range unsupported {
+			fmt.Fprintf(&buf, "// Not supported by DNSControl: %s\n", line)
+		}
+		fmt.Fprintf(&buf, "D(%s, REG_NONE, DnsProvider(DSP_OPUSDNS)", jsValue(strings.TrimSuffix(zone.Name, ".")))
+		for _, line := range lines {
+			fmt.Fprintf(&buf, ",\n\t%s", line)
+		}
+		buf.WriteString("\n);\n")
+	}
+	return buf.Bytes()
+}
+
+// jsValue formats a DNSControl argument as JavaScript.
+func jsValue(v any) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case dnsControlIdent:
+		return string(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,104 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfigExportTestClient(t *testing.T) *Client {
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/dns":
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: []models.Zone{{Name: "example.com."}}})
+		case "/v1/dns/example.com":
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com.", RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeSOA, TTL: 3600, Records: []models.RecordData{{RData: "ns1.opusdns.net. hostmaster.example.com. 1 7200 900 1209600 3600"}}},
+				{Name: "@", Type: models.RRSetTypeNS, TTL: 86400, Records: []models.RecordData{{RData: "ns1.opusdns.net."}}},
+				{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, Records: []models.RecordData{{RData: "10 mail.example.com."}}},
+				{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: `"v=spf1 mx -all"`}}},
+				{Name: "@", Type: models.RRSetTypeCAA, TTL: 3600, Records: []models.RecordData{{RData: `128 issue "letsencrypt.org"`}}},
+				{Name: "www", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordData{{RData: "example.com."}}},
+				{Name: "_sip._tcp", Type: models.RRSetTypeSRV, TTL: 300, Records: []models.RecordData{{RData: "10 60 5060 sip.example.com."}}},
+				{Name: "_ftp._tcp", Type: models.RRSetTypeURI, TTL: 300, Records: []models.RecordData{{RData: `10 1 "ftp://ftp.example.com/"`}}},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestDNSService_ExportConfig(t *testing.T) {
+	t.Run("octodns", func(t *testing.T) {
+		files, err := newConfigExportTestClient(t).DNS.ExportConfig(context.Background(), ConfigFormatOctoDNS)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.Equal(t, "example.com.yaml", files[0].Name)
+		assert.Equal(t, `# Not supported by octoDNS: _ftp._tcp 300 URI 10 1 "ftp://ftp.example.com/"
+---
+"":
+  - ttl: 3600
+    type: CAA
+    values:
+      - flags: 128
+        tag: issue
+        value: letsencrypt.org
+  - ttl: 3600
+    type: MX
+    values:
+      - exchange: mail.example.com.
+        preference: 10
+  - ttl: 86400
+    type: NS
+    values:
+      - ns1.opusdns.net.
+  - ttl: 3600
+    type: TXT
+    values:
+      - v=spf1 mx -all
+_sip._tcp:
+  ttl: 300
+  type: SRV
+  values:
+    - port: 5060
+      priority: 10
+      target: sip.example.com.
+      weight: 60
+www:
+  ttl: 300
+  type: CNAME
+  value: example.com.
+`, string(files[0].Data))
+	})
+
+	t.Run("dnscontrol", func(t *testing.T) {
+		files, err := newConfigExportTestClient(t).DNS.ExportConfig(context.Background(), ConfigFormatDNSControl)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.Equal(t, "dnsconfig.js", files[0].Name)
+		assert.Equal(t, `var REG_NONE = NewRegistrar("none");
+var DSP_OPUSDNS = NewDnsProvider("opusdns");
+
+// Not supported by DNSControl: _ftp._tcp 300 URI 10 1 "ftp://ftp.example.com/"
+D("example.com", REG_NONE, DnsProvider(DSP_OPUSDNS),
+	CAA("@", "issue", "letsencrypt.org", CAA_CRITICAL, TTL(3600)),
+	MX("@", 10, "mail.example.com.", TTL(3600)),
+	TXT("@", "v=spf1 mx -all", TTL(3600)),
+	SRV("_sip._tcp", 10, 60, 5060, "sip.example.com.", TTL(300)),
+	CNAME("www", "example.com.", TTL(300))
+);
+`, string(files[0].Data))
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		_, err := newConfigExportTestClient(t).DNS.ExportConfig(context.Background(), "bind")
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,111 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// DefaultDNSSECPollInterval is the time between zone checks of
+// DNSService.WaitForDNSSEC.
+const DefaultDNSSECPollInterval = 10 * time.Second
+
+// WaitForDNSSEC polls a zone until its DNSSEC status is target and returns
+// the zone. When waiting for models.DNSSECStatusEnabled it also waits until
+// the zone publishes a key signing key in its apex DNSKEY set, so the DS
+// records can be derived from the returned zone with ZoneKeySigningKeys.
+// Bound the wait with the context.
+func (s *DNSService) WaitForDNSSEC(ctx context.Context, zoneName string, target models.DNSSECStatus) (*models.Zone, error) {
+	if target == "" {
+		return nil, &ValidationError{Field: "target", Message: "target DNSSEC status is required"}
+	}
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	clock := s.client.http.clock
+
+	for {
+		zone, err := s.GetZone(ctx, zoneName)
+		if err != nil {
+			return nil, err
+		}
+		if zone.DNSSECStatus == target {
+			if target != models.DNSSECStatusEnabled {
+				return zone, nil
+			}
+			keys, err := ZoneKeySigningKeys(zone)
+			if err != nil {
+				return nil, err
+			}
+			if len(keys) > 0 {
+				return zone, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-clock.After(DefaultDNSSECPollInterval):
+		}
+	}
+}
+
+// ZoneKeySigningKeys returns the key signing keys in the apex DNSKEY set of
+// zone, the keys whose DS records belong at the registry.
+func ZoneKeySigningKeys(zone *models.Zone) ([]models.DNSKEYRecord, error) {
+	keys, err := zoneDNSKEYs(zone, models.RRSetTypeDNSKEY)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(keys, func(k models.DNSKEYRecord) bool { return !k.IsKSK() }), nil
+}
+
+// ZoneCDSRecords returns the apex CDS records of zone, the DS records the
+// zone asks its parent to publish (RFC 7344). A single record for which
+// IsDeleteSignal is true asks for DNSSEC to be turned off at the parent.
+func ZoneCDSRecords(zone *models.Zone) ([]models.DSRecord, error) {
+	var records []models.DSRecord
+	for _, rdata := range zoneApexRData(zone, models.RRSetTypeCDS) {
+		ds, err := models.ParseDSRecord(rdata)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: zone %s: %w", zone.Name, err)
+		}
+		records = append(records, ds)
+	}
+	return records, nil
+}
+
+// ZoneCDNSKEYRecords returns the apex CDNSKEY records of zone, the keys the
+// zone asks its parent to publish DS records for (RFC 7344).
+func ZoneCDNSKEYRecords(zone *models.Zone) ([]models.DNSKEYRecord, error) {
+	return zoneDNSKEYs(zone, models.RRSetTypeCDNSKEY)
+}
+
+// zoneDNSKEYs parses the apex DNSKEY or CDNSKEY records of zone.
+func zoneDNSKEYs(zone *models.Zone, rrtype models.RRSetType) ([]models.DNSKEYRecord, error) {
+	var keys []models.DNSKEYRecord
+	for _, rdata := range zoneApexRData(zone, rrtype) {
+		key, err := models.ParseDNSKEYRecord(rdata)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: zone %s: %w", zone.Name, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// zoneApexRData returns the rdata of the apex records of type rrtype.
+func zoneApexRData(zone *models.Zone, rrtype models.RRSetType) []string {
+	var rdata []string
+	for _, rrset := range zone.RRSets {
+		if rrset.Type != rrtype || (rrset.Name != "@" && rrset.Name != "") {
+			continue
+		}
+		for _, r := range rrset.Records {
+			rdata = append(rdata, r.RData)
+		}
+	}
+	return rdata
+}
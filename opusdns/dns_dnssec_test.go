@@ -0,0 +1,91 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKSK is the DNSKEY of RFC 4509, section 2.3, with the SEP flag set.
+const testKSK = "257 3 5 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestDNSService_WaitForDNSSEC(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		zone := models.Zone{Name: "example.com", DNSSECStatus: models.DNSSECStatusDisabled}
+		if gets >= 2 {
+			zone.DNSSECStatus = models.DNSSECStatusEnabled
+		}
+		if gets >= 3 {
+			zone.RRSets = []models.RRSet{{Name: "@", Type: models.RRSetTypeDNSKEY, Records: []models.RecordData{
+				{RData: "256 3 13 oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA=="},
+				{RData: testKSK},
+			}}}
+		}
+		_ = json.NewEncoder(w).Encode(zone)
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithClock(clock))
+	require.NoError(t, err)
+
+	zone, err := client.DNS.WaitForDNSSEC(context.Background(), "example.com.", models.DNSSECStatusEnabled)
+	require.NoError(t, err)
+	assert.Equal(t, 3, gets)
+	assert.Equal(t, []time.Duration{DefaultDNSSECPollInterval, DefaultDNSSECPollInterval}, clock.waits)
+
+	keys, err := ZoneKeySigningKeys(zone)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	tag, err := keys[0].KeyTag()
+	require.NoError(t, err)
+	assert.Equal(t, 60486, tag) // the SEP flag adds one to the RFC key tag 60485
+
+	t.Run("context ends the wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.DNS.WaitForDNSSEC(ctx, "example.com", models.DNSSECStatusDisabled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestZoneCDSRecords(t *testing.T) {
+	zone := &models.Zone{Name: "example.com", RRSets: []models.RRSet{
+		{Name: "@", Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: "60486 5 2 d4b7 d520"}}},
+		{Name: "@", Type: models.RRSetTypeCDNSKEY, Records: []models.RecordData{{RData: testKSK}}},
+		{Name: "sub", Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: "1 13 2 AB"}}},
+	}}
+
+	cds, err := ZoneCDSRecords(zone)
+	require.NoError(t, err)
+	assert.Equal(t, []models.DSRecord{{KeyTag: 60486, Algorithm: 5, DigestType: 2, Digest: "D4B7D520"}}, cds)
+
+	keys, err := ZoneCDNSKEYRecords(zone)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].IsKSK())
+	assert.False(t, keys[0].IsDeleteSignal())
+
+	deleted := &models.Zone{Name: "example.com", RRSets: []models.RRSet{
+		{Name: "@", Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: "0 0 0 00"}}},
+		{Name: "@", Type: models.RRSetTypeCDNSKEY, Records: []models.RecordData{{RData: "0 3 0 AA=="}}},
+	}}
+	cds, err = ZoneCDSRecords(deleted)
+	require.NoError(t, err)
+	assert.True(t, cds[0].IsDeleteSignal())
+	keys, err = ZoneCDNSKEYRecords(deleted)
+	require.NoError(t, err)
+	assert.True(t, keys[0].IsDeleteSignal())
+
+	_, err = ZoneCDSRecords(&models.Zone{RRSets: []models.RRSet{{Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: "bad"}}}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,283 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// ZoneLockRecordName is the TXT record that holds the advisory lock of a zone.
+const ZoneLockRecordName = "_opusdns-lock"
+
+// ZoneLock is a cooperative lease on a zone, held in the zone's
+// ZoneLockRecordName TXT record. The API does not enforce it: automation that
+// modifies a zone should acquire the lock first and check it with
+// VerifyZoneLock before each change.
+//
+// Token is a fencing token that grows with every acquisition, so a holder
+// whose lease expired (e.g. after a long pause) can tell it was superseded.
+type ZoneLock struct {
+	// Zone is the locked zone.
+	Zone string
+
+	// Owner identifies the holder, e.g. a pipeline or host name.
+	Owner string
+
+	// Token is the fencing token of this acquisition.
+	Token int64
+
+	// Expires is when the lease ends unless it is renewed.
+	Expires time.Time
+}
+
+// String returns the TXT value that represents the lock.
+func (l *ZoneLock) String() string {
+	return fmt.Sprintf("owner=%s token=%d expires=%s", l.Owner, l.Token, l.Expires.UTC().Format(time.RFC3339))
+}
+
+// record returns the lock's TXT record.
+func (l *ZoneLock) record() models.Record {
+	return models.Record{
+		Name:  ZoneLockRecordName,
+		Type:  models.RRSetTypeTXT,
+		TTL:   DefaultTTL,
+		RData: models.TXTData(l.String()).RData(),
+	}
+}
+
+// parseZoneLock parses a lock TXT value. It returns nil for values that are
+// not locks.
+func parseZoneLock(zoneName, rdata string) *ZoneLock {
+	lock := &ZoneLock{Zone: zoneName}
+	for _, field := range strings.Fields(string(models.ParseTXTData(rdata))) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "owner":
+			lock.Owner = value
+		case "token":
+			lock.Token, _ = strconv.ParseInt(value, 10, 64)
+		case "expires":
+			lock.Expires, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	if lock.Owner == "" || lock.Token == 0 {
+		return nil
+	}
+	return lock
+}
+
+// zoneLockRecord is a record of a zone's lock RRset with its parsed lock,
+// which is nil if the value is not a lock.
+type zoneLockRecord struct {
+	lock   *ZoneLock
+	record models.Record
+}
+
+// getZoneLocks returns the records of a zone's lock RRset. Normally there is
+// at most one; several are left behind when two owners raced for the lock.
+func (s *DNSService) getZoneLocks(ctx context.Context, zoneName string) ([]zoneLockRecord, error) {
+	rrset, err := s.GetRRSet(ctx, zoneName, ZoneLockRecordName, models.RRSetTypeTXT)
+	if IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opusdns: failed to read zone lock: %w", err)
+	}
+
+	var records []zoneLockRecord
+	for _, record := range RRSetsToRecords([]models.RRSet{*rrset}) {
+		records = append(records, zoneLockRecord{lock: parseZoneLock(zoneName, record.RData), record: record})
+	}
+	return records, nil
+}
+
+// replaceZoneLock atomically replaces the lock records of a zone with lock.
+func (s *DNSService) replaceZoneLock(ctx context.Context, zoneName string, old []models.Record, lock *ZoneLock) error {
+	ops := make([]models.RecordOperation, 0, len(old)+1)
+	for _, record := range old {
+		ops = append(ops, models.RecordOperation{Op: models.RecordOpRemove, Record: record})
+	}
+	ops = append(ops, models.RecordOperation{Op: models.RecordOpUpsert, Record: lock.record()})
+
+	_, err := s.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{Ops: ops, Atomic: true})
+	return err
+}
+
+// AcquireZoneLock takes the advisory lock of a zone for owner for the given
+// lease duration. It returns an error matching ErrZoneLocked if another owner
+// holds an unexpired lease; the caller may retry later. Acquiring a lock the
+// owner already holds starts a new lease with a new fencing token.
+func (s *DNSService) AcquireZoneLock(ctx context.Context, zoneName, owner string, ttl time.Duration) (*ZoneLock, error) {
+	if owner == "" || strings.ContainsAny(owner, " \t\"") {
+		return nil, &ValidationError{Field: "owner", Message: "must be non-empty and contain no whitespace or quotes", Value: owner}
+	}
+	if ttl <= 0 {
+		return nil, &ValidationError{Field: "ttl", Message: "must be positive", Value: ttl}
+	}
+	zoneName = strings.TrimSuffix(zoneName, ".")
+
+	current, err := s.getZoneLocks(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.client.http.clock.Now()
+	var token int64
+	old := make([]models.Record, 0, len(current))
+	for _, c := range current {
+		old = append(old, c.record)
+		if c.lock == nil {
+			continue
+		}
+		if c.lock.Owner != owner && c.lock.Expires.After(now) {
+			return nil, fmt.Errorf("%w: held by %s until %s", ErrZoneLocked, c.lock.Owner, c.lock.Expires.UTC().Format(time.RFC3339))
+		}
+		token = max(token, c.lock.Token)
+	}
+
+	lock := &ZoneLock{Zone: zoneName, Owner: owner, Token: token + 1, Expires: now.Add(ttl)}
+	if err := s.replaceZoneLock(ctx, zoneName, old, lock); err != nil {
+		return nil, fmt.Errorf("opusdns: failed to write zone lock: %w", err)
+	}
+
+	// Another owner may have written its lock at the same time. If another
+	// unexpired lock is present, step back by removing ours, so it does not
+	// linger next to the winner's.
+	current, err = s.getZoneLocks(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := verifyZoneLock(current, lock, s.client.http.clock.Now()); err != nil {
+		if ours, ok := findZoneLockRecord(current, lock); ok {
+			_, _ = s.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{
+				Ops: []models.RecordOperation{{Op: models.RecordOpRemove, Record: ours}},
+			})
+		}
+		return nil, fmt.Errorf("%w: lost a race for the lock", ErrZoneLocked)
+	}
+
+	return lock, nil
+}
+
+// VerifyZoneLock checks that lock is still present with the same fencing
+// token and unexpired, and that no other owner holds an unexpired lock. It
+// returns an error matching ErrZoneLockLost otherwise.
+func (s *DNSService) VerifyZoneLock(ctx context.Context, lock *ZoneLock) error {
+	current, err := s.getZoneLocks(ctx, lock.Zone)
+	if err != nil {
+		return err
+	}
+	_, err = verifyZoneLock(current, lock, s.client.http.clock.Now())
+	return err
+}
+
+// verifyZoneLock checks lock against the zone's lock records at now and
+// returns the record that holds it. Values that are not locks and expired
+// locks, such as those left by released leases, do not conflict.
+func verifyZoneLock(current []zoneLockRecord, lock *ZoneLock, now time.Time) (models.Record, error) {
+	ours, ok := findZoneLockRecord(current, lock)
+	if !ok {
+		return models.Record{}, ErrZoneLockLost
+	}
+	if !lock.Expires.After(now) {
+		return models.Record{}, fmt.Errorf("%w: lease expired", ErrZoneLockLost)
+	}
+	for _, c := range current {
+		if c.lock != nil && c.lock.Owner != lock.Owner && c.lock.Expires.After(now) {
+			return models.Record{}, fmt.Errorf("%w: also held by %s", ErrZoneLockLost, c.lock.Owner)
+		}
+	}
+	return ours, nil
+}
+
+// findZoneLockRecord returns the record holding lock's owner and token.
+func findZoneLockRecord(current []zoneLockRecord, lock *ZoneLock) (models.Record, bool) {
+	for _, c := range current {
+		if c.lock != nil && c.lock.Owner == lock.Owner && c.lock.Token == lock.Token {
+			return c.record, true
+		}
+	}
+	return models.Record{}, false
+}
+
+// RenewZoneLock extends the lease of a held lock to ttl from now, keeping its
+// fencing token. It returns an error matching ErrZoneLockLost if the lock is
+// no longer held.
+func (s *DNSService) RenewZoneLock(ctx context.Context, lock *ZoneLock, ttl time.Duration) error {
+	if ttl <= 0 {
+		return &ValidationError{Field: "ttl", Message: "must be positive", Value: ttl}
+	}
+
+	current, err := s.getZoneLocks(ctx, lock.Zone)
+	if err != nil {
+		return err
+	}
+	ours, err := verifyZoneLock(current, lock, s.client.http.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	renewed := *lock
+	renewed.Expires = s.client.http.clock.Now().Add(ttl)
+	if err := s.replaceZoneLock(ctx, lock.Zone, []models.Record{ours}, &renewed); err != nil {
+		return fmt.Errorf("opusdns: failed to renew zone lock: %w", err)
+	}
+
+	lock.Expires = renewed.Expires
+	return nil
+}
+
+// KeepZoneLock renews lock every third of ttl until ctx is done, which makes
+// it suitable to run in its own goroutine for the duration of a deployment.
+// It returns ctx.Err() once ctx is done, or the first renewal error, after
+// which the caller must stop modifying the zone.
+func (s *DNSService) KeepZoneLock(ctx context.Context, lock *ZoneLock, ttl time.Duration) error {
+	if ttl <= 0 {
+		return &ValidationError{Field: "ttl", Message: "must be positive", Value: ttl}
+	}
+
+	clock := s.client.http.clock
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(ttl / 3):
+			if err := s.RenewZoneLock(ctx, lock, ttl); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ReleaseZoneLock gives up a held lock. The lock record is kept with an
+// expired lease so the next owner continues the fencing token sequence. It
+// returns an error matching ErrZoneLockLost if the lock was no longer held.
+func (s *DNSService) ReleaseZoneLock(ctx context.Context, lock *ZoneLock) error {
+	current, err := s.getZoneLocks(ctx, lock.Zone)
+	if err != nil {
+		return err
+	}
+	ours, err := verifyZoneLock(current, lock, s.client.http.clock.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.expireZoneLock(ctx, ours, lock); err != nil {
+		return fmt.Errorf("opusdns: failed to release zone lock: %w", err)
+	}
+	return nil
+}
+
+// expireZoneLock replaces the lock's record with an expired copy.
+func (s *DNSService) expireZoneLock(ctx context.Context, record models.Record, lock *ZoneLock) error {
+	expired := *lock
+	expired.Expires = s.client.http.clock.Now().Add(-time.Second)
+	if err := s.replaceZoneLock(ctx, lock.Zone, []models.Record{record}, &expired); err != nil {
+		return err
+	}
+	lock.Expires = expired.Expires
+	return nil
+}
@@ -0,0 +1,185 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockServer serves the lock RRset of example.com from memory. onPatch, if
+// set, runs after each patch with the lock held.
+type lockServer struct {
+	mu      sync.Mutex
+	values  []string
+	onPatch func(values []string) []string
+}
+
+func (s *lockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		zone := models.Zone{Name: "example.com"}
+		if len(s.values) > 0 {
+			rrset := models.RRSet{Name: ZoneLockRecordName, Type: models.RRSetTypeTXT, TTL: DefaultTTL}
+			for _, v := range s.values {
+				rrset.Records = append(rrset.Records, models.RecordData{RData: v})
+			}
+			zone.RRSets = []models.RRSet{rrset}
+		}
+		_ = json.NewEncoder(w).Encode(zone)
+	case http.MethodPatch:
+		var req models.RecordPatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, op := range req.Ops {
+			kept := s.values[:0]
+			for _, v := range s.values {
+				if v != op.Record.RData {
+					kept = append(kept, v)
+				}
+			}
+			s.values = kept
+			if op.Op == models.RecordOpUpsert {
+				s.values = append(s.values, op.Record.RData)
+			}
+		}
+		if s.onPatch != nil {
+			s.values = s.onPatch(s.values)
+		}
+		_ = json.NewEncoder(w).Encode(models.RecordPatchResult{Atomic: req.Atomic})
+	}
+}
+
+func TestDNSService_ZoneLock(t *testing.T) {
+	client := newTestClient(t, &lockServer{})
+	ctx := context.Background()
+
+	a, err := client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-a", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), a.Token)
+
+	_, err = client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-b", time.Minute)
+	assert.ErrorIs(t, err, ErrZoneLocked)
+
+	require.NoError(t, client.DNS.VerifyZoneLock(ctx, a))
+
+	expires := a.Expires
+	require.NoError(t, client.DNS.RenewZoneLock(ctx, a, 2*time.Minute))
+	assert.True(t, a.Expires.After(expires))
+	require.NoError(t, client.DNS.VerifyZoneLock(ctx, a))
+
+	require.NoError(t, client.DNS.ReleaseZoneLock(ctx, a))
+
+	b, err := client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-b", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), b.Token, "fencing token continues after release")
+
+	assert.ErrorIs(t, client.DNS.VerifyZoneLock(ctx, a), ErrZoneLockLost)
+	assert.ErrorIs(t, client.DNS.RenewZoneLock(ctx, a, time.Minute), ErrZoneLockLost)
+	assert.ErrorIs(t, client.DNS.ReleaseZoneLock(ctx, a), ErrZoneLockLost)
+}
+
+func TestDNSService_AcquireZoneLock_Race(t *testing.T) {
+	rival := &ZoneLock{Owner: "pipeline-b", Token: 1, Expires: time.Now().Add(time.Minute)}
+	server := &lockServer{}
+	server.onPatch = func(values []string) []string {
+		server.onPatch = nil
+		return append(values, rival.record().RData)
+	}
+	client := newTestClient(t, server)
+
+	_, err := client.DNS.AcquireZoneLock(context.Background(), "example.com", "pipeline-a", time.Minute)
+	assert.ErrorIs(t, err, ErrZoneLocked)
+
+	// The loser removed its record, so only the rival's lease remains.
+	assert.Equal(t, []string{rival.record().RData}, server.values)
+}
+
+func TestDNSService_ZoneLock_StaleRecordFromLostRace(t *testing.T) {
+	server := &lockServer{}
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	a, err := client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-a", time.Minute)
+	require.NoError(t, err)
+
+	// A racer that lost left an expired copy of its lock next to ours, and
+	// the zone holds an unrelated TXT value.
+	stale := &ZoneLock{Owner: "pipeline-b", Token: 1, Expires: time.Now().Add(-time.Second)}
+	server.mu.Lock()
+	server.values = append(server.values, stale.record().RData, `"v=spf1 -all"`)
+	server.mu.Unlock()
+
+	require.NoError(t, client.DNS.VerifyZoneLock(ctx, a))
+	require.NoError(t, client.DNS.RenewZoneLock(ctx, a, time.Minute))
+
+	keepCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.DNS.KeepZoneLock(keepCtx, a, 150*time.Millisecond), context.DeadlineExceeded)
+
+	// An unexpired lock of another owner is still a conflict.
+	rival := &ZoneLock{Owner: "pipeline-b", Token: 2, Expires: time.Now().Add(time.Minute)}
+	server.mu.Lock()
+	server.values = append(server.values, rival.record().RData)
+	server.mu.Unlock()
+	assert.ErrorIs(t, client.DNS.VerifyZoneLock(ctx, a), ErrZoneLockLost)
+}
+
+func TestDNSService_ZoneLock_Expiry(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)}
+	client := newTestClient(t, &lockServer{}, WithClock(clock))
+	ctx := context.Background()
+
+	a, err := client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-a", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, clock.Now().Add(time.Minute), a.Expires)
+
+	clock.advance(59 * time.Second)
+	require.NoError(t, client.DNS.VerifyZoneLock(ctx, a))
+	_, err = client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-b", time.Minute)
+	assert.ErrorIs(t, err, ErrZoneLocked)
+
+	clock.advance(2 * time.Second)
+	err = client.DNS.VerifyZoneLock(ctx, a)
+	assert.ErrorIs(t, err, ErrZoneLockLost)
+	assert.Contains(t, err.Error(), "lease expired")
+
+	b, err := client.DNS.AcquireZoneLock(ctx, "example.com", "pipeline-b", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), b.Token)
+	assert.Equal(t, clock.Now().Add(time.Minute), b.Expires)
+}
+
+func TestDNSService_KeepZoneLock(t *testing.T) {
+	client := newTestClient(t, &lockServer{})
+
+	lock, err := client.DNS.AcquireZoneLock(context.Background(), "example.com", "pipeline-a", 300*time.Millisecond)
+	require.NoError(t, err)
+	expires := lock.Expires
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	err = client.DNS.KeepZoneLock(ctx, lock, 300*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, lock.Expires.After(expires))
+}
+
+func TestDNSService_AcquireZoneLock_Validation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("opk_test"))
+	require.NoError(t, err)
+
+	_, err = client.DNS.AcquireZoneLock(context.Background(), "example.com", "ci deploy", time.Minute)
+	assert.True(t, IsValidationError(err))
+
+	_, err = client.DNS.AcquireZoneLock(context.Background(), "example.com", "ci", 0)
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,103 @@
+package opusdns
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DNS name limits (RFC 1035).
+const (
+	maxLabelLength = 63
+	maxNameLength  = 253
+)
+
+// ValidateZoneName checks that name is a valid zone name: ASCII
+// letters, digits and hyphens (LDH), labels of 1-63 characters not starting or
+// ending with a hyphen, at least two labels, and at most 253 characters. A
+// trailing dot is allowed. Internationalized names must be given in their
+// punycode ("xn--") form.
+func ValidateZoneName(name string) error {
+	return validateZoneName("name", name)
+}
+
+// ValidateRecordName checks that name is a valid record name relative to
+// zoneName: "@" for the apex, or LDH labels where a label may also start with
+// an underscore (as in "_dmarc" or "_sip._tcp") and the first label may be
+// the wildcard "*". The full name including the zone must not exceed 253
+// characters. Names ending in a dot are treated as fully qualified.
+func ValidateRecordName(name, zoneName string) error {
+	return validateRecordName("name", name, zoneName)
+}
+
+func validateZoneName(field, name string) error {
+	trimmed := strings.TrimSuffix(name, ".")
+	if trimmed == "" {
+		return &ValidationError{Field: field, Message: "zone name is required"}
+	}
+	if len(trimmed) > maxNameLength {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters (got %d)", maxNameLength, len(trimmed)), Value: name}
+	}
+
+	labels := strings.Split(trimmed, ".")
+	if len(labels) < 2 {
+		return &ValidationError{Field: field, Message: "must have at least two labels", Value: name}
+	}
+	for i, label := range labels {
+		if msg := checkLabel(label, false); msg != "" {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("label %d %q %s", i+1, label, msg), Value: name}
+		}
+	}
+	return nil
+}
+
+func validateRecordName(field, name, zoneName string) error {
+	if name == "" || name == "@" {
+		return nil
+	}
+
+	full := strings.TrimSuffix(name, ".")
+	if !strings.HasSuffix(name, ".") && zoneName != "" {
+		full += "." + strings.TrimSuffix(zoneName, ".")
+	}
+	if len(full) > maxNameLength {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("full name must be at most %d characters (got %d)", maxNameLength, len(full)), Value: name}
+	}
+
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, label := range labels {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if msg := checkLabel(label, true); msg != "" {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("label %d %q %s", i+1, label, msg), Value: name}
+		}
+	}
+	return nil
+}
+
+// checkLabel returns why label is invalid, or "" if it is valid. If
+// underscore is true, the label may start with an underscore.
+func checkLabel(label string, underscore bool) string {
+	switch {
+	case label == "":
+		return "is empty"
+	case len(label) > maxLabelLength:
+		return fmt.Sprintf("is longer than %d characters", maxLabelLength)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return "must not start or end with a hyphen"
+	}
+
+	for i, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+		case r == '_' && underscore && i == 0:
+		case r == '_':
+			return "may only contain an underscore as its first character"
+		case r > 127:
+			return "must be ASCII (use the punycode form for internationalized names)"
+		default:
+			return fmt.Sprintf("contains invalid character %q", r)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package opusdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateZoneName(t *testing.T) {
+	long := strings.Repeat("a", 63)
+
+	valid := []string{"example.com", "example.com.", "xn--mnchen-3ya.de", "a-b.co.uk", long + ".com"}
+	for _, name := range valid {
+		assert.NoError(t, ValidateZoneName(name), name)
+	}
+
+	invalid := map[string]string{
+		"":                                  "required",
+		"com":                               "two labels",
+		"example..com":                      "empty",
+		"-example.com":                      "hyphen",
+		"example-.com":                      "hyphen",
+		"_example.com":                      "underscore",
+		"exa mple.com":                      "invalid character",
+		"münchen.de":                        "punycode",
+		long + "a.com":                      "longer than 63",
+		strings.Repeat(long+".", 4) + "com": "at most 253",
+	}
+	for name, msg := range invalid {
+		err := ValidateZoneName(name)
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr, name)
+		assert.Contains(t, validationErr.Message, msg, name)
+	}
+}
+
+func TestValidateRecordName(t *testing.T) {
+	valid := []string{"", "@", "www", "*", "*.dev", "_dmarc", "s1._domainkey", "_sip._tcp.voice", "www.example.com."}
+	for _, name := range valid {
+		assert.NoError(t, ValidateRecordName(name, "example.com"), name)
+	}
+
+	invalid := []string{"www..dev", "a*.dev", "dev.*", "ww_w", "www-", strings.Repeat("a", 64)}
+	for _, name := range invalid {
+		assert.True(t, IsValidationError(ValidateRecordName(name, "example.com")), name)
+	}
+
+	// The full name, including the zone, must fit in 253 characters.
+	name := strings.Repeat(strings.Repeat("a", 60)+".", 4)[:243]
+	assert.NoError(t, ValidateRecordName(name, "a.com"))
+	assert.Error(t, ValidateRecordName(name, "example.com"))
+}
+
+func TestDNSService_NameValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.DNS.CreateZone(context.Background(), &models.ZoneCreateRequest{
+		Name:   "example.com",
+		RRSets: []models.RRSetCreate{{Name: "www"}, {Name: "bad_name"}},
+	})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "rrsets[1].name", validationErr.Field)
+
+	err = client.DNS.UpsertRecord(context.Background(), "example.com", models.Record{Name: "-www", Type: models.RRSetTypeA})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "record.name", validationErr.Field)
+}
@@ -0,0 +1,245 @@
+package opusdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Plan is a set of record changes spanning several zones, applied together
+// by DNSService.ApplyPlan.
+type Plan struct {
+	// Changesets are applied in order, one zone each.
+	Changesets []ZoneChangeset
+}
+
+// ZoneChangeset is the record operations of a plan for one zone.
+type ZoneChangeset struct {
+	// Zone is the zone name.
+	Zone string
+
+	// Ops are the record operations, applied as one atomic patch.
+	Ops []models.RecordOperation
+}
+
+// ApplyPlanOptions contains options for DNSService.ApplyPlan.
+type ApplyPlanOptions struct {
+	// NoRollback leaves the changesets applied before a failure in place
+	// instead of reverting them.
+	NoRollback bool
+}
+
+// PlanZoneState is the state a zone's changeset was left in by ApplyPlan.
+type PlanZoneState string
+
+const (
+	// PlanZoneApplied means the changeset is applied.
+	PlanZoneApplied PlanZoneState = "applied"
+
+	// PlanZoneFailed means applying the changeset failed and nothing of it
+	// was applied.
+	PlanZoneFailed PlanZoneState = "failed"
+
+	// PlanZoneReverted means the changeset was applied and then reverted.
+	PlanZoneReverted PlanZoneState = "reverted"
+
+	// PlanZoneRevertFailed means the changeset was applied, completely or in
+	// part, and reverting it failed, so it is still in effect.
+	PlanZoneRevertFailed PlanZoneState = "revert_failed"
+
+	// PlanZoneSkipped means the changeset was not attempted.
+	PlanZoneSkipped PlanZoneState = "skipped"
+)
+
+// PlanZoneResult is the outcome of one changeset of a plan.
+type PlanZoneResult struct {
+	// Zone is the zone name.
+	Zone string
+
+	// State is the state the changeset was left in.
+	State PlanZoneState
+
+	// ChangesetID is the API's changeset ID of the applied patch, if reported.
+	ChangesetID string
+
+	// RevertChangesetID is the API's changeset ID of the revert, if reported.
+	RevertChangesetID string
+
+	// Err is the error that failed the changeset or its revert.
+	Err error
+}
+
+// PlanResult is the outcome of ApplyPlan, one entry per changeset in plan
+// order.
+type PlanResult struct {
+	Zones []PlanZoneResult
+}
+
+// LeftApplied returns the changesets that are in effect after ApplyPlan:
+// all of them on success, and those that could not be reverted otherwise.
+func (r *PlanResult) LeftApplied() []PlanZoneResult {
+	var applied []PlanZoneResult
+	for _, zone := range r.Zones {
+		if zone.State == PlanZoneApplied || zone.State == PlanZoneRevertFailed {
+			applied = append(applied, zone)
+		}
+	}
+	return applied
+}
+
+// ApplyPlan applies the changesets of a plan zone by zone, each as one
+// atomic record patch. If a changeset fails, the changesets applied before it
+// (and any part of the failed one the API applied) are reverted in reverse
+// order, so a multi-zone cutover is either complete or rolled back.
+//
+// The API cannot revert a changeset by ID, so before each changeset the
+// affected RRsets are snapshotted and a revert restores them. Changes others
+// make to those RRsets in the meantime are reverted as well.
+//
+// The result reports the state of every changeset; on failure it is returned
+// along with the error, and LeftApplied lists what is still in effect.
+func (s *DNSService) ApplyPlan(ctx context.Context, plan *Plan, opts *ApplyPlanOptions) (*PlanResult, error) {
+	if plan == nil || len(plan.Changesets) == 0 {
+		return nil, &ValidationError{Field: "plan", Message: "plan has no changesets"}
+	}
+	seen := map[string]bool{}
+	for i, changeset := range plan.Changesets {
+		zone := strings.ToLower(strings.TrimSuffix(changeset.Zone, "."))
+		if zone == "" {
+			return nil, &ValidationError{Field: fmt.Sprintf("changesets[%d].zone", i), Message: "zone is required"}
+		}
+		if seen[zone] {
+			return nil, &ValidationError{Field: fmt.Sprintf("changesets[%d].zone", i), Message: "zone appears in more than one changeset", Value: changeset.Zone}
+		}
+		seen[zone] = true
+	}
+	if opts == nil {
+		opts = &ApplyPlanOptions{}
+	}
+
+	result := &PlanResult{Zones: make([]PlanZoneResult, len(plan.Changesets))}
+	snapshots := make([][]models.Record, len(plan.Changesets))
+	for i, changeset := range plan.Changesets {
+		result.Zones[i] = PlanZoneResult{Zone: changeset.Zone, State: PlanZoneSkipped}
+	}
+
+	for i, changeset := range plan.Changesets {
+		zone := &result.Zones[i]
+
+		snapshot, err := s.snapshotRRSets(ctx, changeset.Zone, changeset.Ops)
+		if err != nil {
+			zone.State, zone.Err = PlanZoneFailed, err
+			return result, s.abortPlan(ctx, plan, result, snapshots, i, opts)
+		}
+		snapshots[i] = snapshot
+
+		patch, err := s.PatchRecordsWithRequest(ctx, changeset.Zone, &models.RecordPatchRequest{Ops: changeset.Ops, Atomic: true})
+		if patch != nil {
+			zone.ChangesetID = patch.ChangesetID
+		}
+		if err != nil {
+			zone.State, zone.Err = PlanZoneFailed, err
+			// A partially applied changeset is rolled back with the others.
+			var batchErr *BatchError
+			if errors.As(err, &batchErr) && batchErr.Applied {
+				zone.State = PlanZoneApplied
+			}
+			return result, s.abortPlan(ctx, plan, result, snapshots, i, opts)
+		}
+		zone.State = PlanZoneApplied
+	}
+
+	return result, nil
+}
+
+// abortPlan reverts the applied changesets up to and including index failed,
+// unless rollback is disabled, and returns the error describing the outcome.
+func (s *DNSService) abortPlan(ctx context.Context, plan *Plan, result *PlanResult, snapshots [][]models.Record, failed int, opts *ApplyPlanOptions) error {
+	cause := result.Zones[failed].Err
+	if result.Zones[failed].State == PlanZoneApplied {
+		cause = fmt.Errorf("partially applied: %w", cause)
+	}
+
+	if !opts.NoRollback {
+		// Revert even if the apply was cancelled.
+		ctx = context.WithoutCancel(ctx)
+		for i := failed; i >= 0; i-- {
+			zone := &result.Zones[i]
+			if zone.State != PlanZoneApplied {
+				continue
+			}
+			changesetID, err := s.revertRRSets(ctx, zone.Zone, plan.Changesets[i].Ops, snapshots[i])
+			if err != nil {
+				zone.State, zone.Err = PlanZoneRevertFailed, err
+				continue
+			}
+			zone.State, zone.RevertChangesetID = PlanZoneReverted, changesetID
+		}
+	}
+
+	var left []string
+	for _, zone := range result.LeftApplied() {
+		left = append(left, zone.Zone)
+	}
+	if len(left) > 0 {
+		return fmt.Errorf("opusdns: plan failed at zone %s, left applied: %s: %w", result.Zones[failed].Zone, strings.Join(left, ", "), cause)
+	}
+	return fmt.Errorf("opusdns: plan failed at zone %s, all changes reverted: %w", result.Zones[failed].Zone, cause)
+}
+
+// snapshotRRSets returns the current records of the RRsets ops touch.
+func (s *DNSService) snapshotRRSets(ctx context.Context, zoneName string, ops []models.RecordOperation) ([]models.Record, error) {
+	zone, err := s.GetZone(ctx, zoneName)
+	if err != nil {
+		return nil, fmt.Errorf("opusdns: failed to snapshot zone %s: %w", zoneName, err)
+	}
+	return touchedRecords(RRSetsToRecords(zone.RRSets), ops), nil
+}
+
+// revertRRSets restores the RRsets ops touch to snapshot and returns the
+// changeset ID of the revert, if the API reports one.
+func (s *DNSService) revertRRSets(ctx context.Context, zoneName string, ops []models.RecordOperation, snapshot []models.Record) (string, error) {
+	zone, err := s.GetZone(ctx, zoneName)
+	if err != nil {
+		return "", fmt.Errorf("opusdns: failed to read zone %s for revert: %w", zoneName, err)
+	}
+
+	revert := DiffRecords(touchedRecords(RRSetsToRecords(zone.RRSets), ops), snapshot)
+	if len(revert) == 0 {
+		return "", nil
+	}
+
+	patch, err := s.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{Ops: revert, Atomic: true})
+	if err != nil {
+		return "", fmt.Errorf("opusdns: failed to revert zone %s: %w", zoneName, err)
+	}
+	return patch.ChangesetID, nil
+}
+
+// touchedRecords returns the records that belong to an RRset one of ops
+// operates on.
+func touchedRecords(records []models.Record, ops []models.RecordOperation) []models.Record {
+	key := func(r models.Record) string {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		return strings.ToLower(name) + " " + strings.ToUpper(string(r.Type))
+	}
+
+	touched := map[string]bool{}
+	for _, op := range ops {
+		touched[key(op.Record)] = true
+	}
+
+	var out []models.Record
+	for _, record := range records {
+		if touched[key(record)] {
+			out = append(out, record)
+		}
+	}
+	return out
+}
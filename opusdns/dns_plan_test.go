@@ -0,0 +1,172 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// planServer serves the records of several zones from memory. The next
+// fail[zone] patches to a zone are rejected with a conflict.
+type planServer struct {
+	mu    sync.Mutex
+	zones map[string][]models.Record
+	fail  map[string]int
+}
+
+func (s *planServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/dns/"), "/")[0]
+	switch r.Method {
+	case http.MethodGet:
+		rrsets, _ := RecordsToRRSets(s.zones[zone], TTLConflictError)
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: zone, RRSets: rrsets})
+	case http.MethodPatch:
+		if s.fail[zone] > 0 {
+			s.fail[zone]--
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"zone is busy"}`))
+			return
+		}
+		var req models.RecordPatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, op := range req.Ops {
+			kept := s.zones[zone][:0]
+			for _, record := range s.zones[zone] {
+				if record.Name != op.Record.Name || record.Type != op.Record.Type || record.RData != op.Record.RData {
+					kept = append(kept, record)
+				}
+			}
+			s.zones[zone] = kept
+			if op.Op == models.RecordOpUpsert {
+				s.zones[zone] = append(s.zones[zone], op.Record)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(models.RecordPatchResult{Atomic: req.Atomic, ChangesetID: "cs_" + zone})
+	}
+}
+
+func newPlanServer() *planServer {
+	return &planServer{
+		zones: map[string][]models.Record{
+			"blue.example":  {{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+			"green.example": {{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+			"third.example": {{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+		},
+		fail: map[string]int{},
+	}
+}
+
+func cutoverPlan(zones ...string) *Plan {
+	plan := &Plan{}
+	for _, zone := range zones {
+		plan.Changesets = append(plan.Changesets, ZoneChangeset{Zone: zone, Ops: []models.RecordOperation{
+			{Op: models.RecordOpRemove, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+			{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "198.51.100.1"}},
+		}})
+	}
+	return plan
+}
+
+func TestDNSService_ApplyPlan(t *testing.T) {
+	server := newPlanServer()
+	client := newTestClient(t, server)
+
+	result, err := client.DNS.ApplyPlan(context.Background(), cutoverPlan("blue.example", "green.example"), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.Zones, 2)
+	for _, zone := range result.Zones {
+		assert.Equal(t, PlanZoneApplied, zone.State)
+		assert.Equal(t, "cs_"+zone.Zone, zone.ChangesetID)
+		assert.Equal(t, "198.51.100.1", server.zones[zone.Zone][0].RData)
+	}
+	assert.Len(t, result.LeftApplied(), 2)
+}
+
+func TestDNSService_ApplyPlan_Rollback(t *testing.T) {
+	server := newPlanServer()
+	server.fail["third.example"] = 1
+	client := newTestClient(t, server)
+
+	result, err := client.DNS.ApplyPlan(context.Background(), cutoverPlan("blue.example", "green.example", "third.example"), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all changes reverted")
+	assert.True(t, IsConflictError(err))
+
+	assert.Equal(t, PlanZoneReverted, result.Zones[0].State)
+	assert.Equal(t, PlanZoneReverted, result.Zones[1].State)
+	assert.Equal(t, PlanZoneFailed, result.Zones[2].State)
+	assert.Empty(t, result.LeftApplied())
+
+	for zone, records := range server.zones {
+		require.Len(t, records, 1, zone)
+		assert.Equal(t, "192.0.2.1", records[0].RData, zone)
+	}
+}
+
+func TestDNSService_ApplyPlan_RevertFails(t *testing.T) {
+	server := newPlanServer()
+	server.fail["green.example"] = 1
+	client := newTestClient(t, &revertFailServer{planServer: server, zone: "blue.example"})
+
+	result, err := client.DNS.ApplyPlan(context.Background(), cutoverPlan("blue.example", "green.example"), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "left applied: blue.example")
+
+	assert.Equal(t, PlanZoneRevertFailed, result.Zones[0].State)
+	assert.Error(t, result.Zones[0].Err)
+	assert.Equal(t, PlanZoneFailed, result.Zones[1].State)
+	require.Len(t, result.LeftApplied(), 1)
+	assert.Equal(t, "blue.example", result.LeftApplied()[0].Zone)
+}
+
+// revertFailServer rejects every patch to zone after the first.
+type revertFailServer struct {
+	*planServer
+	zone    string
+	patched bool
+}
+
+func (s *revertFailServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPatch && strings.Contains(r.URL.Path, s.zone) {
+		if s.patched {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"rejected"}`))
+			return
+		}
+		s.patched = true
+	}
+	s.planServer.ServeHTTP(w, r)
+}
+
+func TestDNSService_ApplyPlan_NoRollback(t *testing.T) {
+	server := newPlanServer()
+	server.fail["green.example"] = 1
+	client := newTestClient(t, server)
+
+	result, err := client.DNS.ApplyPlan(context.Background(), cutoverPlan("blue.example", "green.example"), &ApplyPlanOptions{NoRollback: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "left applied: blue.example")
+	assert.Equal(t, PlanZoneApplied, result.Zones[0].State)
+	assert.Equal(t, "198.51.100.1", server.zones["blue.example"][0].RData)
+}
+
+func TestDNSService_ApplyPlan_Validation(t *testing.T) {
+	client := newTestClient(t, newPlanServer())
+
+	_, err := client.DNS.ApplyPlan(context.Background(), &Plan{}, nil)
+	assert.True(t, IsValidationError(err))
+
+	_, err = client.DNS.ApplyPlan(context.Background(), cutoverPlan("blue.example", "Blue.example."), nil)
+	assert.True(t, IsValidationError(err))
+}
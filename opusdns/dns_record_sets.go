@@ -0,0 +1,199 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// recordSetAttempts is how often AddToRecordSet and RemoveFromRecordSet
+// re-read and rewrite a record set that changed under them.
+const recordSetAttempts = 3
+
+// SetRecordSet replaces all values of an RRset, such as the addresses of a
+// round-robin A set, in one atomic write. name is relative to the zone ("@"
+// for the apex). A and AAAA values must be IP addresses; duplicates are
+// dropped. A ttl of 0 uses the client's default TTL.
+func (s *DNSService) SetRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string, ttl int) error {
+	if name == "" {
+		name = "@"
+	}
+	if err := validateRecordName("name", name, zoneName); err != nil {
+		return err
+	}
+	if err := validateApexCNAME("name", name, rrtype, zoneName); err != nil {
+		return err
+	}
+	values, err := recordSetValues(rrtype, values)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return &ValidationError{Field: "values", Message: "at least one value is required"}
+	}
+	if ttl <= 0 {
+		ttl = s.client.DefaultTTL()
+	}
+
+	records := make([]models.RecordCreate, len(values))
+	for i, v := range values {
+		records[i] = models.RecordCreate{RData: v}
+	}
+	return s.PatchRRSets(ctx, zoneName, []models.RRSetPatchOp{{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: name, Type: rrtype, TTL: ttl, Records: records},
+	}})
+}
+
+// AddToRecordSet adds values to an RRset, creating it if needed, and leaves
+// its other values alone. A ttl of 0 keeps the set's TTL, or uses the
+// client's default TTL for a new set.
+//
+// The change is made optimistically: the set is read, and only the missing
+// values are written in one atomic patch, conditional on the zone serial read
+// with it when the API reports one. The set is then read again to check that
+// they are present. If the zone changed in between or a concurrent writer
+// undid the change, it is retried from the new state; after a few attempts
+// an error matching ErrConflict is returned.
+func (s *DNSService) AddToRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string, ttl int) error {
+	return s.modifyRecordSet(ctx, zoneName, name, rrtype, values, ttl, true)
+}
+
+// RemoveFromRecordSet removes values from an RRset and leaves its other
+// values alone; removing the last value deletes the set. Values that are not
+// in the set are ignored. Concurrent changes are handled as in AddToRecordSet.
+func (s *DNSService) RemoveFromRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string) error {
+	return s.modifyRecordSet(ctx, zoneName, name, rrtype, values, 0, false)
+}
+
+// modifyRecordSet adds or removes values in an RRset, re-reading and retrying
+// when a concurrent writer interferes.
+func (s *DNSService) modifyRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string, ttl int, add bool) error {
+	if name == "" {
+		name = "@"
+	}
+	if err := validateRecordName("name", name, zoneName); err != nil {
+		return err
+	}
+	if add {
+		if err := validateApexCNAME("name", name, rrtype, zoneName); err != nil {
+			return err
+		}
+	}
+	values, err := recordSetValues(rrtype, values)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return &ValidationError{Field: "values", Message: "at least one value is required"}
+	}
+
+	for attempt := 0; attempt < recordSetAttempts; attempt++ {
+		current, currentTTL, serial, err := s.readRecordSet(ctx, zoneName, name, rrtype)
+		if err != nil {
+			return err
+		}
+
+		setTTL := ttl
+		if setTTL <= 0 {
+			setTTL = currentTTL
+		}
+		if setTTL <= 0 {
+			setTTL = s.client.DefaultTTL()
+		}
+
+		var ops []models.RecordOperation
+		for _, v := range values {
+			if slices.Contains(current, v) != add {
+				op := models.RecordOpUpsert
+				if !add {
+					op = models.RecordOpRemove
+				}
+				ops = append(ops, models.RecordOperation{Op: op, Record: models.Record{Name: name, Type: rrtype, TTL: setTTL, RData: v}})
+			}
+		}
+		if add && len(current) > 0 && setTTL != currentTTL {
+			// The TTL belongs to the whole set; rewrite the values already in it too.
+			for _, v := range current {
+				if !slices.Contains(values, v) {
+					ops = append(ops, models.RecordOperation{Op: models.RecordOpUpsert, Record: models.Record{Name: name, Type: rrtype, TTL: setTTL, RData: v}})
+				}
+			}
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+
+		_, err = s.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{Ops: ops, Atomic: true, ExpectedSerial: serial})
+		if IsConflictVersionError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		after, _, _, err := s.readRecordSet(ctx, zoneName, name, rrtype)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(values, func(v string) bool { return slices.Contains(after, v) != add }) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: record set %s %s in zone %s kept changing concurrently", ErrConflict, name, rrtype, zoneName)
+}
+
+// readRecordSet returns the normalized values and TTL of an RRset, or no
+// values if it does not exist, and the zone serial (0 if not reported).
+func (s *DNSService) readRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType) ([]string, int, int64, error) {
+	zone, err := s.GetZoneWithOptions(ctx, zoneName, &models.GetZoneOptions{
+		Types:      []models.RRSetType{rrtype},
+		NameFilter: name,
+	})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	rrset := findRRSet(zone, name, rrtype)
+	if rrset == nil {
+		return nil, 0, zone.Serial, nil
+	}
+
+	var raw []string
+	for _, r := range rrset.Records {
+		raw = append(raw, r.RData)
+	}
+	values, err := recordSetValues(rrtype, raw)
+	if err != nil {
+		// Keep unparsable values as they are so they still compare equal.
+		values = raw
+	}
+	return values, rrset.TTL, zone.Serial, nil
+}
+
+// recordSetValues trims and de-duplicates values; A and AAAA values must be
+// addresses of the right family and are put in canonical form.
+func recordSetValues(rrtype models.RRSetType, values []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if rrtype == models.RRSetTypeA || rrtype == models.RRSetTypeAAAA {
+			ip := net.ParseIP(v)
+			if ip == nil || (ip.To4() != nil) != (rrtype == models.RRSetTypeA) {
+				return nil, &ValidationError{Field: "values", Message: fmt.Sprintf("not a valid %s address", rrtype), Value: v}
+			}
+			v = ip.String()
+		}
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,185 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordSetServer fakes a zone with a single "www" A RRset. The first lost
+// record patches are acknowledged but not applied, as if a concurrent writer
+// undid them. With a serial, patches expecting another serial are rejected
+// and applied ones bump it; bump changes it before the first patch, as a
+// concurrent writer would.
+type recordSetServer struct {
+	mu      sync.Mutex
+	ttl     int
+	values  []string
+	lost    int
+	serial  int64
+	bump    bool
+	matches []string
+	patches []models.RecordPatchRequest
+	rrsets  []models.RRSetPatchRequest
+}
+
+func (f *recordSetServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+			zone := models.Zone{Name: "example.com", Serial: f.serial}
+			if len(f.values) > 0 {
+				rrset := models.RRSet{Name: "www", Type: models.RRSetTypeA, TTL: f.ttl}
+				for _, v := range f.values {
+					rrset.Records = append(rrset.Records, models.RecordData{RData: v})
+				}
+				zone.RRSets = append(zone.RRSets, rrset)
+			}
+			_ = json.NewEncoder(w).Encode(zone)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/records":
+			var req models.RecordPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.patches = append(f.patches, req)
+			f.matches = append(f.matches, r.Header.Get("If-Match"))
+			if f.bump {
+				f.bump = false
+				f.serial++
+			}
+			if match := r.Header.Get("If-Match"); match != "" && match != fmt.Sprintf("%q", strconv.FormatInt(f.serial, 10)) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			if f.serial != 0 {
+				f.serial++
+			}
+			if f.lost > 0 {
+				f.lost--
+			} else {
+				for _, op := range req.Ops {
+					f.ttl = op.Record.TTL
+					f.values = slices.DeleteFunc(f.values, func(v string) bool { return v == op.Record.RData })
+					if op.Op == models.RecordOpUpsert {
+						f.values = append(f.values, op.Record.RData)
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(models.RecordPatchResult{Atomic: req.Atomic})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/rrsets":
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.rrsets = append(f.rrsets, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestDNSService_SetRecordSet(t *testing.T) {
+	fake := &recordSetServer{}
+	client := newTestClient(t, fake.handler(t))
+
+	err := client.DNS.SetRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.1", " 192.0.2.2", "192.0.2.1"}, 0)
+	require.NoError(t, err)
+
+	require.Len(t, fake.rrsets, 1)
+	assert.Equal(t, []models.RRSetPatchOp{{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: "www", Type: models.RRSetTypeA, TTL: DefaultTTL, Records: []models.RecordCreate{{RData: "192.0.2.1"}, {RData: "192.0.2.2"}}},
+	}}, fake.rrsets[0].Ops)
+
+	err = client.DNS.SetRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"2001:db8::1"}, 0)
+	assert.True(t, IsValidationError(err))
+	err = client.DNS.SetRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, nil, 0)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestDNSService_AddToRecordSet(t *testing.T) {
+	t.Run("adds only missing values and keeps the TTL", func(t *testing.T) {
+		fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1"}}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.1", "192.0.2.2"}, 0)
+		require.NoError(t, err)
+
+		require.Len(t, fake.patches, 1)
+		assert.True(t, fake.patches[0].Atomic)
+		assert.Equal(t, []models.RecordOperation{
+			{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.2"}},
+		}, fake.patches[0].Ops)
+		assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, fake.values)
+
+		// Nothing to add: no write.
+		err = client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2"}, 0)
+		require.NoError(t, err)
+		assert.Len(t, fake.patches, 1)
+	})
+
+	t.Run("retries after a concurrent change", func(t *testing.T) {
+		fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1"}, lost: 1}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2"}, 0)
+		require.NoError(t, err)
+		assert.Len(t, fake.patches, 2)
+		assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, fake.values)
+	})
+
+	t.Run("retries when the zone serial moved", func(t *testing.T) {
+		fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1"}, serial: 7, bump: true}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2"}, 0)
+		require.NoError(t, err)
+		require.Len(t, fake.patches, 2)
+		assert.Equal(t, []string{`"7"`, `"8"`}, fake.matches)
+		assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, fake.values)
+		assert.Equal(t, int64(9), fake.serial)
+	})
+
+	t.Run("gives up with a conflict", func(t *testing.T) {
+		fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1"}, lost: recordSetAttempts}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2"}, 0)
+		assert.True(t, IsConflictError(err))
+		assert.Len(t, fake.patches, recordSetAttempts)
+	})
+
+	t.Run("changing the TTL rewrites the whole set", func(t *testing.T) {
+		fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1"}}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.DNS.AddToRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2"}, 60)
+		require.NoError(t, err)
+		require.Len(t, fake.patches, 1)
+		assert.Len(t, fake.patches[0].Ops, 2)
+		assert.Equal(t, 60, fake.ttl)
+	})
+}
+
+func TestDNSService_RemoveFromRecordSet(t *testing.T) {
+	fake := &recordSetServer{ttl: 300, values: []string{"192.0.2.1", "192.0.2.2"}}
+	client := newTestClient(t, fake.handler(t))
+
+	err := client.DNS.RemoveFromRecordSet(context.Background(), "example.com", "www", models.RRSetTypeA, []string{"192.0.2.2", "192.0.2.9"})
+	require.NoError(t, err)
+
+	require.Len(t, fake.patches, 1)
+	assert.Equal(t, []models.RecordOperation{
+		{Op: models.RecordOpRemove, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.2"}},
+	}, fake.patches[0].Ops)
+	assert.Equal(t, []string{"192.0.2.1"}, fake.values)
+}
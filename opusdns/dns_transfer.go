@@ -0,0 +1,137 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// UpdateZoneTransferSettings replaces the primary servers and TSIG key a
+// secondary zone is transferred from. The settings are validated like those
+// of CreateZone before the request is sent.
+func (s *DNSService) UpdateZoneTransferSettings(ctx context.Context, zoneName string, settings *models.ZoneTransferSettings) (*models.Zone, error) {
+	if err := validateZoneTransfer("transfer", settings); err != nil {
+		return nil, err
+	}
+
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "transfer")
+
+	resp, err := s.client.http.Put(ctx, path, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var zone models.Zone
+	if err := s.client.http.DecodeResponse(resp, &zone); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// RetransferZone makes a secondary zone pull a full copy (AXFR) from its
+// primaries now instead of waiting for the SOA refresh interval.
+func (s *DNSService) RetransferZone(ctx context.Context, zoneName string) error {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "transfer", "retransfer")
+
+	resp, err := s.client.http.Post(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, nil)
+}
+
+// AXFR streams a full transfer of a zone from the API's export endpoint,
+// calling fn with each RRset as it is read, so very large zones can be backed
+// up without holding them in memory. If fn returns an error the transfer is
+// aborted and that error is returned.
+func (s *DNSService) AXFR(ctx context.Context, zoneName string, fn func(models.RRSet) error) error {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "export")
+
+	body, err := s.client.http.Stream(ctx, &Request{
+		Method:  http.MethodGet,
+		Path:    path,
+		Query:   url.Values{"format": {"ndjson"}},
+		Headers: http.Header{"Accept": {"application/x-ndjson"}},
+	})
+	if err != nil {
+		return err
+	}
+	defer body.Close() //nolint:errcheck
+
+	dec := json.NewDecoder(body)
+	for {
+		var rrset models.RRSet
+		if err := dec.Decode(&rrset); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("opusdns: failed to read zone export: %w", err)
+		}
+		if err := fn(rrset); err != nil {
+			return err
+		}
+	}
+}
+
+// validateZoneKind checks that a zone create request's kind, transfer
+// settings and initial RRsets are consistent.
+func validateZoneKind(req *models.ZoneCreateRequest) error {
+	switch req.Kind {
+	case "", models.ZoneKindPrimary:
+		if req.Transfer != nil {
+			return &ValidationError{Field: "transfer", Message: "transfer settings are only allowed for secondary zones"}
+		}
+		return nil
+	case models.ZoneKindSecondary:
+		if len(req.RRSets) > 0 {
+			return &ValidationError{Field: "rrsets", Message: "secondary zones get their records from the primary and cannot have initial RRsets"}
+		}
+		return validateZoneTransfer("transfer", req.Transfer)
+	default:
+		return &ValidationError{Field: "kind", Message: "must be primary or secondary", Value: req.Kind}
+	}
+}
+
+// validateZoneTransfer checks the primaries and TSIG key of transfer settings.
+func validateZoneTransfer(field string, settings *models.ZoneTransferSettings) error {
+	if settings == nil || len(settings.Primaries) == 0 {
+		return &ValidationError{Field: field + ".primaries", Message: "at least one primary server is required"}
+	}
+	for i, primary := range settings.Primaries {
+		if _, err := netip.ParseAddr(primary); err != nil {
+			return &ValidationError{Field: fmt.Sprintf("%s.primaries[%d]", field, i), Message: "must be an IP address", Value: primary}
+		}
+	}
+
+	key := settings.TSIGKey
+	if key == nil {
+		return nil
+	}
+	if key.Name == "" {
+		return &ValidationError{Field: field + ".tsig_key.name", Message: "TSIG key name is required"}
+	}
+	switch key.Algorithm {
+	case models.TSIGAlgorithmHMACSHA256, models.TSIGAlgorithmHMACSHA384, models.TSIGAlgorithmHMACSHA512:
+	default:
+		return &ValidationError{Field: field + ".tsig_key.algorithm", Message: "unsupported TSIG algorithm", Value: key.Algorithm}
+	}
+	if _, err := base64.StdEncoding.DecodeString(key.Secret); err != nil || key.Secret == "" {
+		return &ValidationError{Field: field + ".tsig_key.secret", Message: "must be a base64-encoded key"}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSService_CreateZone_Secondary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.ZoneCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, models.ZoneKindSecondary, req.Kind)
+		require.NotNil(t, req.Transfer)
+		assert.Equal(t, []string{"192.0.2.53", "2001:db8::53"}, req.Transfer.Primaries)
+		assert.Equal(t, "transfer-key", req.Transfer.TSIGKey.Name)
+
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: req.Name, Kind: req.Kind})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zone, err := client.DNS.CreateZone(context.Background(), &models.ZoneCreateRequest{
+		Name: "example.com",
+		Kind: models.ZoneKindSecondary,
+		Transfer: &models.ZoneTransferSettings{
+			Primaries: []string{"192.0.2.53", "2001:db8::53"},
+			TSIGKey: &models.TSIGKey{
+				Name:      "transfer-key",
+				Algorithm: models.TSIGAlgorithmHMACSHA256,
+				Secret:    "c2VjcmV0LWtleS1tYXRlcmlhbA==",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ZoneKindSecondary, zone.Kind)
+}
+
+func TestValidateZoneKind(t *testing.T) {
+	transfer := &models.ZoneTransferSettings{Primaries: []string{"192.0.2.53"}}
+
+	tests := []struct {
+		name  string
+		req   models.ZoneCreateRequest
+		field string
+	}{
+		{"primary", models.ZoneCreateRequest{}, ""},
+		{"primary with transfer", models.ZoneCreateRequest{Transfer: transfer}, "transfer"},
+		{"unknown kind", models.ZoneCreateRequest{Kind: "tertiary"}, "kind"},
+		{"secondary", models.ZoneCreateRequest{Kind: models.ZoneKindSecondary, Transfer: transfer}, ""},
+		{"secondary without primaries", models.ZoneCreateRequest{Kind: models.ZoneKindSecondary}, "transfer.primaries"},
+		{"secondary with rrsets", models.ZoneCreateRequest{
+			Kind:     models.ZoneKindSecondary,
+			Transfer: transfer,
+			RRSets:   []models.RRSetCreate{{Name: "www", Type: models.RRSetTypeA}},
+		}, "rrsets"},
+		{"hostname primary", models.ZoneCreateRequest{
+			Kind:     models.ZoneKindSecondary,
+			Transfer: &models.ZoneTransferSettings{Primaries: []string{"ns1.example.net"}},
+		}, "transfer.primaries[0]"},
+		{"bad algorithm", models.ZoneCreateRequest{
+			Kind: models.ZoneKindSecondary,
+			Transfer: &models.ZoneTransferSettings{
+				Primaries: []string{"192.0.2.53"},
+				TSIGKey:   &models.TSIGKey{Name: "k", Algorithm: "hmac-md5", Secret: "c2VjcmV0"},
+			},
+		}, "transfer.tsig_key.algorithm"},
+		{"bad secret", models.ZoneCreateRequest{
+			Kind: models.ZoneKindSecondary,
+			Transfer: &models.ZoneTransferSettings{
+				Primaries: []string{"192.0.2.53"},
+				TSIGKey:   &models.TSIGKey{Name: "k", Algorithm: models.TSIGAlgorithmHMACSHA512, Secret: "not base64!"},
+			},
+		}, "transfer.tsig_key.secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateZoneKind(&tt.req)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tt.field, verr.Field)
+		})
+	}
+}
+
+func TestDNSService_UpdateZoneTransferSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/v1/dns/example.com/transfer", r.URL.Path)
+
+		var settings models.ZoneTransferSettings
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&settings))
+		assert.Equal(t, []string{"198.51.100.53"}, settings.Primaries)
+
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", Kind: models.ZoneKindSecondary, Transfer: &settings})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zone, err := client.DNS.UpdateZoneTransferSettings(context.Background(), "example.com.", &models.ZoneTransferSettings{
+		Primaries: []string{"198.51.100.53"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"198.51.100.53"}, zone.Transfer.Primaries)
+
+	_, err = client.DNS.UpdateZoneTransferSettings(context.Background(), "example.com", nil)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestDNSService_RetransferZone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/dns/example.com/transfer/retransfer", r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, client.DNS.RetransferZone(context.Background(), "example.com"))
+}
+
+func TestDNSService_AXFR(t *testing.T) {
+	const total = 5000
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/dns/example.com/export", r.URL.Path)
+		assert.Equal(t, []string{"application/x-ndjson"}, r.Header.Values("Accept"))
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		enc := json.NewEncoder(w)
+		for i := 0; i < total; i++ {
+			_ = enc.Encode(models.RRSet{
+				Name:    fmt.Sprintf("host%d", i),
+				Type:    models.RRSetTypeA,
+				TTL:     300,
+				Records: []models.RecordData{{RData: "192.0.2.1"}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithRetryWait(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	count := 0
+	err = client.DNS.AXFR(context.Background(), "example.com", func(rrset models.RRSet) error {
+		assert.Equal(t, fmt.Sprintf("host%d", count), rrset.Name)
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, total, count)
+	assert.Equal(t, 2, attempts)
+
+	stop := errors.New("stop")
+	count = 0
+	err = client.DNS.AXFR(context.Background(), "example.com", func(models.RRSet) error {
+		count++
+		if count == 10 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 10, count)
+}
+
+func TestDNSService_AXFR_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"title":"Zone not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	err = client.DNS.AXFR(context.Background(), "missing.com", func(models.RRSet) error {
+		t.Fatal("callback must not be called")
+		return nil
+	})
+	assert.True(t, IsNotFoundError(err))
+}
@@ -0,0 +1,92 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// zoneCache remembers which names are not zones, so repeated FindZone calls
+// that fall back to probing skip the candidates already known not to exist. Zones themselves are
+// fetched on every call, so their records are never stale.
+type zoneCache struct {
+	mu      sync.Mutex
+	missing map[string]time.Time // name -> when the entry expires
+}
+
+// isMissing reports whether name is known, as of now, not to be a zone.
+func (c *zoneCache) isMissing(name string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires, ok := c.missing[name]
+	return ok && now.Before(expires)
+}
+
+// markMissing records that name is not a zone until now+ttl.
+func (c *zoneCache) markMissing(name string, now time.Time, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.missing == nil {
+		c.missing = map[string]time.Time{}
+	}
+	c.missing[name] = now.Add(ttl)
+}
+
+// forget drops what is known about name, or about all names if name is "".
+func (c *zoneCache) forget(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if name == "" {
+		c.missing = nil
+		return
+	}
+	delete(c.missing, name)
+}
+
+// findZoneCached probes the candidates for fqdn with GetZone, using the zone
+// cache when Config.ZoneCacheTTL is positive.
+func (s *DNSService) findZoneCached(ctx context.Context, fqdn string) (*models.Zone, error) {
+	ttl := s.client.Config.ZoneCacheTTL
+	if ttl <= 0 {
+		return findZone(ctx, s, fqdn)
+	}
+
+	clock := s.client.http.clock
+	for _, candidate := range zoneCandidates(fqdn) {
+		if s.zones.isMissing(candidate, clock.Now()) {
+			continue
+		}
+
+		zone, err := s.GetZone(ctx, candidate)
+		if IsNotFoundError(err) {
+			s.zones.markMissing(candidate, clock.Now(), ttl)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		zone.Name = candidate
+		return zone, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrZoneNotFound, fqdn)
+}
+
+// ClearZoneCache discards what FindZone has cached about which names
+// are not zones. Zones created through this client are removed from the cache
+// automatically; call it after creating zones by other means.
+func (s *DNSService) ClearZoneCache() {
+	s.zones.forget("")
+}
+
+// forgetZone drops the cache entry of a zone created through this client.
+// Deleted zones need no invalidation, as only missing names are cached.
+func (s *DNSService) forgetZone(name string) {
+	s.zones.forget(strings.ToLower(strings.TrimSuffix(name, ".")))
+}
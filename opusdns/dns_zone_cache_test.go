@@ -0,0 +1,113 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSService_FindZoneForFQDN_Cache(t *testing.T) {
+	var mu sync.Mutex
+	zones := map[string]bool{"example.com": true}
+	requests := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/v1/dns" && r.Method == http.MethodGet {
+			// Too many zones to list in one page: probe instead.
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Pagination: models.Pagination{HasNextPage: true}})
+			return
+		}
+		if r.Method == http.MethodPost {
+			var req models.ZoneCreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			zones[req.Name] = true
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: req.Name})
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/v1/dns/")
+		requests[name]++
+		if !zones[name] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: name})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithZoneCache(time.Minute))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zone, err := client.DNS.FindZoneForFQDN(ctx, "_acme-challenge.www.example.com")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "example.com", zone.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	first := requests["_acme-challenge.www.example.com"]
+	requests = map[string]int{}
+	mu.Unlock()
+	assert.GreaterOrEqual(t, first, 1)
+
+	// Known misses are skipped; the zone itself is fetched every time.
+	for i := 0; i < 3; i++ {
+		_, err := client.DNS.FindZoneForFQDN(ctx, "_acme-challenge.www.example.com")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"example.com": 3}, requests)
+
+	// Creating a zone through the client invalidates its miss.
+	_, err = client.DNS.CreateZone(ctx, &models.ZoneCreateRequest{Name: "www.example.com"})
+	require.NoError(t, err)
+	zone, err := client.DNS.FindZoneForFQDN(ctx, "_acme-challenge.www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", zone.Name)
+
+	// Zones created elsewhere are found after ClearZoneCache.
+	mu.Lock()
+	zones["_acme-challenge.www.example.com"] = true
+	mu.Unlock()
+	zone, err = client.DNS.FindZoneForFQDN(ctx, "_acme-challenge.www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", zone.Name)
+
+	client.DNS.ClearZoneCache()
+	zone, err = client.DNS.FindZoneForFQDN(ctx, "_acme-challenge.www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "_acme-challenge.www.example.com", zone.Name)
+}
+
+func TestZoneCache_Expiry(t *testing.T) {
+	var c zoneCache
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, c.isMissing("example.com", now))
+	c.markMissing("example.com", now, time.Minute)
+	assert.True(t, c.isMissing("example.com", now.Add(59*time.Second)))
+	assert.False(t, c.isMissing("example.com", now.Add(time.Minute)))
+
+	c.markMissing("example.com", now, time.Minute)
+	c.forget("example.com")
+	assert.False(t, c.isMissing("example.com", now))
+}
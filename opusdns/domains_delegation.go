@@ -0,0 +1,283 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// DelegationIssueKind classifies a problem found by DomainsService.CheckDelegation.
+type DelegationIssueKind string
+
+const (
+	// DelegationIssueLame means a registry nameserver could not be reached or
+	// does not serve NS records for the domain.
+	DelegationIssueLame DelegationIssueKind = "lame_delegation"
+
+	// DelegationIssueMismatch means a nameserver serves an NS set that differs
+	// from the registry delegation.
+	DelegationIssueMismatch DelegationIssueKind = "ns_mismatch"
+
+	// DelegationIssueZoneMismatch means the apex NS records of the OpusDNS
+	// hosted zone differ from the registry delegation.
+	DelegationIssueZoneMismatch DelegationIssueKind = "zone_mismatch"
+
+	// DelegationIssueMissingGlue means an in-bailiwick nameserver (one below
+	// the domain itself) has no glue addresses at the registry.
+	DelegationIssueMissingGlue DelegationIssueKind = "missing_glue"
+)
+
+// DelegationIssue is a single delegation problem.
+type DelegationIssue struct {
+	// Kind classifies the issue.
+	Kind DelegationIssueKind `json:"kind"`
+
+	// Nameserver is the nameserver the issue relates to, if any.
+	Nameserver string `json:"nameserver,omitempty"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+// NameserverCheck is the result of querying one registry nameserver.
+type NameserverCheck struct {
+	// Hostname is the nameserver hostname.
+	Hostname string `json:"hostname"`
+
+	// Addresses are the addresses queried (glue from the registry, or resolved).
+	Addresses []string `json:"addresses,omitempty"`
+
+	// ServedNS is the NS set the nameserver returned for the domain.
+	ServedNS []string `json:"served_ns,omitempty"`
+
+	// Error is set when the nameserver could not be queried.
+	Error string `json:"error,omitempty"`
+}
+
+// DelegationReport is the result of DomainsService.CheckDelegation.
+// Nameserver hostnames are lower-cased, without a trailing dot, and sorted.
+type DelegationReport struct {
+	// Domain is the checked domain name.
+	Domain string `json:"domain"`
+
+	// RegistryNameservers is the delegation registered at the registry.
+	RegistryNameservers []string `json:"registry_nameservers"`
+
+	// Hosted reports whether the domain has a zone at OpusDNS.
+	Hosted bool `json:"hosted"`
+
+	// ZoneNameservers is the apex NS set of the OpusDNS zone, when hosted.
+	ZoneNameservers []string `json:"zone_nameservers,omitempty"`
+
+	// Nameservers holds the per-nameserver query results.
+	Nameservers []NameserverCheck `json:"nameservers"`
+
+	// Issues lists every problem found.
+	Issues []DelegationIssue `json:"issues,omitempty"`
+}
+
+// OK reports whether the delegation has no issues.
+func (r *DelegationReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// delegationResolver performs the DNS lookups for CheckDelegation.
+// Tests replace it to avoid network access.
+var delegationResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupNSAt(ctx context.Context, server, domain string) ([]string, error)
+} = netDelegationResolver{}
+
+// netDelegationResolver resolves through the system resolver and queries nameservers directly on port 53.
+type netDelegationResolver struct{}
+
+func (netDelegationResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+func (netDelegationResolver) LookupNSAt(ctx context.Context, server, domain string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, net.JoinHostPort(server, "53"))
+		},
+	}
+
+	records, err := resolver.LookupNS(ctx, domain+".")
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]string, len(records))
+	for i, ns := range records {
+		hosts[i] = ns.Host
+	}
+	return hosts, nil
+}
+
+// CheckDelegation compares the registry delegation of a domain with the NS
+// records served by each delegated nameserver and, when the domain is hosted
+// at OpusDNS, with the apex NS records of its zone. It reports mismatches,
+// lame delegations and missing glue. Lookup failures are reported as issues
+// rather than errors; an error is returned only if the domain or zone cannot
+// be fetched.
+func (s *DomainsService) CheckDelegation(ctx context.Context, domainName string) (*DelegationReport, error) {
+	domain, err := s.GetDomain(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DelegationReport{Domain: normalizeHostname(domain.Name)}
+	glue := make(map[string][]string, len(domain.Nameservers))
+	for _, ns := range domain.Nameservers {
+		host := normalizeHostname(ns.Hostname)
+		report.RegistryNameservers = append(report.RegistryNameservers, host)
+		glue[host] = ns.IPAddresses
+	}
+	slices.Sort(report.RegistryNameservers)
+
+	zone, err := s.client.DNS.GetZone(ctx, report.Domain)
+	switch {
+	case err == nil:
+		report.Hosted = true
+		report.ZoneNameservers = zoneApexNameservers(zone)
+		if !slices.Equal(report.ZoneNameservers, report.RegistryNameservers) {
+			report.Issues = append(report.Issues, DelegationIssue{
+				Kind:    DelegationIssueZoneMismatch,
+				Message: fmt.Sprintf("zone apex NS %v differs from registry delegation %v", report.ZoneNameservers, report.RegistryNameservers),
+			})
+		}
+	case IsNotFoundError(err):
+		// Not hosted at OpusDNS; only the registry delegation is checked.
+	default:
+		return nil, err
+	}
+
+	for _, host := range report.RegistryNameservers {
+		check := NameserverCheck{Hostname: host, Addresses: glue[host]}
+
+		if inBailiwick(host, report.Domain) && len(check.Addresses) == 0 {
+			report.Issues = append(report.Issues, DelegationIssue{
+				Kind:       DelegationIssueMissingGlue,
+				Nameserver: host,
+				Message:    "nameserver is inside the domain but has no glue addresses at the registry",
+			})
+		}
+
+		queryNameserver(ctx, report.Domain, &check)
+
+		switch {
+		case check.Error != "":
+			report.Issues = append(report.Issues, DelegationIssue{Kind: DelegationIssueLame, Nameserver: host, Message: check.Error})
+		case len(check.ServedNS) == 0:
+			report.Issues = append(report.Issues, DelegationIssue{Kind: DelegationIssueLame, Nameserver: host, Message: "nameserver returned no NS records for the domain"})
+		case !slices.Equal(check.ServedNS, report.RegistryNameservers):
+			report.Issues = append(report.Issues, DelegationIssue{
+				Kind:       DelegationIssueMismatch,
+				Nameserver: host,
+				Message:    fmt.Sprintf("served NS %v differs from registry delegation %v", check.ServedNS, report.RegistryNameservers),
+			})
+		}
+
+		report.Nameservers = append(report.Nameservers, check)
+	}
+
+	return report, nil
+}
+
+// waitForDelegation runs CheckDelegation every interval until the
+// delegation is consistent or timeout would be exceeded, waiting on clock.
+// It returns the last report, also on failure, so callers can record it.
+func (s *DomainsService) waitForDelegation(ctx context.Context, clock Clock, domainName string, timeout, interval time.Duration) (*DelegationReport, error) {
+	deadline := clock.Now().Add(timeout)
+
+	for {
+		report, err := s.CheckDelegation(ctx, domainName)
+		if err != nil {
+			return nil, err
+		}
+		if report.OK() {
+			return report, nil
+		}
+
+		if clock.Now().Add(interval).After(deadline) {
+			return report, fmt.Errorf("delegation not consistent after %s: %d issue(s), first: %s", timeout, len(report.Issues), report.Issues[0].Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// queryNameserver fills in check.Addresses (when not set from glue) and
+// check.ServedNS, using the first address that answers.
+func queryNameserver(ctx context.Context, domain string, check *NameserverCheck) {
+	if len(check.Addresses) == 0 {
+		addrs, err := delegationResolver.LookupHost(ctx, check.Hostname)
+		if err != nil {
+			check.Error = fmt.Sprintf("resolve nameserver: %v", err)
+			return
+		}
+		check.Addresses = addrs
+	}
+
+	var lastErr error
+	for _, addr := range check.Addresses {
+		served, err := delegationResolver.LookupNSAt(ctx, addr, domain)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		check.ServedNS = normalizeHostnames(served)
+		return
+	}
+	if lastErr != nil {
+		check.Error = fmt.Sprintf("query nameserver: %v", lastErr)
+	}
+}
+
+// zoneApexNameservers returns the normalized apex NS set of a zone.
+func zoneApexNameservers(zone *models.Zone) []string {
+	var hosts []string
+	for _, rrset := range zone.RRSets {
+		if rrset.Type != models.RRSetTypeNS {
+			continue
+		}
+		if name := normalizeHostname(rrset.Name); name != "@" && name != "" && name != normalizeHostname(zone.Name) {
+			continue
+		}
+		for _, record := range rrset.Records {
+			hosts = append(hosts, record.RData)
+		}
+	}
+	return normalizeHostnames(hosts)
+}
+
+// normalizeHostname lower-cases a hostname and trims whitespace and a trailing dot.
+func normalizeHostname(host string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+}
+
+// inBailiwick reports whether host is the domain or below it.
+func inBailiwick(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// normalizeHostnames normalizes, sorts and de-duplicates hostnames.
+func normalizeHostnames(hosts []string) []string {
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		out = append(out, normalizeHostname(h))
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
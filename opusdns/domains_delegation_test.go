@@ -0,0 +1,125 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDelegationResolver answers lookups from fixed tables.
+type fakeDelegationResolver struct {
+	hosts  map[string][]string
+	served map[string][]string // by server address
+}
+
+func (f fakeDelegationResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := f.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (f fakeDelegationResolver) LookupNSAt(_ context.Context, server, _ string) ([]string, error) {
+	if ns, ok := f.served[server]; ok {
+		return ns, nil
+	}
+	return nil, errors.New("i/o timeout")
+}
+
+func useDelegationResolver(t *testing.T, r fakeDelegationResolver) {
+	t.Helper()
+	orig := delegationResolver
+	delegationResolver = r
+	t.Cleanup(func() { delegationResolver = orig })
+}
+
+func TestDomainsService_CheckDelegation(t *testing.T) {
+	t.Run("healthy hosted delegation", func(t *testing.T) {
+		useDelegationResolver(t, fakeDelegationResolver{
+			hosts: map[string][]string{"ns1.opusdns.net": {"192.0.2.1"}, "ns2.opusdns.net": {"192.0.2.2"}},
+			served: map[string][]string{
+				"192.0.2.1": {"ns1.opusdns.net.", "ns2.opusdns.net."},
+				"192.0.2.2": {"NS2.opusdns.net.", "ns1.opusdns.net."},
+			},
+		})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/domains/example.com":
+				_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: []models.Nameserver{
+					{Hostname: "ns2.opusdns.net"}, {Hostname: "ns1.opusdns.net."},
+				}})
+			case "/v1/dns/example.com":
+				_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", RRSets: []models.RRSet{
+					{Name: "@", Type: models.RRSetTypeNS, Records: []models.RecordData{{RData: "ns1.opusdns.net."}, {RData: "ns2.opusdns.net."}}},
+					{Name: "sub", Type: models.RRSetTypeNS, Records: []models.RecordData{{RData: "ns.elsewhere.net."}}},
+				}})
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		report, err := client.Domains.CheckDelegation(context.Background(), "example.com")
+		require.NoError(t, err)
+
+		assert.True(t, report.OK(), "issues: %+v", report.Issues)
+		assert.True(t, report.Hosted)
+		assert.Equal(t, []string{"ns1.opusdns.net", "ns2.opusdns.net"}, report.RegistryNameservers)
+		assert.Equal(t, []string{"ns1.opusdns.net", "ns2.opusdns.net"}, report.ZoneNameservers)
+		require.Len(t, report.Nameservers, 2)
+		assert.Equal(t, []string{"192.0.2.1"}, report.Nameservers[0].Addresses)
+	})
+
+	t.Run("reports mismatches, lame servers and missing glue", func(t *testing.T) {
+		useDelegationResolver(t, fakeDelegationResolver{
+			hosts: map[string][]string{"ns2.example.net": {"198.51.100.2"}},
+			served: map[string][]string{
+				"198.51.100.1": {"ns1.example.com", "ns2.example.net", "ns3.example.net"},
+			},
+		})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/domains/example.com":
+				_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: []models.Nameserver{
+					{Hostname: "ns1.example.com", IPAddresses: []string{"198.51.100.1"}},
+					{Hostname: "ns2.example.net"},
+					{Hostname: "ns0.example.com"},
+				}})
+			case "/v1/dns/example.com":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		report, err := client.Domains.CheckDelegation(context.Background(), "example.com")
+		require.NoError(t, err)
+
+		assert.False(t, report.OK())
+		assert.False(t, report.Hosted)
+
+		kinds := map[string][]DelegationIssueKind{}
+		for _, issue := range report.Issues {
+			kinds[issue.Nameserver] = append(kinds[issue.Nameserver], issue.Kind)
+		}
+		assert.Equal(t, []DelegationIssueKind{DelegationIssueMissingGlue, DelegationIssueLame}, kinds["ns0.example.com"])
+		assert.Equal(t, []DelegationIssueKind{DelegationIssueMismatch}, kinds["ns1.example.com"])
+		assert.Equal(t, []DelegationIssueKind{DelegationIssueLame}, kinds["ns2.example.net"])
+	})
+}
@@ -0,0 +1,436 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Default settings for DomainsService.EnableDNSSECAndPublishDS.
+const (
+	DefaultDNSSECKeyTimeout     = 10 * time.Minute
+	DefaultDNSSECVerifyTimeout  = time.Hour
+	DefaultDNSSECVerifyInterval = time.Minute
+
+	// DefaultDNSSECResolverURL is Cloudflare's public validating
+	// DNS-over-HTTPS resolver (JSON API). It is only used when passed as
+	// DNSSECPublishOptions.ResolverURL.
+	DefaultDNSSECResolverURL = "https://cloudflare-dns.com/dns-query"
+)
+
+// DNSSECPublishStep identifies a step of DomainsService.EnableDNSSECAndPublishDS.
+type DNSSECPublishStep string
+
+const (
+	DNSSECPublishStepEnableSigning DNSSECPublishStep = "enable_signing"
+	DNSSECPublishStepWaitForKeys   DNSSECPublishStep = "wait_for_keys"
+	DNSSECPublishStepPublishDS     DNSSECPublishStep = "publish_ds"
+	DNSSECPublishStepVerifyChain   DNSSECPublishStep = "verify_chain"
+)
+
+// DNSSECPublishOptions configures DomainsService.EnableDNSSECAndPublishDS. Zero values use the defaults.
+type DNSSECPublishOptions struct {
+	// KeyTimeout bounds how long to wait for the zone to be signed.
+	KeyTimeout time.Duration
+
+	// VerifyTimeout bounds how long the chain of trust is re-checked before
+	// the workflow fails, typically longer than the parent's DS TTL.
+	VerifyTimeout time.Duration
+
+	// VerifyInterval is the time between chain checks.
+	VerifyInterval time.Duration
+
+	// Resolver is the validating resolver used to check the chain of trust.
+	// If nil, ResolverURL is queried.
+	Resolver DNSSECResolver
+
+	// ResolverURL is the validating DNS-over-HTTPS resolver (JSON API)
+	// queried with the client's HTTP client when Resolver is nil. The domain
+	// name is sent to it, so no resolver is chosen by default: set Resolver,
+	// ResolverURL (such as DefaultDNSSECResolverURL) or SkipVerify.
+	ResolverURL string
+
+	// SkipVerify stops after the DS records were published.
+	SkipVerify bool
+
+	// UseCDSScanning leaves the DS update to the registry when the domain's
+	// TLD scans CDS records (models.TLD.CDSScanningSupported) and the zone
+	// publishes them. Verification then waits for the registry's next scan,
+	// so allow a VerifyTimeout of a day or more. Otherwise the DS records are
+	// pushed as usual.
+	UseCDSScanning bool
+}
+
+// DNSSECPublishStepResult is the outcome of one step of the workflow.
+type DNSSECPublishStepResult struct {
+	// Step is the step.
+	Step DNSSECPublishStep `json:"step"`
+
+	// Detail describes what the step did or found.
+	Detail string `json:"detail,omitempty"`
+
+	// Error is set if the step failed.
+	Error string `json:"error,omitempty"`
+}
+
+// DNSSECPublishReport is the result of DomainsService.EnableDNSSECAndPublishDS.
+type DNSSECPublishReport struct {
+	// Domain is the domain name.
+	Domain string `json:"domain"`
+
+	// Steps lists the steps run, in order.
+	Steps []DNSSECPublishStepResult `json:"steps"`
+
+	// DSRecords are the DS records published at the registry.
+	DSRecords []models.DSRecord `json:"ds_records,omitempty"`
+
+	// CDSScanning reports whether the DS update was left to the registry's
+	// scanning of the zone's CDS records.
+	CDSScanning bool `json:"cds_scanning,omitempty"`
+
+	// Validated reports whether a validating resolver authenticated the
+	// domain's DNSKEY set with the published DS records.
+	Validated bool `json:"validated"`
+}
+
+// DNSSECResolver queries a validating resolver for EnableDNSSECAndPublishDS.
+type DNSSECResolver interface {
+	// Query returns the rdata of the answers for name and type, and whether
+	// the resolver authenticated them.
+	Query(ctx context.Context, name, rrtype string) (answers []string, authenticated bool, err error)
+}
+
+// dohResolver queries a DNS-over-HTTPS resolver with the JSON API.
+type dohResolver struct {
+	client *http.Client
+	url    string
+}
+
+// Query implements DNSSECResolver.
+func (r dohResolver) Query(ctx context.Context, name, rrtype string) ([]string, bool, error) {
+	u, err := url.Parse(r.url)
+	if err != nil {
+		return nil, false, err
+	}
+	u.RawQuery = url.Values{"name": {name}, "type": {rrtype}, "do": {"1"}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("resolver returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Status int  `json:"Status"`
+		AD     bool `json:"AD"`
+		Answer []struct {
+			Type int    `json:"type"`
+			Data string `json:"data"`
+		} `json:"Answer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, false, err
+	}
+	if result.Status != 0 {
+		// SERVFAIL (2) is what a validating resolver answers for a broken chain.
+		return nil, false, fmt.Errorf("resolver returned DNS status %d", result.Status)
+	}
+
+	var answers []string
+	for _, a := range result.Answer {
+		if strings.EqualFold(dnsTypeName(a.Type), rrtype) {
+			answers = append(answers, a.Data)
+		}
+	}
+	return answers, result.AD, nil
+}
+
+// dnsTypeName names the record types dohResolver is queried for.
+func dnsTypeName(t int) string {
+	switch t {
+	case 43:
+		return "DS"
+	case 48:
+		return "DNSKEY"
+	}
+	return fmt.Sprint(t)
+}
+
+// EnableDNSSECAndPublishDS turns on DNSSEC for a domain hosted at OpusDNS,
+// following a fixed runbook:
+//
+//  1. enable signing of the domain's zone, unless it is already signed;
+//  2. wait with DNSService.WaitForDNSSEC until the key signing keys are published;
+//  3. replace the domain's DNSSEC data at the registry with SHA-256 DS records
+//     of those keys, or, with UseCDSScanning, leave that to a registry that
+//     scans the zone's CDS records;
+//  4. re-check through a validating resolver until the parent serves the DS
+//     records and the zone's DNSKEY set validates.
+//
+// Completed steps are not undone on failure, as removing keys or DS records
+// of a zone being validated would break resolution. The report lists every
+// step run; the error is a *DNSSECPublishError naming the failed step.
+func (s *DomainsService) EnableDNSSECAndPublishDS(ctx context.Context, domainName string, opts *DNSSECPublishOptions) (*DNSSECPublishReport, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domainName), "."))
+	if domainName == "" {
+		return nil, &ValidationError{Field: "domain", Message: "domain name is required"}
+	}
+
+	o := DNSSECPublishOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.KeyTimeout <= 0 {
+		o.KeyTimeout = DefaultDNSSECKeyTimeout
+	}
+	if o.VerifyTimeout <= 0 {
+		o.VerifyTimeout = DefaultDNSSECVerifyTimeout
+	}
+	if o.VerifyInterval <= 0 {
+		o.VerifyInterval = DefaultDNSSECVerifyInterval
+	}
+	if o.Resolver == nil && !o.SkipVerify {
+		if o.ResolverURL == "" {
+			return nil, &ValidationError{Field: "resolver_url", Message: "a resolver is required to verify the chain of trust; set Resolver, ResolverURL or SkipVerify"}
+		}
+		o.Resolver = dohResolver{client: s.client.http.httpClient, url: o.ResolverURL}
+	}
+
+	report := &DNSSECPublishReport{Domain: domainName}
+	p := &dnssecPublish{service: s, opts: o, report: report}
+
+	var zone *models.Zone
+	steps := []struct {
+		step DNSSECPublishStep
+		fn   func() (string, error)
+	}{
+		{DNSSECPublishStepEnableSigning, func() (string, error) { return p.enableSigning(ctx) }},
+		{DNSSECPublishStepWaitForKeys, func() (detail string, err error) {
+			zone, detail, err = p.waitForKeys(ctx)
+			return detail, err
+		}},
+		{DNSSECPublishStepPublishDS, func() (string, error) { return p.publishDS(ctx, zone) }},
+		{DNSSECPublishStepVerifyChain, func() (string, error) { return p.verify(ctx) }},
+	}
+	if o.SkipVerify {
+		steps = steps[:len(steps)-1]
+	}
+
+	for _, step := range steps {
+		detail, err := step.fn()
+		result := DNSSECPublishStepResult{Step: step.step, Detail: detail}
+		if err != nil {
+			result.Error = err.Error()
+			report.Steps = append(report.Steps, result)
+			return report, &DNSSECPublishError{Step: step.step, Err: err}
+		}
+		report.Steps = append(report.Steps, result)
+	}
+
+	return report, nil
+}
+
+// dnssecPublish holds the state of a single EnableDNSSECAndPublishDS call.
+type dnssecPublish struct {
+	service *DomainsService
+	opts    DNSSECPublishOptions
+	report  *DNSSECPublishReport
+}
+
+// enableSigning enables DNSSEC for the zone unless it is already enabled.
+func (p *dnssecPublish) enableSigning(ctx context.Context) (string, error) {
+	zone, err := p.service.client.DNS.GetZone(ctx, p.report.Domain)
+	if err != nil {
+		return "", err
+	}
+	if zone.DNSSECStatus == models.DNSSECStatusEnabled {
+		return "already enabled", nil
+	}
+
+	if _, err := p.service.client.DNS.EnableDNSSEC(ctx, p.report.Domain); err != nil {
+		return "", err
+	}
+	return "enabled", nil
+}
+
+// waitForKeys waits until the zone publishes its key signing keys.
+func (p *dnssecPublish) waitForKeys(ctx context.Context) (*models.Zone, string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, p.opts.KeyTimeout)
+	defer cancel()
+
+	zone, err := p.service.client.DNS.WaitForDNSSEC(waitCtx, p.report.Domain, models.DNSSECStatusEnabled)
+	if err != nil {
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			return nil, "", fmt.Errorf("zone not signed after %s: %w", p.opts.KeyTimeout, err)
+		}
+		return nil, "", err
+	}
+	keys, err := ZoneKeySigningKeys(zone)
+	if err != nil {
+		return nil, "", err
+	}
+	return zone, fmt.Sprintf("%d key signing key(s)", len(keys)), nil
+}
+
+// publishDS replaces the domain's DNSSEC data at the registry with the DS
+// records of the zone's key signing keys, unless it is left to CDS scanning.
+func (p *dnssecPublish) publishDS(ctx context.Context, zone *models.Zone) (string, error) {
+	var note string
+	if p.opts.UseCDSScanning {
+		detail, scanned, err := p.useCDSScanning(ctx, zone)
+		if err != nil || scanned {
+			return detail, err
+		}
+		note = detail + "; "
+	}
+
+	keys, err := ZoneKeySigningKeys(zone)
+	if err != nil {
+		return "", err
+	}
+
+	var data []models.DomainDNSSECDataCreate
+	for _, key := range keys {
+		ds, err := key.DS(p.report.Domain)
+		if err != nil {
+			return "", err
+		}
+		p.report.DSRecords = append(p.report.DSRecords, ds)
+		data = append(data, ds.DNSSECData())
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("zone %s publishes no key signing key", zone.Name)
+	}
+
+	if _, err := p.service.PutDNSSEC(ctx, p.report.Domain, data); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%spushed %d DS record(s)", note, len(data)), nil
+}
+
+// useCDSScanning leaves the DS update to the registry if the zone publishes
+// CDS records and the domain's TLD scans them, and reports whether it did;
+// otherwise detail says why not.
+func (p *dnssecPublish) useCDSScanning(ctx context.Context, zone *models.Zone) (detail string, scanned bool, err error) {
+	cds, err := ZoneCDSRecords(zone)
+	if err != nil {
+		return "", false, err
+	}
+	if len(cds) == 0 {
+		return "zone publishes no CDS records", false, nil
+	}
+	for _, ds := range cds {
+		if ds.IsDeleteSignal() {
+			return "", false, fmt.Errorf("zone %s publishes a CDS delete signal", zone.Name)
+		}
+	}
+
+	tld, err := p.domainTLD(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if tld == nil || !tld.CDSScanningSupported {
+		return "registry does not scan CDS records", false, nil
+	}
+
+	p.report.DSRecords = cds
+	p.report.CDSScanning = true
+	return fmt.Sprintf("left %d CDS record(s) to the .%s registry's scanning", len(cds), tld.Name), true, nil
+}
+
+// domainTLD returns the details of the domain's TLD, trying the longest
+// suffix first so TLDs such as "co.uk" are found, or nil if none is known.
+func (p *dnssecPublish) domainTLD(ctx context.Context) (*models.TLDDetails, error) {
+	labels := strings.Split(p.report.Domain, ".")
+	for i := 1; i < len(labels); i++ {
+		name := strings.Join(labels[i:], ".")
+		details, err := p.service.client.TLDs.GetTLD(ctx, name)
+		if IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if details.Name == "" {
+			details.Name = name
+		}
+		return details, nil
+	}
+	return nil, nil
+}
+
+// verify re-checks the chain of trust until it validates or VerifyTimeout passes.
+func (p *dnssecPublish) verify(ctx context.Context) (string, error) {
+	clock := p.service.client.http.clock
+	deadline := clock.Now().Add(p.opts.VerifyTimeout)
+
+	for {
+		problem := p.checkChain(ctx)
+		if problem == "" {
+			p.report.Validated = true
+			return "chain of trust validates", nil
+		}
+
+		if clock.Now().Add(p.opts.VerifyInterval).After(deadline) {
+			return "", fmt.Errorf("chain of trust not valid after %s: %s", p.opts.VerifyTimeout, problem)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-clock.After(p.opts.VerifyInterval):
+		}
+	}
+}
+
+// checkChain describes why the chain of trust does not validate yet, or
+// returns "" if it does.
+func (p *dnssecPublish) checkChain(ctx context.Context) string {
+	answers, _, err := p.opts.Resolver.Query(ctx, p.report.Domain, "DS")
+	if err != nil {
+		return fmt.Sprintf("DS lookup failed: %v", err)
+	}
+
+	var served []models.DSRecord
+	for _, rdata := range answers {
+		if ds, err := models.ParseDSRecord(rdata); err == nil {
+			served = append(served, ds)
+		}
+	}
+	for _, want := range p.report.DSRecords {
+		found := false
+		for _, ds := range served {
+			if ds.Equal(want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("parent does not serve DS %s yet", want)
+		}
+	}
+
+	_, authenticated, err := p.opts.Resolver.Query(ctx, p.report.Domain, "DNSKEY")
+	if err != nil {
+		return fmt.Sprintf("DNSKEY lookup failed: %v", err)
+	}
+	if !authenticated {
+		return "resolver did not authenticate the DNSKEY set"
+	}
+	return ""
+}
@@ -0,0 +1,230 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDNSSECResolver serves the DS answers in ds and authenticates DNSKEY
+// lookups from the authenticateFrom-th one on.
+type fakeDNSSECResolver struct {
+	mu               sync.Mutex
+	ds               []string
+	authenticateFrom int
+	dnskeyQueries    int
+}
+
+func (f *fakeDNSSECResolver) Query(_ context.Context, _, rrtype string) ([]string, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if rrtype == "DS" {
+		return f.ds, false, nil
+	}
+	f.dnskeyQueries++
+	return nil, f.authenticateFrom > 0 && f.dnskeyQueries >= f.authenticateFrom, nil
+}
+
+func TestDNSKEYRecord_DS(t *testing.T) {
+	// RFC 4509, section 2.3
+	key, err := models.ParseDNSKEYRecord(strings.Replace(testKSK, "257", "256", 1))
+	require.NoError(t, err)
+	assert.False(t, key.IsKSK())
+
+	ds, err := key.DS("dskey.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", ds.String())
+
+	parsed, err := models.ParseDSRecord("60485 5 2 d4b7d520e7bb5f0f67674a0cceb1e3e0614b93c4f9e99b8383f6a1e4469da50a")
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(ds))
+}
+
+func TestDomainsService_EnableDNSSECAndPublishDS(t *testing.T) {
+	var mu sync.Mutex
+	enabled := false
+	var published []models.DomainDNSSECDataCreate
+	resolver := &fakeDNSSECResolver{authenticateFrom: 2}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+			zone := models.Zone{Name: "example.com", DNSSECStatus: models.DNSSECStatusDisabled}
+			if enabled {
+				zone.DNSSECStatus = models.DNSSECStatusEnabled
+				zone.RRSets = []models.RRSet{{Name: "@", Type: models.RRSetTypeDNSKEY, Records: []models.RecordData{{RData: testKSK}}}}
+			}
+			_ = json.NewEncoder(w).Encode(zone)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/dns/example.com/dnssec/enable":
+			enabled = true
+			_ = json.NewEncoder(w).Encode(models.DNSChanges{ZoneName: "example.com"})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/domains/example.com/dnssec":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&published))
+			resolver.mu.Lock()
+			resolver.ds = []string{"60486 5 2 " + strings.ToLower(resolverDigest(t))}
+			resolver.mu.Unlock()
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithClock(clock))
+	require.NoError(t, err)
+
+	report, err := client.Domains.EnableDNSSECAndPublishDS(context.Background(), "Example.com.", &DNSSECPublishOptions{Resolver: resolver})
+	require.NoError(t, err)
+	assert.True(t, report.Validated)
+	// The first chain check failed; the second one validated.
+	assert.Equal(t, DefaultDNSSECVerifyInterval, clock.waits[len(clock.waits)-1])
+
+	steps := make([]DNSSECPublishStep, len(report.Steps))
+	for i, s := range report.Steps {
+		steps[i] = s.Step
+		assert.Empty(t, s.Error)
+	}
+	assert.Equal(t, []DNSSECPublishStep{
+		DNSSECPublishStepEnableSigning, DNSSECPublishStepWaitForKeys, DNSSECPublishStepPublishDS, DNSSECPublishStepVerifyChain,
+	}, steps)
+
+	require.Len(t, report.DSRecords, 1)
+	assert.Equal(t, 60486, report.DSRecords[0].KeyTag)
+	require.Len(t, published, 1)
+	assert.Equal(t, models.DNSSECRecordTypeDSData, published[0].RecordType)
+	assert.Equal(t, report.DSRecords[0].Digest, *published[0].Digest)
+
+	t.Run("verification times out", func(t *testing.T) {
+		// The parent never serves the DS records.
+		report, err := client.Domains.EnableDNSSECAndPublishDS(context.Background(), "example.com", &DNSSECPublishOptions{
+			VerifyTimeout: 5 * time.Minute,
+			Resolver:      &fakeDNSSECResolver{},
+		})
+		var publishErr *DNSSECPublishError
+		require.ErrorAs(t, err, &publishErr)
+		assert.Equal(t, DNSSECPublishStepVerifyChain, publishErr.Step)
+		assert.Contains(t, err.Error(), "parent does not serve DS 60486 5 2")
+		assert.Equal(t, "already enabled", report.Steps[0].Detail)
+		assert.False(t, report.Validated)
+	})
+
+	t.Run("requires a resolver", func(t *testing.T) {
+		_, err := client.Domains.EnableDNSSECAndPublishDS(context.Background(), "example.com", nil)
+		assert.True(t, IsValidationError(err))
+	})
+}
+
+func TestDOHResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dns-query", r.URL.Path)
+		assert.Equal(t, "application/dns-json", r.Header.Get("Accept"))
+		assert.Equal(t, "example.com", r.URL.Query().Get("name"))
+		assert.Equal(t, "DS", r.URL.Query().Get("type"))
+		_, _ = w.Write([]byte(`{"Status": 0, "AD": true, "Answer": [
+			{"type": 46, "data": "DS 13 2 ..."},
+			{"type": 43, "data": "60486 5 2 ABCD"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"))
+	require.NoError(t, err)
+	resolver := dohResolver{client: client.http.httpClient, url: server.URL + "/dns-query"}
+
+	answers, authenticated, err := resolver.Query(context.Background(), "example.com", "DS")
+	require.NoError(t, err)
+	assert.True(t, authenticated)
+	assert.Equal(t, []string{"60486 5 2 ABCD"}, answers)
+}
+
+// resolverDigest returns the SHA-256 DS digest of testKSK for example.com.
+func resolverDigest(t *testing.T) string {
+	key, err := models.ParseDNSKEYRecord(testKSK)
+	require.NoError(t, err)
+	ds, err := key.DS("example.com")
+	require.NoError(t, err)
+	return ds.Digest
+}
+
+func TestDomainsService_EnableDNSSECAndPublishDS_CDSScanning(t *testing.T) {
+	cds := "60486 5 2 " + resolverDigest(t)
+
+	tests := []struct {
+		name        string
+		scanned     bool
+		wantPush    bool
+		wantDetail  string
+		zoneRecords []models.RRSet
+	}{
+		{
+			name:       "registry scans CDS",
+			scanned:    true,
+			wantDetail: "left 1 CDS record(s) to the .co.uk registry's scanning",
+			zoneRecords: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: cds}}},
+			},
+		},
+		{
+			name:       "registry does not scan CDS",
+			wantPush:   true,
+			wantDetail: "registry does not scan CDS records; pushed 1 DS record(s)",
+			zoneRecords: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeCDS, Records: []models.RecordData{{RData: cds}}},
+			},
+		},
+		{
+			name:       "zone publishes no CDS",
+			scanned:    true,
+			wantPush:   true,
+			wantDetail: "zone publishes no CDS records; pushed 1 DS record(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pushed := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/dns/example.co.uk":
+					rrsets := append([]models.RRSet{{Name: "@", Type: models.RRSetTypeDNSKEY, Records: []models.RecordData{{RData: testKSK}}}}, tt.zoneRecords...)
+					_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.co.uk", DNSSECStatus: models.DNSSECStatusEnabled, RRSets: rrsets})
+				case "/v1/tlds/co.uk":
+					_ = json.NewEncoder(w).Encode(models.TLDDetails{TLD: models.TLD{Name: "co.uk", CDSScanningSupported: tt.scanned}})
+				case "/v1/domains/example.co.uk/dnssec":
+					pushed = true
+					_, _ = w.Write([]byte(`[]`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+			require.NoError(t, err)
+
+			report, err := client.Domains.EnableDNSSECAndPublishDS(context.Background(), "example.co.uk", &DNSSECPublishOptions{UseCDSScanning: true, SkipVerify: true})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPush, pushed)
+			assert.Equal(t, !tt.wantPush, report.CDSScanning)
+			require.Len(t, report.Steps, 3)
+			assert.Equal(t, tt.wantDetail, report.Steps[2].Detail)
+			require.Len(t, report.DSRecords, 1)
+			assert.Equal(t, 60486, report.DSRecords[0].KeyTag)
+		})
+	}
+}
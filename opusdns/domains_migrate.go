@@ -0,0 +1,299 @@
+package opusdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Default settings for DomainsService.MigrateNameservers.
+const (
+	DefaultMigrationTTL            = 300
+	DefaultMigrationVerifyTimeout  = 10 * time.Minute
+	DefaultMigrationVerifyInterval = 30 * time.Second
+)
+
+// NSMigrationStep identifies a step of DomainsService.MigrateNameservers.
+type NSMigrationStep string
+
+const (
+	NSMigrationStepCheckZone         NSMigrationStep = "check_zone"
+	NSMigrationStepLowerTTLs         NSMigrationStep = "lower_ttls"
+	NSMigrationStepUpdateNameservers NSMigrationStep = "update_nameservers"
+	NSMigrationStepVerifyDelegation  NSMigrationStep = "verify_delegation"
+	NSMigrationStepRestoreTTLs       NSMigrationStep = "restore_ttls"
+)
+
+// NSMigrationOptions configures DomainsService.MigrateNameservers. Zero values use the defaults.
+type NSMigrationOptions struct {
+	// AllowEmptyZone skips the check that the destination zone holds records
+	// other than the apex NS and SOA.
+	AllowEmptyZone bool
+
+	// MigrationTTL is the TTL, in seconds, set on the zone's RRsets during the migration.
+	MigrationTTL int
+
+	// WaitAfterLowering is how long to wait after lowering TTLs before the
+	// registry delegation is changed, so resolvers drop the records cached
+	// with the old TTLs. Zero waits for the longest lowered TTL; a negative
+	// value does not wait.
+	WaitAfterLowering time.Duration
+
+	// VerifyTimeout bounds how long delegation is re-checked before the migration fails.
+	VerifyTimeout time.Duration
+
+	// VerifyInterval is the time between delegation checks.
+	VerifyInterval time.Duration
+
+	// NoRollback leaves the registry delegation and lowered TTLs in place on failure.
+	NoRollback bool
+
+	// Checkpoint is called with the migration state after every completed step,
+	// so callers can persist progress or log it (optional).
+	Checkpoint func(NSMigrationState)
+}
+
+// NSMigrationState records the progress of a nameserver migration.
+type NSMigrationState struct {
+	// Domain is the migrated domain name.
+	Domain string `json:"domain"`
+
+	// PreviousNameservers is the delegation before the migration.
+	PreviousNameservers []models.Nameserver `json:"previous_nameservers"`
+
+	// Nameservers is the target delegation.
+	Nameservers []models.Nameserver `json:"nameservers"`
+
+	// OriginalTTLs maps "<name> <type>" of each lowered RRset to its original TTL.
+	OriginalTTLs map[string]int `json:"original_ttls,omitempty"`
+
+	// Completed lists the completed steps in order.
+	Completed []NSMigrationStep `json:"completed"`
+
+	// Report is the last delegation report, once verification has run.
+	Report *DelegationReport `json:"report,omitempty"`
+}
+
+// MigrateNameservers moves a domain's delegation to newNS without downtime,
+// following a fixed runbook:
+//
+//  1. check that the OpusDNS zone for the domain exists and is populated;
+//  2. lower the TTL of the zone's RRsets to MigrationTTL and wait for the
+//     old TTLs to run out;
+//  3. update the nameservers at the registry;
+//  4. verify the delegation with CheckDelegation until it is consistent;
+//  5. restore the original TTLs.
+//
+// If a step after the TTLs were lowered fails, the previous registry
+// delegation and the original TTLs are restored unless NoRollback is set. The
+// returned state reflects the completed steps, and the error is a
+// *NSMigrationError naming the failed step.
+func (s *DomainsService) MigrateNameservers(ctx context.Context, domainRef string, newNS []models.Nameserver, opts *NSMigrationOptions) (*NSMigrationState, error) {
+	if len(newNS) == 0 {
+		return nil, &ValidationError{Field: "newNS", Message: "at least one nameserver is required"}
+	}
+
+	o := NSMigrationOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MigrationTTL <= 0 {
+		o.MigrationTTL = DefaultMigrationTTL
+	}
+	if o.VerifyTimeout <= 0 {
+		o.VerifyTimeout = DefaultMigrationVerifyTimeout
+	}
+	if o.VerifyInterval <= 0 {
+		o.VerifyInterval = DefaultMigrationVerifyInterval
+	}
+
+	domain, err := s.GetDomain(ctx, domainRef)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &NSMigrationState{
+		Domain:              domain.Name,
+		PreviousNameservers: domain.Nameservers,
+		Nameservers:         newNS,
+		OriginalTTLs:        map[string]int{},
+	}
+	m := &nsMigration{service: s, opts: o, state: state}
+
+	var zone *models.Zone
+	steps := []struct {
+		step NSMigrationStep
+		fn   func() error
+	}{
+		{NSMigrationStepCheckZone, func() error {
+			zone, err = m.checkZone(ctx)
+			return err
+		}},
+		{NSMigrationStepLowerTTLs, func() error { return m.lowerTTLs(ctx, zone) }},
+		{NSMigrationStepUpdateNameservers, func() error { return m.setNameservers(ctx, newNS) }},
+		{NSMigrationStepVerifyDelegation, func() error { return m.verify(ctx) }},
+		{NSMigrationStepRestoreTTLs, func() error { return m.restoreTTLs(ctx, zone) }},
+	}
+
+	for _, step := range steps {
+		if err := step.fn(); err != nil {
+			return state, m.fail(ctx, zone, step.step, err)
+		}
+		state.Completed = append(state.Completed, step.step)
+		if o.Checkpoint != nil {
+			o.Checkpoint(*state)
+		}
+	}
+
+	return state, nil
+}
+
+// nsMigration holds the state of a single MigrateNameservers call.
+type nsMigration struct {
+	service *DomainsService
+	opts    NSMigrationOptions
+	state   *NSMigrationState
+}
+
+// checkZone fetches the destination zone and checks that it is populated.
+func (m *nsMigration) checkZone(ctx context.Context) (*models.Zone, error) {
+	zone, err := m.service.client.DNS.GetZone(ctx, m.state.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.opts.AllowEmptyZone {
+		populated := false
+		for _, rrset := range zone.RRSets {
+			if !isApexInfrastructure(rrset) {
+				populated = true
+				break
+			}
+		}
+		if !populated {
+			return nil, &ValidationError{Field: "zone", Message: "destination zone has no records besides apex NS and SOA", Value: zone.Name}
+		}
+	}
+
+	return zone, nil
+}
+
+// lowerTTLs sets MigrationTTL on every RRset with a higher TTL and waits
+// WaitAfterLowering, by default the longest lowered TTL.
+func (m *nsMigration) lowerTTLs(ctx context.Context, zone *models.Zone) error {
+	var ops []models.RRSetPatchOp
+	longest := 0
+	for _, rrset := range zone.RRSets {
+		if rrset.Type == models.RRSetTypeSOA || rrset.TTL <= m.opts.MigrationTTL {
+			continue
+		}
+		m.state.OriginalTTLs[rrsetKey(rrset)] = rrset.TTL
+		longest = max(longest, rrset.TTL)
+		ops = append(ops, upsertRRSetWithTTL(rrset, m.opts.MigrationTTL))
+	}
+
+	if len(ops) > 0 {
+		if err := m.service.client.DNS.PatchRRSets(ctx, zone.Name, ops); err != nil {
+			return err
+		}
+	}
+
+	wait := m.opts.WaitAfterLowering
+	if wait == 0 {
+		wait = time.Duration(longest) * time.Second
+	}
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.service.client.http.clock.After(wait):
+		}
+	}
+
+	return nil
+}
+
+// restoreTTLs puts back the TTLs recorded by lowerTTLs.
+func (m *nsMigration) restoreTTLs(ctx context.Context, zone *models.Zone) error {
+	var ops []models.RRSetPatchOp
+	for _, rrset := range zone.RRSets {
+		if ttl, ok := m.state.OriginalTTLs[rrsetKey(rrset)]; ok {
+			ops = append(ops, upsertRRSetWithTTL(rrset, ttl))
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	return m.service.client.DNS.PatchRRSets(ctx, zone.Name, ops)
+}
+
+// setNameservers updates the registry delegation.
+func (m *nsMigration) setNameservers(ctx context.Context, nameservers []models.Nameserver) error {
+	_, err := m.service.UpdateDomain(ctx, m.state.Domain, &models.DomainUpdateRequest{Nameservers: nameservers})
+	return err
+}
+
+// verify re-checks the delegation until it is consistent or VerifyTimeout passes.
+func (m *nsMigration) verify(ctx context.Context) error {
+	report, err := m.service.waitForDelegation(ctx, m.service.client.http.clock, m.state.Domain, m.opts.VerifyTimeout, m.opts.VerifyInterval)
+	if report != nil {
+		m.state.Report = report
+	}
+	return err
+}
+
+// fail rolls back completed steps (unless disabled) and builds the migration error.
+func (m *nsMigration) fail(ctx context.Context, zone *models.Zone, step NSMigrationStep, err error) error {
+	migErr := &NSMigrationError{Step: step, Err: err}
+	if m.opts.NoRollback || step == NSMigrationStepCheckZone || step == NSMigrationStepRestoreTTLs {
+		return migErr
+	}
+
+	// Roll back with a fresh context so a cancelled ctx does not strand the domain half-migrated.
+	rollbackCtx := context.WithoutCancel(ctx)
+
+	var rollbackErrs []error
+	if step == NSMigrationStepVerifyDelegation {
+		if err := m.setNameservers(rollbackCtx, m.state.PreviousNameservers); err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Errorf("restore nameservers: %w", err))
+		}
+	}
+	if zone != nil {
+		if err := m.restoreTTLs(rollbackCtx, zone); err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Errorf("restore TTLs: %w", err))
+		}
+	}
+
+	migErr.RolledBack = len(rollbackErrs) == 0
+	migErr.RollbackErr = errors.Join(rollbackErrs...)
+	return migErr
+}
+
+// isApexInfrastructure reports whether rrset is the apex NS or SOA set created with every zone.
+func isApexInfrastructure(rrset models.RRSet) bool {
+	if rrset.Name != "@" && rrset.Name != "" {
+		return false
+	}
+	return rrset.Type == models.RRSetTypeNS || rrset.Type == models.RRSetTypeSOA
+}
+
+// rrsetKey identifies an RRset by name and type.
+func rrsetKey(rrset models.RRSet) string {
+	return rrset.Name + " " + string(rrset.Type)
+}
+
+// upsertRRSetWithTTL builds an upsert of rrset with its records unchanged and the given TTL.
+func upsertRRSetWithTTL(rrset models.RRSet, ttl int) models.RRSetPatchOp {
+	records := make([]models.RecordCreate, len(rrset.Records))
+	for i, r := range rrset.Records {
+		records[i] = models.RecordCreate{RData: r.RData}
+	}
+	return models.RRSetPatchOp{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: rrset.Name, Type: rrset.Type, TTL: ttl, Records: records},
+	}
+}
@@ -0,0 +1,172 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migrationServer fakes the domain, zone and patch endpoints used by MigrateNameservers.
+type migrationServer struct {
+	mu      sync.Mutex
+	ns      []models.Nameserver
+	patches []models.RRSetPatchRequest
+	updates [][]models.Nameserver
+}
+
+func (m *migrationServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.com":
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: m.ns})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/domains/example.com":
+			var req models.DomainUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			m.ns = req.Nameservers
+			m.updates = append(m.updates, req.Nameservers)
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: m.ns})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeSOA, TTL: 3600, Records: []models.RecordData{{RData: "ns1.opusdns.net. hostmaster.example.com. 1 7200 900 1209600 3600"}}},
+				{Name: "@", Type: models.RRSetTypeNS, TTL: 86400, Records: []models.RecordData{{RData: "ns1.opusdns.net."}}},
+				{Name: "www", Type: models.RRSetTypeA, TTL: 3600, Records: []models.RecordData{{RData: "192.0.2.1"}}},
+				{Name: "api", Type: models.RRSetTypeA, TTL: 60, Records: []models.RecordData{{RData: "192.0.2.2"}}},
+			}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/rrsets":
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			m.patches = append(m.patches, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestDomainsService_MigrateNameservers(t *testing.T) {
+	newNS := []models.Nameserver{{Hostname: "ns1.opusdns.net"}}
+
+	t.Run("migrates and restores TTLs", func(t *testing.T) {
+		useDelegationResolver(t, fakeDelegationResolver{
+			hosts:  map[string][]string{"ns1.opusdns.net": {"192.0.2.53"}},
+			served: map[string][]string{"192.0.2.53": {"ns1.opusdns.net."}},
+		})
+
+		fake := &migrationServer{ns: []models.Nameserver{{Hostname: "ns1.oldhost.net"}}}
+		clock := &fakeClock{now: time.Now()}
+		client := newTestClient(t, fake.handler(t), WithClock(clock))
+
+		var checkpoints []NSMigrationStep
+		state, err := client.Domains.MigrateNameservers(context.Background(), "example.com", newNS, &NSMigrationOptions{
+			Checkpoint: func(s NSMigrationState) { checkpoints = append(checkpoints, s.Completed[len(s.Completed)-1]) },
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []NSMigrationStep{
+			NSMigrationStepCheckZone,
+			NSMigrationStepLowerTTLs,
+			NSMigrationStepUpdateNameservers,
+			NSMigrationStepVerifyDelegation,
+			NSMigrationStepRestoreTTLs,
+		}, checkpoints)
+		assert.Equal(t, map[string]int{"@ NS": 86400, "www A": 3600}, state.OriginalTTLs)
+		assert.Equal(t, []models.Nameserver{{Hostname: "ns1.oldhost.net"}}, state.PreviousNameservers)
+		require.NotNil(t, state.Report)
+		assert.True(t, state.Report.OK())
+
+		require.Len(t, fake.patches, 2)
+		require.Len(t, fake.patches[0].Ops, 2)
+		for _, op := range fake.patches[0].Ops {
+			assert.Equal(t, DefaultMigrationTTL, op.RRSet.TTL)
+		}
+		assert.Equal(t, 86400, fake.patches[1].Ops[0].RRSet.TTL)
+		assert.Equal(t, 3600, fake.patches[1].Ops[1].RRSet.TTL)
+		assert.Equal(t, [][]models.Nameserver{newNS}, fake.updates)
+		assert.Equal(t, []time.Duration{86400 * time.Second}, clock.waits, "waits for the longest lowered TTL")
+	})
+
+	t.Run("explicit wait after lowering", func(t *testing.T) {
+		useDelegationResolver(t, fakeDelegationResolver{
+			hosts:  map[string][]string{"ns1.opusdns.net": {"192.0.2.53"}},
+			served: map[string][]string{"192.0.2.53": {"ns1.opusdns.net."}},
+		})
+
+		for _, tc := range []struct {
+			wait  time.Duration
+			waits []time.Duration
+		}{
+			{wait: 10 * time.Minute, waits: []time.Duration{10 * time.Minute}},
+			{wait: -1, waits: nil},
+		} {
+			fake := &migrationServer{ns: []models.Nameserver{{Hostname: "ns1.oldhost.net"}}}
+			clock := &fakeClock{now: time.Now()}
+			client := newTestClient(t, fake.handler(t), WithClock(clock))
+
+			_, err := client.Domains.MigrateNameservers(context.Background(), "example.com", newNS, &NSMigrationOptions{WaitAfterLowering: tc.wait})
+			require.NoError(t, err)
+			assert.Equal(t, tc.waits, clock.waits)
+		}
+	})
+
+	t.Run("rolls back when delegation does not verify", func(t *testing.T) {
+		useDelegationResolver(t, fakeDelegationResolver{
+			hosts: map[string][]string{"ns1.opusdns.net": {"192.0.2.53"}},
+		})
+
+		oldNS := []models.Nameserver{{Hostname: "ns1.oldhost.net"}}
+		fake := &migrationServer{ns: oldNS}
+		clock := &fakeClock{now: time.Now()}
+		client := newTestClient(t, fake.handler(t), WithClock(clock))
+
+		state, err := client.Domains.MigrateNameservers(context.Background(), "example.com", newNS, &NSMigrationOptions{
+			VerifyTimeout:  time.Minute,
+			VerifyInterval: 20 * time.Second,
+		})
+		require.Error(t, err)
+
+		var migErr *NSMigrationError
+		require.True(t, errors.As(err, &migErr))
+		assert.Equal(t, NSMigrationStepVerifyDelegation, migErr.Step)
+		assert.True(t, migErr.RolledBack)
+		assert.NoError(t, migErr.RollbackErr)
+
+		assert.Equal(t, [][]models.Nameserver{newNS, oldNS}, fake.updates)
+		require.Len(t, fake.patches, 2)
+		assert.Equal(t, 86400, fake.patches[1].Ops[0].RRSet.TTL)
+		assert.Equal(t, []NSMigrationStep{NSMigrationStepCheckZone, NSMigrationStepLowerTTLs, NSMigrationStepUpdateNameservers}, state.Completed)
+		assert.Equal(t, []time.Duration{86400 * time.Second, 20 * time.Second, 20 * time.Second, 20 * time.Second}, clock.waits)
+	})
+
+	t.Run("refuses an empty zone", func(t *testing.T) {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/domains/example.com":
+				_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com"})
+			case "/v1/dns/example.com":
+				_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", RRSets: []models.RRSet{
+					{Name: "@", Type: models.RRSetTypeNS, TTL: 3600, Records: []models.RecordData{{RData: "ns1.opusdns.net."}}},
+				}})
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+
+		_, err := client.Domains.MigrateNameservers(context.Background(), "example.com", newNS, nil)
+		assert.True(t, IsValidationError(err))
+
+		var migErr *NSMigrationError
+		require.True(t, errors.As(err, &migErr))
+		assert.Equal(t, NSMigrationStepCheckZone, migErr.Step)
+	})
+}
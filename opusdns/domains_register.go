@@ -0,0 +1,288 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// RegistrationStep identifies a step of DomainsService.RegisterWithDefaults.
+type RegistrationStep string
+
+const (
+	RegistrationStepCheckAvailability RegistrationStep = "check_availability"
+	RegistrationStepResolveContacts   RegistrationStep = "resolve_contacts"
+	RegistrationStepRegisterDomain    RegistrationStep = "register_domain"
+	RegistrationStepCreateZone        RegistrationStep = "create_zone"
+	RegistrationStepSetRecords        RegistrationStep = "set_records"
+)
+
+// RegistrationProgress is reported to RegistrationProfile.Progress when a step
+// starts (Done is false) and when it finishes (Done is true, Err set on failure).
+type RegistrationProgress struct {
+	// Step is the step being reported.
+	Step RegistrationStep
+
+	// Done is false when the step starts and true when it finishes.
+	Done bool
+
+	// Err is the step's error, if it failed.
+	Err error
+}
+
+// RegistrationContact is one contact role of a RegistrationProfile: either an
+// existing ContactID or a Contact to look up or create.
+type RegistrationContact struct {
+	// ContactID uses an existing contact.
+	ContactID models.ContactID
+
+	// Contact is created (or reused, see RegistrationProfile.ReuseContactScore)
+	// when ContactID is empty. Roles sharing the same pointer share one contact.
+	Contact *models.ContactCreateRequest
+}
+
+// RegistrationProfile holds reusable defaults for RegisterWithDefaults.
+type RegistrationProfile struct {
+	// Contacts maps each contact role to its contact. A registrant is required.
+	Contacts map[models.DomainContactType]RegistrationContact
+
+	// ReuseContactScore, when greater than zero, reuses an existing contact whose
+	// ScoreContactMatch score is at least this value instead of creating a new one.
+	ReuseContactScore float64
+
+	// Period is the registration period (defaults to 1 year).
+	Period models.DomainPeriod
+
+	// RenewalMode is the renewal mode (defaults to renew).
+	RenewalMode models.RenewalMode
+
+	// Nameservers are the nameservers to delegate to (optional).
+	Nameservers []models.Nameserver
+
+	// CreateZone creates an OpusDNS zone for the domain after registration.
+	CreateZone bool
+
+	// Records are upserted into the zone when CreateZone is set. Names and
+	// values may use template variables (see RenderRecordTemplate); {{ .Domain }}
+	// is always available and resolves to the registered domain name.
+	Records []models.RRSetCreate
+
+	// Variables supplies additional template variables for Records.
+	Variables map[string]string
+
+	// Progress is called as each step starts and finishes (optional).
+	Progress func(RegistrationProgress)
+}
+
+// RegistrationResult describes what RegisterWithDefaults did. On failure it
+// holds everything completed before the failing step, so callers can resume or
+// clean up.
+type RegistrationResult struct {
+	// Contacts maps each contact role to the contact used.
+	Contacts map[models.DomainContactType]models.ContactID
+
+	// CreatedContacts lists contacts created by this call.
+	CreatedContacts []models.ContactID
+
+	// Domain is the registered domain.
+	Domain *models.Domain
+
+	// Zone is the created or existing zone, when CreateZone is set.
+	Zone *models.Zone
+}
+
+// RegisterWithDefaults registers a domain in one call using a reusable profile:
+// it checks availability, resolves or creates the profile's contacts, registers
+// the domain, and optionally creates its zone and upserts default records.
+// Record templates are rendered before any change is made. An existing zone is
+// reused. On failure the returned error is a *RegistrationError naming the
+// step, and the result reports what was already done.
+func (s *DomainsService) RegisterWithDefaults(ctx context.Context, name string, profile *RegistrationProfile) (*RegistrationResult, error) {
+	if profile == nil {
+		return nil, &ValidationError{Field: "profile", Message: "profile is required"}
+	}
+	if _, ok := profile.Contacts[models.DomainContactTypeRegistrant]; !ok {
+		return nil, &ValidationError{Field: "contacts", Message: "a registrant contact is required"}
+	}
+
+	var records []models.RRSetCreate
+	if profile.CreateZone && len(profile.Records) > 0 {
+		vars := make(map[string]string, len(profile.Variables)+1)
+		for k, v := range profile.Variables {
+			vars[k] = v
+		}
+		vars["Domain"] = name
+
+		var err error
+		if records, err = RenderRRSets(profile.Records, vars); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &RegistrationResult{Contacts: map[models.DomainContactType]models.ContactID{}}
+
+	run := func(step RegistrationStep, fn func() error) error {
+		if profile.Progress != nil {
+			profile.Progress(RegistrationProgress{Step: step})
+		}
+		err := fn()
+		if profile.Progress != nil {
+			profile.Progress(RegistrationProgress{Step: step, Done: true, Err: err})
+		}
+		if err != nil {
+			return &RegistrationError{Step: step, Err: err}
+		}
+		return nil
+	}
+
+	if err := run(RegistrationStepCheckAvailability, func() error {
+		availability, err := s.client.Availability.CheckSingleAvailability(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !availability.Status.IsAvailable() {
+			return fmt.Errorf("%w: %s (status %s)", ErrDomainUnavailable, name, availability.Status)
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	if err := run(RegistrationStepResolveContacts, func() error {
+		return s.resolveRegistrationContacts(ctx, profile, result)
+	}); err != nil {
+		return result, err
+	}
+
+	if err := run(RegistrationStepRegisterDomain, func() error {
+		req := &models.DomainCreateRequest{
+			Name:        name,
+			Contacts:    map[models.DomainContactType][]models.ContactHandle{},
+			RenewalMode: profile.RenewalMode,
+			Period:      profile.Period,
+			Nameservers: profile.Nameservers,
+		}
+		if req.RenewalMode == "" {
+			req.RenewalMode = models.RenewalModeRenew
+		}
+		if req.Period.Value == 0 {
+			req.Period = models.DomainPeriod{Value: 1, Unit: models.PeriodUnitYear}
+		}
+		for role, id := range result.Contacts {
+			req.Contacts[role] = []models.ContactHandle{{ContactID: id}}
+		}
+
+		domain, err := s.CreateDomain(ctx, req)
+		if err != nil {
+			return err
+		}
+		result.Domain = domain
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	if !profile.CreateZone {
+		return result, nil
+	}
+
+	if err := run(RegistrationStepCreateZone, func() error {
+		zone, err := s.client.DNS.CreateZone(ctx, &models.ZoneCreateRequest{Name: name})
+		if IsConflictError(err) {
+			zone, err = s.client.DNS.GetZone(ctx, name)
+		}
+		if err != nil {
+			return err
+		}
+		result.Zone = zone
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	if len(records) == 0 {
+		return result, nil
+	}
+
+	if err := run(RegistrationStepSetRecords, func() error {
+		ops := make([]models.RRSetPatchOp, len(records))
+		for i, rrset := range records {
+			ops[i] = models.RRSetPatchOp{
+				Op:    models.RecordOpUpsert,
+				RRSet: models.RRSetPatch{Name: rrset.Name, Type: rrset.Type, TTL: rrset.TTL, Records: rrset.Records},
+			}
+		}
+		return s.client.DNS.PatchRRSets(ctx, name, ops)
+	}); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// resolveRegistrationContacts fills result.Contacts from the profile, reusing
+// or creating contacts as configured. Roles sharing a request share a contact.
+func (s *DomainsService) resolveRegistrationContacts(ctx context.Context, profile *RegistrationProfile, result *RegistrationResult) error {
+	resolved := map[*models.ContactCreateRequest]models.ContactID{}
+
+	// Resolve in a stable order, registrant first, so repeated runs behave the same.
+	roles := make([]models.DomainContactType, 0, len(profile.Contacts))
+	for role := range profile.Contacts {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if (roles[i] == models.DomainContactTypeRegistrant) != (roles[j] == models.DomainContactTypeRegistrant) {
+			return roles[i] == models.DomainContactTypeRegistrant
+		}
+		return roles[i] < roles[j]
+	})
+
+	for _, role := range roles {
+		contact := profile.Contacts[role]
+		if contact.ContactID != "" {
+			result.Contacts[role] = contact.ContactID
+			continue
+		}
+		if contact.Contact == nil {
+			return &ValidationError{Field: "contacts." + string(role), Message: "either ContactID or Contact is required"}
+		}
+		if id, ok := resolved[contact.Contact]; ok {
+			result.Contacts[role] = id
+			continue
+		}
+
+		id, created, err := s.findOrCreateContact(ctx, contact.Contact, profile.ReuseContactScore)
+		if err != nil {
+			return err
+		}
+		if created {
+			result.CreatedContacts = append(result.CreatedContacts, id)
+		}
+		resolved[contact.Contact] = id
+		result.Contacts[role] = id
+	}
+
+	return nil
+}
+
+// findOrCreateContact reuses the best matching contact scoring at least
+// minScore (when minScore > 0) or creates a new one.
+func (s *DomainsService) findOrCreateContact(ctx context.Context, req *models.ContactCreateRequest, minScore float64) (models.ContactID, bool, error) {
+	if minScore > 0 {
+		matches, err := s.client.Contacts.FindMatching(ctx, *req)
+		if err != nil {
+			return "", false, err
+		}
+		if len(matches) > 0 && matches[0].Score >= minScore {
+			return matches[0].Contact.ContactID, false, nil
+		}
+	}
+
+	contact, err := s.client.Contacts.CreateContact(ctx, req)
+	if err != nil {
+		return "", false, err
+	}
+	return contact.ContactID, true, nil
+}
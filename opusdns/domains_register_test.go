@@ -0,0 +1,140 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsService_RegisterWithDefaults(t *testing.T) {
+	t.Run("registers domain with shared contact, zone and records", func(t *testing.T) {
+		var domainReq models.DomainCreateRequest
+		var patchReq models.RRSetPatchRequest
+		contactsCreated := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/availability":
+				assert.Equal(t, "example.com", r.URL.Query().Get("domains"))
+				_ = json.NewEncoder(w).Encode(models.AvailabilityResponse{Results: []models.DomainAvailability{
+					{Domain: "example.com", Status: models.AvailabilityStatusAvailable},
+				}})
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/contacts":
+				contactsCreated++
+				_ = json.NewEncoder(w).Encode(models.Contact{ContactID: "contact_1"})
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/domains":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&domainReq))
+				_ = json.NewEncoder(w).Encode(models.Domain{DomainID: "domain_1", Name: "example.com"})
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/dns":
+				w.WriteHeader(http.StatusConflict)
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+				_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com"})
+			case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/rrsets":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&patchReq))
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		owner := &models.ContactCreateRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.org"}
+		var steps []RegistrationStep
+
+		result, err := client.Domains.RegisterWithDefaults(context.Background(), "example.com", &RegistrationProfile{
+			Contacts: map[models.DomainContactType]RegistrationContact{
+				models.DomainContactTypeRegistrant: {Contact: owner},
+				models.DomainContactTypeAdmin:      {Contact: owner},
+				models.DomainContactTypeTech:       {ContactID: "contact_tech"},
+			},
+			CreateZone: true,
+			Records: []models.RRSetCreate{
+				{Name: "www", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordCreate{{RData: "{{ .Domain }}."}}},
+				{Name: "@", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordCreate{{RData: "{{ .WebIP }}"}}},
+			},
+			Variables: map[string]string{"WebIP": "192.0.2.1"},
+			Progress: func(p RegistrationProgress) {
+				if p.Done {
+					assert.NoError(t, p.Err)
+					steps = append(steps, p.Step)
+				}
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, contactsCreated)
+		assert.Equal(t, []models.ContactID{"contact_1"}, result.CreatedContacts)
+		assert.Equal(t, models.ContactID("contact_1"), result.Contacts[models.DomainContactTypeAdmin])
+		assert.Equal(t, models.ContactID("contact_tech"), result.Contacts[models.DomainContactTypeTech])
+
+		assert.Equal(t, models.RenewalModeRenew, domainReq.RenewalMode)
+		assert.Equal(t, models.DomainPeriod{Value: 1, Unit: models.PeriodUnitYear}, domainReq.Period)
+		assert.Equal(t, models.ContactID("contact_1"), domainReq.Contacts[models.DomainContactTypeRegistrant][0].ContactID)
+
+		require.NotNil(t, result.Zone)
+		require.Len(t, patchReq.Ops, 2)
+		assert.Equal(t, "example.com.", patchReq.Ops[0].RRSet.Records[0].RData)
+		assert.Equal(t, "192.0.2.1", patchReq.Ops[1].RRSet.Records[0].RData)
+
+		assert.Equal(t, []RegistrationStep{
+			RegistrationStepCheckAvailability,
+			RegistrationStepResolveContacts,
+			RegistrationStepRegisterDomain,
+			RegistrationStepCreateZone,
+			RegistrationStepSetRecords,
+		}, steps)
+	})
+
+	t.Run("stops when domain is unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/availability" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.AvailabilityResponse{Results: []models.DomainAvailability{
+				{Domain: "taken.com", Status: models.AvailabilityStatusUnavailable},
+			}})
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Domains.RegisterWithDefaults(context.Background(), "taken.com", &RegistrationProfile{
+			Contacts: map[models.DomainContactType]RegistrationContact{
+				models.DomainContactTypeRegistrant: {ContactID: "contact_1"},
+			},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrDomainUnavailable))
+
+		var regErr *RegistrationError
+		require.True(t, errors.As(err, &regErr))
+		assert.Equal(t, RegistrationStepCheckAvailability, regErr.Step)
+		assert.Nil(t, result.Domain)
+	})
+
+	t.Run("rejects unresolved template variables before any request", func(t *testing.T) {
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint("http://127.0.0.1:0"))
+		require.NoError(t, err)
+
+		_, err = client.Domains.RegisterWithDefaults(context.Background(), "example.com", &RegistrationProfile{
+			Contacts: map[models.DomainContactType]RegistrationContact{
+				models.DomainContactTypeRegistrant: {ContactID: "contact_1"},
+			},
+			CreateZone: true,
+			Records:    []models.RRSetCreate{{Name: "@", Type: models.RRSetTypeA, Records: []models.RecordCreate{{RData: "{{ .Missing }}"}}}},
+		})
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,357 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Default settings for DomainsService.SetupVanityNameservers.
+const (
+	DefaultVanityNSTTL            = 3600
+	DefaultVanityNSVerifyTimeout  = 10 * time.Minute
+	DefaultVanityNSVerifyInterval = 30 * time.Second
+)
+
+// VanityNSStep identifies a step of DomainsService.SetupVanityNameservers.
+type VanityNSStep string
+
+const (
+	VanityNSStepCreateHosts      VanityNSStep = "create_hosts"
+	VanityNSStepAddRecords       VanityNSStep = "add_address_records"
+	VanityNSStepBrandZone        VanityNSStep = "brand_zone"
+	VanityNSStepUpdateDelegation VanityNSStep = "update_delegation"
+	VanityNSStepVerifyDelegation VanityNSStep = "verify_delegation"
+)
+
+// VanityNSSetupOptions configures DomainsService.SetupVanityNameservers. Zero values use the defaults.
+type VanityNSSetupOptions struct {
+	// TTL is the TTL, in seconds, of the A and AAAA records of the nameservers.
+	TTL int
+
+	// VanitySetID is the vanity nameserver set whose apex NS and SOA brand the
+	// domain's zone. If nil, the active set listing exactly the nameserver
+	// hostnames is used, if any; otherwise the zone apex is left unchanged.
+	VanitySetID *models.VanityNameserverSetID
+
+	// VerifyTimeout bounds how long delegation is re-checked before the setup fails.
+	VerifyTimeout time.Duration
+
+	// VerifyInterval is the time between delegation checks.
+	VerifyInterval time.Duration
+
+	// SkipVerify stops after the delegation was updated.
+	SkipVerify bool
+
+	// NoRollback leaves the new delegation in place when verification fails.
+	NoRollback bool
+}
+
+// VanityNSStepResult is the outcome of one step of the setup.
+type VanityNSStepResult struct {
+	// Step is the step.
+	Step VanityNSStep `json:"step"`
+
+	// Detail describes what the step did or found.
+	Detail string `json:"detail,omitempty"`
+
+	// Error is set if the step failed.
+	Error string `json:"error,omitempty"`
+}
+
+// VanityNSSetupReport is the result of DomainsService.SetupVanityNameservers.
+type VanityNSSetupReport struct {
+	// Domain is the domain name.
+	Domain string `json:"domain"`
+
+	// PreviousNameservers is the delegation before the setup.
+	PreviousNameservers []models.Nameserver `json:"previous_nameservers"`
+
+	// Nameservers is the target delegation.
+	Nameservers []models.Nameserver `json:"nameservers"`
+
+	// Steps lists the steps run, in order.
+	Steps []VanityNSStepResult `json:"steps"`
+
+	// Delegation is the last delegation report, once verification has run.
+	Delegation *DelegationReport `json:"delegation,omitempty"`
+}
+
+// SetupVanityNameservers delegates a domain hosted at OpusDNS to nameservers
+// named after it, such as ns1.example.com, in one call:
+//
+//  1. create (or update) the glue host objects of the nameservers inside the domain at the registry;
+//  2. upsert their A and AAAA records in the domain's zone;
+//  3. brand the zone's apex NS and SOA with the matching vanity nameserver set;
+//  4. update the nameservers at the registry;
+//  5. verify the delegation with CheckDelegation until it is consistent.
+//
+// Nameservers inside the domain need IPAddresses, typically those of the
+// OpusDNS nameservers they stand in for; others are only delegated to. If
+// verification fails, the previous delegation is restored unless NoRollback
+// is set; hosts and records are left in place. The report lists every step
+// run; the error is a *VanityNSSetupError naming the failed step.
+func (s *DomainsService) SetupVanityNameservers(ctx context.Context, domainName string, nameservers []models.Nameserver, opts *VanityNSSetupOptions) (*VanityNSSetupReport, error) {
+	domainName = normalizeHostname(strings.TrimSpace(domainName))
+	if domainName == "" {
+		return nil, &ValidationError{Field: "domain", Message: "domain name is required"}
+	}
+	if len(nameservers) == 0 {
+		return nil, &ValidationError{Field: "nameservers", Message: "at least one nameserver is required"}
+	}
+	for _, ns := range nameservers {
+		host := normalizeHostname(ns.Hostname)
+		if host == "" {
+			return nil, &ValidationError{Field: "nameservers", Message: "nameserver hostname is required"}
+		}
+		if inBailiwick(host, domainName) && len(ns.IPAddresses) == 0 {
+			return nil, &ValidationError{Field: "nameservers", Message: "nameserver inside the domain needs IP addresses for its glue", Value: ns.Hostname}
+		}
+		for _, ip := range ns.IPAddresses {
+			if net.ParseIP(ip) == nil {
+				return nil, &ValidationError{Field: "nameservers", Message: "invalid IP address", Value: ip}
+			}
+		}
+	}
+
+	o := VanityNSSetupOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.TTL <= 0 {
+		o.TTL = DefaultVanityNSTTL
+	}
+	if o.VerifyTimeout <= 0 {
+		o.VerifyTimeout = DefaultVanityNSVerifyTimeout
+	}
+	if o.VerifyInterval <= 0 {
+		o.VerifyInterval = DefaultVanityNSVerifyInterval
+	}
+
+	domain, err := s.GetDomain(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VanityNSSetupReport{
+		Domain:              domainName,
+		PreviousNameservers: domain.Nameservers,
+		Nameservers:         nameservers,
+	}
+	v := &vanityNSSetup{service: s, opts: o, report: report}
+
+	steps := []struct {
+		step VanityNSStep
+		fn   func() (string, error)
+	}{
+		{VanityNSStepCreateHosts, func() (string, error) { return v.createHosts(ctx) }},
+		{VanityNSStepAddRecords, func() (string, error) { return v.addRecords(ctx) }},
+		{VanityNSStepBrandZone, func() (string, error) { return v.brandZone(ctx) }},
+		{VanityNSStepUpdateDelegation, func() (string, error) { return v.setNameservers(ctx, nameservers) }},
+		{VanityNSStepVerifyDelegation, func() (string, error) { return v.verify(ctx) }},
+	}
+	if o.SkipVerify {
+		steps = steps[:len(steps)-1]
+	}
+
+	for _, step := range steps {
+		detail, err := step.fn()
+		result := VanityNSStepResult{Step: step.step, Detail: detail}
+		if err != nil {
+			result.Error = err.Error()
+			report.Steps = append(report.Steps, result)
+			return report, v.fail(ctx, step.step, err)
+		}
+		report.Steps = append(report.Steps, result)
+	}
+
+	return report, nil
+}
+
+// vanityNSSetup holds the state of a single SetupVanityNameservers call.
+type vanityNSSetup struct {
+	service *DomainsService
+	opts    VanityNSSetupOptions
+	report  *VanityNSSetupReport
+}
+
+// glueNameservers returns the nameservers inside the domain.
+func (v *vanityNSSetup) glueNameservers() []models.Nameserver {
+	var glue []models.Nameserver
+	for _, ns := range v.report.Nameservers {
+		if inBailiwick(normalizeHostname(ns.Hostname), v.report.Domain) {
+			glue = append(glue, ns)
+		}
+	}
+	return glue
+}
+
+// createHosts creates the glue host objects, or updates their addresses.
+func (v *vanityNSSetup) createHosts(ctx context.Context) (string, error) {
+	var created, updated, unchanged int
+	for _, ns := range v.glueNameservers() {
+		host := normalizeHostname(ns.Hostname)
+		existing, err := v.service.client.Hosts.GetHost(ctx, host)
+		switch {
+		case IsNotFoundError(err):
+			if _, err := v.service.client.Hosts.CreateHost(ctx, &models.HostCreateRequest{Hostname: host, IPAddresses: ns.IPAddresses}); err != nil {
+				return "", fmt.Errorf("create host %s: %w", host, err)
+			}
+			created++
+		case err != nil:
+			return "", fmt.Errorf("get host %s: %w", host, err)
+		case sameAddresses(existing.IPAddresses, ns.IPAddresses):
+			unchanged++
+		default:
+			if _, err := v.service.client.Hosts.UpdateHost(ctx, host, &models.HostUpdateRequest{IPAddresses: ns.IPAddresses}); err != nil {
+				return "", fmt.Errorf("update host %s: %w", host, err)
+			}
+			updated++
+		}
+	}
+	return fmt.Sprintf("%d created, %d updated, %d unchanged", created, updated, unchanged), nil
+}
+
+// addRecords upserts the A and AAAA records of the glue nameservers in the domain's zone.
+func (v *vanityNSSetup) addRecords(ctx context.Context) (string, error) {
+	var ops []models.RRSetPatchOp
+	for _, ns := range v.glueNameservers() {
+		host := normalizeHostname(ns.Hostname)
+		name := strings.TrimSuffix(strings.TrimSuffix(host, v.report.Domain), ".")
+		if name == "" {
+			name = "@"
+		}
+
+		byType := map[models.RRSetType][]models.RecordCreate{}
+		for _, ip := range ns.IPAddresses {
+			rrtype := models.RRSetTypeAAAA
+			if net.ParseIP(ip).To4() != nil {
+				rrtype = models.RRSetTypeA
+			}
+			byType[rrtype] = append(byType[rrtype], models.RecordCreate{RData: ip})
+		}
+		for _, rrtype := range []models.RRSetType{models.RRSetTypeA, models.RRSetTypeAAAA} {
+			if records := byType[rrtype]; len(records) > 0 {
+				ops = append(ops, models.RRSetPatchOp{
+					Op:    models.RecordOpUpsert,
+					RRSet: models.RRSetPatch{Name: name, Type: rrtype, TTL: v.opts.TTL, Records: records},
+				})
+			}
+		}
+	}
+	if len(ops) == 0 {
+		return "no nameserver inside the domain", nil
+	}
+
+	if err := v.service.client.DNS.PatchRRSets(ctx, v.report.Domain, ops); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d RRset(s) upserted", len(ops)), nil
+}
+
+// brandZone assigns the vanity nameserver set to the domain's zone.
+func (v *vanityNSSetup) brandZone(ctx context.Context) (string, error) {
+	setID := v.opts.VanitySetID
+	if setID == nil {
+		set, err := v.findVanitySet(ctx)
+		if err != nil {
+			return "", err
+		}
+		if set == nil {
+			return "no vanity nameserver set lists these nameservers; zone apex unchanged", nil
+		}
+		setID = &set.SetID
+	}
+
+	if _, err := v.service.client.DNS.SetZoneVanitySet(ctx, v.report.Domain, setID); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("zone uses vanity nameserver set %s", *setID), nil
+}
+
+// findVanitySet returns the active vanity nameserver set whose hostnames are
+// exactly the target nameservers, or nil.
+func (v *vanityNSSetup) findVanitySet(ctx context.Context) (*models.VanityNameserverSet, error) {
+	want := make([]string, len(v.report.Nameservers))
+	for i, ns := range v.report.Nameservers {
+		want[i] = normalizeHostname(ns.Hostname)
+	}
+	slices.Sort(want)
+
+	sets, err := v.service.client.VanityNameservers.ListSets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, set := range sets {
+		if set.Status != models.VanityNameserverSetStatusActive {
+			continue
+		}
+		hosts := make([]string, len(set.Nameservers))
+		for j, ns := range set.Nameservers {
+			hosts[j] = normalizeHostname(ns.Hostname)
+		}
+		slices.Sort(hosts)
+		if slices.Equal(hosts, want) {
+			return &sets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// setNameservers updates the registry delegation.
+func (v *vanityNSSetup) setNameservers(ctx context.Context, nameservers []models.Nameserver) (string, error) {
+	if _, err := v.service.UpdateDomain(ctx, v.report.Domain, &models.DomainUpdateRequest{Nameservers: nameservers}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d nameserver(s)", len(nameservers)), nil
+}
+
+// verify re-checks the delegation until it is consistent or VerifyTimeout passes.
+func (v *vanityNSSetup) verify(ctx context.Context) (string, error) {
+	report, err := v.service.waitForDelegation(ctx, v.service.client.http.clock, v.report.Domain, v.opts.VerifyTimeout, v.opts.VerifyInterval)
+	if report != nil {
+		v.report.Delegation = report
+	}
+	if err != nil {
+		return "", err
+	}
+	return "delegation consistent", nil
+}
+
+// fail restores the previous delegation if verification failed (unless
+// disabled) and builds the setup error.
+func (v *vanityNSSetup) fail(ctx context.Context, step VanityNSStep, err error) error {
+	setupErr := &VanityNSSetupError{Step: step, Err: err}
+	if v.opts.NoRollback || step != VanityNSStepVerifyDelegation {
+		return setupErr
+	}
+
+	// Roll back with a fresh context so a cancelled ctx does not strand the domain.
+	if _, err := v.setNameservers(context.WithoutCancel(ctx), v.report.PreviousNameservers); err != nil {
+		setupErr.RollbackErr = fmt.Errorf("restore nameservers: %w", err)
+		return setupErr
+	}
+	setupErr.RolledBack = true
+	return setupErr
+}
+
+// sameAddresses reports whether a and b hold the same IP addresses in any order.
+func sameAddresses(a, b []string) bool {
+	normalize := func(ips []string) []string {
+		out := make([]string, len(ips))
+		for i, ip := range ips {
+			if parsed := net.ParseIP(ip); parsed != nil {
+				ip = parsed.String()
+			}
+			out[i] = ip
+		}
+		slices.Sort(out)
+		return out
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
@@ -0,0 +1,188 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vanityNSServer fakes the domain, host, zone and vanity set endpoints used by SetupVanityNameservers.
+type vanityNSServer struct {
+	mu       sync.Mutex
+	ns       []models.Nameserver
+	hosts    map[string][]string
+	apexNS   []string
+	sets     []models.VanityNameserverSet
+	created  []string
+	updated  []string
+	patches  []models.RRSetPatchRequest
+	branded  []string
+	updates  [][]models.Nameserver
+	keepApex bool
+}
+
+func (v *vanityNSServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		host, isHost := strings.CutPrefix(r.URL.Path, "/v1/hosts/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.com":
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: v.ns})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/domains/example.com":
+			var req models.DomainUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			v.ns = req.Nameservers
+			v.updates = append(v.updates, req.Nameservers)
+			_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com", Nameservers: v.ns})
+		case r.Method == http.MethodGet && isHost:
+			ips, ok := v.hosts[host]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"detail":"host not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.Host{Hostname: host, IPAddresses: ips})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/hosts":
+			var req models.HostCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			v.hosts[req.Hostname] = req.IPAddresses
+			v.created = append(v.created, req.Hostname)
+			_ = json.NewEncoder(w).Encode(models.Host{Hostname: req.Hostname, IPAddresses: req.IPAddresses})
+		case r.Method == http.MethodPut && isHost:
+			var req models.HostUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			v.hosts[host] = req.IPAddresses
+			v.updated = append(v.updated, host)
+			_ = json.NewEncoder(w).Encode(models.Host{Hostname: host, IPAddresses: req.IPAddresses})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/rrsets":
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			v.patches = append(v.patches, req)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/vanity-nameserver-sets":
+			_ = json.NewEncoder(w).Encode(models.VanityNameserverSetListResponse{Results: v.sets})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/vanity-set":
+			var req models.ZoneVanitySetUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NotNil(t, req.VanityNameserverSetID)
+			v.branded = append(v.branded, string(*req.VanityNameserverSetID))
+			if !v.keepApex {
+				v.apexNS = []string{"ns1.example.com.", "ns2.example.com."}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]models.Zone{"zone": {Name: "example.com"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+			var records []models.RecordData
+			for _, ns := range v.apexNS {
+				records = append(records, models.RecordData{RData: ns})
+			}
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeNS, TTL: 86400, Records: records},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestDomainsService_SetupVanityNameservers(t *testing.T) {
+	vanityNS := []models.Nameserver{
+		{Hostname: "ns1.example.com", IPAddresses: []string{"192.0.2.53", "2001:db8::53"}},
+		{Hostname: "ns2.example.com", IPAddresses: []string{"198.51.100.53"}},
+	}
+	served := []string{"ns1.example.com.", "ns2.example.com."}
+	resolver := fakeDelegationResolver{served: map[string][]string{"192.0.2.53": served, "198.51.100.53": served}}
+
+	newFake := func() *vanityNSServer {
+		return &vanityNSServer{
+			ns:     []models.Nameserver{{Hostname: "ns1.opusdns.net"}},
+			hosts:  map[string][]string{"ns2.example.com": {"198.51.100.1"}},
+			apexNS: []string{"ns1.opusdns.net."},
+			sets: []models.VanityNameserverSet{
+				{SetID: "vns_other", Status: models.VanityNameserverSetStatusActive, Nameservers: []models.VanityNameserver{{Hostname: "ns1.example.net"}}},
+				{SetID: "vns_example", Status: models.VanityNameserverSetStatusActive, Nameservers: []models.VanityNameserver{{Hostname: "NS2.example.com"}, {Hostname: "ns1.example.com"}}},
+			},
+		}
+	}
+
+	t.Run("sets up hosts, records, branding and delegation", func(t *testing.T) {
+		useDelegationResolver(t, resolver)
+
+		fake := newFake()
+		client := newTestClient(t, fake.handler(t))
+
+		report, err := client.Domains.SetupVanityNameservers(context.Background(), "example.com", vanityNS, nil)
+		require.NoError(t, err)
+
+		var steps []VanityNSStep
+		for _, s := range report.Steps {
+			steps = append(steps, s.Step)
+		}
+		assert.Equal(t, []VanityNSStep{
+			VanityNSStepCreateHosts,
+			VanityNSStepAddRecords,
+			VanityNSStepBrandZone,
+			VanityNSStepUpdateDelegation,
+			VanityNSStepVerifyDelegation,
+		}, steps)
+		assert.Equal(t, []models.Nameserver{{Hostname: "ns1.opusdns.net"}}, report.PreviousNameservers)
+		require.NotNil(t, report.Delegation)
+		assert.True(t, report.Delegation.OK())
+
+		assert.Equal(t, []string{"ns1.example.com"}, fake.created)
+		assert.Equal(t, []string{"ns2.example.com"}, fake.updated)
+		assert.Equal(t, []string{"vns_example"}, fake.branded)
+		assert.Equal(t, [][]models.Nameserver{vanityNS}, fake.updates)
+
+		require.Len(t, fake.patches, 1)
+		assert.Equal(t, []models.RRSetPatchOp{
+			{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "ns1", Type: models.RRSetTypeA, TTL: DefaultVanityNSTTL, Records: []models.RecordCreate{{RData: "192.0.2.53"}}}},
+			{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "ns1", Type: models.RRSetTypeAAAA, TTL: DefaultVanityNSTTL, Records: []models.RecordCreate{{RData: "2001:db8::53"}}}},
+			{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "ns2", Type: models.RRSetTypeA, TTL: DefaultVanityNSTTL, Records: []models.RecordCreate{{RData: "198.51.100.53"}}}},
+		}, fake.patches[0].Ops)
+	})
+
+	t.Run("rolls back when delegation does not verify", func(t *testing.T) {
+		useDelegationResolver(t, resolver)
+
+		fake := newFake()
+		fake.keepApex = true
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		client := newTestClient(t, fake.handler(t), WithClock(clock))
+
+		report, err := client.Domains.SetupVanityNameservers(context.Background(), "example.com", vanityNS, &VanityNSSetupOptions{
+			VerifyTimeout:  time.Minute,
+			VerifyInterval: 20 * time.Second,
+		})
+		require.Error(t, err)
+
+		var setupErr *VanityNSSetupError
+		require.True(t, errors.As(err, &setupErr))
+		assert.Equal(t, VanityNSStepVerifyDelegation, setupErr.Step)
+		assert.True(t, setupErr.RolledBack)
+		assert.NoError(t, setupErr.RollbackErr)
+
+		assert.Equal(t, [][]models.Nameserver{vanityNS, {{Hostname: "ns1.opusdns.net"}}}, fake.updates)
+		assert.Len(t, clock.waits, 3)
+		require.Len(t, report.Steps, 5)
+		assert.NotEmpty(t, report.Steps[4].Error)
+	})
+
+	t.Run("requires glue addresses for nameservers inside the domain", func(t *testing.T) {
+		client, err := NewClient(WithAPIKey("opk_test"))
+		require.NoError(t, err)
+
+		_, err = client.Domains.SetupVanityNameservers(context.Background(), "example.com", []models.Nameserver{{Hostname: "ns1.example.com"}}, nil)
+		assert.True(t, IsValidationError(err))
+	})
+}
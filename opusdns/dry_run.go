@@ -0,0 +1,52 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// dryRunKey is the context key for the per-call dry-run override.
+type dryRunKey struct{}
+
+// ContextWithDryRun returns a context that turns dry-run mode on or off for
+// the calls made with it, overriding Config.DryRun.
+func ContextWithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// isDryRun reports whether req must not be sent because dry-run mode is on.
+// Only mutating methods are held back; GET, HEAD and OPTIONS always go out.
+func (c *HTTPClient) isDryRun(ctx context.Context, req *Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if req.ReadOnly {
+		return false
+	}
+	if dryRun, ok := ctx.Value(dryRunKey{}).(bool); ok {
+		return dryRun
+	}
+	return c.config.DryRun
+}
+
+// newDryRunError describes the request that dry-run mode held back.
+func (c *HTTPClient) newDryRunError(ctx context.Context, req *Request) error {
+	reqURL := c.baseURL.JoinPath(req.Path)
+	if req.Query != nil {
+		reqURL.RawQuery = req.Query.Encode()
+	}
+
+	dryRunErr := &DryRunError{Method: req.Method, URL: reqURL.String()}
+	if req.Body != nil {
+		data, err := json.Marshal(req.Body)
+		if err != nil {
+			return &RequestError{Op: "marshal", URL: dryRunErr.URL, Err: err}
+		}
+		dryRunErr.Body = data
+	}
+
+	c.logf(ctx, "Dry run: %s %s", req.Method, dryRunErr.URL)
+	return dryRunErr
+}
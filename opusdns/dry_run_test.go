@@ -0,0 +1,71 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithDryRun(true))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("reads are sent", func(t *testing.T) {
+		methods = nil
+		_, err := client.DNS.GetZone(ctx, "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodGet}, methods)
+	})
+
+	t.Run("mutations return the request", func(t *testing.T) {
+		methods = nil
+		_, err := client.DNS.CreateZone(ctx, &models.ZoneCreateRequest{Name: "example.com"})
+		require.Error(t, err)
+		assert.True(t, IsDryRunError(err))
+		assert.Empty(t, methods)
+
+		var dryRunErr *DryRunError
+		require.True(t, errors.As(err, &dryRunErr))
+		assert.Equal(t, http.MethodPost, dryRunErr.Method)
+		assert.Equal(t, server.URL+"/v1/dns", dryRunErr.URL)
+		assert.JSONEq(t, `{"name":"example.com"}`, string(dryRunErr.Body))
+
+		err = client.DNS.DeleteZone(ctx, "example.com")
+		assert.True(t, IsDryRunError(err))
+		assert.Empty(t, methods)
+	})
+
+	t.Run("read-only posts are sent", func(t *testing.T) {
+		methods = nil
+		_, err := client.VanityNameservers.CheckSet(ctx, "set_1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodPost}, methods)
+	})
+
+	t.Run("context override", func(t *testing.T) {
+		methods = nil
+		err := client.DNS.DeleteZone(ContextWithDryRun(ctx, false), "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodDelete}, methods)
+
+		live, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+		err = live.DNS.DeleteZone(ContextWithDryRun(ctx, true), "example.com")
+		assert.ErrorIs(t, err, ErrDryRun)
+	})
+}
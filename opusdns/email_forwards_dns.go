@@ -0,0 +1,118 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// DNS records required for email forwarding to work.
+var (
+	// EmailForwardMXRecords are the MX records (preference and exchange) that
+	// route mail for a forwarded hostname to the forwarding servers.
+	EmailForwardMXRecords = []string{"10 mx1.improvmx.com.", "20 mx2.improvmx.com."}
+
+	// EmailForwardSPFInclude is the SPF mechanism that authorizes the forwarding servers.
+	EmailForwardSPFInclude = "include:spf.improvmx.com"
+)
+
+// EmailForwardDNSResult is returned by EnsureDNS and CheckDNS.
+type EmailForwardDNSResult struct {
+	// Zone is the OpusDNS zone holding the hostname.
+	Zone string `json:"zone"`
+
+	// Name is the hostname relative to Zone.
+	Name string `json:"name"`
+
+	// Changes lists the changes made (EnsureDNS) or needed (CheckDNS).
+	// It is empty when the records were already in place.
+	Changes []MailRecordChange `json:"changes"`
+}
+
+// CheckDNS reports which MX and SPF records are missing for email forwarding
+// on hostname, without changing anything. The hostname must be in, or be the
+// apex of, an OpusDNS zone; otherwise an error matching ErrZoneNotFound is
+// returned. If the hostname already has MX records for another mail provider,
+// an error matching ErrConflict is returned, as forwarding cannot work
+// alongside them.
+func (s *EmailForwardsService) CheckDNS(ctx context.Context, hostname string) (*EmailForwardDNSResult, error) {
+	result, zone, err := s.checkDNS(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	_, result.Changes = planMailRecords(zone, result.Name, emailForwardMailRecords())
+	return result, nil
+}
+
+// EnsureDNS creates the MX and SPF records required for email forwarding on
+// hostname in its OpusDNS zone using DNS.UpsertMailRecords, and returns what
+// was added. Existing TXT records are preserved, and an existing SPF record is
+// extended with EmailForwardSPFInclude rather than replaced. It fails like
+// CheckDNS.
+func (s *EmailForwardsService) EnsureDNS(ctx context.Context, hostname string) (*EmailForwardDNSResult, error) {
+	result, _, err := s.checkDNS(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Changes, err = s.client.DNS.UpsertMailRecords(ctx, result.Zone, result.Name, emailForwardMailRecords())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// emailForwardMailRecords returns the records required for email forwarding.
+func emailForwardMailRecords() *MailRecords {
+	return &MailRecords{MX: EmailForwardMXRecords, SPF: []string{EmailForwardSPFInclude}}
+}
+
+// checkDNS finds the zone for hostname and checks that its MX records, if
+// any, belong to the forwarding servers.
+func (s *EmailForwardsService) checkDNS(ctx context.Context, hostname string) (*EmailForwardDNSResult, *models.Zone, error) {
+	hostname = normalizeHostname(hostname)
+	if hostname == "" {
+		return nil, nil, &ValidationError{Field: "hostname", Message: "hostname is required"}
+	}
+
+	zone, err := findZone(ctx, s.client.DNS, hostname)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &EmailForwardDNSResult{Zone: zone.Name, Name: relativeName(hostname, zone.Name)}
+	if mx := findRRSet(zone, result.Name, models.RRSetTypeMX); mx != nil {
+		for _, r := range mx.Records {
+			if !isEmailForwardMX(normalizeMX(r.RData)) {
+				return nil, nil, fmt.Errorf("%w: %s already has MX record %q for another mail provider", ErrConflict, hostname, r.RData)
+			}
+		}
+	}
+
+	return result, zone, nil
+}
+
+// isEmailForwardMX reports whether a normalized MX rdata is one of the forwarding records.
+func isEmailForwardMX(rdata string) bool {
+	for _, want := range EmailForwardMXRecords {
+		if normalizeMX(want) == rdata {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMX lower-cases an MX rdata and removes the exchange's trailing dot.
+func normalizeMX(rdata string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Join(strings.Fields(rdata), " ")), ".")
+}
+
+// relativeName returns hostname relative to zone, or "@" for the apex.
+func relativeName(hostname, zone string) string {
+	if hostname == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(hostname, "."+zone)
+}
@@ -0,0 +1,130 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emailDNSServer serves example.com with rrsets and records RRset patches.
+func emailDNSServer(t *testing.T, rrsets []models.RRSet, patches *[]models.RRSetPatchRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns/example.com":
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com.", RRSets: rrsets})
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/dns/example.com/rrsets":
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*patches = append(*patches, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestEmailForwardsService_EnsureDNS(t *testing.T) {
+	t.Run("creates MX and SPF for a subdomain", func(t *testing.T) {
+		var patches []models.RRSetPatchRequest
+		server := emailDNSServer(t, []models.RRSet{
+			{Name: "mail", Type: models.RRSetTypeTXT, TTL: 300, Records: []models.RecordData{{RData: `"google-site-verification=abc"`}}},
+		}, &patches)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.EmailForwards.EnsureDNS(context.Background(), "mail.example.com.")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com", result.Zone)
+		assert.Equal(t, "mail", result.Name)
+		require.Len(t, result.Changes, 2)
+		assert.Equal(t, EmailForwardMXRecords, result.Changes[0].Added)
+
+		require.Len(t, patches, 1)
+		require.Len(t, patches[0].Ops, 2)
+		mx, txt := patches[0].Ops[0].RRSet, patches[0].Ops[1].RRSet
+		assert.Equal(t, models.RRSetTypeMX, mx.Type)
+		assert.Equal(t, DefaultMailRecordTTL, mx.TTL)
+		assert.Len(t, mx.Records, 2)
+		assert.Equal(t, 300, txt.TTL)
+		assert.Equal(t, []models.RecordCreate{
+			{RData: `"google-site-verification=abc"`},
+			{RData: `"v=spf1 include:spf.improvmx.com ~all"`},
+		}, txt.Records)
+	})
+
+	t.Run("extends an existing SPF record", func(t *testing.T) {
+		var patches []models.RRSetPatchRequest
+		server := emailDNSServer(t, []models.RRSet{
+			{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, Records: []models.RecordData{{RData: "10 MX1.improvmx.com"}, {RData: "20 mx2.improvmx.com."}}},
+			{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: `"v=spf1 include:_spf.example.net -all"`}}},
+		}, &patches)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.EmailForwards.EnsureDNS(context.Background(), "example.com")
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 1)
+		assert.Equal(t, models.RRSetTypeTXT, result.Changes[0].Type)
+		assert.Equal(t, []string{`"v=spf1 include:_spf.example.net -all"`}, result.Changes[0].Replaced)
+
+		require.Len(t, patches, 1)
+		require.Len(t, patches[0].Ops, 1)
+		assert.Equal(t, `"v=spf1 include:_spf.example.net include:spf.improvmx.com -all"`, patches[0].Ops[0].RRSet.Records[0].RData)
+	})
+
+	t.Run("does nothing when records are in place", func(t *testing.T) {
+		var patches []models.RRSetPatchRequest
+		server := emailDNSServer(t, []models.RRSet{
+			{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, Records: []models.RecordData{{RData: "10 mx1.improvmx.com."}, {RData: "20 mx2.improvmx.com."}}},
+			{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: `"v=spf1 include:spf.improvmx.com ~all"`}}},
+		}, &patches)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.EmailForwards.EnsureDNS(context.Background(), "example.com")
+		require.NoError(t, err)
+		assert.Empty(t, result.Changes)
+		assert.Empty(t, patches)
+	})
+
+	t.Run("refuses to mix with another mail provider", func(t *testing.T) {
+		var patches []models.RRSetPatchRequest
+		server := emailDNSServer(t, []models.RRSet{
+			{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, Records: []models.RecordData{{RData: "1 aspmx.l.google.com."}}},
+		}, &patches)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		_, err = client.EmailForwards.CheckDNS(context.Background(), "example.com")
+		assert.True(t, IsConflictError(err))
+		assert.Empty(t, patches)
+	})
+
+	t.Run("reports a missing zone", func(t *testing.T) {
+		var patches []models.RRSetPatchRequest
+		server := emailDNSServer(t, nil, &patches)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		_, err = client.EmailForwards.EnsureDNS(context.Background(), "mail.other.org")
+		assert.ErrorIs(t, err, ErrZoneNotFound)
+	})
+}
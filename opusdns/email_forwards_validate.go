@@ -0,0 +1,141 @@
+package opusdns
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// maxAliasLength is the maximum length of an email local part (RFC 5321).
+const maxAliasLength = 64
+
+// checkAliasCreate runs the checks on a new alias that need no knowledge of
+// the email forward, so obviously invalid requests fail without a round trip.
+func checkAliasCreate(req *models.EmailForwardAliasCreate) error {
+	if req == nil {
+		return &ValidationError{Field: "request", Message: "request is required"}
+	}
+	if err := validateAliasName(req.Alias); err != nil {
+		return err
+	}
+	return validateForwardTo("", req.Alias, req.ForwardTo)
+}
+
+// validateAliasCreate checks a new alias against the email forward it is
+// added to. maxAliases of zero disables the alias limit.
+func validateAliasCreate(forward *models.EmailForward, req *models.EmailForwardAliasCreate, maxAliases int) error {
+	if err := checkAliasCreate(req); err != nil {
+		return err
+	}
+
+	for _, existing := range forward.Aliases {
+		if !strings.EqualFold(existing.Alias, req.Alias) {
+			continue
+		}
+		if existing.IsCatchAll() {
+			return &ValidationError{Field: "alias", Message: fmt.Sprintf("%s already has a catch-all alias", forward.Hostname), Value: req.Alias}
+		}
+		return &ValidationError{Field: "alias", Message: fmt.Sprintf("alias already exists on %s", forward.Hostname), Value: req.Alias}
+	}
+	if maxAliases > 0 && len(forward.Aliases) >= maxAliases {
+		return &ValidationError{Field: "alias", Message: fmt.Sprintf("%s already has the maximum of %d aliases", forward.Hostname, maxAliases), Value: req.Alias}
+	}
+
+	return validateForwardTo(forward.Hostname, req.Alias, req.ForwardTo)
+}
+
+// checkAliasUpdate runs the checks on new destinations that need no
+// knowledge of the email forward.
+func checkAliasUpdate(req *models.EmailForwardAliasUpdate) error {
+	if req == nil {
+		return &ValidationError{Field: "request", Message: "request is required"}
+	}
+	return validateForwardTo("", "", req.ForwardTo)
+}
+
+// validateAliasUpdate checks new destinations for an existing alias. Unknown
+// aliases are left for the API to reject.
+func validateAliasUpdate(forward *models.EmailForward, aliasID models.EmailForwardAliasID, req *models.EmailForwardAliasUpdate) error {
+	if err := checkAliasUpdate(req); err != nil {
+		return err
+	}
+
+	alias := ""
+	for _, existing := range forward.Aliases {
+		if existing.EmailForwardAliasID == aliasID {
+			alias = existing.Alias
+			break
+		}
+	}
+	return validateForwardTo(forward.Hostname, alias, req.ForwardTo)
+}
+
+// validateAliasName checks that alias is "*" or a valid email local part.
+func validateAliasName(alias string) error {
+	switch {
+	case alias == "":
+		return &ValidationError{Field: "alias", Message: "alias is required"}
+	case alias == "*":
+		return nil
+	case len(alias) > maxAliasLength:
+		return &ValidationError{Field: "alias", Message: fmt.Sprintf("must be at most %d characters", maxAliasLength), Value: alias}
+	case strings.HasPrefix(alias, ".") || strings.HasSuffix(alias, ".") || strings.Contains(alias, ".."):
+		return &ValidationError{Field: "alias", Message: "must not start or end with a dot or contain consecutive dots", Value: alias}
+	}
+
+	for _, r := range alias {
+		if !isLocalPartRune(r) {
+			return &ValidationError{Field: "alias", Message: fmt.Sprintf("invalid character %q", r), Value: alias}
+		}
+	}
+	return nil
+}
+
+// isLocalPartRune reports whether r may appear in an unquoted email local part.
+func isLocalPartRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~.", r)
+}
+
+// validateForwardTo checks the destinations of alias on hostname. It rejects
+// malformed and duplicate addresses, forwarding an alias to itself, and a
+// catch-all forwarding to its own hostname, which would loop.
+func validateForwardTo(hostname, alias string, forwardTo []string) error {
+	if len(forwardTo) == 0 {
+		return &ValidationError{Field: "forward_to", Message: "at least one destination address is required"}
+	}
+
+	hostname = normalizeHostname(hostname)
+	seen := make(map[string]bool, len(forwardTo))
+	for i, address := range forwardTo {
+		field := fmt.Sprintf("forward_to[%d]", i)
+
+		parsed, err := mail.ParseAddress(address)
+		if err != nil || parsed.Address != address || parsed.Name != "" {
+			return &ValidationError{Field: field, Message: "must be a plain email address", Value: address}
+		}
+		local, domain, _ := strings.Cut(strings.ToLower(address), "@")
+		if !strings.Contains(domain, ".") {
+			return &ValidationError{Field: field, Message: "domain must be fully qualified", Value: address}
+		}
+
+		key := local + "@" + domain
+		if seen[key] {
+			return &ValidationError{Field: field, Message: "duplicate destination address", Value: address}
+		}
+		seen[key] = true
+
+		if hostname == "" || domain != hostname {
+			continue
+		}
+		if alias == "*" {
+			return &ValidationError{Field: field, Message: "a catch-all alias cannot forward to its own hostname", Value: address}
+		}
+		if strings.EqualFold(local, alias) {
+			return &ValidationError{Field: field, Message: "alias cannot forward to itself", Value: address}
+		}
+	}
+	return nil
+}
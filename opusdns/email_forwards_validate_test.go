@@ -0,0 +1,136 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAliasCreate(t *testing.T) {
+	forward := &models.EmailForward{
+		Hostname: "example.com",
+		Aliases: []models.EmailForwardAlias{
+			{EmailForwardAliasID: "email_forward_alias_1", Alias: "info"},
+			{EmailForwardAliasID: "email_forward_alias_2", Alias: "*"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		req   models.EmailForwardAliasCreate
+		field string
+	}{
+		{"valid", models.EmailForwardAliasCreate{Alias: "sales.team", ForwardTo: []string{"a@example.net", "info@example.com"}}, ""},
+		{"empty alias", models.EmailForwardAliasCreate{ForwardTo: []string{"a@example.net"}}, "alias"},
+		{"invalid alias", models.EmailForwardAliasCreate{Alias: "in fo", ForwardTo: []string{"a@example.net"}}, "alias"},
+		{"leading dot", models.EmailForwardAliasCreate{Alias: ".info", ForwardTo: []string{"a@example.net"}}, "alias"},
+		{"duplicate alias", models.EmailForwardAliasCreate{Alias: "INFO", ForwardTo: []string{"a@example.net"}}, "alias"},
+		{"second catch-all", models.EmailForwardAliasCreate{Alias: "*", ForwardTo: []string{"a@example.net"}}, "alias"},
+		{"no destinations", models.EmailForwardAliasCreate{Alias: "sales"}, "forward_to"},
+		{"display name", models.EmailForwardAliasCreate{Alias: "sales", ForwardTo: []string{"A <a@example.net>"}}, "forward_to[0]"},
+		{"unqualified domain", models.EmailForwardAliasCreate{Alias: "sales", ForwardTo: []string{"a@localhost"}}, "forward_to[0]"},
+		{"duplicate destination", models.EmailForwardAliasCreate{Alias: "sales", ForwardTo: []string{"a@example.net", "A@Example.net"}}, "forward_to[1]"},
+		{"forwards to itself", models.EmailForwardAliasCreate{Alias: "sales", ForwardTo: []string{"sales@example.com"}}, "forward_to[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAliasCreate(forward, &tt.req, DefaultMaxEmailForwardAliases)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	t.Run("catch-all to own hostname", func(t *testing.T) {
+		err := validateAliasCreate(&models.EmailForward{Hostname: "example.com"}, &models.EmailForwardAliasCreate{
+			Alias:     "*",
+			ForwardTo: []string{"inbox@example.com"},
+		}, DefaultMaxEmailForwardAliases)
+		assert.True(t, IsValidationError(err))
+	})
+
+	t.Run("alias limit", func(t *testing.T) {
+		full := &models.EmailForward{Hostname: "example.com", Aliases: make([]models.EmailForwardAlias, DefaultMaxEmailForwardAliases)}
+		for i := range full.Aliases {
+			full.Aliases[i].Alias = "alias" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		}
+		req := &models.EmailForwardAliasCreate{Alias: "new", ForwardTo: []string{"a@example.net"}}
+		assert.True(t, IsValidationError(validateAliasCreate(full, req, DefaultMaxEmailForwardAliases)))
+		assert.NoError(t, validateAliasCreate(full, req, 0), "zero disables the limit")
+	})
+}
+
+func TestEmailForwardsService_CreateAlias_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method, "invalid alias must not be sent")
+		_ = json.NewEncoder(w).Encode(models.EmailForward{
+			EmailForwardID: "email_forward_123",
+			Hostname:       "example.com",
+			Aliases:        []models.EmailForwardAlias{{Alias: "*"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.EmailForwards.CreateAlias(context.Background(), "email_forward_123", &models.EmailForwardAliasCreate{
+		Alias:     "*",
+		ForwardTo: []string{"a@example.net"},
+	})
+	assert.True(t, IsValidationError(err))
+
+	_, err = client.EmailForwards.UpdateAlias(context.Background(), "email_forward_123", "email_forward_alias_1", &models.EmailForwardAliasUpdate{})
+	assert.True(t, IsValidationError(err))
+}
+
+func TestEmailForwardsService_Alias_InvalidWithoutRequest(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	ctx := context.Background()
+
+	_, err := client.EmailForwards.CreateAlias(ctx, "email_forward_123", nil)
+	assert.True(t, IsValidationError(err))
+	_, err = client.EmailForwards.CreateAlias(ctx, "email_forward_123", &models.EmailForwardAliasCreate{Alias: "in fo", ForwardTo: []string{"a@example.net"}})
+	assert.True(t, IsValidationError(err))
+	_, err = client.EmailForwards.CreateAlias(ctx, "email_forward_123", &models.EmailForwardAliasCreate{Alias: "sales", ForwardTo: []string{"not-an-address"}})
+	assert.True(t, IsValidationError(err))
+	_, err = client.EmailForwards.UpdateAlias(ctx, "email_forward_123", "email_forward_alias_1", nil)
+	assert.True(t, IsValidationError(err))
+	_, err = client.EmailForwards.UpdateAlias(ctx, "email_forward_123", "email_forward_alias_1", &models.EmailForwardAliasUpdate{ForwardTo: []string{"a@localhost"}})
+	assert.True(t, IsValidationError(err))
+
+	assert.Zero(t, requests, "obviously invalid input must not reach the API")
+}
+
+func TestEmailForwardsService_CreateAlias_ConfiguredLimit(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method, "an alias over the limit must not be sent")
+		_ = json.NewEncoder(w).Encode(models.EmailForward{
+			EmailForwardID: "email_forward_123",
+			Hostname:       "example.com",
+			Aliases:        []models.EmailForwardAlias{{Alias: "info"}, {Alias: "sales"}},
+		})
+	}), WithMaxEmailForwardAliases(2))
+
+	_, err := client.EmailForwards.CreateAlias(context.Background(), "email_forward_123", &models.EmailForwardAliasCreate{
+		Alias:     "support",
+		ForwardTo: []string{"a@example.net"},
+	})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "maximum of 2 aliases")
+}
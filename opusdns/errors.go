@@ -2,10 +2,17 @@
 package opusdns
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
 )
 
 // Standard sentinel errors for common error conditions.
@@ -28,17 +35,62 @@ var (
 	// ErrConflict is returned when there is a resource conflict.
 	ErrConflict = errors.New("opusdns: conflict - resource already exists or state conflict")
 
+	// ErrConflictVersion is returned when a conditional write, such as a record
+	// patch with RecordPatchRequest.ExpectedSerial, fails because the resource
+	// changed since the expected version (HTTP 412). It also matches ErrConflict.
+	ErrConflictVersion = errors.New("opusdns: conflict - resource changed since the expected version")
+
 	// ErrTimeout is returned when a request times out.
 	ErrTimeout = errors.New("opusdns: request timeout")
 
 	// ErrZoneNotFound is returned when a zone cannot be found for a given FQDN.
 	ErrZoneNotFound = errors.New("opusdns: no matching zone found for FQDN")
 
+	// ErrDomainUnavailable is returned when a domain cannot be registered because it is not available.
+	ErrDomainUnavailable = errors.New("opusdns: domain is not available for registration")
+
 	// ErrInvalidInput is returned when input validation fails.
 	ErrInvalidInput = errors.New("opusdns: invalid input")
 
+	// ErrPaymentRequired is returned when an operation needs payment confirmation,
+	// typically because the wallet balance is insufficient.
+	ErrPaymentRequired = errors.New("opusdns: payment required")
+
 	// ErrServerError is returned when the server returns an internal error.
 	ErrServerError = errors.New("opusdns: server error")
+
+	// ErrRecordProtected is returned when a record patch changes or removes a
+	// protected record without RecordPatchRequest.AllowProtected.
+	ErrRecordProtected = errors.New("opusdns: record is protected")
+
+	// ErrZoneLocked is returned when another owner holds the advisory lock of a zone.
+	ErrZoneLocked = errors.New("opusdns: zone is locked")
+
+	// ErrZoneLockLost is returned when a zone lock has expired or was taken over.
+	ErrZoneLockLost = errors.New("opusdns: zone lock lost")
+
+	// ErrDryRun is matched by the *DryRunError returned for mutating calls in dry-run mode.
+	ErrDryRun = errors.New("opusdns: dry run")
+
+	// ErrPolicyViolation is matched by the *PolicyViolationError returned
+	// when a configured Policy stops a mutating call.
+	ErrPolicyViolation = errors.New("opusdns: policy violation")
+
+	// ErrCircuitOpen is returned without sending the request while the
+	// client's circuit breaker is open.
+	ErrCircuitOpen = errors.New("opusdns: circuit breaker open")
+
+	// ErrMaintenance is matched by the *MaintenanceError returned while the
+	// platform is in a maintenance window.
+	ErrMaintenance = errors.New("opusdns: platform under maintenance")
+
+	// ErrTooManyResults is matched by the *TooManyResultsError returned when
+	// ListZones or ListDomains exceeds Config.MaxPages or Config.MaxItems.
+	ErrTooManyResults = errors.New("opusdns: too many results")
+
+	// ErrDeadlineWouldExceed is matched by the *DeadlineWouldExceedError
+	// returned when the next attempt would start after the context deadline.
+	ErrDeadlineWouldExceed = errors.New("opusdns: deadline would be exceeded")
 )
 
 // APIError represents an error response from the OpusDNS API.
@@ -97,7 +149,11 @@ func (e *APIError) Is(target error) bool {
 	case ErrBadRequest:
 		return e.StatusCode == http.StatusBadRequest
 	case ErrConflict:
-		return e.StatusCode == http.StatusConflict
+		return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+	case ErrConflictVersion:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case ErrPaymentRequired:
+		return e.StatusCode == http.StatusPaymentRequired
 	case ErrServerError:
 		return e.StatusCode >= 500
 	}
@@ -119,6 +175,10 @@ func (e *APIError) Unwrap() error {
 		return ErrBadRequest
 	case http.StatusConflict:
 		return ErrConflict
+	case http.StatusPreconditionFailed:
+		return ErrConflictVersion
+	case http.StatusPaymentRequired:
+		return ErrPaymentRequired
 	default:
 		if e.StatusCode >= 500 {
 			return ErrServerError
@@ -185,6 +245,333 @@ func NewAPIError(resp *http.Response, body []byte) *APIError {
 	return apiErr
 }
 
+// errorCodeRecordProtected is the API error code of operations on protected records.
+const errorCodeRecordProtected = "record_protected"
+
+// BatchError is returned by DNSService.PatchRecordsWithRequest when the API
+// rejected some operations of a record patch. It lists the index, operation
+// and reason of each failed operation, and matches ErrRecordProtected if any
+// of them was rejected because its record is protected.
+//
+// If Applied is true, the API applied the other operations; fix and resend
+// FailedOps to complete the patch. Otherwise nothing was applied and
+// PassedOps can be sent on their own to apply the valid part of the batch.
+type BatchError struct {
+	// APIError is the underlying API error. It is nil if the API applied the
+	// other operations and only reported the failed ones.
+	APIError *APIError
+
+	// Applied reports whether the operations that did not fail were applied.
+	Applied bool
+
+	// Failed lists the failed operations in request order. An operation with
+	// several problems appears once per problem.
+	Failed []models.RecordOperationError
+
+	// ops are the operations of the request.
+	ops []models.RecordOperation
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("opusdns: %d of %d record operation(s) failed", len(e.FailedOps()), len(e.ops))
+	if len(e.Failed) > 0 {
+		first := e.Failed[0]
+		reason := first.Message
+		if reason == "" {
+			reason = first.ErrorCode
+		}
+		msg += fmt.Sprintf(": ops[%d] %s %s %s: %s", first.Index, first.Operation.Op, first.Operation.Record.Name, first.Operation.Record.Type, reason)
+	}
+	if e.APIError != nil && e.APIError.RequestID != "" {
+		msg += fmt.Sprintf(" (request_id: %s)", e.APIError.RequestID)
+	}
+	return msg
+}
+
+// Is implements errors.Is, matching ErrRecordProtected.
+func (e *BatchError) Is(target error) bool {
+	if target != ErrRecordProtected {
+		return false
+	}
+	for _, failed := range e.Failed {
+		if failed.ErrorCode == errorCodeRecordProtected {
+			return true
+		}
+	}
+	return e.APIError != nil && e.APIError.ErrorCode == errorCodeRecordProtected
+}
+
+// Unwrap returns the underlying APIError, if any.
+func (e *BatchError) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+	return e.APIError
+}
+
+// FailedOps returns the operations that failed, in request order.
+func (e *BatchError) FailedOps() []models.RecordOperation {
+	var ops []models.RecordOperation
+	for i, op := range e.ops {
+		if e.failed(i) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// PassedOps returns the operations that did not fail, in request order.
+func (e *BatchError) PassedOps() []models.RecordOperation {
+	var ops []models.RecordOperation
+	for i, op := range e.ops {
+		if !e.failed(i) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func (e *BatchError) failed(index int) bool {
+	for _, failed := range e.Failed {
+		if failed.Index == index {
+			return true
+		}
+	}
+	return false
+}
+
+// newBatchError builds a BatchError for ops from the failed operations the API
+// reported, either in a successful response (failed) or in the body of an
+// error response (apiErr). It returns nil if no operation failed and the
+// error is not about protected records.
+func newBatchError(apiErr *APIError, failed []models.RecordOperationError, ops []models.RecordOperation) *BatchError {
+	if apiErr != nil {
+		failed = append(failed, parseOperationErrors(apiErr)...)
+		if len(failed) == 0 && apiErr.ErrorCode != errorCodeRecordProtected {
+			return nil
+		}
+	} else if len(failed) == 0 {
+		return nil
+	}
+
+	valid := failed[:0]
+	for _, f := range failed {
+		if f.Index >= 0 && f.Index < len(ops) {
+			f.Operation = ops[f.Index]
+			valid = append(valid, f)
+		}
+	}
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Index < valid[j].Index })
+
+	return &BatchError{APIError: apiErr, Applied: apiErr == nil, Failed: valid, ops: ops}
+}
+
+// parseOperationErrors extracts per-operation errors from an error response.
+// The API lists them in details.rejected or details.errors, while request
+// validation errors are a "detail" list whose "loc" points into the ops
+// (["body", "ops", 3, "record", "rdata"]).
+func parseOperationErrors(apiErr *APIError) []models.RecordOperationError {
+	var failed []models.RecordOperationError
+	for _, key := range []string{"rejected", "errors"} {
+		if raw, ok := apiErr.Details[key]; ok {
+			data, _ := json.Marshal(raw)
+			_ = json.Unmarshal(data, &failed)
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+
+	var body struct {
+		Detail []struct {
+			Loc  []interface{} `json:"loc"`
+			Msg  string        `json:"msg"`
+			Type string        `json:"type"`
+		} `json:"detail"`
+	}
+	if err := json.Unmarshal([]byte(apiErr.RawBody), &body); err != nil {
+		return nil
+	}
+	for _, detail := range body.Detail {
+		for i := 0; i+1 < len(detail.Loc); i++ {
+			index, ok := detail.Loc[i+1].(float64)
+			if detail.Loc[i] != "ops" || !ok {
+				continue
+			}
+			var field []string
+			for _, part := range detail.Loc[i+2:] {
+				field = append(field, fmt.Sprint(part))
+			}
+			message := detail.Msg
+			if len(field) > 0 {
+				message = strings.Join(field, ".") + ": " + message
+			}
+			failed = append(failed, models.RecordOperationError{Index: int(index), ErrorCode: detail.Type, Message: message})
+			break
+		}
+	}
+	return failed
+}
+
+// PaymentRequiredError is returned for 402 responses, when an operation can
+// only proceed once payment has been confirmed. Pass ContinuationToken to
+// OrganizationsService.ConfirmPayment to pay and complete the operation.
+type PaymentRequiredError struct {
+	// APIError is the underlying API error.
+	APIError *APIError
+
+	// Amount is the quoted amount to be paid.
+	Amount string
+
+	// Currency is the currency of Amount.
+	Currency models.Currency
+
+	// ContinuationToken identifies the pending operation when confirming payment.
+	ContinuationToken string
+}
+
+// Error implements the error interface.
+func (e *PaymentRequiredError) Error() string {
+	msg := "opusdns: payment required"
+	if e.Amount != "" {
+		msg += fmt.Sprintf(": %s %s", e.Amount, e.Currency)
+	}
+	if e.APIError != nil && e.APIError.Message != "" {
+		msg += fmt.Sprintf(" (%s)", e.APIError.Message)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying APIError.
+func (e *PaymentRequiredError) Unwrap() error {
+	return e.APIError
+}
+
+// newPaymentRequiredError extracts the payment quote from a 402 APIError. The
+// fields are read from the error details, falling back to the top-level body.
+func newPaymentRequiredError(apiErr *APIError) *PaymentRequiredError {
+	payErr := &PaymentRequiredError{APIError: apiErr}
+
+	var body map[string]interface{}
+	_ = json.Unmarshal([]byte(apiErr.RawBody), &body)
+
+	lookup := func(keys ...string) string {
+		for _, source := range []map[string]interface{}{apiErr.Details, body} {
+			for _, key := range keys {
+				switch v := source[key].(type) {
+				case string:
+					return v
+				case float64:
+					return strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			}
+		}
+		return ""
+	}
+
+	payErr.Amount = lookup("amount", "quoted_amount")
+	payErr.Currency = models.Currency(lookup("currency"))
+	payErr.ContinuationToken = lookup("continuation_token", "payment_token")
+	return payErr
+}
+
+// MaintenanceError is returned for 423 responses, and for 503 responses
+// flagged as maintenance, while the platform is in a maintenance window. It
+// means the request itself was fine and can be sent again once the window
+// has ended.
+type MaintenanceError struct {
+	// APIError is the underlying API error.
+	APIError *APIError
+
+	// Until is the advertised end of the maintenance window, or zero if the
+	// API did not announce one.
+	Until time.Time
+}
+
+// Error implements the error interface.
+func (e *MaintenanceError) Error() string {
+	msg := "opusdns: platform under maintenance"
+	if !e.Until.IsZero() {
+		msg += " until " + e.Until.UTC().Format(time.RFC3339)
+	}
+	if e.APIError != nil && e.APIError.Message != "" {
+		msg += fmt.Sprintf(" (%s)", e.APIError.Message)
+	}
+	return msg
+}
+
+// Is implements errors.Is for MaintenanceError.
+func (e *MaintenanceError) Is(target error) bool {
+	return target == ErrMaintenance
+}
+
+// Unwrap returns the underlying APIError.
+func (e *MaintenanceError) Unwrap() error {
+	return e.APIError
+}
+
+// TooManyResultsError is returned by DNSService.ListZones and
+// DomainsService.ListDomains when they would fetch more pages or items than allowed,
+// which also guards against an API that never stops reporting a next page.
+type TooManyResultsError struct {
+	// Limit is the exceeded limit, "MaxPages" or "MaxItems".
+	Limit string
+
+	// Max is the value of the exceeded limit.
+	Max int
+}
+
+// Error implements the error interface.
+func (e *TooManyResultsError) Error() string {
+	return fmt.Sprintf("opusdns: too many results: more than %s=%d; raise the limit or list page by page with the ...Page method", e.Limit, e.Max)
+}
+
+// Is implements errors.Is for TooManyResultsError.
+func (e *TooManyResultsError) Is(target error) bool {
+	return target == ErrTooManyResults
+}
+
+// DeadlineWouldExceedError is returned instead of waiting for a retry, a
+// rate limit or a maintenance window that would last past the context's
+// deadline, so the caller learns at once that the call cannot succeed in
+// time and when to try again. It also matches ErrTimeout and
+// context.DeadlineExceeded.
+type DeadlineWouldExceedError struct {
+	// Reason is what the call would have waited for: "retry backoff",
+	// "rate limit" or "maintenance".
+	Reason string
+
+	// NextAttempt is when the next attempt would have been sent.
+	NextAttempt time.Time
+
+	// Deadline is the context's deadline.
+	Deadline time.Time
+
+	// Err is the error of the last attempt, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DeadlineWouldExceedError) Error() string {
+	msg := fmt.Sprintf("opusdns: waiting for %s until %s would exceed the deadline %s",
+		e.Reason, e.NextAttempt.Format(time.RFC3339), e.Deadline.Format(time.RFC3339))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is implements errors.Is for DeadlineWouldExceedError.
+func (e *DeadlineWouldExceedError) Is(target error) bool {
+	return target == ErrDeadlineWouldExceed || target == ErrTimeout || target == context.DeadlineExceeded
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *DeadlineWouldExceedError) Unwrap() error {
+	return e.Err
+}
+
 // RequestError represents an error that occurred while making a request.
 type RequestError struct {
 	// Op is the operation that was attempted (e.g., "marshal", "create", "execute", "read").
@@ -207,6 +594,106 @@ func (e *RequestError) Unwrap() error {
 	return e.Err
 }
 
+// decodeErrorSnippetSize is the number of body bytes DecodeError includes in
+// its message.
+const decodeErrorSnippetSize = 512
+
+// DecodeError is returned when a successful response cannot be decoded, for
+// example after a schema change or when a proxy answers with an HTML page.
+// It keeps the full response body for inspection.
+type DecodeError struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// ContentType is the Content-Type header of the response.
+	ContentType string
+
+	// RequestID is the unique identifier for the request (from X-Request-ID header).
+	RequestID string
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+// Error implements the error interface. The message includes the start of the body.
+func (e *DecodeError) Error() string {
+	snippet := e.Body
+	suffix := ""
+	if len(snippet) > decodeErrorSnippetSize {
+		snippet, suffix = snippet[:decodeErrorSnippetSize], "..."
+	}
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "none"
+	}
+	return fmt.Sprintf("opusdns: failed to decode response (status %d, content-type %s): %v; body: %q%s",
+		e.StatusCode, contentType, e.Err, snippet, suffix)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DryRunError is returned instead of sending a mutating request in dry-run
+// mode. It carries the request that would have been sent.
+type DryRunError struct {
+	// Method is the HTTP method.
+	Method string
+
+	// URL is the full request URL, including the query.
+	URL string
+
+	// Body is the JSON request body, or nil if the request has none.
+	Body json.RawMessage
+}
+
+// Error implements the error interface.
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("opusdns: dry run: %s %s not sent", e.Method, e.URL)
+}
+
+// Is implements errors.Is for DryRunError.
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// PolicyViolationError is returned instead of sending a mutating request
+// that a Policy configured with WithPolicy rejected.
+type PolicyViolationError struct {
+	// Rule names the built-in policy that was violated, or is empty for
+	// custom policies.
+	Rule string
+
+	// Method and Path identify the request that was not sent.
+	Method string
+	Path   string
+
+	// Reason describes the violation.
+	Reason string
+
+	// Err is the error returned by a custom policy, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("opusdns: policy violation: %s %s: %s", e.Method, e.Path, e.Reason)
+}
+
+// Is implements errors.Is for PolicyViolationError.
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// Unwrap returns the error of the custom policy, if any.
+func (e *PolicyViolationError) Unwrap() error {
+	return e.Err
+}
+
 // ValidationError represents a validation error for input data.
 type ValidationError struct {
 	// Field is the name of the field that failed validation.
@@ -261,6 +748,139 @@ func (e *ConfigError) Unwrap() error {
 	return ErrInvalidInput
 }
 
+// PermissionError is returned by a client-side permission pre-check when the
+// authenticated identity lacks one or more required permissions.
+type PermissionError struct {
+	// Missing lists the required permissions the identity does not hold.
+	Missing []models.Permission
+}
+
+// Error implements the error interface.
+func (e *PermissionError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, p := range e.Missing {
+		missing[i] = string(p)
+	}
+	return fmt.Sprintf("opusdns: missing required permissions: %s", strings.Join(missing, ", "))
+}
+
+// Is implements errors.Is for PermissionError.
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// Unwrap returns ErrForbidden.
+func (e *PermissionError) Unwrap() error {
+	return ErrForbidden
+}
+
+// RegistrationError is returned by DomainsService.RegisterWithDefaults and
+// records which step failed. Steps before it have completed and are reflected
+// in the accompanying RegistrationResult.
+type RegistrationError struct {
+	// Step is the step that failed.
+	Step RegistrationStep
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RegistrationError) Error() string {
+	return fmt.Sprintf("opusdns: registration failed at %s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RegistrationError) Unwrap() error {
+	return e.Err
+}
+
+// NSMigrationError is returned by DomainsService.MigrateNameservers and
+// records which step failed and whether the migration was rolled back.
+type NSMigrationError struct {
+	// Step is the step that failed.
+	Step NSMigrationStep
+
+	// Err is the underlying error.
+	Err error
+
+	// RolledBack reports whether the previous delegation and TTLs were restored.
+	RolledBack bool
+
+	// RollbackErr is the error from rolling back, if it failed.
+	RollbackErr error
+}
+
+// Error implements the error interface.
+func (e *NSMigrationError) Error() string {
+	msg := fmt.Sprintf("opusdns: nameserver migration failed at %s: %v", e.Step, e.Err)
+	switch {
+	case e.RolledBack:
+		msg += " (rolled back)"
+	case e.RollbackErr != nil:
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *NSMigrationError) Unwrap() error {
+	return e.Err
+}
+
+// DNSSECPublishError is returned by DomainsService.EnableDNSSECAndPublishDS
+// and records which step failed.
+type DNSSECPublishError struct {
+	// Step is the step that failed.
+	Step DNSSECPublishStep
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DNSSECPublishError) Error() string {
+	return fmt.Sprintf("opusdns: DNSSEC setup failed at %s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DNSSECPublishError) Unwrap() error {
+	return e.Err
+}
+
+// VanityNSSetupError is returned by DomainsService.SetupVanityNameservers
+// and records which step failed and whether the delegation was rolled back.
+type VanityNSSetupError struct {
+	// Step is the step that failed.
+	Step VanityNSStep
+
+	// Err is the underlying error.
+	Err error
+
+	// RolledBack reports whether the previous delegation was restored.
+	RolledBack bool
+
+	// RollbackErr is the error from rolling back, if it failed.
+	RollbackErr error
+}
+
+// Error implements the error interface.
+func (e *VanityNSSetupError) Error() string {
+	msg := fmt.Sprintf("opusdns: vanity nameserver setup failed at %s: %v", e.Step, e.Err)
+	switch {
+	case e.RolledBack:
+		msg += " (rolled back)"
+	case e.RollbackErr != nil:
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *VanityNSSetupError) Unwrap() error {
+	return e.Err
+}
+
 // Helper functions for error checking
 
 // IsAPIError returns true if err is an APIError and extracts it.
@@ -272,6 +892,24 @@ func IsAPIError(err error) (*APIError, bool) {
 	return nil, false
 }
 
+// IsPaymentRequiredError returns true if err is a PaymentRequiredError and extracts it.
+func IsPaymentRequiredError(err error) (*PaymentRequiredError, bool) {
+	var payErr *PaymentRequiredError
+	if errors.As(err, &payErr) {
+		return payErr, true
+	}
+	return nil, false
+}
+
+// IsMaintenanceError returns true if err is a MaintenanceError and extracts it.
+func IsMaintenanceError(err error) (*MaintenanceError, bool) {
+	var maintErr *MaintenanceError
+	if errors.As(err, &maintErr) {
+		return maintErr, true
+	}
+	return nil, false
+}
+
 // IsNotFoundError returns true if the error indicates a resource was not found.
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
@@ -297,6 +935,12 @@ func IsConflictError(err error) bool {
 	return errors.Is(err, ErrConflict)
 }
 
+// IsConflictVersionError returns true if a conditional write failed because
+// the resource changed since the expected version.
+func IsConflictVersionError(err error) bool {
+	return errors.Is(err, ErrConflictVersion)
+}
+
 // IsRetryableError returns true if the error is retryable.
 func IsRetryableError(err error) bool {
 	var apiErr *APIError
@@ -306,6 +950,44 @@ func IsRetryableError(err error) bool {
 	return false
 }
 
+// IsRecordProtectedError returns true if a record patch was rejected because
+// it changes or removes a protected record.
+func IsRecordProtectedError(err error) bool {
+	return errors.Is(err, ErrRecordProtected)
+}
+
+// IsDryRunError returns true if a mutating call was not sent because of dry-run mode.
+func IsDryRunError(err error) bool {
+	return errors.Is(err, ErrDryRun)
+}
+
+// IsPolicyViolationError returns true if err is a PolicyViolationError and extracts it.
+func IsPolicyViolationError(err error) (*PolicyViolationError, bool) {
+	var violation *PolicyViolationError
+	if errors.As(err, &violation) {
+		return violation, true
+	}
+	return nil, false
+}
+
+// IsCircuitOpenError returns true if a request was not sent because the
+// circuit breaker is open.
+func IsCircuitOpenError(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
+
+// IsTooManyResultsError returns true if ListZones or ListDomains stopped
+// because it exceeded MaxPages or MaxItems.
+func IsTooManyResultsError(err error) bool {
+	return errors.Is(err, ErrTooManyResults)
+}
+
+// IsDeadlineWouldExceedError returns true if a call gave up early because
+// its next attempt would start after the context deadline.
+func IsDeadlineWouldExceedError(err error) bool {
+	return errors.Is(err, ErrDeadlineWouldExceed)
+}
+
 // IsValidationError returns true if the error is a validation error.
 func IsValidationError(err error) bool {
 	var validationErr *ValidationError
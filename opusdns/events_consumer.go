@@ -0,0 +1,296 @@
+package opusdns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Default settings for EventsService.Consume.
+const (
+	DefaultConsumePollInterval = 30 * time.Second
+	DefaultConsumeBufferSize   = 100
+	DefaultConsumeMaxInFlight  = 1
+	DefaultConsumeMaxAttempts  = 3
+	DefaultConsumeRetryWaitMin = 1 * time.Second
+	DefaultConsumeRetryWaitMax = 30 * time.Second
+)
+
+// EventHandler processes a single event. Returning nil marks the event as
+// handled, after which it is acknowledged.
+type EventHandler func(ctx context.Context, event models.Event) error
+
+// ConsumeOptions configures EventsService.Consume. Zero values use the defaults.
+type ConsumeOptions struct {
+	// Filter narrows which events are consumed (type, subtype, object).
+	// Paging, sorting and the acknowledged filter are set by Consume.
+	Filter *models.ListEventsOptions
+
+	// PollInterval is the time to wait after the unacknowledged events have been drained.
+	PollInterval time.Duration
+
+	// BufferSize is the number of fetched events that may wait for a handler.
+	// When the buffer is full, polling pauses until handlers catch up.
+	BufferSize int
+
+	// MaxInFlight is the number of events handled concurrently.
+	MaxInFlight int
+
+	// MaxAttempts is the number of handler attempts per delivery before the
+	// event is passed to DeadLetter.
+	MaxAttempts int
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between handler attempts.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// DeadLetter is called with the last handler error once MaxAttempts is
+	// exhausted. If it returns nil the event is acknowledged; otherwise (or if
+	// DeadLetter is nil) the event stays unacknowledged and is delivered again
+	// on a later poll.
+	DeadLetter func(ctx context.Context, event models.Event, err error) error
+
+	// OnError is called for polling and acknowledgement failures, which do not stop Consume.
+	OnError func(err error)
+}
+
+// withDefaults returns a copy of opts with zero values replaced by defaults.
+func (o *ConsumeOptions) withDefaults() ConsumeOptions {
+	var out ConsumeOptions
+	if o != nil {
+		out = *o
+	}
+	if out.PollInterval <= 0 {
+		out.PollInterval = DefaultConsumePollInterval
+	}
+	if out.BufferSize <= 0 {
+		out.BufferSize = DefaultConsumeBufferSize
+	}
+	if out.MaxInFlight <= 0 {
+		out.MaxInFlight = DefaultConsumeMaxInFlight
+	}
+	if out.MaxAttempts <= 0 {
+		out.MaxAttempts = DefaultConsumeMaxAttempts
+	}
+	if out.RetryWaitMin <= 0 {
+		out.RetryWaitMin = DefaultConsumeRetryWaitMin
+	}
+	if out.RetryWaitMax < out.RetryWaitMin {
+		out.RetryWaitMax = DefaultConsumeRetryWaitMax
+		if out.RetryWaitMax < out.RetryWaitMin {
+			out.RetryWaitMax = out.RetryWaitMin
+		}
+	}
+	return out
+}
+
+// Consume polls unacknowledged events oldest first and passes each to handler
+// until ctx is done, then waits for in-flight handlers and returns ctx.Err().
+//
+// Delivery is at-least-once: an event is acknowledged only after handler (or
+// DeadLetter) succeeds, so a crash, a failed acknowledgement or an unhandled
+// failure leads to redelivery. Handlers should therefore be idempotent.
+// Memory is bounded by BufferSize plus one page of events: polling blocks while
+// the buffer is full rather than accumulating events.
+func (s *EventsService) Consume(ctx context.Context, handler EventHandler, opts *ConsumeOptions) error {
+	o := opts.withDefaults()
+
+	c := &eventConsumer{
+		service: s,
+		handler: handler,
+		opts:    o,
+		queue:   make(chan models.Event, o.BufferSize),
+		pending: make(map[models.EventID]bool),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.MaxInFlight; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range c.queue {
+				c.process(ctx, event)
+			}
+		}()
+	}
+
+	c.poll(ctx)
+
+	close(c.queue)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// eventConsumer holds the state of a single Consume call.
+type eventConsumer struct {
+	service *EventsService
+	handler EventHandler
+	opts    ConsumeOptions
+	queue   chan models.Event
+
+	mu      sync.Mutex
+	pending map[models.EventID]bool // queued or in flight
+}
+
+// poll enqueues unacknowledged events until ctx is done.
+func (c *eventConsumer) poll(ctx context.Context) {
+	for {
+		if err := c.drain(ctx); err != nil && ctx.Err() == nil {
+			c.reportError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.service.client.http.clock.After(c.opts.PollInterval):
+		}
+	}
+}
+
+// drain walks the unacknowledged events and enqueues those not already
+// pending. Acknowledgements remove events from the listing while it is
+// walked, which would shift later events onto pages already read, so it pages
+// by creation time: each request asks for the events created after the newest
+// one seen (a second early, as the filter has second precision) and skips
+// events already seen in this drain. Page numbers are only advanced while a
+// full page shares one second and the cursor cannot move.
+func (c *eventConsumer) drain(ctx context.Context) error {
+	acknowledged := false
+	seen := make(map[models.EventID]bool)
+	var cursor *time.Time
+
+	for page := 1; ; {
+		pageOpts := cloneOptions(c.opts.Filter)
+		pageOpts.Page = page
+		if pageOpts.PageSize == 0 {
+			pageOpts.PageSize = DefaultPageSize
+		}
+		pageOpts.SortBy = models.EventSortByCreatedOn
+		pageOpts.SortOrder = models.SortAsc
+		pageOpts.Acknowledged = &acknowledged
+		if cursor != nil {
+			after := cursor.Truncate(time.Second).Add(-time.Second)
+			pageOpts.CreatedAfter = &after
+		}
+
+		resp, err := c.service.ListEventsPage(ctx, pageOpts)
+		if err != nil {
+			return err
+		}
+
+		var newest *time.Time
+		for _, event := range resp.Results {
+			if event.CreatedOn != nil {
+				newest = event.CreatedOn
+			}
+			if seen[event.EventID] {
+				continue
+			}
+			seen[event.EventID] = true
+			if !c.markPending(event.EventID) {
+				continue
+			}
+			select {
+			case c.queue <- event:
+			case <-ctx.Done():
+				c.clearPending(event.EventID)
+				return ctx.Err()
+			}
+		}
+
+		if !resp.Pagination.HasNextPage {
+			return nil
+		}
+		if newest == nil || (cursor != nil && !newest.Truncate(time.Second).After(cursor.Truncate(time.Second))) {
+			page++
+			continue
+		}
+
+		// Only events from the second before the new cursor on can be
+		// listed again, so forget the older ones.
+		cursor, page = newest, 1
+		window := cursor.Truncate(time.Second).Add(-time.Second)
+		seen = make(map[models.EventID]bool)
+		for _, event := range resp.Results {
+			if event.CreatedOn == nil || !event.CreatedOn.Before(window) {
+				seen[event.EventID] = true
+			}
+		}
+	}
+}
+
+// process handles one event with retries and acknowledges it on success.
+func (c *eventConsumer) process(ctx context.Context, event models.Event) {
+	defer c.clearPending(event.EventID)
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	var err error
+	for attempt := 1; attempt <= c.opts.MaxAttempts; attempt++ {
+		if err = c.handler(ctx, event); err == nil {
+			break
+		}
+		if attempt == c.opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.service.client.http.clock.After(c.backoff(attempt)):
+		}
+	}
+
+	if err != nil {
+		if c.opts.DeadLetter == nil || ctx.Err() != nil {
+			return
+		}
+		if c.opts.DeadLetter(ctx, event, err) != nil {
+			return
+		}
+	}
+
+	if err := c.service.AcknowledgeEvent(ctx, event.EventID); err != nil && ctx.Err() == nil {
+		c.reportError(err)
+	}
+}
+
+// backoff returns the wait before the attempt following attempt.
+func (c *eventConsumer) backoff(attempt int) time.Duration {
+	wait := c.opts.RetryWaitMin << (attempt - 1)
+	if wait <= 0 || wait > c.opts.RetryWaitMax {
+		wait = c.opts.RetryWaitMax
+	}
+	return wait
+}
+
+// markPending records id as pending and reports whether it was not already.
+func (c *eventConsumer) markPending(id models.EventID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending[id] {
+		return false
+	}
+	c.pending[id] = true
+	return true
+}
+
+// clearPending removes id from the pending set.
+func (c *eventConsumer) clearPending(id models.EventID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, id)
+}
+
+// reportError passes err to the OnError callback, if any.
+func (c *eventConsumer) reportError(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
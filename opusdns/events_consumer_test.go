@@ -0,0 +1,212 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventStore is a fake events endpoint that serves unacknowledged events and records acknowledgements.
+type eventStore struct {
+	mu    sync.Mutex
+	ids   []models.EventID
+	acked map[models.EventID]bool
+}
+
+func (s *eventStore) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/events":
+			assert.Equal(t, "false", r.URL.Query().Get("acknowledged"))
+			assert.Equal(t, "created_on", r.URL.Query().Get("sort_by"))
+			assert.Equal(t, "asc", r.URL.Query().Get("sort_order"))
+
+			var results []models.Event
+			for _, id := range s.ids {
+				if !s.acked[id] {
+					results = append(results, models.Event{EventID: id})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(models.EventListResponse{Results: results})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/v1/events/"):
+			s.acked[models.EventID(strings.TrimPrefix(r.URL.Path, "/v1/events/"))] = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func (s *eventStore) isAcked(id models.EventID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acked[id]
+}
+
+func TestEventsService_Consume(t *testing.T) {
+	store := &eventStore{
+		ids:   []models.EventID{"event_ok", "event_flaky", "event_poison", "event_dropped"},
+		acked: map[models.EventID]bool{},
+	}
+	client := newTestClient(t, store.handler(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	attempts := map[models.EventID]int{}
+	var deadLettered []models.EventID
+
+	handler := func(ctx context.Context, event models.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[event.EventID]++
+		switch event.EventID {
+		case "event_flaky":
+			if attempts[event.EventID] == 1 {
+				return errors.New("temporary failure")
+			}
+		case "event_poison", "event_dropped":
+			return errors.New("cannot process")
+		}
+		return nil
+	}
+
+	opts := &ConsumeOptions{
+		PollInterval: 10 * time.Millisecond,
+		BufferSize:   1,
+		MaxInFlight:  2,
+		MaxAttempts:  2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+		DeadLetter: func(ctx context.Context, event models.Event, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if event.EventID == "event_dropped" {
+				return errors.New("dead-letter queue unavailable")
+			}
+			deadLettered = append(deadLettered, event.EventID)
+			if len(deadLettered) == 1 {
+				// Everything that can be acknowledged has been by now or will be shortly.
+				go func() {
+					assert.Eventually(t, func() bool {
+						return store.isAcked("event_ok") && store.isAcked("event_flaky") && store.isAcked("event_poison")
+					}, time.Second, 5*time.Millisecond)
+					cancel()
+				}()
+			}
+			return nil
+		},
+	}
+
+	err := client.Events.Consume(ctx, handler, opts)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.True(t, store.isAcked("event_ok"))
+	assert.True(t, store.isAcked("event_flaky"))
+	assert.True(t, store.isAcked("event_poison"))
+	assert.False(t, store.isAcked("event_dropped"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, attempts["event_ok"])
+	assert.Equal(t, 2, attempts["event_flaky"])
+	assert.Equal(t, []models.EventID{"event_poison"}, deadLettered)
+}
+
+func TestEventConsumer_DrainWhileAcknowledging(t *testing.T) {
+	base := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	type storedEvent struct {
+		id      models.EventID
+		created time.Time
+	}
+	// Two events share a second and straddle the first page boundary.
+	events := []storedEvent{
+		{"event_1", base},
+		{"event_2", base.Add(time.Second)},
+		{"event_3", base.Add(time.Second + 500*time.Millisecond)},
+		{"event_4", base.Add(2 * time.Second)},
+		{"event_5", base.Add(3 * time.Second)},
+	}
+	acked := map[models.EventID]bool{}
+	var createdAfter []string
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		createdAfter = append(createdAfter, q.Get("created_after"))
+		var after time.Time
+		if v := q.Get("created_after"); v != "" {
+			var err error
+			after, err = time.Parse(time.RFC3339, v)
+			require.NoError(t, err)
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("page_size"))
+
+		var unacked []models.Event
+		for _, e := range events {
+			if !acked[e.id] && e.created.After(after) {
+				unacked = append(unacked, models.Event{EventID: e.id, CreatedOn: models.TimePtr(e.created)})
+			}
+		}
+		start := min((page-1)*size, len(unacked))
+		end := min(start+size, len(unacked))
+		results := unacked[start:end]
+		// Served events are handled and acknowledged at once, removing them
+		// from the listing before the next page is fetched.
+		for _, e := range results {
+			acked[e.EventID] = true
+		}
+		_ = json.NewEncoder(w).Encode(models.EventListResponse{
+			Results:    results,
+			Pagination: models.Pagination{HasNextPage: end < len(unacked)},
+		})
+	}))
+
+	c := &eventConsumer{
+		service: client.Events.(*EventsService),
+		opts:    ConsumeOptions{Filter: &models.ListEventsOptions{PageSize: 2}},
+		queue:   make(chan models.Event, len(events)),
+		pending: make(map[models.EventID]bool),
+	}
+	require.NoError(t, c.drain(context.Background()))
+	close(c.queue)
+
+	var drained []models.EventID
+	for event := range c.queue {
+		drained = append(drained, event.EventID)
+	}
+	assert.Equal(t, []models.EventID{"event_1", "event_2", "event_3", "event_4", "event_5"}, drained)
+	assert.Equal(t, []string{"", "2026-10-15T12:00:00Z", "2026-10-15T12:00:01Z"}, createdAfter)
+}
+
+func TestConsumeOptions_Defaults(t *testing.T) {
+	var opts *ConsumeOptions
+	o := opts.withDefaults()
+
+	assert.Equal(t, DefaultConsumePollInterval, o.PollInterval)
+	assert.Equal(t, DefaultConsumeBufferSize, o.BufferSize)
+	assert.Equal(t, DefaultConsumeMaxInFlight, o.MaxInFlight)
+	assert.Equal(t, DefaultConsumeMaxAttempts, o.MaxAttempts)
+
+	c := &eventConsumer{opts: ConsumeOptions{RetryWaitMin: time.Second, RetryWaitMax: 5 * time.Second}}
+	assert.Equal(t, time.Second, c.backoff(1))
+	assert.Equal(t, 4*time.Second, c.backoff(3))
+	assert.Equal(t, 5*time.Second, c.backoff(4))
+	assert.Equal(t, 5*time.Second, c.backoff(100))
+}
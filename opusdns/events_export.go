@@ -0,0 +1,189 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// EventExportFormat is the output format of EventsService.Export.
+type EventExportFormat string
+
+const (
+	// EventExportFormatCSV writes one CSV row per event with a header row.
+	EventExportFormatCSV EventExportFormat = "csv"
+
+	// EventExportFormatJSONL writes one JSON object per line.
+	EventExportFormatJSONL EventExportFormat = "jsonl"
+)
+
+// eventCSVHeader is the column layout written by the CSV export.
+var eventCSVHeader = []string{
+	"event_id",
+	"created_on",
+	"type",
+	"subtype",
+	"object_type",
+	"object_id",
+	"message",
+	"error_code",
+	"error_detail",
+	"acknowledged_on",
+}
+
+// EventExportOptions configures EventsService.Export.
+type EventExportOptions struct {
+	// Type filters by event type (optional).
+	Type models.EventType
+
+	// Subtype filters by event subtype (optional).
+	Subtype models.EventSubtype
+
+	// ObjectType filters by object type (optional).
+	ObjectType models.EventObjectType
+
+	// Since includes only events created at or after this time (optional).
+	Since time.Time
+
+	// Until includes only events created before this time (optional).
+	Until time.Time
+
+	// Format is the output format (defaults to CSV).
+	Format EventExportFormat
+
+	// PageSize is the number of events fetched per request (defaults to DefaultPageSize).
+	PageSize int
+
+	// Progress is called after each page is written with the running total (optional).
+	Progress func(exported int)
+}
+
+// Export streams all events matching opts to w, oldest first, fetching one
+// page at a time so large extracts never have to fit in memory. It returns
+// the number of events written.
+func (s *EventsService) Export(ctx context.Context, w io.Writer, opts *EventExportOptions) (int, error) {
+	o := EventExportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Format == "" {
+		o.Format = EventExportFormatCSV
+	}
+	if !o.Since.IsZero() && !o.Until.IsZero() && !o.Until.After(o.Since) {
+		return 0, &ValidationError{Field: "until", Message: "must be after since", Value: o.Until}
+	}
+
+	var write func(models.Event) error
+	var flush func() error
+	switch o.Format {
+	case EventExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(eventCSVHeader); err != nil {
+			return 0, err
+		}
+		write = func(e models.Event) error { return cw.Write(eventCSVRow(e)) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case EventExportFormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(e models.Event) error { return enc.Encode(e) }
+		flush = func() error { return nil }
+	default:
+		return 0, &ValidationError{Field: "format", Message: "must be csv or jsonl", Value: o.Format}
+	}
+
+	listOpts := &models.ListEventsOptions{
+		PageSize:   o.PageSize,
+		SortBy:     models.EventSortByCreatedOn,
+		SortOrder:  models.SortAsc,
+		Type:       o.Type,
+		Subtype:    o.Subtype,
+		ObjectType: o.ObjectType,
+	}
+	if listOpts.PageSize == 0 {
+		listOpts.PageSize = DefaultPageSize
+	}
+	if !o.Since.IsZero() {
+		// The filter is exclusive with second precision, so start a second
+		// early and skip older events below
+		after := o.Since.Truncate(time.Second).Add(-time.Second)
+		listOpts.CreatedAfter = &after
+	}
+	if !o.Until.IsZero() {
+		listOpts.CreatedBefore = &o.Until
+	}
+
+	exported := 0
+	for page := 1; ; page++ {
+		listOpts.Page = page
+		resp, err := s.ListEventsPage(ctx, listOpts)
+		if err != nil {
+			return exported, err
+		}
+
+		// Events are sorted by creation time, so the first one past Until ends the export.
+		done := !resp.Pagination.HasNextPage
+		for _, event := range resp.Results {
+			if event.CreatedOn != nil {
+				if !o.Since.IsZero() && event.CreatedOn.Before(o.Since) {
+					continue
+				}
+				if !o.Until.IsZero() && !event.CreatedOn.Before(o.Until) {
+					done = true
+					break
+				}
+			}
+			if err := write(event); err != nil {
+				return exported, fmt.Errorf("opusdns: failed to write event %s: %w", event.EventID, err)
+			}
+			exported++
+		}
+
+		if err := flush(); err != nil {
+			return exported, err
+		}
+		if o.Progress != nil {
+			o.Progress(exported)
+		}
+		if done {
+			return exported, nil
+		}
+	}
+}
+
+// eventCSVRow formats an event as a row matching eventCSVHeader.
+func eventCSVRow(e models.Event) []string {
+	var errorCode, errorDetail string
+	if e.EventData.Error != nil {
+		errorCode = e.EventData.Error.Code
+		errorDetail = e.EventData.Error.Detail
+	}
+
+	return []string{
+		string(e.EventID),
+		formatEventTime(e.CreatedOn),
+		string(models.Deref(e.Type)),
+		string(models.Deref(e.Subtype)),
+		string(e.ObjectType),
+		models.Deref(e.ObjectID),
+		e.EventData.Message,
+		errorCode,
+		errorDetail,
+		formatEventTime(e.AcknowledgedOn),
+	}
+}
+
+// formatEventTime formats t as RFC 3339 in UTC, or "" when unset.
+func formatEventTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,147 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsService_Export(t *testing.T) {
+	at := func(s string) *time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &ts
+	}
+	transfer := models.EventTypeInboundTransfer
+	objectID := "domain_1"
+
+	pages := [][]models.Event{
+		{
+			{EventID: "event_1", CreatedOn: at("2024-01-01T10:00:00Z"), Type: &transfer, ObjectID: &objectID, EventData: models.EventData{Message: "transfer started"}},
+			{EventID: "event_2", CreatedOn: at("2024-06-01T10:00:00Z"), Type: &transfer, EventData: models.EventData{Message: "transfer failed", Error: &models.EventError{Code: "denied", Detail: "auth code invalid"}}},
+		},
+		{
+			{EventID: "event_3", CreatedOn: at("2024-12-31T23:00:00Z"), Type: &transfer},
+			{EventID: "event_4", CreatedOn: at("2025-01-01T00:00:00Z"), Type: &transfer},
+		},
+		{
+			{EventID: "event_5", CreatedOn: at("2025-02-01T00:00:00Z"), Type: &transfer},
+		},
+	}
+
+	newServer := func(requested *[]int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			assert.Equal(t, "/v1/events", r.URL.Path)
+			assert.Equal(t, "created_on", q.Get("sort_by"))
+			assert.Equal(t, "asc", q.Get("sort_order"))
+			assert.Equal(t, "INBOUND_TRANSFER", q.Get("type"))
+			assert.Equal(t, "2023-12-31T23:59:59Z", q.Get("created_after"))
+			assert.Equal(t, "2025-01-01T00:00:00Z", q.Get("created_before"))
+
+			page := 1
+			if p := q.Get("page"); p != "" {
+				page = int(p[0] - '0')
+			}
+			*requested = append(*requested, page)
+
+			_ = json.NewEncoder(w).Encode(models.EventListResponse{
+				Results:    pages[page-1],
+				Pagination: models.Pagination{HasNextPage: page < len(pages)},
+			})
+		}))
+	}
+
+	opts := func(format EventExportFormat, progress *[]int) *EventExportOptions {
+		return &EventExportOptions{
+			Type:     models.EventTypeInboundTransfer,
+			Since:    *at("2024-01-01T00:00:00Z"),
+			Until:    *at("2025-01-01T00:00:00Z"),
+			Format:   format,
+			Progress: func(n int) { *progress = append(*progress, n) },
+		}
+	}
+
+	t.Run("csv stops at until", func(t *testing.T) {
+		var requested, progress []int
+		server := newServer(&requested)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		n, err := client.Events.Export(context.Background(), &buf, opts(EventExportFormatCSV, &progress))
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, []int{1, 2}, requested)
+		assert.Equal(t, []int{2, 3}, progress)
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, eventCSVHeader, rows[0])
+		assert.Equal(t, []string{"event_1", "2024-01-01T10:00:00Z", "INBOUND_TRANSFER", "", "", "domain_1", "transfer started", "", "", ""}, rows[1])
+		assert.Equal(t, "denied", rows[2][7])
+		assert.Equal(t, "event_3", rows[3][0])
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		var requested, progress []int
+		server := newServer(&requested)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		n, err := client.Events.Export(context.Background(), &buf, opts(EventExportFormatJSONL, &progress))
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		var event models.Event
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+		assert.Equal(t, models.EventID("event_2"), event.EventID)
+	})
+
+	t.Run("includes events created exactly at since", func(t *testing.T) {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "2024-01-01T09:59:59Z", r.URL.Query().Get("created_after"))
+			_ = json.NewEncoder(w).Encode(models.EventListResponse{Results: []models.Event{
+				{EventID: "event_early", CreatedOn: at("2024-01-01T09:59:59Z")},
+				{EventID: "event_at_since", CreatedOn: at("2024-01-01T10:00:00Z")},
+				{EventID: "event_later", CreatedOn: at("2024-01-01T10:00:01Z")},
+			}})
+		}))
+
+		var buf bytes.Buffer
+		n, err := client.Events.Export(context.Background(), &buf, &EventExportOptions{
+			Since:  *at("2024-01-01T10:00:00Z"),
+			Format: EventExportFormatJSONL,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Contains(t, buf.String(), "event_at_since")
+		assert.NotContains(t, buf.String(), "event_early")
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint("http://127.0.0.1:0"))
+		require.NoError(t, err)
+
+		_, err = client.Events.Export(context.Background(), &bytes.Buffer{}, &EventExportOptions{Format: "xml"})
+		assert.True(t, IsValidationError(err))
+	})
+}
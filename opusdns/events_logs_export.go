@@ -0,0 +1,230 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// LogExportFormat is the output format of EventsService.ExportLogs.
+type LogExportFormat string
+
+const (
+	// LogExportFormatNDJSON writes one JSON object per line, as read by the
+	// Splunk HTTP Event Collector and Elastic's NDJSON inputs.
+	LogExportFormatNDJSON LogExportFormat = "ndjson"
+
+	// LogExportFormatCEF writes one ArcSight Common Event Format line per
+	// entry.
+	LogExportFormatCEF LogExportFormat = "cef"
+)
+
+// LogExportOptions configures EventsService.ExportLogs.
+type LogExportOptions struct {
+	// ObjectType filters by object type (optional).
+	ObjectType models.EventObjectType
+
+	// Action filters by action (optional).
+	Action string
+
+	// UserID filters by the user who performed the action (optional).
+	UserID models.UserID
+
+	// Since includes only entries created at or after this time (optional).
+	Since time.Time
+
+	// Until includes only entries created before this time (optional).
+	Until time.Time
+
+	// ResumeToken continues a previous export after its last entry, from
+	// LogExportResult.ResumeToken. It replaces Since.
+	ResumeToken string
+
+	// PageSize is the number of entries fetched per request (defaults to DefaultPageSize).
+	PageSize int
+}
+
+// LogExportResult reports the progress of EventsService.ExportLogs.
+type LogExportResult struct {
+	// Exported is the number of entries written.
+	Exported int
+
+	// ResumeToken continues the export after the last entry written. It is
+	// the token passed in when nothing was written, and empty if neither a
+	// token nor an entry was available.
+	ResumeToken string
+}
+
+// logResumeToken is the position encoded in a resume token: the creation
+// time of the last entry written and the IDs of the entries written with
+// that time, which the API cannot tell apart by time alone.
+type logResumeToken struct {
+	After time.Time `json:"after"`
+	IDs   []string  `json:"ids,omitempty"`
+}
+
+// ExportLogs streams the audit log (object logs) to w in an SIEM format,
+// oldest first, fetching one page at a time. Feed the returned resume token
+// to the next export to continue where this one stopped without duplicates,
+// for example from a cron job shipping the log to Splunk or Elastic.
+//
+// The result is returned with errors too: it covers the entries written
+// before the error, so the export can be resumed from there.
+func (s *EventsService) ExportLogs(ctx context.Context, opts *LogExportOptions, w io.Writer, format LogExportFormat) (*LogExportResult, error) {
+	o := LogExportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	result := &LogExportResult{ResumeToken: o.ResumeToken}
+
+	var write func(models.ObjectLog) error
+	switch format {
+	case LogExportFormatNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(l models.ObjectLog) error { return enc.Encode(l) }
+	case LogExportFormatCEF:
+		write = func(l models.ObjectLog) error {
+			_, err := io.WriteString(w, formatCEF(l)+"\n")
+			return err
+		}
+	default:
+		return nil, &ValidationError{Field: "format", Message: "must be ndjson or cef", Value: format}
+	}
+
+	var pos logResumeToken
+	since := o.Since
+	if o.ResumeToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(o.ResumeToken)
+		if err == nil {
+			err = json.Unmarshal(data, &pos)
+		}
+		if err != nil {
+			return nil, &ValidationError{Field: "resume_token", Message: "invalid resume token", Value: o.ResumeToken}
+		}
+		since = pos.After
+	}
+	if !since.IsZero() && !o.Until.IsZero() && !o.Until.After(since) {
+		return nil, &ValidationError{Field: "until", Message: "must be after since", Value: o.Until}
+	}
+
+	listOpts := &models.ListObjectLogsOptions{
+		PageSize:   o.PageSize,
+		SortBy:     "created_on",
+		SortOrder:  models.SortAsc,
+		ObjectType: o.ObjectType,
+		Action:     o.Action,
+		UserID:     o.UserID,
+	}
+	if listOpts.PageSize == 0 {
+		listOpts.PageSize = DefaultPageSize
+	}
+	if !since.IsZero() {
+		// The filter has second precision, so start a second early and skip
+		// what was already exported below
+		after := since.Truncate(time.Second).Add(-time.Second)
+		listOpts.CreatedAfter = &after
+	}
+	if !o.Until.IsZero() {
+		listOpts.CreatedBefore = &o.Until
+	}
+
+	for page := 1; ; page++ {
+		listOpts.Page = page
+		resp, err := s.ListObjectLogs(ctx, listOpts)
+		if err != nil {
+			return result, err
+		}
+
+		done := !resp.Pagination.HasNextPage
+		for _, entry := range resp.Results {
+			if created := entry.CreatedOn; created != nil {
+				if !since.IsZero() && created.Before(since) {
+					continue
+				}
+				if created.Equal(pos.After) && slices.Contains(pos.IDs, entry.ObjectLogID) {
+					continue
+				}
+				if !o.Until.IsZero() && !created.Before(o.Until) {
+					done = true
+					break
+				}
+			}
+			if err := write(entry); err != nil {
+				return result, fmt.Errorf("opusdns: failed to write log entry %s: %w", entry.ObjectLogID, err)
+			}
+			result.Exported++
+
+			if created := entry.CreatedOn; created != nil {
+				if created.Equal(pos.After) {
+					pos.IDs = append(pos.IDs, entry.ObjectLogID)
+				} else if created.After(pos.After) {
+					pos = logResumeToken{After: *created, IDs: []string{entry.ObjectLogID}}
+				}
+				data, _ := json.Marshal(pos)
+				result.ResumeToken = base64.RawURLEncoding.EncodeToString(data)
+			}
+		}
+
+		if done {
+			return result, nil
+		}
+	}
+}
+
+// formatCEF formats a log entry as a CEF line. The action becomes the
+// signature ID and, lowercased, the name; deletions and outbound transfers
+// have a medium severity and failures a high one.
+func formatCEF(l models.ObjectLog) string {
+	action := string(l.Action)
+	severity := 3
+	switch {
+	case strings.HasSuffix(action, "_FAILED"):
+		severity = 7
+	case l.Action == models.ObjectEventTypeDeleted || strings.HasPrefix(action, "TRANSFER_OUT"):
+		severity = 5
+	}
+	name := strings.TrimSpace(l.ObjectType + " " + strings.ToLower(strings.ReplaceAll(action, "_", " ")))
+
+	header := []string{"CEF:0", "OpusDNS", "OpusDNS API", Version, action, name, strconv.Itoa(severity)}
+	for i, field := range header[1:] {
+		header[i+1] = strings.NewReplacer(`\`, `\\`, `|`, `\|`).Replace(field)
+	}
+
+	var ext []string
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(value))
+		}
+	}
+	if l.CreatedOn != nil {
+		add("rt", strconv.FormatInt(l.CreatedOn.UnixMilli(), 10))
+	}
+	add("externalId", l.ObjectLogID)
+	add("cs1Label", "objectType")
+	add("cs1", l.ObjectType)
+	add("cs2Label", "objectId")
+	add("cs2", l.ObjectID)
+	add("suser", models.Deref(l.PerformedByID))
+	if l.PerformedByType != nil {
+		add("cs3Label", "performedByType")
+		add("cs3", string(*l.PerformedByType))
+	}
+	if l.ServerRequestID != nil {
+		add("cs4Label", "serverRequestId")
+		add("cs4", *l.ServerRequestID)
+	}
+	if l.Details != nil {
+		details, _ := json.Marshal(*l.Details)
+		add("msg", string(details))
+	}
+
+	return strings.Join(header, "|") + "|" + strings.Join(ext, " ")
+}
@@ -0,0 +1,148 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsService_ExportLogs(t *testing.T) {
+	at := func(s string) *time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &ts
+	}
+	user := "user_1"
+	byUser := models.ExecutingEntityUser
+	requestID := "req_1"
+
+	logs := []models.ObjectLog{
+		{ObjectLogID: "log_1", ObjectID: "domain_1", ObjectType: "domain", Action: models.ObjectEventTypeCreated, CreatedOn: at("2024-01-01T10:00:00Z"),
+			PerformedByID: &user, PerformedByType: &byUser, ServerRequestID: &requestID, Details: &map[string]interface{}{"name": "example.com"}},
+		{ObjectLogID: "log_2", ObjectID: "domain_1", ObjectType: "domain", Action: models.ObjectEventTypeDeleted, CreatedOn: at("2024-01-01T10:00:00Z")},
+		{ObjectLogID: "log_3", ObjectID: "txn_1", ObjectType: "billing_transaction", Action: models.ObjectEventTypeBillingTransactionFailed, CreatedOn: at("2024-01-02T10:00:00Z")},
+	}
+
+	// newServer serves logs one per page, honouring created_after like the API
+	newServer := func(t *testing.T, fail int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			assert.Equal(t, "/v1/archive/object-logs", r.URL.Path)
+			assert.Equal(t, "created_on", q.Get("sort_by"))
+			assert.Equal(t, "asc", q.Get("sort_order"))
+
+			var matching []models.ObjectLog
+			for _, l := range logs {
+				if after := q.Get("created_after"); after == "" || l.CreatedOn.Format(time.RFC3339) > after {
+					matching = append(matching, l)
+				}
+			}
+			page := 1
+			if p := q.Get("page"); p != "" {
+				page = int(p[0] - '0')
+			}
+			if page == fail {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var results []models.ObjectLog
+			if page <= len(matching) {
+				results = matching[page-1 : page]
+			}
+			_ = json.NewEncoder(w).Encode(models.ObjectLogListResponse{
+				Results:    results,
+				Pagination: models.Pagination{HasNextPage: page < len(matching)},
+			})
+		}))
+	}
+	newTestClient := func(t *testing.T, server *httptest.Server) *Client {
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0))
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		server := newServer(t, 0)
+		defer server.Close()
+
+		var buf bytes.Buffer
+		result, err := newTestClient(t, server).Events.ExportLogs(context.Background(), nil, &buf, LogExportFormatNDJSON)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Exported)
+		assert.NotEmpty(t, result.ResumeToken)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		var first models.ObjectLog
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, "log_1", first.ObjectLogID)
+	})
+
+	t.Run("cef", func(t *testing.T) {
+		server := newServer(t, 0)
+		defer server.Close()
+
+		var buf bytes.Buffer
+		_, err := newTestClient(t, server).Events.ExportLogs(context.Background(), nil, &buf, LogExportFormatCEF)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "CEF:0|OpusDNS|OpusDNS API|"+Version+"|CREATED|domain created|3|rt=1704103200000 externalId=log_1 "+
+			"cs1Label=objectType cs1=domain cs2Label=objectId cs2=domain_1 suser=user_1 cs3Label=performedByType cs3=user "+
+			`cs4Label=serverRequestId cs4=req_1 msg={"name":"example.com"}`, lines[0])
+		assert.Contains(t, lines[1], "|DELETED|domain deleted|5|")
+		assert.Contains(t, lines[2], "|BILLING_TRANSACTION_FAILED|billing_transaction billing transaction failed|7|")
+	})
+
+	t.Run("resumes after an interrupted export", func(t *testing.T) {
+		server := newServer(t, 2)
+		defer server.Close()
+
+		var buf bytes.Buffer
+		result, err := newTestClient(t, server).Events.ExportLogs(context.Background(), nil, &buf, LogExportFormatNDJSON)
+		require.Error(t, err)
+		assert.Equal(t, 1, result.Exported)
+
+		// log_2 shares log_1's timestamp, so only the token's IDs keep it apart
+		resumed := newServer(t, 0)
+		defer resumed.Close()
+		buf.Reset()
+		result, err = newTestClient(t, resumed).Events.ExportLogs(context.Background(), &LogExportOptions{ResumeToken: result.ResumeToken}, &buf, LogExportFormatNDJSON)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Exported)
+		assert.Contains(t, buf.String(), `"log_2"`)
+		assert.Contains(t, buf.String(), `"log_3"`)
+
+		// Nothing new: the token is kept
+		token := result.ResumeToken
+		result, err = newTestClient(t, resumed).Events.ExportLogs(context.Background(), &LogExportOptions{ResumeToken: token}, &buf, LogExportFormatNDJSON)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Exported)
+		assert.Equal(t, token, result.ResumeToken)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		client, err := NewClient(WithAPIKey("opk_test"))
+		require.NoError(t, err)
+
+		_, err = client.Events.ExportLogs(context.Background(), nil, &bytes.Buffer{}, "xml")
+		var valErr *ValidationError
+		require.True(t, errors.As(err, &valErr))
+		assert.Equal(t, "format", valErr.Field)
+
+		_, err = client.Events.ExportLogs(context.Background(), &LogExportOptions{ResumeToken: "not a token"}, &bytes.Buffer{}, LogExportFormatCEF)
+		require.True(t, errors.As(err, &valErr))
+		assert.Equal(t, "resume_token", valErr.Field)
+	})
+}
@@ -0,0 +1,285 @@
+package opusdns
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Default settings for EventBatcher.
+const (
+	DefaultBatchMaxEvents    = 1000
+	DefaultBatchMaxBytes     = 1 << 20 // 1 MiB, uncompressed
+	DefaultBatchMaxAge       = 10 * time.Second
+	DefaultBatchMaxAttempts  = 5
+	DefaultBatchRetryWaitMin = 1 * time.Second
+	DefaultBatchRetryWaitMax = 30 * time.Second
+)
+
+// EventBatch is a batch of events encoded as JSON lines.
+type EventBatch struct {
+	// Events is the number of events in the batch.
+	Events int
+
+	// Body is the JSON lines payload, gzip-compressed if ContentEncoding is "gzip".
+	Body []byte
+
+	// ContentEncoding is "gzip" for compressed batches and "" otherwise.
+	ContentEncoding string
+}
+
+// EventSink delivers batches of events to an external system. A returned
+// error makes the batcher retry the batch.
+type EventSink interface {
+	WriteBatch(ctx context.Context, batch EventBatch) error
+}
+
+// EventSinkFunc adapts a function to the EventSink interface.
+type EventSinkFunc func(ctx context.Context, batch EventBatch) error
+
+// WriteBatch calls f(ctx, batch).
+func (f EventSinkFunc) WriteBatch(ctx context.Context, batch EventBatch) error {
+	return f(ctx, batch)
+}
+
+// EventBatcherOptions configures an EventBatcher. Zero values use the defaults.
+type EventBatcherOptions struct {
+	// MaxEvents flushes the batch once it holds this many events.
+	MaxEvents int
+
+	// MaxBytes flushes the batch once its uncompressed size reaches this many bytes.
+	MaxBytes int
+
+	// MaxAge flushes the batch this long after its first event was added.
+	MaxAge time.Duration
+
+	// Compress gzips each batch before delivery.
+	Compress bool
+
+	// MaxAttempts is the number of delivery attempts per batch.
+	MaxAttempts int
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between delivery attempts.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// OnError is called when a batch flushed by MaxAge cannot be delivered.
+	// Such a batch is dropped after MaxAttempts.
+	OnError func(err error)
+}
+
+// withDefaults returns a copy of opts with zero values replaced by defaults.
+func (o *EventBatcherOptions) withDefaults() EventBatcherOptions {
+	var out EventBatcherOptions
+	if o != nil {
+		out = *o
+	}
+	if out.MaxEvents <= 0 {
+		out.MaxEvents = DefaultBatchMaxEvents
+	}
+	if out.MaxBytes <= 0 {
+		out.MaxBytes = DefaultBatchMaxBytes
+	}
+	if out.MaxAge <= 0 {
+		out.MaxAge = DefaultBatchMaxAge
+	}
+	if out.MaxAttempts <= 0 {
+		out.MaxAttempts = DefaultBatchMaxAttempts
+	}
+	if out.RetryWaitMin <= 0 {
+		out.RetryWaitMin = DefaultBatchRetryWaitMin
+	}
+	if out.RetryWaitMax < out.RetryWaitMin {
+		out.RetryWaitMax = DefaultBatchRetryWaitMax
+		if out.RetryWaitMax < out.RetryWaitMin {
+			out.RetryWaitMax = out.RetryWaitMin
+		}
+	}
+	return out
+}
+
+// EventBatcher collects events into batches and delivers them to a sink when
+// a batch is full or old enough. It is safe for concurrent use; Add blocks
+// while a full batch is being delivered, which pushes back on producers.
+type EventBatcher struct {
+	sink EventSink
+	opts EventBatcherOptions
+
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	events int
+	timer  *time.Timer
+	closed bool
+}
+
+// NewEventBatcher creates an EventBatcher delivering to sink.
+func NewEventBatcher(sink EventSink, opts *EventBatcherOptions) *EventBatcher {
+	return &EventBatcher{sink: sink, opts: opts.withDefaults()}
+}
+
+// Add appends event to the current batch and delivers the batch if it is
+// full. The returned error is the delivery error of that batch, which is
+// dropped after MaxAttempts.
+func (b *EventBatcher) Add(ctx context.Context, event models.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("opusdns: failed to encode event %s: %w", event.EventID, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("opusdns: event batcher is closed")
+	}
+
+	b.buf.Write(line)
+	b.buf.WriteByte('\n')
+	b.events++
+	if b.events == 1 {
+		b.timer = time.AfterFunc(b.opts.MaxAge, b.flushAged)
+	}
+
+	if b.events >= b.opts.MaxEvents || b.buf.Len() >= b.opts.MaxBytes {
+		return b.flushLocked(ctx)
+	}
+	return nil
+}
+
+// Flush delivers the current batch, if any.
+func (b *EventBatcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.flushLocked(ctx)
+}
+
+// Close delivers the current batch and stops the batcher. Later calls to Add fail.
+func (b *EventBatcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	return b.flushLocked(ctx)
+}
+
+// flushAged delivers the batch once MaxAge has passed since its first event.
+func (b *EventBatcher) flushAged() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.flushLocked(context.Background()); err != nil && b.opts.OnError != nil {
+		b.opts.OnError(err)
+	}
+}
+
+// flushLocked delivers and resets the current batch. b.mu must be held.
+func (b *EventBatcher) flushLocked(ctx context.Context) error {
+	if b.events == 0 {
+		return nil
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	batch := EventBatch{Events: b.events, Body: append([]byte(nil), b.buf.Bytes()...)}
+	b.buf.Reset()
+	b.events = 0
+
+	if b.opts.Compress {
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		if _, err := zw.Write(batch.Body); err != nil {
+			return fmt.Errorf("opusdns: failed to compress event batch: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("opusdns: failed to compress event batch: %w", err)
+		}
+		batch.Body = compressed.Bytes()
+		batch.ContentEncoding = "gzip"
+	}
+
+	return b.deliver(ctx, batch)
+}
+
+// deliver writes batch to the sink, retrying with exponential backoff.
+func (b *EventBatcher) deliver(ctx context.Context, batch EventBatch) error {
+	var err error
+	for attempt := 1; attempt <= b.opts.MaxAttempts; attempt++ {
+		if err = b.sink.WriteBatch(ctx, batch); err == nil {
+			return nil
+		}
+		if attempt == b.opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("opusdns: failed to deliver batch of %d event(s): %w", batch.Events, ctx.Err())
+		case <-time.After(b.backoff(attempt)):
+		}
+	}
+	return fmt.Errorf("opusdns: failed to deliver batch of %d event(s) after %d attempt(s): %w", batch.Events, b.opts.MaxAttempts, err)
+}
+
+// backoff returns the wait before the attempt following attempt.
+func (b *EventBatcher) backoff(attempt int) time.Duration {
+	wait := b.opts.RetryWaitMin << (attempt - 1)
+	if wait <= 0 || wait > b.opts.RetryWaitMax {
+		wait = b.opts.RetryWaitMax
+	}
+	return wait
+}
+
+// HTTPEventSink posts each batch to a URL as application/x-ndjson, with
+// Content-Encoding set for compressed batches. Any non-2xx response fails the
+// delivery.
+type HTTPEventSink struct {
+	// URL is the endpoint batches are posted to.
+	URL string
+
+	// Header is added to every request, e.g. for authorization (optional).
+	Header http.Header
+
+	// HTTPClient sends the requests (defaults to http.DefaultClient).
+	HTTPClient *http.Client
+}
+
+// WriteBatch posts batch to s.URL.
+func (s *HTTPEventSink) WriteBatch(ctx context.Context, batch EventBatch) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(batch.Body))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if batch.ContentEncoding != "" {
+		req.Header.Set("Content-Encoding", batch.ContentEncoding)
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("opusdns: event sink returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package opusdns
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink stores the batches it receives. The first failures writes fail.
+type recordingSink struct {
+	mu       sync.Mutex
+	batches  []EventBatch
+	attempts int
+	failures int
+}
+
+func (s *recordingSink) WriteBatch(ctx context.Context, batch EventBatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *recordingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.batches)
+}
+
+func TestEventBatcher_MaxEvents(t *testing.T) {
+	sink := &recordingSink{}
+	b := NewEventBatcher(sink, &EventBatcherOptions{MaxEvents: 2, MaxAge: time.Hour})
+	ctx := context.Background()
+
+	for _, id := range []models.EventID{"event_1", "event_2", "event_3"} {
+		require.NoError(t, b.Add(ctx, models.Event{EventID: id}))
+	}
+	require.Len(t, sink.batches, 1)
+	assert.Equal(t, 2, sink.batches[0].Events)
+	assert.Equal(t, 2, strings.Count(string(sink.batches[0].Body), "\n"))
+	assert.Empty(t, sink.batches[0].ContentEncoding)
+
+	require.NoError(t, b.Close(ctx))
+	require.Len(t, sink.batches, 2)
+	assert.Contains(t, string(sink.batches[1].Body), `"event_3"`)
+
+	assert.Error(t, b.Add(ctx, models.Event{EventID: "event_4"}))
+}
+
+func TestEventBatcher_MaxBytes(t *testing.T) {
+	sink := &recordingSink{}
+	b := NewEventBatcher(sink, &EventBatcherOptions{MaxBytes: 10, MaxAge: time.Hour})
+
+	require.NoError(t, b.Add(context.Background(), models.Event{EventID: "event_1"}))
+	require.Len(t, sink.batches, 1)
+	assert.Equal(t, 1, sink.batches[0].Events)
+}
+
+func TestEventBatcher_MaxAge(t *testing.T) {
+	sink := &recordingSink{}
+	b := NewEventBatcher(sink, &EventBatcherOptions{MaxAge: 10 * time.Millisecond})
+
+	require.NoError(t, b.Add(context.Background(), models.Event{EventID: "event_1"}))
+	assert.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestEventBatcher_Compress(t *testing.T) {
+	sink := &recordingSink{}
+	b := NewEventBatcher(sink, &EventBatcherOptions{Compress: true, MaxAge: time.Hour})
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, b.Add(ctx, models.Event{EventID: "event_1", EventData: models.EventData{Message: "domain renewed"}}))
+	}
+	require.NoError(t, b.Flush(ctx))
+	require.Len(t, sink.batches, 1)
+	assert.Equal(t, "gzip", sink.batches[0].ContentEncoding)
+
+	zr, err := gzip.NewReader(bytes.NewReader(sink.batches[0].Body))
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, 100, strings.Count(string(body), "\n"))
+	assert.Less(t, len(sink.batches[0].Body), len(body)/5)
+}
+
+func TestEventBatcher_Retry(t *testing.T) {
+	ctx := context.Background()
+	opts := &EventBatcherOptions{MaxAttempts: 3, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond, MaxAge: time.Hour}
+
+	sink := &recordingSink{failures: 2}
+	b := NewEventBatcher(sink, opts)
+	require.NoError(t, b.Add(ctx, models.Event{EventID: "event_1"}))
+	require.NoError(t, b.Flush(ctx))
+	assert.Equal(t, 3, sink.attempts)
+	assert.Len(t, sink.batches, 1)
+
+	sink = &recordingSink{failures: 3}
+	b = NewEventBatcher(sink, opts)
+	require.NoError(t, b.Add(ctx, models.Event{EventID: "event_1"}))
+	err := b.Flush(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempt(s)")
+	assert.Empty(t, sink.batches)
+}
+
+func TestHTTPEventSink(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	sink := &HTTPEventSink{URL: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	require.NoError(t, sink.WriteBatch(context.Background(), EventBatch{Events: 1, Body: []byte("{}\n"), ContentEncoding: "gzip"}))
+	assert.Equal(t, "application/x-ndjson", got.Get("Content-Type"))
+	assert.Equal(t, "gzip", got.Get("Content-Encoding"))
+
+	sink.Header = nil
+	assert.Error(t, sink.WriteBatch(context.Background(), EventBatch{Events: 1, Body: []byte("{}\n")}))
+}
@@ -0,0 +1,653 @@
+package opusdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// ExportKind is a kind of resource handled by ExportService.
+type ExportKind string
+
+const (
+	ExportZones          ExportKind = "zones"
+	ExportDomains        ExportKind = "domains"
+	ExportDomainForwards ExportKind = "domain_forwards"
+	ExportEmailForwards  ExportKind = "email_forwards"
+	ExportContacts       ExportKind = "contacts"
+)
+
+// ExportOptions configures ExportService.Export.
+type ExportOptions struct {
+	// Kinds limits the export to these resource kinds (default: all).
+	Kinds []ExportKind
+}
+
+// includes reports whether kind is exported.
+func (o *ExportOptions) includes(kind ExportKind) bool {
+	if o == nil || len(o.Kinds) == 0 {
+		return true
+	}
+	for _, k := range o.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceAction is what ExportService.Apply did with a resource.
+type ResourceAction string
+
+const (
+	ResourceCreated   ResourceAction = "created"
+	ResourceUpdated   ResourceAction = "updated"
+	ResourceUnchanged ResourceAction = "unchanged"
+	ResourceSkipped   ResourceAction = "skipped"
+	ResourceFailed    ResourceAction = "failed"
+)
+
+// ResourceChange is the outcome of applying one resource.
+type ResourceChange struct {
+	// Kind and Name identify the resource.
+	Kind ExportKind
+	Name string
+
+	// Action is what was done.
+	Action ResourceAction
+
+	// Detail explains skipped resources.
+	Detail string
+
+	// Err is set if Action is ResourceFailed.
+	Err error
+}
+
+// ApplyResult lists the outcome of ExportService.Apply per resource, in the
+// order they were applied.
+type ApplyResult struct {
+	Changes []ResourceChange
+}
+
+// Err returns the per-resource errors joined into one, or nil if no resource failed.
+func (r *ApplyResult) Err() error {
+	var errs []error
+	for _, c := range r.Changes {
+		if c.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", c.Kind, c.Name, c.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ExportService serializes an account's resources into a models.ResourceSet
+// and provisions accounts from one. It calls the other services through the
+// Client fields and needs the read (Export) or manage (Apply) permission of
+// each resource kind involved.
+type ExportService struct {
+	client *Client
+}
+
+// Export reads the zones, domains, domain forwards, email forwards and
+// contacts of the account into a ResourceSet. The result is sorted and free
+// of timestamps, so it can be committed and diffed, and marshals to JSON or
+// YAML.
+func (s *ExportService) Export(ctx context.Context, opts *ExportOptions) (*models.ResourceSet, error) {
+	set := &models.ResourceSet{Version: models.ResourceSetVersion}
+
+	if opts.includes(ExportZones) {
+		zones, err := s.client.DNS.ListZones(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list zones: %w", err)
+		}
+		for _, listed := range zones {
+			zone, err := s.client.DNS.GetZone(ctx, listed.Name)
+			if err != nil {
+				return nil, fmt.Errorf("opusdns: failed to get zone %s: %w", listed.Name, err)
+			}
+			set.Zones = append(set.Zones, exportZone(zone))
+		}
+		sort.Slice(set.Zones, func(i, j int) bool { return set.Zones[i].Name < set.Zones[j].Name })
+	}
+
+	if opts.includes(ExportDomains) {
+		domains, err := s.client.Domains.ListDomains(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list domains: %w", err)
+		}
+		for _, d := range domains {
+			set.Domains = append(set.Domains, exportDomain(d))
+		}
+		sort.Slice(set.Domains, func(i, j int) bool { return set.Domains[i].Name < set.Domains[j].Name })
+	}
+
+	if opts.includes(ExportDomainForwards) {
+		forwards, err := s.client.DomainForwards.ListDomainForwards(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list domain forwards: %w", err)
+		}
+		for _, f := range forwards {
+			set.DomainForwards = append(set.DomainForwards, exportDomainForward(f))
+		}
+		sort.Slice(set.DomainForwards, func(i, j int) bool { return set.DomainForwards[i].Hostname < set.DomainForwards[j].Hostname })
+	}
+
+	if opts.includes(ExportEmailForwards) {
+		forwards, err := s.client.EmailForwards.ListEmailForwards(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list email forwards: %w", err)
+		}
+		for _, f := range forwards {
+			set.EmailForwards = append(set.EmailForwards, exportEmailForward(f))
+		}
+		sort.Slice(set.EmailForwards, func(i, j int) bool { return set.EmailForwards[i].Hostname < set.EmailForwards[j].Hostname })
+	}
+
+	if opts.includes(ExportContacts) {
+		contacts, err := s.client.Contacts.ListContacts(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list contacts: %w", err)
+		}
+		for _, c := range contacts {
+			set.Contacts = append(set.Contacts, exportContact(c))
+		}
+		sort.Slice(set.Contacts, func(i, j int) bool { return set.Contacts[i].ImportID < set.Contacts[j].ImportID })
+	}
+
+	return set, nil
+}
+
+// Apply provisions the resources of set, creating missing ones and updating
+// those that differ. Nothing outside the set is deleted, except that the
+// records of each zone are made to match the set; the SOA record and
+// protected records are left alone.
+//
+// Contacts are applied first. A contact whose import ID does not exist in the
+// account is created, and domains referring to it use the new contact.
+// Domains are never registered: missing ones are skipped.
+//
+// Failures of individual resources are recorded in the result and do not stop
+// the others; the returned error is reserved for an unusable set or failures
+// listing the existing resources.
+func (s *ExportService) Apply(ctx context.Context, set *models.ResourceSet) (*ApplyResult, error) {
+	if set == nil {
+		return nil, &ValidationError{Field: "set", Message: "resource set is required"}
+	}
+	if set.Version != models.ResourceSetVersion {
+		return nil, &ValidationError{Field: "version", Message: fmt.Sprintf("unsupported resource set version (want %d)", models.ResourceSetVersion), Value: set.Version}
+	}
+
+	result := &ApplyResult{}
+	record := func(kind ExportKind, name string, action ResourceAction, err error) {
+		if err != nil {
+			action = ResourceFailed
+		}
+		result.Changes = append(result.Changes, ResourceChange{Kind: kind, Name: name, Action: action, Err: err})
+	}
+
+	contactIDs, err := s.applyContacts(ctx, set.Contacts, record)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range set.Zones {
+		action, err := s.applyZone(ctx, zone)
+		record(ExportZones, zone.Name, action, err)
+	}
+
+	if len(set.Domains) > 0 {
+		domains, err := s.client.Domains.ListDomains(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list domains: %w", err)
+		}
+		existing := make(map[string]models.Domain, len(domains))
+		for _, d := range domains {
+			existing[strings.ToLower(d.Name)] = d
+		}
+		for _, domain := range set.Domains {
+			current, ok := existing[strings.ToLower(domain.Name)]
+			if !ok {
+				result.Changes = append(result.Changes, ResourceChange{Kind: ExportDomains, Name: domain.Name, Action: ResourceSkipped, Detail: "not registered in this account"})
+				continue
+			}
+			action, err := s.applyDomain(ctx, current, domain, contactIDs)
+			record(ExportDomains, domain.Name, action, err)
+		}
+	}
+
+	if len(set.DomainForwards) > 0 {
+		forwards, err := s.client.DomainForwards.ListDomainForwards(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list domain forwards: %w", err)
+		}
+		existing := make(map[string]models.DomainForward, len(forwards))
+		for _, f := range forwards {
+			existing[strings.ToLower(f.Hostname)] = f
+		}
+		for _, forward := range set.DomainForwards {
+			current, ok := existing[strings.ToLower(forward.Hostname)]
+			action, err := s.applyDomainForward(ctx, current, ok, forward)
+			record(ExportDomainForwards, forward.Hostname, action, err)
+		}
+	}
+
+	if len(set.EmailForwards) > 0 {
+		forwards, err := s.client.EmailForwards.ListEmailForwards(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("opusdns: failed to list email forwards: %w", err)
+		}
+		existing := make(map[string]models.EmailForward, len(forwards))
+		for _, f := range forwards {
+			existing[strings.ToLower(f.Hostname)] = f
+		}
+		for _, forward := range set.EmailForwards {
+			current, ok := existing[strings.ToLower(forward.Hostname)]
+			action, err := s.applyEmailForward(ctx, current, ok, forward)
+			record(ExportEmailForwards, forward.Hostname, action, err)
+		}
+	}
+
+	return result, nil
+}
+
+// applyContacts creates the contacts whose import ID does not exist and
+// returns the mapping from import IDs to the IDs in this account.
+func (s *ExportService) applyContacts(ctx context.Context, contacts []models.ContactResource, record func(ExportKind, string, ResourceAction, error)) (map[string]models.ContactID, error) {
+	ids := make(map[string]models.ContactID, len(contacts))
+	if len(contacts) == 0 {
+		return ids, nil
+	}
+
+	existing, err := s.client.Contacts.ListContacts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opusdns: failed to list contacts: %w", err)
+	}
+	for _, c := range existing {
+		ids[string(c.ContactID)] = c.ContactID
+	}
+
+	for _, c := range contacts {
+		if _, ok := ids[c.ImportID]; ok && c.ImportID != "" {
+			record(ExportContacts, c.ImportID, ResourceUnchanged, nil)
+			continue
+		}
+		created, err := s.client.Contacts.CreateContact(ctx, &models.ContactCreateRequest{
+			FirstName:  c.FirstName,
+			LastName:   c.LastName,
+			Org:        c.Org,
+			Title:      c.Title,
+			Email:      c.Email,
+			Phone:      c.Phone,
+			Fax:        c.Fax,
+			Street:     c.Street,
+			City:       c.City,
+			State:      c.State,
+			PostalCode: c.PostalCode,
+			Country:    c.Country,
+			Disclose:   c.Disclose,
+		})
+		if err == nil {
+			ids[c.ImportID] = created.ContactID
+		}
+		record(ExportContacts, c.ImportID, ResourceCreated, err)
+	}
+	return ids, nil
+}
+
+// applyZone creates the zone if needed and makes its records match.
+func (s *ExportService) applyZone(ctx context.Context, desired models.ZoneResource) (ResourceAction, error) {
+	action := ResourceUpdated
+	zone, err := s.client.DNS.GetZone(ctx, desired.Name)
+	if IsNotFoundError(err) {
+		if _, err := s.client.DNS.CreateZone(ctx, &models.ZoneCreateRequest{Name: desired.Name}); err != nil {
+			return "", err
+		}
+		action = ResourceCreated
+		zone, err = s.client.DNS.GetZone(ctx, desired.Name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// The SOA record and protected records are left as they are
+	var current []models.Record
+	protected := map[string]bool{}
+	for _, r := range RRSetsToRecords(zone.RRSets) {
+		switch {
+		case r.Protected:
+			protected[recordKey(r)] = true
+		case r.Type != models.RRSetTypeSOA:
+			current = append(current, r)
+		}
+	}
+	var records []models.Record
+	for _, r := range desired.Records {
+		record := models.Record{Name: r.Name, Type: r.Type, TTL: r.TTL, RData: r.RData}
+		if r.Type != models.RRSetTypeSOA && !protected[recordKey(record)] {
+			records = append(records, record)
+		}
+	}
+
+	ops := DiffRecords(current, records)
+	if len(ops) == 0 {
+		if action == ResourceCreated {
+			return action, nil
+		}
+		return ResourceUnchanged, nil
+	}
+	if err := s.client.DNS.PatchRecords(ctx, desired.Name, ops); err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+// applyDomain updates the nameservers, renewal mode and contacts of a domain.
+func (s *ExportService) applyDomain(ctx context.Context, current models.Domain, desired models.DomainResource, contactIDs map[string]models.ContactID) (ResourceAction, error) {
+	req := &models.DomainUpdateRequest{}
+	changed := false
+
+	have := exportDomain(current)
+	if len(desired.Nameservers) > 0 && !slices.Equal(sortedLower(desired.Nameservers), have.Nameservers) {
+		for _, ns := range desired.Nameservers {
+			req.Nameservers = append(req.Nameservers, models.Nameserver{Hostname: ns})
+		}
+		changed = true
+	}
+	if desired.RenewalMode != "" && desired.RenewalMode != current.RenewalMode {
+		mode := desired.RenewalMode
+		req.RenewalMode = &mode
+		changed = true
+	}
+	for contactType, importID := range desired.Contacts {
+		id, ok := contactIDs[importID]
+		if !ok {
+			id = models.ContactID(importID)
+		}
+		if have.Contacts[contactType] == string(id) {
+			continue
+		}
+		if req.Contacts == nil {
+			req.Contacts = map[models.DomainContactType][]models.ContactHandle{}
+		}
+		req.Contacts[contactType] = []models.ContactHandle{{ContactID: id}}
+		changed = true
+	}
+
+	if !changed {
+		return ResourceUnchanged, nil
+	}
+	if _, err := s.client.Domains.UpdateDomain(ctx, current.Name, req); err != nil {
+		return "", err
+	}
+	return ResourceUpdated, nil
+}
+
+// applyDomainForward creates a domain forward or updates its redirects and state.
+func (s *ExportService) applyDomainForward(ctx context.Context, current models.DomainForward, exists bool, desired models.DomainForwardResource) (ResourceAction, error) {
+	if !exists {
+		req := &models.DomainForwardCreateRequest{Hostname: desired.Hostname, Enabled: desired.Enabled}
+		if len(desired.HTTP) > 0 {
+			req.HTTP = &models.DomainForwardProtocolSetRequest{Redirects: redirectRequests(desired.HTTP)}
+		}
+		if len(desired.HTTPS) > 0 {
+			req.HTTPS = &models.DomainForwardProtocolSetRequest{Redirects: redirectRequests(desired.HTTPS)}
+		}
+		if _, err := s.client.DomainForwards.CreateDomainForward(ctx, req); err != nil {
+			return "", err
+		}
+		return ResourceCreated, nil
+	}
+
+	have := exportDomainForward(current)
+	changed := false
+	for _, p := range []struct {
+		protocol      models.HttpProtocol
+		have, desired []models.RedirectResource
+	}{
+		{models.HttpProtocolHTTP, have.HTTP, sortedRedirects(desired.HTTP)},
+		{models.HttpProtocolHTTPS, have.HTTPS, sortedRedirects(desired.HTTPS)},
+	} {
+		if slices.Equal(p.have, p.desired) {
+			continue
+		}
+		changed = true
+		if len(p.desired) == 0 {
+			if err := s.client.DomainForwards.DeleteDomainForwardConfig(ctx, desired.Hostname, p.protocol); err != nil {
+				return "", err
+			}
+			continue
+		}
+		req := &models.DomainForwardProtocolSetRequest{Redirects: redirectRequests(p.desired)}
+		if _, err := s.client.DomainForwards.UpdateDomainForwardConfig(ctx, desired.Hostname, p.protocol, req); err != nil {
+			return "", err
+		}
+	}
+
+	if desired.Enabled != current.Enabled {
+		changed = true
+		var err error
+		if desired.Enabled {
+			err = s.client.DomainForwards.EnableDomainForward(ctx, desired.Hostname)
+		} else {
+			err = s.client.DomainForwards.DisableDomainForward(ctx, desired.Hostname)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if !changed {
+		return ResourceUnchanged, nil
+	}
+	return ResourceUpdated, nil
+}
+
+// applyEmailForward creates an email forward or updates its aliases and state.
+func (s *ExportService) applyEmailForward(ctx context.Context, current models.EmailForward, exists bool, desired models.EmailForwardResource) (ResourceAction, error) {
+	if !exists {
+		enabled := desired.Enabled
+		req := &models.EmailForwardCreateRequest{Hostname: desired.Hostname, Enabled: &enabled}
+		for _, a := range desired.Aliases {
+			req.Aliases = append(req.Aliases, models.EmailForwardAliasCreate{Alias: a.Alias, ForwardTo: a.ForwardTo})
+		}
+		if _, err := s.client.EmailForwards.CreateEmailForward(ctx, req); err != nil {
+			return "", err
+		}
+		return ResourceCreated, nil
+	}
+
+	id := current.EmailForwardID
+	changed := false
+	have := make(map[string]models.EmailForwardAlias, len(current.Aliases))
+	for _, a := range current.Aliases {
+		have[strings.ToLower(a.Alias)] = a
+	}
+
+	for _, a := range desired.Aliases {
+		key := strings.ToLower(a.Alias)
+		old, ok := have[key]
+		delete(have, key)
+		switch {
+		case !ok:
+			if _, err := s.client.EmailForwards.CreateAlias(ctx, id, &models.EmailForwardAliasCreate{Alias: a.Alias, ForwardTo: a.ForwardTo}); err != nil {
+				return "", err
+			}
+		case !slices.Equal(sortedLower(old.ForwardTo), sortedLower(a.ForwardTo)):
+			if _, err := s.client.EmailForwards.UpdateAlias(ctx, id, old.EmailForwardAliasID, &models.EmailForwardAliasUpdate{ForwardTo: a.ForwardTo}); err != nil {
+				return "", err
+			}
+		default:
+			continue
+		}
+		changed = true
+	}
+	for _, old := range have {
+		if err := s.client.EmailForwards.DeleteAlias(ctx, id, old.EmailForwardAliasID); err != nil {
+			return "", err
+		}
+		changed = true
+	}
+
+	if desired.Enabled != current.Enabled {
+		changed = true
+		var err error
+		if desired.Enabled {
+			err = s.client.EmailForwards.EnableEmailForward(ctx, id)
+		} else {
+			err = s.client.EmailForwards.DisableEmailForward(ctx, id)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if !changed {
+		return ResourceUnchanged, nil
+	}
+	return ResourceUpdated, nil
+}
+
+// exportZone converts a zone to its resource, leaving out the SOA record.
+func exportZone(zone *models.Zone) models.ZoneResource {
+	name := strings.TrimSuffix(zone.Name, ".")
+	res := models.ZoneResource{ImportID: name, Name: name}
+	for _, r := range RRSetsToRecords(zone.RRSets) {
+		if r.Type == models.RRSetTypeSOA {
+			continue
+		}
+		recordName := r.Name
+		if recordName == "" {
+			recordName = "@"
+		}
+		res.Records = append(res.Records, models.RecordResource{Name: recordName, Type: r.Type, TTL: r.TTL, RData: r.RData})
+	}
+	sort.Slice(res.Records, func(i, j int) bool {
+		a, b := res.Records[i], res.Records[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.RData < b.RData
+	})
+	return res
+}
+
+// exportDomain converts a domain to its resource.
+func exportDomain(d models.Domain) models.DomainResource {
+	res := models.DomainResource{ImportID: string(d.DomainID), Name: d.Name, RenewalMode: d.RenewalMode}
+	for _, ns := range d.Nameservers {
+		res.Nameservers = append(res.Nameservers, ns.Hostname)
+	}
+	res.Nameservers = sortedLower(res.Nameservers)
+	for _, c := range d.Contacts {
+		if res.Contacts == nil {
+			res.Contacts = map[models.DomainContactType]string{}
+		}
+		res.Contacts[c.ContactType] = string(c.ContactID)
+	}
+	return res
+}
+
+// exportDomainForward converts a domain forward to its resource.
+func exportDomainForward(f models.DomainForward) models.DomainForwardResource {
+	res := models.DomainForwardResource{ImportID: f.Hostname, Hostname: f.Hostname, Enabled: f.Enabled}
+	convert := func(set *models.DomainForwardProtocolSet) []models.RedirectResource {
+		if set == nil {
+			return nil
+		}
+		var out []models.RedirectResource
+		for _, r := range set.Redirects {
+			out = append(out, models.RedirectResource{
+				RequestPath:    r.RequestPath,
+				TargetProtocol: r.TargetProtocol,
+				TargetHostname: r.TargetHostname,
+				TargetPath:     r.TargetPath,
+				RedirectCode:   r.RedirectCode,
+			})
+		}
+		return sortedRedirects(out)
+	}
+	res.HTTP = convert(f.HTTP)
+	res.HTTPS = convert(f.HTTPS)
+	return res
+}
+
+// exportEmailForward converts an email forward to its resource.
+func exportEmailForward(f models.EmailForward) models.EmailForwardResource {
+	res := models.EmailForwardResource{ImportID: string(f.EmailForwardID), Hostname: f.Hostname, Enabled: f.Enabled}
+	for _, a := range f.Aliases {
+		forwardTo := append([]string(nil), a.ForwardTo...)
+		sort.Strings(forwardTo)
+		res.Aliases = append(res.Aliases, models.EmailAliasResource{Alias: a.Alias, ForwardTo: forwardTo})
+	}
+	sort.Slice(res.Aliases, func(i, j int) bool { return res.Aliases[i].Alias < res.Aliases[j].Alias })
+	return res
+}
+
+// exportContact converts a contact to its resource.
+func exportContact(c models.Contact) models.ContactResource {
+	return models.ContactResource{
+		ImportID:   string(c.ContactID),
+		FirstName:  c.FirstName,
+		LastName:   c.LastName,
+		Org:        c.Org,
+		Title:      c.Title,
+		Email:      c.Email,
+		Phone:      c.Phone,
+		Fax:        c.Fax,
+		Street:     c.Street,
+		City:       c.City,
+		State:      c.State,
+		PostalCode: c.PostalCode,
+		Country:    c.Country,
+		Disclose:   c.Disclose,
+	}
+}
+
+// recordKey identifies a record by name, type and data like DiffRecords.
+func recordKey(r models.Record) string {
+	name := r.Name
+	if name == "" {
+		name = "@"
+	}
+	return strings.ToLower(name) + " " + strings.ToUpper(string(r.Type)) + " " + r.RData
+}
+
+// sortedRedirects returns redirects sorted by request path.
+func sortedRedirects(redirects []models.RedirectResource) []models.RedirectResource {
+	out := append([]models.RedirectResource(nil), redirects...)
+	sort.Slice(out, func(i, j int) bool { return out[i].RequestPath < out[j].RequestPath })
+	return out
+}
+
+// redirectRequests converts redirect resources to API requests.
+func redirectRequests(redirects []models.RedirectResource) []models.HttpRedirectRequest {
+	out := make([]models.HttpRedirectRequest, len(redirects))
+	for i, r := range redirects {
+		out[i] = models.HttpRedirectRequest{
+			RequestPath:    r.RequestPath,
+			TargetProtocol: r.TargetProtocol,
+			TargetHostname: r.TargetHostname,
+			TargetPath:     r.TargetPath,
+			RedirectCode:   r.RedirectCode,
+		}
+	}
+	return out
+}
+
+// sortedLower returns the values lowercased, without trailing dots, and sorted.
+func sortedLower(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.TrimSuffix(strings.ToLower(v), ".")
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,122 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportServer fakes the zone endpoints used by Export and Apply.
+type exportServer struct {
+	mu      sync.Mutex
+	zones   map[string][]models.RRSet
+	created []string
+	patches []models.RRSetPatchRequest
+}
+
+func (e *exportServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns":
+			var zones []models.Zone
+			for name := range e.zones {
+				zones = append(zones, models.Zone{Name: name})
+			}
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: zones})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/dns":
+			var req models.ZoneCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			e.zones[req.Name] = nil
+			e.created = append(e.created, req.Name)
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: req.Name})
+		case r.Method == http.MethodGet:
+			name := r.URL.Path[len("/v1/dns/"):]
+			rrsets, ok := e.zones[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"title":"Zone not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: name + ".", RRSets: rrsets})
+		case r.Method == http.MethodPatch:
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			e.patches = append(e.patches, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestExportService_Export(t *testing.T) {
+	fake := &exportServer{zones: map[string][]models.RRSet{
+		"example.com": {
+			{Name: "@", Type: models.RRSetTypeSOA, TTL: 3600, Records: []models.RecordData{{RData: "ns1.opusdns.net. hostmaster.example.com. 1 7200 900 1209600 3600"}}},
+			{Name: "www", Type: models.RRSetTypeA, TTL: 3600, Records: []models.RecordData{{RData: "192.0.2.2"}, {RData: "192.0.2.1"}}},
+			{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, Records: []models.RecordData{{RData: "10 mail.example.com."}}},
+		},
+	}}
+	client := newTestClient(t, fake.handler(t))
+
+	set, err := client.Export.Export(context.Background(), &ExportOptions{Kinds: []ExportKind{ExportZones}})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.ResourceSetVersion, set.Version)
+	assert.Empty(t, set.Domains)
+	assert.Empty(t, set.Contacts)
+	require.Len(t, set.Zones, 1)
+	assert.Equal(t, models.ZoneResource{
+		ImportID: "example.com",
+		Name:     "example.com",
+		Records: []models.RecordResource{
+			{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, RData: "10 mail.example.com."},
+			{Name: "www", Type: models.RRSetTypeA, TTL: 3600, RData: "192.0.2.1"},
+			{Name: "www", Type: models.RRSetTypeA, TTL: 3600, RData: "192.0.2.2"},
+		},
+	}, set.Zones[0])
+}
+
+func TestExportService_Apply(t *testing.T) {
+	t.Run("creates missing zones and syncs records", func(t *testing.T) {
+		fake := &exportServer{zones: map[string][]models.RRSet{
+			"example.com": {
+				{Name: "www", Type: models.RRSetTypeA, TTL: 3600, Records: []models.RecordData{{RData: "192.0.2.1"}}},
+			},
+		}}
+		client := newTestClient(t, fake.handler(t))
+
+		result, err := client.Export.Apply(context.Background(), &models.ResourceSet{
+			Version: models.ResourceSetVersion,
+			Zones: []models.ZoneResource{
+				{Name: "example.com", Records: []models.RecordResource{{Name: "www", Type: models.RRSetTypeA, TTL: 3600, RData: "192.0.2.1"}}},
+				{Name: "example.org", Records: []models.RecordResource{{Name: "@", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.9"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err())
+
+		assert.Equal(t, []ResourceChange{
+			{Kind: ExportZones, Name: "example.com", Action: ResourceUnchanged},
+			{Kind: ExportZones, Name: "example.org", Action: ResourceCreated},
+		}, result.Changes)
+		assert.Equal(t, []string{"example.org"}, fake.created)
+		assert.Len(t, fake.patches, 1)
+	})
+
+	t.Run("rejects unsupported versions", func(t *testing.T) {
+		client := newTestClient(t, (&exportServer{}).handler(t))
+
+		_, err := client.Export.Apply(context.Background(), &models.ResourceSet{Version: 99})
+		assert.True(t, IsValidationError(err))
+	})
+}
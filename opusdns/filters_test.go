@@ -0,0 +1,76 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc3339UTCSeconds matches an RFC 3339 UTC timestamp without fractional seconds.
+var rfc3339UTCSeconds = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)
+
+func TestFilterConstructors_Query(t *testing.T) {
+	var query url.Values
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{}})
+	}))
+	ctx := context.Background()
+
+	// assertTime checks that param is a whole-second UTC time within a
+	// few seconds of want, allowing for truncation and the clock ticking.
+	assertTime := func(t *testing.T, param string, want time.Time) {
+		t.Helper()
+		value := query.Get(param)
+		require.Regexp(t, rfc3339UTCSeconds, value, param)
+		got, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err)
+		assert.WithinDuration(t, want, got, 2*time.Second, param)
+	}
+
+	t.Run("zones", func(t *testing.T) {
+		_, err := client.DNS.ListZonesPage(ctx, models.ZonesCreatedWithin(24*time.Hour))
+		require.NoError(t, err)
+		assertTime(t, "created_after", time.Now().Add(-24*time.Hour))
+
+		_, err = client.DNS.ListZonesPage(ctx, models.ZonesUpdatedWithin(time.Hour))
+		require.NoError(t, err)
+		assertTime(t, "updated_after", time.Now().Add(-time.Hour))
+		assert.Empty(t, query.Get("created_after"))
+	})
+
+	t.Run("domains", func(t *testing.T) {
+		_, err := client.Domains.ListDomainsPage(ctx, models.DomainsExpiringWithin(30*24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, "expires_on", query.Get("sort_by"))
+		assert.Equal(t, "asc", query.Get("sort_order"))
+		assertTime(t, "expires_after", time.Now())
+		assertTime(t, "expires_before", time.Now().Add(30*24*time.Hour))
+
+		_, err = client.Domains.ListDomainsPage(ctx, models.DomainsCreatedWithin(7*24*time.Hour))
+		require.NoError(t, err)
+		assertTime(t, "created_after", time.Now().Add(-7*24*time.Hour))
+	})
+
+	t.Run("events", func(t *testing.T) {
+		_, err := client.Events.ListEventsPage(ctx, models.EventsForObject(models.EventObjectTypeDomain, "domain_01h45"))
+		require.NoError(t, err)
+		assert.Equal(t, "created_on", query.Get("sort_by"))
+		assert.Equal(t, "asc", query.Get("sort_order"))
+		assert.Equal(t, "DOMAIN", query.Get("object_type"))
+		assert.Equal(t, "domain_01h45", query.Get("object_id"))
+		assert.Empty(t, query.Get("created_after"))
+
+		_, err = client.Events.ListEventsPage(ctx, models.EventsCreatedWithin(time.Hour))
+		require.NoError(t, err)
+		assertTime(t, "created_after", time.Now().Add(-time.Hour))
+	})
+}
@@ -5,11 +5,14 @@ import (
 	"bytes"
 	"context"
 	cryptorand "crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -26,10 +29,29 @@ type HTTPClient struct {
 	httpClient *http.Client
 	baseURL    *url.URL
 
+	// guard is the retry budget and circuit breaker shared by all calls
+	guard *retryGuard
+
+	// signer signs requests when RequestSigningSecret is set
+	signer *requestSigner
+
+	// clock is Config.Clock, or the system clock
+	clock Clock
+
+	// attemptTimeout is the default limit of one attempt: HTTPTimeout,
+	// unless a custom HTTPClient brings its own timeout
+	attemptTimeout time.Duration
+
 	// Rate limiting
 	mu          sync.Mutex
 	rateLimited bool
 	retryAfter  time.Time
+
+	// schemaWarnings holds the schema drift warnings already logged
+	schemaWarnings sync.Map
+
+	// audit is the journal of mutating calls, or nil without AuditWriter
+	audit *auditJournal
 }
 
 // NewHTTPClient creates a new low-level HTTP client with the given configuration.
@@ -49,24 +71,83 @@ func NewHTTPClient(config *Config) (*HTTPClient, error) {
 		return nil, &ConfigError{Field: "APIEndpoint", Message: fmt.Sprintf("invalid URL: %v", err)}
 	}
 
-	// Use provided HTTP client or create default
+	// Use provided HTTP client or create default. HTTPTimeout is applied per
+	// attempt through the request context, so calls can override it.
 	httpClient := config.HTTPClient
+	var attemptTimeout time.Duration
 	if httpClient == nil {
 		httpClient = &http.Client{
-			Timeout: config.HTTPTimeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 100,
-				IdleConnTimeout:     90 * time.Second,
-			},
+			Transport: newTransport(config),
 		}
+		attemptTimeout = config.HTTPTimeout
 	}
 
-	return &HTTPClient{
-		config:     config,
-		httpClient: httpClient,
-		baseURL:    baseURL,
-	}, nil
+	var clock Clock = systemClock{}
+	if config.Clock != nil {
+		clock = config.Clock
+	}
+
+	c := &HTTPClient{
+		config:         config,
+		httpClient:     httpClient,
+		baseURL:        baseURL,
+		guard:          newRetryGuard(config, clock),
+		signer:         newRequestSigner(config, clock),
+		clock:          clock,
+		attemptTimeout: attemptTimeout,
+	}
+	if config.AuditWriter != nil {
+		c.audit = &auditJournal{w: config.AuditWriter}
+	}
+	return c, nil
+}
+
+// newTransport returns config.Transport, or an *http.Transport based on
+// http.DefaultTransport (proxy from the environment, dial and TLS handshake
+// timeouts) with the configured connection, proxy and TLS settings. Zero settings in a
+// Config not created by NewConfig fall back to the defaults.
+func newTransport(config *Config) http.RoundTripper {
+	if config.Transport != nil {
+		return config.Transport
+	}
+
+	idleConns := config.MaxIdleConnsPerHost
+	if idleConns == 0 {
+		idleConns = DefaultMaxIdleConnsPerHost
+	}
+	idleTimeout := config.IdleConnTimeout
+	if idleTimeout == 0 {
+		idleTimeout = DefaultIdleConnTimeout
+	}
+	keepAlive := config.TCPKeepAlive
+	if keepAlive == 0 {
+		keepAlive = DefaultTCPKeepAlive
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: keepAlive}).DialContext
+	transport.MaxConnsPerHost = config.MaxConnsPerHost
+	transport.MaxIdleConns = idleConns
+	transport.MaxIdleConnsPerHost = idleConns
+	transport.IdleConnTimeout = idleTimeout
+	transport.DisableCompression = config.DisableCompression
+	if config.ProxyURL != "" {
+		proxyURL, _ := url.Parse(config.ProxyURL) // checked by Validate
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if config.RootCAs != nil || len(config.ClientCertificates) > 0 {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:      config.RootCAs,
+			Certificates: config.ClientCertificates,
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+	if config.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto turns off HTTP/2 negotiation
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
 }
 
 // Request represents an HTTP request to the OpusDNS API.
@@ -77,6 +158,10 @@ type Request struct {
 	Body        interface{}
 	Headers     http.Header
 	ContentType string
+
+	// ReadOnly marks a request with a mutating method, such as a POST that
+	// runs a check, as free of side effects so it is sent in dry-run mode.
+	ReadOnly bool
 }
 
 // Response represents an HTTP response from the OpusDNS API.
@@ -84,31 +169,85 @@ type Response struct {
 	StatusCode int
 	Headers    http.Header
 	Body       []byte
+
+	// ctx is the context of the call that returned the response, used to
+	// pick the logger for warnings raised while decoding it.
+	ctx context.Context
 }
 
 // Do executes an HTTP request with retry logic and returns the response.
-func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
+// When OverallTimeout (or ContextWithOverallTimeout) is set, the whole call
+// including retries is bounded by it and exceeding it returns an error
+// matching ErrTimeout. Mutating calls are recorded in the audit journal
+// when AuditWriter is set.
+func (c *HTTPClient) Do(ctx context.Context, req *Request) (resp *Response, err error) {
+	if c.auditable(req) {
+		start := c.clock.Now()
+		defer func() { c.recordAudit(ctx, req, start, resp, err) }()
+	}
+
+	if err := c.checkPolicies(ctx, req); err != nil {
+		return nil, err
+	}
+	if c.isDryRun(ctx, req) {
+		return nil, c.newDryRunError(ctx, req)
+	}
+
+	overall := c.overallTimeout(ctx)
+	if overall <= 0 {
+		return c.do(ctx, req)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, overall)
+	defer cancel()
+
+	resp, err = c.do(callCtx, req)
+	if err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+		// The overall budget ran out rather than the caller's context.
+		return nil, fmt.Errorf("%w: overall timeout of %v exceeded: %w", ErrTimeout, overall, err)
+	}
+	return resp, err
+}
+
+// do runs the retry loop for Do.
+func (c *HTTPClient) do(ctx context.Context, req *Request) (*Response, error) {
 	var lastErr error
 
+	var call *callLog
+	if c.config.Debug {
+		call = &callLog{id: newCallID(), start: c.clock.Now()}
+		ctx = context.WithValue(ctx, callLogKey{}, call)
+	}
+
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
+		if call != nil {
+			call.attempt = attempt + 1
+		}
+
+		if err := c.guard.allow(attempt, lastErr); err != nil {
+			return nil, err
+		}
 
 		// Check if we should wait due to rate limiting
-		if err := c.waitForRateLimit(ctx); err != nil {
+		if err := c.waitForRateLimit(ctx, lastErr); err != nil {
 			return nil, err
 		}
 
 		// Calculate backoff delay for retries
 		if attempt > 0 {
 			delay := c.calculateBackoff(attempt)
-			c.logf("Retry attempt %d after %v", attempt, delay)
+			if err := c.checkDeadline(ctx, "retry backoff", delay, lastErr); err != nil {
+				return nil, err
+			}
+			c.logf(ctx, "Retry attempt %d after %v", attempt, delay)
 
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(delay):
+			case <-c.clock.After(delay):
 			}
 		}
 
@@ -117,7 +256,7 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 		}
 
 		// Execute the request
-		resp, err := c.doRequest(ctx, req)
+		resp, err := c.doSignedRequest(ctx, req)
 		if err != nil {
 			lastErr = err
 
@@ -127,24 +266,44 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 			}
 
 			// Retry on network errors
-			c.logf("Request failed (attempt %d): %v", attempt+1, err)
+			c.guard.record(true)
+			c.logf(ctx, "Request failed: %v", err)
+			continue
+		}
+
+		// Wait out short maintenance windows; otherwise DecodeResponse
+		// reports the maintenance without further retries
+		if m := parseMaintenance(resp, c.clock.Now()); m != nil {
+			waited, err := c.waitForMaintenance(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			if !waited {
+				return resp, nil
+			}
+			lastErr = m
 			continue
 		}
 
 		// Handle rate limiting
 		if resp.StatusCode == http.StatusTooManyRequests {
-			c.handleRateLimit(resp)
+			c.guard.record(true)
+			c.handleRateLimit(ctx, resp)
 			lastErr = NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
 			continue
 		}
 
 		// Retry on server errors (5xx)
 		if resp.StatusCode >= 500 {
+			c.guard.record(true)
 			lastErr = NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
-			c.logf("Server error %d (attempt %d)", resp.StatusCode, attempt+1)
+			c.logf(ctx, "Server error %d", resp.StatusCode)
 			continue
 		}
 
+		c.guard.record(false)
+		c.reportDeprecation(ctx, req, resp)
+
 		// Return response (success or client error)
 		return resp, nil
 	}
@@ -152,8 +311,66 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 	return nil, fmt.Errorf("opusdns: max retries exceeded: %w", lastErr)
 }
 
-// doRequest performs a single HTTP request without retries.
+// doSignedRequest performs a request with doRequest and, if it was rejected
+// after the signer's clock was corrected for skew, sends it once more with a
+// fresh signature.
+func (c *HTTPClient) doSignedRequest(ctx context.Context, req *Request) (*Response, error) {
+	resp, err := c.doRequest(ctx, req)
+	if err != nil || !c.signer.observe(resp.Headers) || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	c.logf(ctx, "Clock skew detected, signing again")
+	return c.doRequest(ctx, req)
+}
+
+// doRequest performs a single HTTP request without retries, bounded by the
+// request timeout.
 func (c *HTTPClient) doRequest(ctx context.Context, req *Request) (*Response, error) {
+	attemptCtx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := c.newHTTPRequest(attemptCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	reqURL := httpReq.URL
+
+	// Execute request
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, &RequestError{Op: "execute", URL: reqURL.String(), Err: err}
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	if err := decompressBody(httpResp); err != nil {
+		return nil, &RequestError{Op: "decompress", URL: reqURL.String(), Err: err}
+	}
+
+	// Read response body
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, &RequestError{Op: "read", URL: reqURL.String(), Err: err}
+	}
+
+	if reqID := httpResp.Header.Get("X-Request-ID"); reqID != "" {
+		c.logf(ctx, "Response: %d (request_id: %s) %s", httpResp.StatusCode, reqID, string(body))
+	} else {
+		c.logf(ctx, "Response: %d %s", httpResp.StatusCode, string(body))
+	}
+	reportResponse(ctx, req, httpResp.StatusCode, httpResp.Header, body)
+
+	return &Response{
+		StatusCode: httpResp.StatusCode,
+		Headers:    httpResp.Header,
+		Body:       body,
+		ctx:        ctx,
+	}, nil
+}
+
+// newHTTPRequest builds the HTTP request for req, including authentication
+// and the JSON-encoded body.
+func (c *HTTPClient) newHTTPRequest(ctx context.Context, req *Request) (*http.Request, error) {
 	// Build URL
 	reqURL := c.baseURL.JoinPath(req.Path)
 	if req.Query != nil {
@@ -162,13 +379,22 @@ func (c *HTTPClient) doRequest(ctx context.Context, req *Request) (*Response, er
 
 	// Serialize body
 	var bodyReader io.Reader
+	var body []byte
+	var contentEncoding string
 	if req.Body != nil {
 		data, err := json.Marshal(req.Body)
 		if err != nil {
 			return nil, &RequestError{Op: "marshal", URL: reqURL.String(), Err: err}
 		}
+		c.logf(ctx, "Request body: %s", string(data))
+		if threshold := c.config.CompressRequestsOver; threshold > 0 && len(data) >= threshold {
+			if data, err = gzipData(data); err != nil {
+				return nil, &RequestError{Op: "compress", URL: reqURL.String(), Err: err}
+			}
+			contentEncoding = "gzip"
+		}
+		body = data
 		bodyReader = bytes.NewReader(data)
-		c.logf("Request body: %s", string(data))
 	}
 
 	// Create HTTP request
@@ -189,36 +415,130 @@ func (c *HTTPClient) doRequest(ctx context.Context, req *Request) (*Response, er
 		}
 		httpReq.Header.Set("Content-Type", contentType)
 	}
+	if contentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", contentEncoding)
+	}
+	if !c.config.DisableCompression {
+		// Set explicitly so compression also applies with a custom
+		// Transport; responses are decompressed by decompressBody
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
 
-	// Copy custom headers
+	// Copy custom headers, replacing the defaults above
 	for key, values := range req.Headers {
+		httpReq.Header.Del(key)
 		for _, value := range values {
 			httpReq.Header.Add(key, value)
 		}
 	}
+	c.signer.sign(httpReq, body)
 
-	c.logf("%s %s", req.Method, reqURL.String())
+	c.logf(ctx, "%s %s", req.Method, reqURL.String())
 
-	// Execute request
-	httpResp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, &RequestError{Op: "execute", URL: reqURL.String(), Err: err}
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+	return httpReq, nil
+}
 
-	// Read response body
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, &RequestError{Op: "read", URL: reqURL.String(), Err: err}
-	}
+// Stream executes a request and returns the response body unread, for
+// responses too large to hold in memory. Rate limits, server errors and
+// network failures are retried like Do until a successful response starts;
+// other error responses are returned as errors from DecodeResponse. The
+// caller must close the body. OverallTimeout does not apply, but HTTPTimeout
+// (or ContextWithRequestTimeout) bounds the whole transfer including reading
+// the body.
+func (c *HTTPClient) Stream(ctx context.Context, req *Request) (io.ReadCloser, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if err := c.guard.allow(attempt, lastErr); err != nil {
+			return nil, err
+		}
+		if err := c.waitForRateLimit(ctx, lastErr); err != nil {
+			return nil, err
+		}
+		if attempt > 0 {
+			delay := c.calculateBackoff(attempt)
+			if err := c.checkDeadline(ctx, "retry backoff", delay, lastErr); err != nil {
+				return nil, err
+			}
+			c.logf(ctx, "Retry attempt %d after %v", attempt, delay)
 
-	c.logf("Response: %d %s", httpResp.StatusCode, string(body))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-c.clock.After(delay):
+			}
+		}
 
-	return &Response{
-		StatusCode: httpResp.StatusCode,
-		Headers:    httpResp.Header,
-		Body:       body,
-	}, nil
+		attemptCtx, cancel := c.withRequestTimeout(ctx)
+		httpReq, err := c.newHTTPRequest(attemptCtx, req)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		httpResp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			cancel()
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = &RequestError{Op: "execute", URL: httpReq.URL.String(), Err: err}
+			c.guard.record(true)
+			c.logf(ctx, "Request failed: %v", err)
+			continue
+		}
+		if err := decompressBody(httpResp); err != nil {
+			_ = httpResp.Body.Close()
+			cancel()
+			return nil, &RequestError{Op: "decompress", URL: httpReq.URL.String(), Err: err}
+		}
+		c.signer.observe(httpResp.Header)
+		reportResponse(ctx, req, httpResp.StatusCode, httpResp.Header, nil)
+
+		if httpResp.StatusCode < 300 {
+			c.guard.record(false)
+			c.logf(ctx, "Response: %d (streaming)", httpResp.StatusCode)
+			return &cancelOnClose{ReadCloser: httpResp.Body, cancel: cancel}, nil
+		}
+
+		body, err := io.ReadAll(httpResp.Body)
+		_ = httpResp.Body.Close()
+		cancel()
+		if err != nil {
+			return nil, &RequestError{Op: "read", URL: httpReq.URL.String(), Err: err}
+		}
+		resp := &Response{StatusCode: httpResp.StatusCode, Headers: httpResp.Header, Body: body}
+		c.logf(ctx, "Response: %d %s", resp.StatusCode, string(body))
+
+		if m := parseMaintenance(resp, c.clock.Now()); m != nil {
+			waited, err := c.waitForMaintenance(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			if !waited {
+				return nil, m
+			}
+			lastErr = m
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			c.guard.record(true)
+			c.handleRateLimit(ctx, resp)
+			lastErr = NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
+		case resp.StatusCode >= 500:
+			c.guard.record(true)
+			lastErr = NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
+		default:
+			c.guard.record(false)
+			if err := c.DecodeResponse(resp, nil); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("opusdns: unexpected status %d", resp.StatusCode)
+		}
+	}
+
+	return nil, fmt.Errorf("opusdns: max retries exceeded: %w", lastErr)
 }
 
 // Get performs a GET request.
@@ -265,6 +585,87 @@ func (c *HTTPClient) Delete(ctx context.Context, path string) (*Response, error)
 	})
 }
 
+// Head performs a HEAD request.
+func (c *HTTPClient) Head(ctx context.Context, path string, query url.Values) (*Response, error) {
+	return c.Do(ctx, &Request{
+		Method: http.MethodHead,
+		Path:   path,
+		Query:  query,
+	})
+}
+
+// Options performs an OPTIONS request.
+func (c *HTTPClient) Options(ctx context.Context, path string) (*Response, error) {
+	return c.Do(ctx, &Request{
+		Method: http.MethodOptions,
+		Path:   path,
+	})
+}
+
+// EndpointCapabilities describes what an API endpoint supports, as reported by Probe.
+type EndpointCapabilities struct {
+	// Path is the probed API path.
+	Path string
+
+	// Exists is false when the server reported the path as not found.
+	Exists bool
+
+	// Methods lists the HTTP methods advertised in the Allow header (upper-case).
+	Methods []string
+
+	// Headers contains the raw response headers for further inspection.
+	Headers http.Header
+}
+
+// Allows reports whether the endpoint advertises support for the given HTTP method.
+func (e *EndpointCapabilities) Allows(method string) bool {
+	for _, m := range e.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Probe issues a non-mutating OPTIONS request to path and reports the methods the
+// endpoint advertises via its Allow header (falling back to
+// Access-Control-Allow-Methods). It lets callers detect features such as new
+// endpoints without sending trial writes. A 404 is reported as Exists=false
+// rather than an error; a 405 means the endpoint exists but does not answer
+// OPTIONS. Any other error status is returned as an *APIError.
+func (c *HTTPClient) Probe(ctx context.Context, path string) (*EndpointCapabilities, error) {
+	resp, err := c.Options(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &EndpointCapabilities{
+		Path:    path,
+		Exists:  resp.StatusCode != http.StatusNotFound,
+		Headers: resp.Headers,
+	}
+	if !caps.Exists {
+		return caps, nil
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusMethodNotAllowed {
+		return nil, c.DecodeResponse(resp, nil)
+	}
+
+	allow := resp.Headers.Values("Allow")
+	if len(allow) == 0 {
+		allow = resp.Headers.Values("Access-Control-Allow-Methods")
+	}
+	for _, value := range allow {
+		for _, method := range strings.Split(value, ",") {
+			if method = strings.ToUpper(strings.TrimSpace(method)); method != "" {
+				caps.Methods = append(caps.Methods, method)
+			}
+		}
+	}
+
+	return caps, nil
+}
+
 // timestampRegex matches ISO 8601 timestamps without timezone info (e.g., "2026-01-23T08:26:55")
 var timestampRegex = regexp.MustCompile(`"(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2})"`)
 
@@ -274,14 +675,22 @@ func fixTimestamps(data []byte) []byte {
 }
 
 // DecodeResponse decodes the response body into the given target.
-// Returns an APIError if the response indicates an error (status >= 400).
+// Returns an APIError if the response indicates an error (status >= 400), and
+// a *DecodeError holding the raw body if the body cannot be decoded.
 func (c *HTTPClient) DecodeResponse(resp *Response, target interface{}) error {
 	// Check for error responses
 	if resp.StatusCode >= 400 {
-		return NewAPIError(&http.Response{
+		apiErr := NewAPIError(&http.Response{
 			StatusCode: resp.StatusCode,
 			Header:     resp.Headers,
 		}, resp.Body)
+		if apiErr.StatusCode == http.StatusPaymentRequired {
+			return newPaymentRequiredError(apiErr)
+		}
+		if m := parseMaintenance(resp, c.clock.Now()); m != nil {
+			return m
+		}
+		return apiErr
 	}
 
 	// Handle empty responses (204 No Content, etc.)
@@ -294,9 +703,18 @@ func (c *HTTPClient) DecodeResponse(resp *Response, target interface{}) error {
 
 	// Decode JSON response
 	if err := json.Unmarshal(body, target); err != nil {
-		return fmt.Errorf("opusdns: failed to decode response: %w", err)
+		return &DecodeError{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Headers.Get("Content-Type"),
+			RequestID:   resp.Headers.Get("X-Request-ID"),
+			Body:        resp.Body,
+			Err:         err,
+		}
 	}
 
+	if c.config.StrictDecoding {
+		c.checkSchema(resp.ctx, body, target)
+	}
 	return nil
 }
 
@@ -327,7 +745,7 @@ func (c *HTTPClient) calculateJitterFactor() float64 {
 }
 
 // handleRateLimit processes a 429 rate limit response.
-func (c *HTTPClient) handleRateLimit(resp *Response) {
+func (c *HTTPClient) handleRateLimit(ctx context.Context, resp *Response) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -339,34 +757,39 @@ func (c *HTTPClient) handleRateLimit(resp *Response) {
 		if seconds, err := strconv.Atoi(retryAfterStr); err == nil {
 			retryAfter = time.Duration(seconds) * time.Second
 		} else if t, err := http.ParseTime(retryAfterStr); err == nil {
-			retryAfter = time.Until(t)
+			retryAfter = t.Sub(c.clock.Now())
 		}
 	}
 
-	c.retryAfter = time.Now().Add(retryAfter)
-	c.logf("Rate limited, will retry after %v", retryAfter)
+	c.retryAfter = c.clock.Now().Add(retryAfter)
+	c.logf(ctx, "Rate limited, will retry after %v", retryAfter)
 }
 
-// waitForRateLimit blocks until the rate limit period has passed.
-func (c *HTTPClient) waitForRateLimit(ctx context.Context) error {
+// waitForRateLimit blocks until the rate limit period has passed, unless
+// that is after ctx's deadline.
+func (c *HTTPClient) waitForRateLimit(ctx context.Context, lastErr error) error {
 	c.mu.Lock()
-	if !c.rateLimited || time.Now().After(c.retryAfter) {
+	if !c.rateLimited || c.clock.Now().After(c.retryAfter) {
 		c.rateLimited = false
 		c.mu.Unlock()
 		return nil
 	}
 
-	waitDuration := time.Until(c.retryAfter)
+	waitDuration := c.retryAfter.Sub(c.clock.Now())
 	c.mu.Unlock()
 
-	c.logf("Waiting %v for rate limit", waitDuration)
+	if err := c.checkDeadline(ctx, "rate limit", waitDuration, lastErr); err != nil {
+		return err
+	}
+
+	c.logf(ctx, "Waiting %v for rate limit", waitDuration)
 
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.After(waitDuration):
+	case <-c.clock.After(waitDuration):
 		c.mu.Lock()
-		if !time.Now().Before(c.retryAfter) {
+		if !c.clock.Now().Before(c.retryAfter) {
 			c.rateLimited = false
 		}
 		c.mu.Unlock()
@@ -374,20 +797,81 @@ func (c *HTTPClient) waitForRateLimit(ctx context.Context) error {
 	}
 }
 
-// logf logs a debug message if debug logging is enabled.
-func (c *HTTPClient) logf(format string, args ...interface{}) {
+// checkDeadline returns a *DeadlineWouldExceedError if the next attempt,
+// after waiting wait for reason, would start after ctx's deadline.
+func (c *HTTPClient) checkDeadline(ctx context.Context, reason string, wait time.Duration, lastErr error) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	next := c.clock.Now().Add(wait)
+	if next.Before(deadline) {
+		return nil
+	}
+	return &DeadlineWouldExceedError{Reason: reason, NextAttempt: next, Deadline: deadline, Err: lastErr}
+}
+
+// reportDeprecation logs and forwards a deprecation notice on resp, if any.
+func (c *HTTPClient) reportDeprecation(ctx context.Context, req *Request, resp *Response) {
+	d := parseDeprecation(req.Method, req.Path, c.config.APIVersion, resp.Headers)
+	if d == nil {
+		return
+	}
+
+	c.logf(ctx, "Warning: %s", d)
+	if c.config.DeprecationHandler != nil {
+		c.config.DeprecationHandler(*d)
+	}
+}
+
+// callLog carries the fields included in debug output for one Do call.
+type callLog struct {
+	id      string
+	start   time.Time
+	attempt int
+}
+
+// callLogKey is the context key for the current callLog.
+type callLogKey struct{}
+
+// newCallID returns a short random identifier correlating the log lines of one call.
+func newCallID() string {
+	b := make([]byte, 4)
+	_, _ = cryptorand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logf logs a debug message if debug logging is enabled. Messages logged
+// during a call are prefixed with the call ID, attempt number and elapsed
+// time, and go to the context logger if one is configured.
+func (c *HTTPClient) logf(ctx context.Context, format string, args ...interface{}) {
 	if !c.config.Debug {
 		return
 	}
 
 	msg := fmt.Sprintf(format, args...)
-	if c.config.Logger != nil {
-		c.config.Logger.Printf("[opusdns] %s", msg)
+	if call, ok := ctx.Value(callLogKey{}).(*callLog); ok {
+		msg = fmt.Sprintf("call=%s attempt=%d elapsed=%v %s", call.id, call.attempt, c.clock.Now().Sub(call.start).Round(time.Millisecond), msg)
+	}
+
+	if logger := c.logger(ctx); logger != nil {
+		logger.Printf("[opusdns] %s", msg)
 	} else {
 		fmt.Printf("[opusdns] %s\n", msg)
 	}
 }
 
+// logger returns the logger for ctx: the one from ContextLogger if it yields
+// one, otherwise Logger. It returns nil if neither is configured.
+func (c *HTTPClient) logger(ctx context.Context) Logger {
+	if c.config.ContextLogger != nil && ctx != nil {
+		if l := c.config.ContextLogger(ctx); l != nil {
+			return l
+		}
+	}
+	return c.config.Logger
+}
+
 // BuildPath constructs an API path with the configured version prefix.
 func (c *HTTPClient) BuildPath(parts ...string) string {
 	allParts := make([]string, 0, len(parts)+1)
@@ -0,0 +1,97 @@
+package opusdns
+
+import (
+	"context"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Identity describes the principal behind the client's API key: the current
+// user, the organization it belongs to, and its effective permissions.
+// Values returned by Client.Identity are shared and must not be modified.
+type Identity struct {
+	// User is the authenticated user.
+	User models.User
+
+	// OrganizationID is the organization the user belongs to.
+	OrganizationID models.OrganizationID
+
+	// Permissions are the user's effective permissions.
+	Permissions []models.Permission
+
+	// ResolvedAt is when the identity was fetched from the API.
+	ResolvedAt time.Time
+}
+
+// HasPermission reports whether the identity holds the given permission.
+func (i *Identity) HasPermission(permission models.Permission) bool {
+	for _, p := range i.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity returns the authenticated identity, resolving it from the API on first
+// use and caching it for the lifetime of the client. Concurrent callers share a
+// single lookup. Use RefreshIdentity to re-resolve after permission or
+// membership changes.
+func (c *Client) Identity(ctx context.Context) (*Identity, error) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+
+	if c.identity != nil {
+		return c.identity, nil
+	}
+
+	return c.resolveIdentityLocked(ctx)
+}
+
+// RefreshIdentity discards the cached identity and resolves it again.
+func (c *Client) RefreshIdentity(ctx context.Context) (*Identity, error) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+
+	return c.resolveIdentityLocked(ctx)
+}
+
+// resolveIdentityLocked fetches the identity and stores it. The caller must hold identityMu.
+func (c *Client) resolveIdentityLocked(ctx context.Context) (*Identity, error) {
+	user, err := c.Users.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := c.Users.GetUserPermissions(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.identity = &Identity{
+		User:           *user,
+		OrganizationID: user.OrganizationID,
+		Permissions:    perms.Permissions,
+		ResolvedAt:     time.Now(),
+	}
+
+	return c.identity, nil
+}
+
+// resolveOrganizationID returns orgID, or the organization of the cached identity when orgID is empty.
+func (c *Client) resolveOrganizationID(ctx context.Context, orgID models.OrganizationID) (models.OrganizationID, error) {
+	if orgID != "" {
+		return orgID, nil
+	}
+
+	identity, err := c.Identity(ctx)
+	if err != nil {
+		return "", err
+	}
+	if identity.OrganizationID == "" {
+		return "", &ValidationError{Field: "orgID", Message: "organization ID is required and could not be resolved from the current identity"}
+	}
+
+	return identity.OrganizationID, nil
+}
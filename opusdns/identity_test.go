@@ -0,0 +1,84 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdentityServer(t *testing.T, lookups *int32, extra http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/users/me":
+			atomic.AddInt32(lookups, 1)
+			_ = json.NewEncoder(w).Encode(models.User{UserID: "user_1", OrganizationID: "organization_1"})
+		case "/v1/users/user_1/permissions":
+			_ = json.NewEncoder(w).Encode(models.PermissionSet{Permissions: []models.Permission{"domains:read"}})
+		default:
+			if extra == nil {
+				t.Errorf("unexpected request to %s", r.URL.Path)
+				return
+			}
+			extra(w, r)
+		}
+	}))
+}
+
+func TestClient_Identity(t *testing.T) {
+	var lookups int32
+	server := newIdentityServer(t, &lookups, nil)
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			identity, err := client.Identity(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, models.OrganizationID("organization_1"), identity.OrganizationID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+
+	identity, err := client.Identity(context.Background())
+	require.NoError(t, err)
+	assert.True(t, identity.HasPermission("domains:read"))
+	assert.False(t, identity.HasPermission("domains:write"))
+
+	_, err = client.RefreshIdentity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&lookups))
+}
+
+func TestOrganizationsService_ResolvesCurrentOrganization(t *testing.T) {
+	var lookups int32
+	server := newIdentityServer(t, &lookups, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/organizations/organization_1/billing/invoices", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(models.InvoiceListResponse{})
+	})
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Organizations.ListInvoices(context.Background(), "")
+	require.NoError(t, err)
+	_, err = client.Organizations.ListInvoices(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+}
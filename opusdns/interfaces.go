@@ -0,0 +1,368 @@
+package opusdns
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// The interfaces below are implemented by the concrete services and are the
+// types of the Client service fields, so individual services can be replaced
+// with mocks in tests or wrapped with decorators (caching, metrics). Helpers
+// that span services, such as DomainsService.RegisterWithDefaults, call the
+// other services through these fields and therefore use the replacements too.
+
+// DNSAPI is the interface implemented by DNSService.
+type DNSAPI interface {
+	ListZones(ctx context.Context, opts *models.ListZonesOptions) ([]models.Zone, error)
+	ListZonesPage(ctx context.Context, opts *models.ListZonesOptions) (*models.ZoneListResponse, error)
+	GetZone(ctx context.Context, name string) (*models.Zone, error)
+	GetZoneWithOptions(ctx context.Context, name string, opts *models.GetZoneOptions) (*models.Zone, error)
+	FindZone(ctx context.Context, fqdn string) (*models.Zone, error)
+	FindZoneForFQDN(ctx context.Context, fqdn string) (*models.Zone, error)
+	ClearZoneCache()
+	ListRRSets(ctx context.Context, zoneName string, opts *models.ListRRSetsOptions) ([]models.RRSet, error)
+	GetRRSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType) (*models.RRSet, error)
+	CreateZone(ctx context.Context, req *models.ZoneCreateRequest) (*models.Zone, error)
+	DeleteZone(ctx context.Context, name string) error
+	GetSummary(ctx context.Context) (*models.ZoneSummary, error)
+	GetZoneStats(ctx context.Context, zoneName string) (*models.ZoneStats, error)
+	GetQueryStats(ctx context.Context, zoneName string, opts *models.DNSQueryStatsOptions) (*models.DNSQueryStats, error)
+	PutRRSets(ctx context.Context, zoneName string, rrsets []models.RRSetCreate) error
+	PatchRRSets(ctx context.Context, zoneName string, ops []models.RRSetPatchOp) error
+	PatchRecords(ctx context.Context, zoneName string, ops []models.RecordOperation) error
+	PatchRecordsWithRequest(ctx context.Context, zoneName string, req *models.RecordPatchRequest) (*models.RecordPatchResult, error)
+	UpsertRecord(ctx context.Context, zoneName string, record models.Record) error
+	DeleteRecord(ctx context.Context, zoneName string, record models.Record) error
+	UpsertAlias(ctx context.Context, zoneName, name, target string, ttl int) error
+	SetRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string, ttl int) error
+	AddToRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string, ttl int) error
+	RemoveFromRecordSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType, values []string) error
+	SetZoneTags(ctx context.Context, zoneName string, labels []string) error
+	EnableDNSSEC(ctx context.Context, zoneName string) (*models.DNSChanges, error)
+	SetZoneVanitySet(ctx context.Context, zoneName string, setID *models.VanityNameserverSetID) (*models.Zone, error)
+	DisableDNSSEC(ctx context.Context, zoneName string) (*models.DNSChanges, error)
+	WaitForDNSSEC(ctx context.Context, zoneName string, target models.DNSSECStatus) (*models.Zone, error)
+	PutRRSetsTemplate(ctx context.Context, zoneName string, rrsets []models.RRSetCreate, vars map[string]string) error
+	UpsertMailRecords(ctx context.Context, zoneName, name string, records *MailRecords) ([]MailRecordChange, error)
+	UpdateZoneTransferSettings(ctx context.Context, zoneName string, settings *models.ZoneTransferSettings) (*models.Zone, error)
+	RetransferZone(ctx context.Context, zoneName string) error
+	AXFR(ctx context.Context, zoneName string, fn func(models.RRSet) error) error
+	ExportConfig(ctx context.Context, format ConfigFormat) ([]ConfigFile, error)
+	AcquireZoneLock(ctx context.Context, zoneName, owner string, ttl time.Duration) (*ZoneLock, error)
+	VerifyZoneLock(ctx context.Context, lock *ZoneLock) error
+	RenewZoneLock(ctx context.Context, lock *ZoneLock, ttl time.Duration) error
+	KeepZoneLock(ctx context.Context, lock *ZoneLock, ttl time.Duration) error
+	ReleaseZoneLock(ctx context.Context, lock *ZoneLock) error
+	ApplyPlan(ctx context.Context, plan *Plan, opts *ApplyPlanOptions) (*PlanResult, error)
+}
+
+// DomainsAPI is the interface implemented by DomainsService.
+type DomainsAPI interface {
+	ListDomains(ctx context.Context, opts *models.ListDomainsOptions) ([]models.Domain, error)
+	ListDomainsPage(ctx context.Context, opts *models.ListDomainsOptions) (*models.DomainListResponse, error)
+	GetDomain(ctx context.Context, domainRef string) (*models.Domain, error)
+	GetDomainWithOptions(ctx context.Context, domainRef string, opts *models.GetDomainOptions) (*models.Domain, error)
+	CreateDomain(ctx context.Context, req *models.DomainCreateRequest) (*models.Domain, error)
+	UpdateDomain(ctx context.Context, domainRef string, req *models.DomainUpdateRequest) (*models.Domain, error)
+	DeleteDomain(ctx context.Context, domainRef string) error
+	TransferDomain(ctx context.Context, req *models.DomainTransferRequest) (*models.Domain, error)
+	CancelTransfer(ctx context.Context, domainRef string) error
+	RenewDomain(ctx context.Context, domainRef string, req *models.DomainRenewRequest) (*models.Domain, error)
+	RestoreDomain(ctx context.Context, domainRef string, req *models.DomainRestoreRequest) (*models.Domain, error)
+	GetSummary(ctx context.Context) (*models.DomainSummary, error)
+	GetDNSSEC(ctx context.Context, domainRef string) ([]models.DomainDNSSECDataResponse, error)
+	PutDNSSEC(ctx context.Context, domainRef string, data []models.DomainDNSSECDataCreate) ([]models.DomainDNSSECDataResponse, error)
+	DeleteDNSSEC(ctx context.Context, domainRef string) error
+	EnableDNSSEC(ctx context.Context, domainRef string) ([]models.DomainDNSSECDataResponse, error)
+	DisableDNSSEC(ctx context.Context, domainRef string) error
+	CheckDomains(ctx context.Context, domains []string) (*models.DomainCheckResponse, error)
+	CheckDelegation(ctx context.Context, domainName string) (*DelegationReport, error)
+	MigrateNameservers(ctx context.Context, domainRef string, newNS []models.Nameserver, opts *NSMigrationOptions) (*NSMigrationState, error)
+	EnableDNSSECAndPublishDS(ctx context.Context, domainName string, opts *DNSSECPublishOptions) (*DNSSECPublishReport, error)
+	SetupVanityNameservers(ctx context.Context, domainName string, nameservers []models.Nameserver, opts *VanityNSSetupOptions) (*VanityNSSetupReport, error)
+	RegisterWithDefaults(ctx context.Context, name string, profile *RegistrationProfile) (*RegistrationResult, error)
+	SetTags(ctx context.Context, domainName string, labels []string) error
+}
+
+// ContactsAPI is the interface implemented by ContactsService.
+type ContactsAPI interface {
+	ListContacts(ctx context.Context, opts *models.ListContactsOptions) ([]models.Contact, error)
+	ListContactsPage(ctx context.Context, opts *models.ListContactsOptions) (*models.ContactListResponse, error)
+	GetContact(ctx context.Context, contactID models.ContactID) (*models.Contact, error)
+	CreateContact(ctx context.Context, req *models.ContactCreateRequest) (*models.Contact, error)
+	BulkCreate(ctx context.Context, reqs []models.ContactCreateRequest) (*ContactBulkCreateResult, error)
+	DeleteContact(ctx context.Context, contactID models.ContactID) error
+	UpdateDisclosure(ctx context.Context, contactID models.ContactID, req *models.ContactDisclosureUpdateRequest) (*models.Contact, error)
+	GetRegistryAttributes(ctx context.Context, contactID models.ContactID, tld string) (*models.ContactRegistryAttributes, error)
+	SetRegistryAttributes(ctx context.Context, contactID models.ContactID, tld string, attrs map[models.RegistryHandleAttributeType]string) (*models.ContactRegistryAttributes, error)
+	RequestVerification(ctx context.Context, contactID models.ContactID) (*models.ContactVerification, error)
+	GetVerificationStatus(ctx context.Context, contactID models.ContactID) (*models.ContactVerification, error)
+	VerifyContact(ctx context.Context, req *models.ContactVerificationRequest) error
+	ListContactAttributeSets(ctx context.Context, opts *models.ListContactAttributeSetsOptions) ([]models.ContactAttributeSet, error)
+	ListContactAttributeSetsPage(ctx context.Context, opts *models.ListContactAttributeSetsOptions) (*models.ContactAttributeSetListResponse, error)
+	GetContactAttributeSet(ctx context.Context, setID models.ContactAttributeSetID) (*models.ContactAttributeSet, error)
+	CreateContactAttributeSet(ctx context.Context, req *models.ContactAttributeSetCreateRequest) (*models.ContactAttributeSet, error)
+	UpdateContactAttributeSet(ctx context.Context, setID models.ContactAttributeSetID, req *models.ContactAttributeSetUpdateRequest) (*models.ContactAttributeSet, error)
+	DeleteContactAttributeSet(ctx context.Context, setID models.ContactAttributeSetID) error
+	LinkContactAttributeSet(ctx context.Context, contactID models.ContactID, setID models.ContactAttributeSetID) (*models.ContactAttributeLink, error)
+	AttestContactVerification(ctx context.Context, contactID models.ContactID, req *models.ContactAttestRequest) (*models.ContactAttestResponse, error)
+	GetContactVerifications(ctx context.Context, contactID models.ContactID) (*models.ContactAttestResponse, error)
+	CancelContactVerification(ctx context.Context, contactID models.ContactID) error
+	ExportCSV(ctx context.Context, w io.Writer, opts *models.ListContactsOptions) error
+	ImportCSV(ctx context.Context, r io.Reader) (*ContactBulkCreateResult, error)
+	FindMatching(ctx context.Context, req models.ContactCreateRequest) ([]ContactMatch, error)
+	FindDuplicates(ctx context.Context, matchers ...ContactMatcher) ([]ContactDuplicateGroup, error)
+	Merge(ctx context.Context, survivorID models.ContactID, duplicateIDs []models.ContactID, opts *ContactMergeOptions) (*ContactMergeReport, error)
+}
+
+// EmailForwardsAPI is the interface implemented by EmailForwardsService.
+type EmailForwardsAPI interface {
+	ListEmailForwards(ctx context.Context, opts *models.ListEmailForwardsOptions) ([]models.EmailForward, error)
+	ListEmailForwardsPage(ctx context.Context, opts *models.ListEmailForwardsOptions) (*models.EmailForwardListResponse, error)
+	GetEmailForward(ctx context.Context, emailForwardID models.EmailForwardID) (*models.EmailForward, error)
+	GetEmailForwardByHostname(ctx context.Context, hostname string) (*models.EmailForward, error)
+	CreateEmailForward(ctx context.Context, req *models.EmailForwardCreateRequest) (*models.EmailForward, error)
+	DeleteEmailForward(ctx context.Context, emailForwardID models.EmailForwardID) error
+	EnableEmailForward(ctx context.Context, emailForwardID models.EmailForwardID) error
+	DisableEmailForward(ctx context.Context, emailForwardID models.EmailForwardID) error
+	CreateAlias(ctx context.Context, emailForwardID models.EmailForwardID, req *models.EmailForwardAliasCreate) (*models.EmailForwardAlias, error)
+	UpdateAlias(ctx context.Context, emailForwardID models.EmailForwardID, aliasID models.EmailForwardAliasID, req *models.EmailForwardAliasUpdate) (*models.EmailForwardAlias, error)
+	DeleteAlias(ctx context.Context, emailForwardID models.EmailForwardID, aliasID models.EmailForwardAliasID) error
+	ListEmailForwardsByZone(ctx context.Context, zoneName string) ([]models.EmailForward, error)
+	GetMetrics(ctx context.Context, emailForwardID models.EmailForwardID, opts *models.EmailForwardMetricsOptions) (*models.EmailForwardMetrics, error)
+	CheckDNS(ctx context.Context, hostname string) (*EmailForwardDNSResult, error)
+	EnsureDNS(ctx context.Context, hostname string) (*EmailForwardDNSResult, error)
+}
+
+// DomainForwardsAPI is the interface implemented by DomainForwardsService.
+type DomainForwardsAPI interface {
+	ListDomainForwards(ctx context.Context, opts *models.ListDomainForwardsOptions) ([]models.DomainForward, error)
+	ListDomainForwardsPage(ctx context.Context, opts *models.ListDomainForwardsOptions) (*models.DomainForwardListResponse, error)
+	GetDomainForward(ctx context.Context, hostname string) (*models.DomainForward, error)
+	CreateDomainForward(ctx context.Context, req *models.DomainForwardCreateRequest) (*models.DomainForward, error)
+	UpdateDomainForwardConfig(ctx context.Context, hostname string, protocol models.HttpProtocol, req *models.DomainForwardProtocolSetRequest) (*models.DomainForward, error)
+	DeleteDomainForward(ctx context.Context, hostname string) error
+	DeleteDomainForwardConfig(ctx context.Context, hostname string, protocol models.HttpProtocol) error
+	EnableDomainForward(ctx context.Context, hostname string) error
+	DisableDomainForward(ctx context.Context, hostname string) error
+	GetDomainForwardSet(ctx context.Context, hostname string, protocol models.HttpProtocol) (*models.DomainForwardSetResponse, error)
+	CreateDomainForwardSet(ctx context.Context, hostname string, req *models.DomainForwardSetCreateRequest) (*models.DomainForwardSetResponse, error)
+	PatchRedirects(ctx context.Context, req *models.DomainForwardPatchOps) error
+	CreateWildcardRedirect(ctx context.Context, hostname string, protocol models.HttpProtocol, req *models.WildcardHttpRedirectRequest) error
+	ListDomainForwardsByZone(ctx context.Context, zoneName string) ([]models.DomainForward, error)
+	GetMetrics(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardMetrics, error)
+	GetTimeSeries(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardTimeSeriesResponse, error)
+	GetGeoStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardGeoStatsResponse, error)
+	GetBrowserStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardBrowserStatsResponse, error)
+	GetPlatformStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardPlatformStatsResponse, error)
+	GetReferrerStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardReferrerStatsResponse, error)
+	GetStatusCodeStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardStatusCodeStatsResponse, error)
+	GetUserAgentStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardUserAgentStatsResponse, error)
+}
+
+// TLDsAPI is the interface implemented by TLDsService.
+type TLDsAPI interface {
+	ListTLDs(ctx context.Context, opts *models.ListTLDsOptions) ([]models.TLD, error)
+	GetTLD(ctx context.Context, tld string) (*models.TLDDetails, error)
+	GetPortfolio(ctx context.Context) (*models.TLDPortfolio, error)
+	ListPriceChanges(ctx context.Context, opts *models.ListTLDPriceChangesOptions) ([]models.TLDPriceChange, error)
+	ListPriceChangesPage(ctx context.Context, opts *models.ListTLDPriceChangesOptions) (*models.TLDPriceChangeListResponse, error)
+	ComparePricing(ctx context.Context, tlds []string, action models.BillingTransactionAction) ([]models.TLDPrice, error)
+}
+
+// AvailabilityAPI is the interface implemented by AvailabilityService.
+type AvailabilityAPI interface {
+	CheckAvailability(ctx context.Context, domains []string) (*models.AvailabilityResponse, error)
+	CheckSingleAvailability(ctx context.Context, domain string) (*models.DomainAvailability, error)
+	GetSuggestions(ctx context.Context, query string, opts *models.DomainSuggestRequest) (*models.DomainSuggestResponse, error)
+}
+
+// OrganizationsAPI is the interface implemented by OrganizationsService.
+type OrganizationsAPI interface {
+	ListOrganizations(ctx context.Context, opts *models.ListOrganizationsOptions) ([]models.Organization, error)
+	ListOrganizationsPage(ctx context.Context, opts *models.ListOrganizationsOptions) (*models.OrganizationListResponse, error)
+	GetOrganization(ctx context.Context, orgID models.OrganizationID) (*models.Organization, error)
+	CreateOrganization(ctx context.Context, req *models.OrganizationCreateRequest) (*models.Organization, error)
+	UpdateOrganization(ctx context.Context, orgID models.OrganizationID, req *models.OrganizationUpdateRequest) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, orgID models.OrganizationID) error
+	ListIPRestrictions(ctx context.Context) (*models.IPRestrictionListResponse, error)
+	GetIPRestriction(ctx context.Context, restrictionID models.TypeID) (*models.IPRestriction, error)
+	CreateIPRestriction(ctx context.Context, req *models.IPRestrictionCreateRequest) (*models.IPRestriction, error)
+	UpdateIPRestriction(ctx context.Context, restrictionID models.TypeID, req *models.IPRestrictionUpdateRequest) (*models.IPRestriction, error)
+	DeleteIPRestriction(ctx context.Context, restrictionID models.TypeID) error
+	TestIPRestriction(ctx context.Context, ip string) (*IPRestrictionTest, error)
+	ListRoles(ctx context.Context) ([]models.RoleDefinition, error)
+	GetRole(ctx context.Context, label string) (*models.RoleDefinition, error)
+	CreateRole(ctx context.Context, req *models.CustomRoleCreateRequest) (*models.RoleDefinition, error)
+	UpdateRole(ctx context.Context, label string, req *models.CustomRoleUpdateRequest) (*models.RoleDefinition, error)
+	DeleteRole(ctx context.Context, label string) error
+	ListRolePermissions(ctx context.Context) (*models.PermissionCatalogResponse, error)
+	GetCurrentAttributes(ctx context.Context) (*models.OrganizationAttributesResponse, error)
+	UpdateCurrentAttributes(ctx context.Context, req *models.OrganizationAttributeUpdateRequest) (*models.OrganizationAttributesResponse, error)
+	GetAttributes(ctx context.Context, orgID models.OrganizationID) (*models.OrganizationAttributesResponse, error)
+	UpdateAttributes(ctx context.Context, orgID models.OrganizationID, req *models.OrganizationAttributeUpdateRequest) (*models.OrganizationAttributesResponse, error)
+	ListTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions) (*models.BillingTransactionListResponse, error)
+	GetTransaction(ctx context.Context, orgID models.OrganizationID, transactionID models.BillingTransactionID) (*models.BillingTransaction, error)
+	ExportTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions, w io.Writer, format TransactionExportFormat) (int, error)
+	ReconcileTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions) ([]TransactionCostSummary, error)
+	ListInvoices(ctx context.Context, orgID models.OrganizationID) (*models.InvoiceListResponse, error)
+	GetPricing(ctx context.Context, orgID models.OrganizationID, productType string) (*models.ProductPricing, error)
+	ConfirmPayment(ctx context.Context, token string) (*models.PaymentConfirmation, error)
+}
+
+// UsersAPI is the interface implemented by UsersService.
+type UsersAPI interface {
+	GetCurrentUser(ctx context.Context) (*models.User, error)
+	ListUsers(ctx context.Context, opts *models.ListUsersOptions) ([]models.User, error)
+	ListUsersPage(ctx context.Context, opts *models.ListUsersOptions) (*models.UserListResponse, error)
+	GetUser(ctx context.Context, userID models.UserID) (*models.User, error)
+	GetUserWithAttributes(ctx context.Context, userID models.UserID, attributes []string) (*models.User, error)
+	GetUserPermissions(ctx context.Context, userID models.UserID) (*models.PermissionSet, error)
+	GetUserRole(ctx context.Context, userID models.UserID) (*models.RoleAssignment, error)
+	SetUserRole(ctx context.Context, userID models.UserID, role *string) (*models.RoleAssignment, error)
+	CreateUser(ctx context.Context, req *models.UserCreateRequest) (*models.User, error)
+	UpdateUser(ctx context.Context, userID models.UserID, req *models.UserUpdateRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, userID models.UserID) error
+	ListSessions(ctx context.Context, userID models.UserID) ([]models.UserSession, error)
+	RevokeSession(ctx context.Context, userID models.UserID, sessionID models.UserSessionID) error
+	EnableTwoFactor(ctx context.Context, userID models.UserID) (*models.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, userID models.UserID, code string) error
+	DisableTwoFactor(ctx context.Context, userID models.UserID) error
+}
+
+// AuthAPI is the interface implemented by AuthService.
+type AuthAPI interface {
+	IntrospectAPIKey(ctx context.Context) (*models.OrganizationCredential, error)
+	IntrospectToken(ctx context.Context, token string) (*models.TokenIntrospection, error)
+}
+
+// APIKeysAPI is the interface implemented by APIKeysService.
+type APIKeysAPI interface {
+	ListAPIKeys(ctx context.Context, opts *models.ListAPIKeysOptions) ([]models.OrganizationCredential, error)
+	ListAPIKeysPage(ctx context.Context, opts *models.ListAPIKeysOptions) (*models.OrganizationCredentialListResponse, error)
+	GetAPIKey(ctx context.Context, keyID models.OrganizationCredentialID) (*models.OrganizationCredential, error)
+	CreateAPIKey(ctx context.Context, req *models.APIKeyCreateRequest) (*models.APIKeyCreated, error)
+	RevokeAPIKey(ctx context.Context, keyID models.OrganizationCredentialID) error
+}
+
+// VanityNameserversAPI is the interface implemented by VanityNameserversService.
+type VanityNameserversAPI interface {
+	ListSets(ctx context.Context, opts *models.ListVanityNameserverSetsOptions) ([]models.VanityNameserverSet, error)
+	ListSetsPage(ctx context.Context, opts *models.ListVanityNameserverSetsOptions) (*models.VanityNameserverSetListResponse, error)
+	GetSet(ctx context.Context, setID models.VanityNameserverSetID) (*models.VanityNameserverSet, error)
+	CreateSet(ctx context.Context, req *models.VanityNameserverSetCreateRequest) (*models.VanityNameserverSet, error)
+	DeleteSet(ctx context.Context, setID models.VanityNameserverSetID) error
+	CheckSet(ctx context.Context, setID models.VanityNameserverSetID) (*models.VanityNsCheckResponse, error)
+	SetDefault(ctx context.Context, setID models.VanityNameserverSetID) (*models.VanityNameserverSetDefaultResponse, error)
+	ClearDefault(ctx context.Context) (*models.ClearVanityNameserverSetDefaultResponse, error)
+	RestoreSet(ctx context.Context, setID models.VanityNameserverSetID) (*models.VanityNameserverSet, error)
+	ListZonesReferencingSet(ctx context.Context, setID models.VanityNameserverSetID, opts *models.ListVanityNameserverSetsOptions) (*models.ZonesReferencingSetResponse, error)
+}
+
+// HostsAPI is the interface implemented by HostsService.
+type HostsAPI interface {
+	CreateHost(ctx context.Context, req *models.HostCreateRequest) (*models.Host, error)
+	GetHost(ctx context.Context, reference string) (*models.Host, error)
+	UpdateHost(ctx context.Context, reference string, req *models.HostUpdateRequest) (*models.Host, error)
+	DeleteHost(ctx context.Context, reference string) error
+}
+
+// EventsAPI is the interface implemented by EventsService.
+type EventsAPI interface {
+	ListEvents(ctx context.Context, opts *models.ListEventsOptions) ([]models.Event, error)
+	ListEventsPage(ctx context.Context, opts *models.ListEventsOptions) (*models.EventListResponse, error)
+	GetEvent(ctx context.Context, eventID models.EventID) (*models.Event, error)
+	AcknowledgeEvent(ctx context.Context, eventID models.EventID) error
+	ListObjectLogs(ctx context.Context, opts *models.ListObjectLogsOptions) (*models.ObjectLogListResponse, error)
+	GetObjectLog(ctx context.Context, objectID string) (*models.ObjectLogListResponse, error)
+	ListRequestHistory(ctx context.Context, opts *models.ListOptions) (*models.RequestHistoryListResponse, error)
+	ListEmailForwardLogs(ctx context.Context, emailForwardID models.EmailForwardID) (*models.EmailForwardLogListResponse, error)
+	ListAllEmailForwardLogs(ctx context.Context, emailForwardID models.EmailForwardID, opts *models.ListEmailForwardLogsOptions) ([]models.EmailForwardLog, error)
+	ListEmailForwardLogsPage(ctx context.Context, emailForwardID models.EmailForwardID, opts *models.ListEmailForwardLogsOptions) (*models.EmailForwardLogListResponse, error)
+	ListEmailForwardLogsByAlias(ctx context.Context, aliasID models.EmailForwardAliasID) (*models.EmailForwardLogListResponse, error)
+	ListEmailForwardLogsByAliasPage(ctx context.Context, aliasID models.EmailForwardAliasID, opts *models.ListEmailForwardLogsOptions) (*models.EmailForwardLogListResponse, error)
+	Consume(ctx context.Context, handler EventHandler, opts *ConsumeOptions) error
+	Export(ctx context.Context, w io.Writer, opts *EventExportOptions) (int, error)
+	ExportLogs(ctx context.Context, opts *LogExportOptions, w io.Writer, format LogExportFormat) (*LogExportResult, error)
+}
+
+// JobsAPI is the interface implemented by JobsService.
+type JobsAPI interface {
+	ListBatches(ctx context.Context, opts *models.ListBatchesOptions) ([]models.JobBatchMetadataResponse, error)
+	ListBatchesPage(ctx context.Context, opts *models.ListBatchesOptions) (*models.JobBatchListResponse, error)
+	CreateBatch(ctx context.Context, req *models.JobBatchRequest) (*models.CreateJobBatchResponse, error)
+	GetBatchStatus(ctx context.Context, batchID models.BatchID) (*models.JobBatchStatusResponse, error)
+	DeleteBatch(ctx context.Context, batchID models.BatchID) error
+	PauseBatch(ctx context.Context, batchID models.BatchID) error
+	ResumeBatch(ctx context.Context, batchID models.BatchID) error
+	RetryBatch(ctx context.Context, batchID models.BatchID, errorClasses []string) (*models.JobBatchRetryResponse, error)
+	ListBatchJobs(ctx context.Context, batchID models.BatchID, opts *models.ListBatchJobsOptions) ([]models.JobResponse, error)
+	ListBatchJobsPage(ctx context.Context, batchID models.BatchID, opts *models.ListBatchJobsOptions) (*models.JobListResponse, error)
+	GetJob(ctx context.Context, jobID models.JobID) (*models.JobResponse, error)
+	PauseJob(ctx context.Context, jobID models.JobID) error
+	ResumeJob(ctx context.Context, jobID models.JobID) (*models.JobResponse, error)
+	RetryJob(ctx context.Context, jobID models.JobID) (*models.JobResponse, error)
+	DeleteJob(ctx context.Context, jobID models.JobID) error
+}
+
+// ReportsAPI is the interface implemented by ReportsService.
+type ReportsAPI interface {
+	CreateReport(ctx context.Context, req *models.CreateReportRequest) (*models.Report, error)
+	ListReports(ctx context.Context, opts *models.ListReportsOptions) ([]models.Report, error)
+	ListReportsPage(ctx context.Context, opts *models.ListReportsOptions) (*models.ReportListResponse, error)
+	GetReport(ctx context.Context, reportID models.ReportID) (*models.Report, error)
+	DownloadReport(ctx context.Context, reportID models.ReportID) ([]byte, error)
+	DownloadReportToWriter(ctx context.Context, reportID models.ReportID, w io.Writer) error
+}
+
+// TagsAPI is the interface implemented by TagsService.
+type TagsAPI interface {
+	ListTags(ctx context.Context, opts *models.ListTagsOptions) ([]models.Tag, error)
+	ListTagsPage(ctx context.Context, opts *models.ListTagsOptions) (*models.TagListResponse, error)
+	GetTag(ctx context.Context, tagID models.TagID) (*models.Tag, error)
+	CreateTag(ctx context.Context, req *models.TagCreateRequest) (*models.Tag, error)
+	UpdateTag(ctx context.Context, tagID models.TagID, req *models.TagUpdateRequest) (*models.Tag, error)
+	DeleteTag(ctx context.Context, tagID models.TagID) error
+	UpdateTagObjects(ctx context.Context, tagID models.TagID, req *models.ObjectTagChanges) (*models.ObjectTagChangesResponse, error)
+	BulkUpdateObjects(ctx context.Context, req *models.BulkObjectTagChanges) (*models.ObjectTagChangesResponse, error)
+	ResolveLabels(ctx context.Context, tagType models.TagType, labels []string) ([]models.TagID, error)
+	SetObjectTags(ctx context.Context, tagType models.TagType, objects []string, labels []string) error
+}
+
+// ExportAPI is the interface implemented by ExportService.
+type ExportAPI interface {
+	Export(ctx context.Context, opts *ExportOptions) (*models.ResourceSet, error)
+	Apply(ctx context.Context, set *models.ResourceSet) (*ApplyResult, error)
+}
+
+// StatusAPI is the interface implemented by StatusService.
+type StatusAPI interface {
+	GetStatus(ctx context.Context) (*models.PlatformStatus, error)
+	DeferReason(ctx context.Context, margin time.Duration) (string, error)
+}
+
+// Compile-time checks that the services implement their interfaces.
+var (
+	_ DNSAPI               = (*DNSService)(nil)
+	_ DomainsAPI           = (*DomainsService)(nil)
+	_ ContactsAPI          = (*ContactsService)(nil)
+	_ EmailForwardsAPI     = (*EmailForwardsService)(nil)
+	_ DomainForwardsAPI    = (*DomainForwardsService)(nil)
+	_ TLDsAPI              = (*TLDsService)(nil)
+	_ AvailabilityAPI      = (*AvailabilityService)(nil)
+	_ OrganizationsAPI     = (*OrganizationsService)(nil)
+	_ UsersAPI             = (*UsersService)(nil)
+	_ AuthAPI              = (*AuthService)(nil)
+	_ APIKeysAPI           = (*APIKeysService)(nil)
+	_ VanityNameserversAPI = (*VanityNameserversService)(nil)
+	_ HostsAPI             = (*HostsService)(nil)
+	_ EventsAPI            = (*EventsService)(nil)
+	_ JobsAPI              = (*JobsService)(nil)
+	_ ReportsAPI           = (*ReportsService)(nil)
+	_ TagsAPI              = (*TagsService)(nil)
+	_ ExportAPI            = (*ExportService)(nil)
+	_ StatusAPI            = (*StatusService)(nil)
+)
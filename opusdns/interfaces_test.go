@@ -0,0 +1,65 @@
+package opusdns
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceInterfaces_Complete(t *testing.T) {
+	client, err := NewClient(WithAPIKey("opk_test"))
+	require.NoError(t, err)
+
+	fields := reflect.ValueOf(client).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		service := fields.Field(i).Elem().Type()
+		for j := 0; j < service.NumMethod(); j++ {
+			name := service.Method(j).Name
+			_, found := field.Type.MethodByName(name)
+			assert.True(t, found, "%s is missing %s", field.Type.Name(), name)
+		}
+	}
+}
+
+// zonesOnlyDNS serves a fixed zone and records mail record upserts, standing in for DNSService.
+type zonesOnlyDNS struct {
+	DNSAPI
+	zone    models.Zone
+	upserts []*MailRecords
+}
+
+func (d *zonesOnlyDNS) GetZone(_ context.Context, name string) (*models.Zone, error) {
+	if name != d.zone.Name {
+		return nil, &APIError{StatusCode: 404}
+	}
+	zone := d.zone
+	return &zone, nil
+}
+
+func (d *zonesOnlyDNS) UpsertMailRecords(_ context.Context, _, name string, records *MailRecords) ([]MailRecordChange, error) {
+	d.upserts = append(d.upserts, records)
+	return []MailRecordChange{{Name: name, Type: models.RRSetTypeMX, Added: records.MX}}, nil
+}
+
+func TestServiceInterfaces_Replace(t *testing.T) {
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint("http://127.0.0.1:0"))
+	require.NoError(t, err)
+
+	dns := &zonesOnlyDNS{zone: models.Zone{Name: "example.com"}}
+	client.DNS = dns
+
+	result, err := client.EmailForwards.EnsureDNS(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Len(t, result.Changes, 1)
+	require.Len(t, dns.upserts, 1)
+	assert.Equal(t, EmailForwardMXRecords, dns.upserts[0].MX)
+}
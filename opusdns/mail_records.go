@@ -0,0 +1,371 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// DefaultMailRecordTTL is the TTL used by UpsertMailRecords for new record sets.
+const DefaultMailRecordTTL = 3600
+
+// MailRecords describes the mail-related records for a hostname.
+// Empty fields are left untouched.
+type MailRecords struct {
+	// MX replaces the MX records (preference and exchange, e.g. "10 mx.example.net.").
+	MX []string
+
+	// SPF lists mechanisms (e.g. "include:spf.example.net") merged into the
+	// hostname's SPF record, which is created if missing.
+	SPF []string
+
+	// DKIM lists the DKIM keys to publish.
+	DKIM []DKIMKey
+
+	// DMARC is the DMARC policy to publish.
+	DMARC *DMARCPolicy
+
+	// TTL is used for new record sets (defaults to DefaultMailRecordTTL).
+	// Existing record sets keep their TTL.
+	TTL int
+}
+
+// MailRecordChange describes a record set created or updated by UpsertMailRecords.
+type MailRecordChange struct {
+	// Name is the record name relative to the zone ("@" for the apex).
+	Name string `json:"name"`
+
+	// Type is the record type (MX or TXT).
+	Type models.RRSetType `json:"type"`
+
+	// Added lists the record values that were added.
+	Added []string `json:"added"`
+
+	// Replaced lists the record values that were replaced, such as an SPF
+	// record extended with new mechanisms.
+	Replaced []string `json:"replaced,omitempty"`
+}
+
+// DKIMKey is a DKIM public key published at <selector>._domainkey.
+type DKIMKey struct {
+	// Selector is the DKIM selector (e.g. "default" or "s1").
+	Selector string
+
+	// KeyType is the key algorithm (defaults to "rsa").
+	KeyType string
+
+	// PublicKey is the base64-encoded public key. Whitespace is removed.
+	PublicKey string
+}
+
+// Record returns the TXT record data for the key, split into character
+// strings as needed for long keys.
+func (k DKIMKey) Record() string {
+	keyType := k.KeyType
+	if keyType == "" {
+		keyType = "rsa"
+	}
+	return QuoteTXT("v=DKIM1; k=" + keyType + "; p=" + strings.Join(strings.Fields(k.PublicKey), ""))
+}
+
+// DMARCPolicy is a DMARC policy published at _dmarc.
+type DMARCPolicy struct {
+	// Policy is the requested handling of failing mail: "none", "quarantine"
+	// or "reject" (defaults to "none").
+	Policy string
+
+	// SubdomainPolicy overrides Policy for subdomains.
+	SubdomainPolicy string
+
+	// Percent is the percentage of failing mail the policy applies to (0 omits the tag).
+	Percent int
+
+	// AggregateReports lists addresses receiving aggregate reports. A
+	// "mailto:" prefix is added if missing.
+	AggregateReports []string
+
+	// ForensicReports lists addresses receiving failure reports.
+	ForensicReports []string
+
+	// DKIMAlignment and SPFAlignment are "r" (relaxed) or "s" (strict).
+	DKIMAlignment string
+	SPFAlignment  string
+}
+
+// Record returns the TXT record data for the policy.
+func (p DMARCPolicy) Record() string {
+	policy := p.Policy
+	if policy == "" {
+		policy = "none"
+	}
+
+	tags := []string{"v=DMARC1", "p=" + policy}
+	if p.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+p.SubdomainPolicy)
+	}
+	if p.Percent > 0 {
+		tags = append(tags, "pct="+strconv.Itoa(p.Percent))
+	}
+	if len(p.AggregateReports) > 0 {
+		tags = append(tags, "rua="+mailtoList(p.AggregateReports))
+	}
+	if len(p.ForensicReports) > 0 {
+		tags = append(tags, "ruf="+mailtoList(p.ForensicReports))
+	}
+	if p.DKIMAlignment != "" {
+		tags = append(tags, "adkim="+p.DKIMAlignment)
+	}
+	if p.SPFAlignment != "" {
+		tags = append(tags, "aspf="+p.SPFAlignment)
+	}
+	return QuoteTXT(strings.Join(tags, "; "))
+}
+
+// mailtoList formats addresses as a comma-separated list of mailto: URIs.
+func mailtoList(addresses []string) string {
+	uris := make([]string, len(addresses))
+	for i, address := range addresses {
+		if !strings.HasPrefix(strings.ToLower(address), "mailto:") {
+			address = "mailto:" + address
+		}
+		uris[i] = address
+	}
+	return strings.Join(uris, ",")
+}
+
+// QuoteTXT formats value as TXT record data: quoted character strings of at
+// most 255 bytes each, with quotes and backslashes escaped. It is shorthand
+// for models.TXTData(value).RData().
+func QuoteTXT(value string) string {
+	return models.TXTData(value).RData()
+}
+
+// unquoteTXT joins the character strings of a TXT rdata. Unquoted data is returned as is.
+func unquoteTXT(rdata string) string {
+	return string(models.ParseTXTData(rdata))
+}
+
+// MergeSPF adds mechanisms to an SPF record, before its "all" mechanism or
+// redirect modifier. If spf is empty a new record ending in "~all" is
+// returned. It returns false if every mechanism was already present.
+func MergeSPF(spf string, mechanisms ...string) (string, bool) {
+	if strings.TrimSpace(spf) == "" {
+		return strings.Join(append(append([]string{"v=spf1"}, mechanisms...), "~all"), " "), true
+	}
+
+	terms := strings.Fields(spf)
+	var missing []string
+	for _, mechanism := range mechanisms {
+		if !containsFold(terms, mechanism) && !containsFold(missing, mechanism) {
+			missing = append(missing, mechanism)
+		}
+	}
+	if len(missing) == 0 {
+		return spf, false
+	}
+
+	insertAt := len(terms)
+	for i, term := range terms {
+		lower := strings.ToLower(term)
+		if strings.TrimLeft(lower, "+-~?") == "all" || strings.HasPrefix(lower, "redirect=") {
+			insertAt = i
+			break
+		}
+	}
+
+	out := make([]string, 0, len(terms)+len(missing))
+	out = append(out, terms[:insertAt]...)
+	out = append(out, missing...)
+	out = append(out, terms[insertAt:]...)
+	return strings.Join(out, " "), true
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpsertMailRecords creates or updates the mail records for name (relative to
+// the zone, "@" for the apex) and returns what changed. MX records are
+// replaced, SPF mechanisms are merged into the existing SPF record while other
+// TXT records are preserved, and DKIM and DMARC records are written at
+// <selector>._domainkey.<name> and _dmarc.<name>. Records already in place
+// are left alone, so calling it again is a no-op.
+func (s *DNSService) UpsertMailRecords(ctx context.Context, zoneName, name string, records *MailRecords) ([]MailRecordChange, error) {
+	if records == nil {
+		return nil, &ValidationError{Field: "records", Message: "records are required"}
+	}
+
+	zone, err := s.GetZone(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, changes := planMailRecords(zone, name, records)
+	if len(ops) == 0 {
+		return changes, nil
+	}
+	if err := s.PatchRRSets(ctx, zoneName, ops); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// planMailRecords computes the RRset upserts needed to apply records to name in zone.
+func planMailRecords(zone *models.Zone, name string, records *MailRecords) ([]models.RRSetPatchOp, []MailRecordChange) {
+	if name == "" {
+		name = "@"
+	}
+	ttl := records.TTL
+	if ttl <= 0 {
+		ttl = DefaultMailRecordTTL
+	}
+
+	var ops []models.RRSetPatchOp
+	var changes []MailRecordChange
+	add := func(op *models.RRSetPatchOp, change *MailRecordChange) {
+		if op != nil {
+			ops = append(ops, *op)
+			changes = append(changes, *change)
+		}
+	}
+
+	if len(records.MX) > 0 {
+		add(planMXRecords(name, findRRSet(zone, name, models.RRSetTypeMX), records.MX, ttl))
+	}
+	if len(records.SPF) > 0 {
+		add(planSPFRecord(name, findRRSet(zone, name, models.RRSetTypeTXT), records.SPF, ttl))
+	}
+	for _, key := range records.DKIM {
+		keyName := subdomainName(key.Selector+"._domainkey", name)
+		add(planTXTRecord(keyName, findRRSet(zone, keyName, models.RRSetTypeTXT), key.Record(), ttl))
+	}
+	if records.DMARC != nil {
+		dmarcName := subdomainName("_dmarc", name)
+		add(planTXTRecord(dmarcName, findRRSet(zone, dmarcName, models.RRSetTypeTXT), records.DMARC.Record(), ttl))
+	}
+
+	return ops, changes
+}
+
+// planMXRecords returns the upsert replacing the MX records at name with mx, unless they match.
+func planMXRecords(name string, existing *models.RRSet, mx []string, ttl int) (*models.RRSetPatchOp, *MailRecordChange) {
+	have := map[string]bool{}
+	want := map[string]bool{}
+	for _, rdata := range mx {
+		want[normalizeMX(rdata)] = true
+	}
+
+	change := &MailRecordChange{Name: name, Type: models.RRSetTypeMX}
+	if existing != nil {
+		ttl = existing.TTL
+		for _, r := range existing.Records {
+			have[normalizeMX(r.RData)] = true
+			if !want[normalizeMX(r.RData)] {
+				change.Replaced = append(change.Replaced, r.RData)
+			}
+		}
+	}
+
+	records := make([]models.RecordCreate, 0, len(mx))
+	for _, rdata := range mx {
+		records = append(records, models.RecordCreate{RData: rdata})
+		if !have[normalizeMX(rdata)] {
+			change.Added = append(change.Added, rdata)
+		}
+	}
+	if len(change.Added) == 0 && len(change.Replaced) == 0 {
+		return nil, nil
+	}
+
+	return &models.RRSetPatchOp{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: name, Type: models.RRSetTypeMX, TTL: ttl, Records: records},
+	}, change
+}
+
+// planSPFRecord returns the upsert merging mechanisms into the SPF record
+// among the TXT records at name, unless they are all present.
+func planSPFRecord(name string, existing *models.RRSet, mechanisms []string, ttl int) (*models.RRSetPatchOp, *MailRecordChange) {
+	var records []models.RecordCreate
+	spfIndex := -1
+	if existing != nil {
+		ttl = existing.TTL
+		for i, r := range existing.Records {
+			records = append(records, models.RecordCreate{RData: r.RData})
+			if strings.HasPrefix(unquoteTXT(r.RData), "v=spf1") {
+				spfIndex = i
+			}
+		}
+	}
+
+	change := &MailRecordChange{Name: name, Type: models.RRSetTypeTXT}
+	if spfIndex < 0 {
+		spf, _ := MergeSPF("", mechanisms...)
+		records = append(records, models.RecordCreate{RData: QuoteTXT(spf)})
+		change.Added = []string{records[len(records)-1].RData}
+	} else {
+		old := records[spfIndex].RData
+		spf, ok := MergeSPF(unquoteTXT(old), mechanisms...)
+		if !ok {
+			return nil, nil
+		}
+		records[spfIndex].RData = QuoteTXT(spf)
+		change.Added = []string{records[spfIndex].RData}
+		change.Replaced = []string{old}
+	}
+
+	return &models.RRSetPatchOp{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: name, Type: models.RRSetTypeTXT, TTL: ttl, Records: records},
+	}, change
+}
+
+// planTXTRecord returns the upsert replacing the TXT records at name with rdata, unless it matches.
+func planTXTRecord(name string, existing *models.RRSet, rdata string, ttl int) (*models.RRSetPatchOp, *MailRecordChange) {
+	change := &MailRecordChange{Name: name, Type: models.RRSetTypeTXT, Added: []string{rdata}}
+	if existing != nil {
+		ttl = existing.TTL
+		if len(existing.Records) == 1 && unquoteTXT(existing.Records[0].RData) == unquoteTXT(rdata) {
+			return nil, nil
+		}
+		for _, r := range existing.Records {
+			change.Replaced = append(change.Replaced, r.RData)
+		}
+	}
+
+	return &models.RRSetPatchOp{
+		Op:    models.RecordOpUpsert,
+		RRSet: models.RRSetPatch{Name: name, Type: models.RRSetTypeTXT, TTL: ttl, Records: []models.RecordCreate{{RData: rdata}}},
+	}, change
+}
+
+// findRRSet returns the RRset of the given type at name in zone, or nil.
+func findRRSet(zone *models.Zone, name string, rrtype models.RRSetType) *models.RRSet {
+	for i := range zone.RRSets {
+		rrset := &zone.RRSets[i]
+		if rrset.Type != rrtype {
+			continue
+		}
+		if rrset.Name == name || (name == "@" && rrset.Name == "") {
+			return rrset
+		}
+	}
+	return nil
+}
+
+// subdomainName returns label prefixed to the relative name ("@" for the apex).
+func subdomainName(label, name string) string {
+	if name == "@" || name == "" {
+		return label
+	}
+	return fmt.Sprintf("%s.%s", label, name)
+}
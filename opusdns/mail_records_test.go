@@ -0,0 +1,104 @@
+package opusdns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteTXT(t *testing.T) {
+	assert.Equal(t, `"v=spf1 -all"`, QuoteTXT("v=spf1 -all"))
+	assert.Equal(t, `"say \"hi\" \\o/"`, QuoteTXT(`say "hi" \o/`))
+
+	long := strings.Repeat("a", 300)
+	quoted := QuoteTXT(long)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, quoted)
+	assert.Equal(t, long, unquoteTXT(quoted))
+	assert.Equal(t, `say "hi" \o/`, unquoteTXT(QuoteTXT(`say "hi" \o/`)))
+	assert.Equal(t, "unquoted", unquoteTXT("unquoted"))
+}
+
+func TestMergeSPF(t *testing.T) {
+	tests := []struct {
+		name       string
+		spf        string
+		mechanisms []string
+		want       string
+		changed    bool
+	}{
+		{"new record", "", []string{"include:a.example", "ip4:192.0.2.1"}, "v=spf1 include:a.example ip4:192.0.2.1 ~all", true},
+		{"before all", "v=spf1 mx -all", []string{"include:a.example"}, "v=spf1 mx include:a.example -all", true},
+		{"before redirect", "v=spf1 mx redirect=_spf.example", []string{"include:a.example"}, "v=spf1 mx include:a.example redirect=_spf.example", true},
+		{"no all", "v=spf1 mx", []string{"include:a.example"}, "v=spf1 mx include:a.example", true},
+		{"present", "v=spf1 INCLUDE:a.example ~all", []string{"include:a.example"}, "v=spf1 INCLUDE:a.example ~all", false},
+		{"partly present", "v=spf1 include:a.example ~all", []string{"include:a.example", "include:b.example", "include:b.example"}, "v=spf1 include:a.example include:b.example ~all", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := MergeSPF(tt.spf, tt.mechanisms...)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.changed, changed)
+		})
+	}
+}
+
+func TestDKIMKey_Record(t *testing.T) {
+	key := strings.Repeat("MIIBIjAN", 50)
+	record := DKIMKey{Selector: "s1", PublicKey: key[:200] + "\n  " + key[200:]}.Record()
+
+	assert.True(t, strings.HasPrefix(record, `"v=DKIM1; k=rsa; p=MIIB`))
+	assert.Equal(t, 1, strings.Count(record, `" "`))
+	assert.Equal(t, "v=DKIM1; k=rsa; p="+key, unquoteTXT(record))
+}
+
+func TestDMARCPolicy_Record(t *testing.T) {
+	assert.Equal(t, `"v=DMARC1; p=none"`, DMARCPolicy{}.Record())
+
+	policy := DMARCPolicy{
+		Policy:           "reject",
+		SubdomainPolicy:  "quarantine",
+		Percent:          50,
+		AggregateReports: []string{"dmarc@example.com", "mailto:reports@example.net"},
+		DKIMAlignment:    "s",
+	}
+	assert.Equal(t, `"v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.com,mailto:reports@example.net; adkim=s"`, policy.Record())
+}
+
+func TestDNSService_UpsertMailRecords(t *testing.T) {
+	dmarc := &DMARCPolicy{Policy: "quarantine"}
+
+	var patches []models.RRSetPatchRequest
+	server := emailDNSServer(t, []models.RRSet{
+		{Name: "@", Type: models.RRSetTypeMX, TTL: 600, Records: []models.RecordData{{RData: "10 old.example.net."}}},
+		{Name: "_dmarc", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: dmarc.Record()}}},
+	}, &patches)
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	changes, err := client.DNS.UpsertMailRecords(context.Background(), "example.com", "@", &MailRecords{
+		MX:    []string{"10 mx.example.net."},
+		DKIM:  []DKIMKey{{Selector: "s1", PublicKey: "abc"}},
+		DMARC: dmarc,
+		TTL:   300,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, MailRecordChange{Name: "@", Type: models.RRSetTypeMX, Added: []string{"10 mx.example.net."}, Replaced: []string{"10 old.example.net."}}, changes[0])
+	assert.Equal(t, "s1._domainkey", changes[1].Name)
+
+	require.Len(t, patches, 1)
+	require.Len(t, patches[0].Ops, 2)
+	assert.Equal(t, 600, patches[0].Ops[0].RRSet.TTL)
+	assert.Equal(t, 300, patches[0].Ops[1].RRSet.TTL)
+	assert.Equal(t, `"v=DKIM1; k=rsa; p=abc"`, patches[0].Ops[1].RRSet.Records[0].RData)
+
+	_, err = client.DNS.UpsertMailRecords(context.Background(), "example.com", "@", nil)
+	assert.True(t, IsValidationError(err))
+}
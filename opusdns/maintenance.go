@@ -0,0 +1,93 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maintenanceErrorCodes are the API error codes flagging a 503 response as
+// planned maintenance rather than an outage.
+var maintenanceErrorCodes = map[string]bool{
+	"maintenance":      true,
+	"maintenance_mode": true,
+}
+
+// parseMaintenance returns the *MaintenanceError for a response announcing a
+// maintenance window, or nil. A 423 response always does; a 503 response does
+// if its body sets "maintenance": true or a maintenance error code.
+//
+// The window end is read from the Retry-After header, or from a
+// "maintenance_until" or "window_end" timestamp in the body or its details;
+// a Retry-After in seconds is relative to now.
+func parseMaintenance(resp *Response, now time.Time) *MaintenanceError {
+	if resp.StatusCode != http.StatusLocked && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil
+	}
+
+	apiErr := NewAPIError(&http.Response{StatusCode: resp.StatusCode, Header: resp.Headers}, resp.Body)
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body, &body)
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		flagged, _ := body["maintenance"].(bool)
+		if !flagged && !maintenanceErrorCodes[apiErr.ErrorCode] {
+			return nil
+		}
+	}
+
+	m := &MaintenanceError{APIError: apiErr}
+	if retryAfter := resp.Headers.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			m.Until = now.Add(time.Duration(seconds) * time.Second)
+		} else if t, err := http.ParseTime(retryAfter); err == nil {
+			m.Until = t
+		}
+	}
+	if m.Until.IsZero() {
+		m.Until = maintenanceUntil(apiErr.Details, body)
+	}
+	return m
+}
+
+// maintenanceUntil returns the first window end found in sources, or zero.
+func maintenanceUntil(sources ...map[string]interface{}) time.Time {
+	for _, source := range sources {
+		for _, key := range []string{"maintenance_until", "window_end"} {
+			if v, ok := source[key].(string); ok {
+				if t, err := time.Parse(time.RFC3339, v); err == nil {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// waitForMaintenance waits for the end of the maintenance window of m if it
+// ends within MaintenanceMaxWait, and reports whether it did.
+func (c *HTTPClient) waitForMaintenance(ctx context.Context, m *MaintenanceError) (bool, error) {
+	if c.config.MaintenanceMaxWait <= 0 || m.Until.IsZero() {
+		return false, nil
+	}
+	wait := m.Until.Sub(c.clock.Now())
+	if wait > c.config.MaintenanceMaxWait {
+		return false, nil
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	if err := c.checkDeadline(ctx, "maintenance", wait, m); err != nil {
+		return false, err
+	}
+
+	c.logf(ctx, "Platform under maintenance, waiting %v", wait)
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-c.clock.After(wait):
+		return true, nil
+	}
+}
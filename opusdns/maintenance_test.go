@@ -0,0 +1,114 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenance(t *testing.T) {
+	until := time.Date(2030, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    int
+		headers   http.Header
+		body      string
+		wantNil   bool
+		wantUntil time.Time
+	}{
+		{name: "423", status: http.StatusLocked, body: `{"message":"scheduled maintenance"}`},
+		{name: "423 with window end", status: http.StatusLocked, body: `{"details":{"maintenance_until":"2030-01-01T06:00:00Z"}}`, wantUntil: until},
+		{name: "503 with flag", status: http.StatusServiceUnavailable, body: `{"maintenance":true,"window_end":"2030-01-01T06:00:00Z"}`, wantUntil: until},
+		{name: "503 with error code", status: http.StatusServiceUnavailable, body: `{"error_code":"maintenance_mode"}`},
+		{name: "503 with Retry-After date", status: http.StatusServiceUnavailable, headers: http.Header{"Retry-After": {until.Format(http.TimeFormat)}}, body: `{"maintenance":true}`, wantUntil: until},
+		{name: "plain 503", status: http.StatusServiceUnavailable, body: `{"message":"upstream unavailable"}`, wantNil: true},
+		{name: "500", status: http.StatusInternalServerError, body: `{"maintenance":true}`, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := tt.headers
+			if headers == nil {
+				headers = http.Header{}
+			}
+			m := parseMaintenance(&Response{StatusCode: tt.status, Headers: headers, Body: []byte(tt.body)}, time.Now())
+			if tt.wantNil {
+				assert.Nil(t, m)
+				return
+			}
+			require.NotNil(t, m)
+			assert.True(t, m.Until.Equal(tt.wantUntil), "until = %v", m.Until)
+			assert.ErrorIs(t, m, ErrMaintenance)
+		})
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	var calls int
+	var windowEnd time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if time.Now().Before(windowEnd) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"maintenance":       true,
+				"message":           "database upgrade",
+				"maintenance_until": windowEnd.Format(time.RFC3339Nano),
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com"})
+	}))
+	defer server.Close()
+
+	t.Run("fails without retrying", func(t *testing.T) {
+		calls = 0
+		windowEnd = time.Now().Add(time.Hour)
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithRetryWait(time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = client.DNS.GetZone(context.Background(), "example.com")
+		m, ok := IsMaintenanceError(err)
+		require.True(t, ok, "got %v", err)
+		assert.True(t, errors.Is(err, ErrMaintenance))
+		assert.Equal(t, 1, calls)
+		assert.WithinDuration(t, windowEnd, m.Until, time.Second)
+		assert.Contains(t, err.Error(), "database upgrade")
+	})
+
+	t.Run("waits out short windows", func(t *testing.T) {
+		calls = 0
+		windowEnd = time.Now().Add(50 * time.Millisecond)
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL),
+			WithRetryWait(time.Millisecond, time.Millisecond), WithMaintenanceWait(time.Minute))
+		require.NoError(t, err)
+
+		zone, err := client.DNS.GetZone(context.Background(), "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com", zone.Name)
+		assert.GreaterOrEqual(t, calls, 2)
+	})
+
+	t.Run("does not wait out long windows", func(t *testing.T) {
+		calls = 0
+		windowEnd = time.Now().Add(time.Hour)
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaintenanceWait(time.Minute))
+		require.NoError(t, err)
+
+		_, err = client.DNS.GetZone(context.Background(), "example.com")
+		assert.ErrorIs(t, err, ErrMaintenance)
+		assert.Equal(t, 1, calls)
+	})
+}
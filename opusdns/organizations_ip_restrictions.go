@@ -0,0 +1,86 @@
+package opusdns
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// IPRestrictionTest is the result of OrganizationsService.TestIPRestriction.
+type IPRestrictionTest struct {
+	// IP is the tested address.
+	IP netip.Addr `json:"ip"`
+
+	// Allowed reports whether API requests from IP would be accepted.
+	Allowed bool `json:"allowed"`
+
+	// Restricted is false if the organization has no IP restrictions, in
+	// which case every address is allowed.
+	Restricted bool `json:"restricted"`
+
+	// Matches are the restrictions whose network contains IP.
+	Matches []models.IPRestriction `json:"matches,omitempty"`
+
+	// Unparsable are restrictions whose network could not be parsed; they
+	// are not evaluated.
+	Unparsable []models.IPRestriction `json:"unparsable,omitempty"`
+}
+
+// TestIPRestriction reports whether API requests from ip would be allowed
+// under the organization's current IP restrictions, evaluating the networks
+// from ListIPRestrictions on the client. Use it before adding a restriction
+// or rolling out from new addresses, to avoid locking out a deployment.
+func (s *OrganizationsService) TestIPRestriction(ctx context.Context, ip string) (*IPRestrictionTest, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return nil, &ValidationError{Field: "ip", Message: "must be an IPv4 or IPv6 address", Value: ip}
+	}
+	addr = addr.Unmap()
+
+	restrictions, err := s.ListIPRestrictions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IPRestrictionTest{IP: addr, Restricted: len(restrictions.Results) > 0}
+	for _, r := range restrictions.Results {
+		prefix, ok := parseIPNetwork(r.IPNetwork)
+		if !ok {
+			result.Unparsable = append(result.Unparsable, r)
+			continue
+		}
+		if prefix.Contains(addr) {
+			result.Matches = append(result.Matches, r)
+		}
+	}
+	result.Allowed = !result.Restricted || len(result.Matches) > 0
+	return result, nil
+}
+
+// parseIPNetwork parses a restriction network, either CIDR notation or a
+// single address, with IPv4-mapped IPv6 networks converted to IPv4.
+func parseIPNetwork(network string) (netip.Prefix, bool) {
+	network = strings.TrimSpace(network)
+	if !strings.Contains(network, "/") {
+		addr, err := netip.ParseAddr(network)
+		if err != nil {
+			return netip.Prefix{}, false
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), true
+	}
+
+	prefix, err := netip.ParsePrefix(network)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	if addr := prefix.Addr(); addr.Is4In6() {
+		if prefix.Bits() < 96 {
+			return netip.Prefix{}, false
+		}
+		prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+	}
+	return prefix.Masked(), true
+}
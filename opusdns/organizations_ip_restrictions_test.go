@@ -0,0 +1,68 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationsService_TestIPRestriction(t *testing.T) {
+	newTestClient := func(t *testing.T, networks ...string) *Client {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/organizations/ip-restrictions", r.URL.Path)
+			var resp models.IPRestrictionListResponse
+			for i, n := range networks {
+				resp.Results = append(resp.Results, models.IPRestriction{IPRestrictionID: i + 1, IPNetwork: n})
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+		return client
+	}
+
+	tests := []struct {
+		name     string
+		networks []string
+		ip       string
+		allowed  bool
+		matches  int
+	}{
+		{name: "no restrictions", ip: "203.0.113.9", allowed: true},
+		{name: "in a network", networks: []string{"198.51.100.0/24", "203.0.113.0/24"}, ip: "203.0.113.9", allowed: true, matches: 1},
+		{name: "single address", networks: []string{"203.0.113.9"}, ip: "203.0.113.9", allowed: true, matches: 1},
+		{name: "outside all networks", networks: []string{"198.51.100.0/24"}, ip: "203.0.113.9"},
+		{name: "IPv4-mapped address", networks: []string{"203.0.113.0/24"}, ip: "::ffff:203.0.113.9", allowed: true, matches: 1},
+		{name: "IPv4-mapped network", networks: []string{"::ffff:203.0.113.0/120"}, ip: "203.0.113.9", allowed: true, matches: 1},
+		{name: "IPv6", networks: []string{"2001:db8::/32"}, ip: "2001:db8::1", allowed: true, matches: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestClient(t, tt.networks...).Organizations.TestIPRestriction(context.Background(), tt.ip)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, result.Allowed)
+			assert.Equal(t, len(tt.networks) > 0, result.Restricted)
+			assert.Len(t, result.Matches, tt.matches)
+		})
+	}
+
+	t.Run("reports unparsable networks", func(t *testing.T) {
+		result, err := newTestClient(t, "not-a-network").Organizations.TestIPRestriction(context.Background(), "203.0.113.9")
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Len(t, result.Unparsable, 1)
+	})
+
+	t.Run("rejects invalid addresses", func(t *testing.T) {
+		_, err := newTestClient(t).Organizations.TestIPRestriction(context.Background(), "203.0.113.0/24")
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,275 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// TransactionExportFormat is the output format of OrganizationsService.ExportTransactions.
+type TransactionExportFormat string
+
+const (
+	// TransactionExportFormatCSV writes one CSV row per transaction with a header row.
+	TransactionExportFormatCSV TransactionExportFormat = "csv"
+
+	// TransactionExportFormatNDJSON writes one JSON object per line.
+	TransactionExportFormatNDJSON TransactionExportFormat = "ndjson"
+)
+
+// transactionCSVHeader is the column layout written by ExportTransactions.
+var transactionCSVHeader = []string{
+	"billing_transaction_id",
+	"created_on",
+	"completed_on",
+	"product_type",
+	"product_reference",
+	"action",
+	"status",
+	"volume",
+	"unit",
+	"price",
+	"tax_rate",
+	"tax_amount",
+	"amount",
+	"currency",
+}
+
+// ExportTransactions writes the billing transactions of an organization to w,
+// fetching all pages, and returns the number written. opts filters the
+// transactions; its Page is ignored. If orgID is empty, the organization of
+// the authenticated identity is used.
+func (s *OrganizationsService) ExportTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions, w io.Writer, format TransactionExportFormat) (int, error) {
+	var write func(models.BillingTransaction) error
+	var flush func() error
+	switch format {
+	case TransactionExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(transactionCSVHeader); err != nil {
+			return 0, err
+		}
+		write = func(t models.BillingTransaction) error { return cw.Write(transactionCSVRow(t)) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case TransactionExportFormatNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(t models.BillingTransaction) error { return enc.Encode(t) }
+		flush = func() error { return nil }
+	default:
+		return 0, &ValidationError{Field: "format", Message: "must be csv or ndjson", Value: format}
+	}
+
+	written := 0
+	err := s.eachTransaction(ctx, orgID, opts, func(t models.BillingTransaction) error {
+		if err := write(t); err != nil {
+			return fmt.Errorf("opusdns: failed to write transaction %s: %w", t.BillingTransactionID, err)
+		}
+		written++
+		return nil
+	})
+	if flushErr := flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("opusdns: failed to write transactions: %w", flushErr)
+	}
+	return written, err
+}
+
+// transactionCSVRow returns the CSV columns of a transaction.
+func transactionCSVRow(t models.BillingTransaction) []string {
+	formatTime := func(ts *time.Time) string {
+		if ts == nil {
+			return ""
+		}
+		return ts.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		string(t.BillingTransactionID),
+		formatTime(t.CreatedOn),
+		formatTime(t.CompletedOn),
+		string(t.ProductType),
+		models.Deref(t.ProductReference),
+		string(t.Action),
+		string(t.Status),
+		t.Volume,
+		models.Deref(t.Unit),
+		t.Price,
+		t.TaxRate,
+		t.TaxAmount,
+		t.Amount,
+		string(t.Currency),
+	}
+}
+
+// eachTransaction calls fn for every transaction matching opts, one page at a time.
+func (s *OrganizationsService) eachTransaction(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions, fn func(models.BillingTransaction) error) error {
+	o := models.ListTransactionsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PageSize == 0 {
+		o.PageSize = DefaultPageSize
+	}
+
+	for page := 1; ; page++ {
+		o.Page = page
+		resp, err := s.ListTransactions(ctx, orgID, &o)
+		if err != nil {
+			return err
+		}
+		for _, t := range resp.Results {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if !resp.Pagination.HasNextPage {
+			return nil
+		}
+	}
+}
+
+// TransactionCostSummary totals the succeeded transactions of one month for
+// one product reference, product type, action and currency.
+type TransactionCostSummary struct {
+	// Month is the month the transactions completed in (or were created in,
+	// if not completed), as "2006-01" in UTC.
+	Month string
+
+	// Reference is the product reference, such as the domain name.
+	Reference string
+
+	// ProductType is the type of product.
+	ProductType models.BillingTransactionProductType
+
+	// Action is the billed action.
+	Action models.BillingTransactionAction
+
+	// Currency is the currency of the amounts.
+	Currency models.Currency
+
+	// Count is the number of transactions.
+	Count int
+
+	// Price, Tax and Amount are the totals of the transactions' base price,
+	// tax amount and amount including tax, rounded to the currency's minor units.
+	Price  string
+	Tax    string
+	Amount string
+}
+
+// ReconcileTransactions fetches the transactions matching opts and groups
+// the succeeded ones by month, product reference (such as the domain),
+// product type, action and currency, for monthly cost reports. Summaries
+// are sorted by month, then reference, product type, action and currency.
+// If orgID is empty, the organization of the authenticated identity is used.
+func (s *OrganizationsService) ReconcileTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions) ([]TransactionCostSummary, error) {
+	var transactions []models.BillingTransaction
+	err := s.eachTransaction(ctx, orgID, opts, func(t models.BillingTransaction) error {
+		transactions = append(transactions, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeTransactions(transactions)
+}
+
+// SummarizeTransactions groups succeeded transactions like
+// OrganizationsService.ReconcileTransactions does, for transactions that
+// were already fetched or exported. Amounts are summed exactly.
+func SummarizeTransactions(transactions []models.BillingTransaction) ([]TransactionCostSummary, error) {
+	type key struct {
+		month, reference string
+		productType      models.BillingTransactionProductType
+		action           models.BillingTransactionAction
+		currency         models.Currency
+	}
+	type totals struct {
+		count              int
+		price, tax, amount big.Rat
+	}
+
+	groups := map[key]*totals{}
+	for _, t := range transactions {
+		if t.Status != models.BillingStatusSucceeded {
+			continue
+		}
+		at := t.CompletedOn
+		if at == nil {
+			at = t.CreatedOn
+		}
+		month := ""
+		if at != nil {
+			month = at.UTC().Format("2006-01")
+		}
+
+		k := key{month, models.Deref(t.ProductReference), t.ProductType, t.Action, t.Currency}
+		g := groups[k]
+		if g == nil {
+			g = &totals{}
+			groups[k] = g
+		}
+		g.count++
+		for _, f := range []struct {
+			sum   *big.Rat
+			value string
+		}{{&g.price, t.Price}, {&g.tax, t.TaxAmount}, {&g.amount, t.Amount}} {
+			if f.value == "" {
+				continue
+			}
+			v, ok := new(big.Rat).SetString(f.value)
+			if !ok {
+				return nil, fmt.Errorf("opusdns: invalid amount %q in transaction %s", f.value, t.BillingTransactionID)
+			}
+			f.sum.Add(f.sum, v)
+		}
+	}
+
+	summaries := make([]TransactionCostSummary, 0, len(groups))
+	for k, g := range groups {
+		summary := TransactionCostSummary{
+			Month:       k.month,
+			Reference:   k.reference,
+			ProductType: k.productType,
+			Action:      k.action,
+			Currency:    k.currency,
+			Count:       g.count,
+		}
+		for _, f := range []struct {
+			dst *string
+			sum *big.Rat
+		}{{&summary.Price, &g.price}, {&summary.Tax, &g.tax}, {&summary.Amount, &g.amount}} {
+			// API amounts have far fewer than 12 decimals, so this is exact
+			rounded, err := k.currency.RoundAmount(f.sum.FloatString(12))
+			if err != nil {
+				return nil, err
+			}
+			*f.dst = rounded
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		switch {
+		case a.Month != b.Month:
+			return a.Month < b.Month
+		case a.Reference != b.Reference:
+			return a.Reference < b.Reference
+		case a.ProductType != b.ProductType:
+			return a.ProductType < b.ProductType
+		case a.Action != b.Action:
+			return a.Action < b.Action
+		default:
+			return a.Currency < b.Currency
+		}
+	})
+	return summaries, nil
+}
@@ -0,0 +1,109 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transactionsTestClient(t *testing.T, transactions []models.BillingTransaction) *Client {
+	t.Helper()
+
+	// Serves the transactions two per page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/organizations/org_1/transactions", r.URL.Path)
+		assert.Equal(t, "renew", r.URL.Query().Get("action"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		end := min(page*2, len(transactions))
+		_ = json.NewEncoder(w).Encode(models.BillingTransactionListResponse{
+			Results:    transactions[(page-1)*2 : end],
+			Pagination: models.Pagination{HasNextPage: end < len(transactions)},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0))
+	require.NoError(t, err)
+	return client
+}
+
+func TestOrganizationsService_ExportTransactions(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	domain, unit := "example.com", "y"
+	transactions := []models.BillingTransaction{
+		{BillingTransactionID: "txn_1", ProductType: models.BillingProductTypeDomain, ProductReference: &domain,
+			Action: models.BillingActionRenew, Status: models.BillingStatusSucceeded, Volume: "1", Unit: &unit,
+			Price: "10.00", TaxRate: "0.19", TaxAmount: "1.90", Amount: "11.90", Currency: models.CurrencyEUR, CreatedOn: &created},
+		{BillingTransactionID: "txn_2", Action: models.BillingActionRenew},
+		{BillingTransactionID: "txn_3", Action: models.BillingActionRenew},
+	}
+	client := transactionsTestClient(t, transactions)
+	opts := &models.ListTransactionsOptions{Action: models.BillingActionRenew}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := client.Organizations.ExportTransactions(context.Background(), "org_1", opts, &buf, TransactionExportFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, strings.Join(transactionCSVHeader, ","), lines[0])
+		assert.Equal(t, "txn_1,2026-01-15T10:00:00Z,,domain,example.com,renew,succeeded,1,y,10.00,0.19,1.90,11.90,EUR", lines[1])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := client.Organizations.ExportTransactions(context.Background(), "org_1", opts, &buf, TransactionExportFormatNDJSON)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		_, err := client.Organizations.ExportTransactions(context.Background(), "org_1", opts, &bytes.Buffer{}, "xml")
+		assert.True(t, IsValidationError(err))
+	})
+}
+
+func TestOrganizationsService_ReconcileTransactions(t *testing.T) {
+	jan := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	renewal := func(id, domain string, completed *time.Time, amount string, status models.BillingTransactionStatus) models.BillingTransaction {
+		return models.BillingTransaction{BillingTransactionID: models.BillingTransactionID(id), ProductType: models.BillingProductTypeDomain,
+			ProductReference: &domain, Action: models.BillingActionRenew, Status: status,
+			Price: amount, TaxAmount: "0.005", Amount: amount, Currency: models.CurrencyEUR, CompletedOn: completed}
+	}
+	client := transactionsTestClient(t, []models.BillingTransaction{
+		renewal("txn_1", "example.com", &jan, "10.10", models.BillingStatusSucceeded),
+		renewal("txn_2", "example.com", &jan, "0.20", models.BillingStatusSucceeded),
+		renewal("txn_3", "example.com", &jan, "99", models.BillingStatusFailed),
+		renewal("txn_4", "example.com", &feb, "10.10", models.BillingStatusSucceeded),
+		renewal("txn_5", "example.org", &jan, "5", models.BillingStatusSucceeded),
+	})
+
+	summaries, err := client.Organizations.ReconcileTransactions(context.Background(), "org_1", &models.ListTransactionsOptions{Action: models.BillingActionRenew})
+	require.NoError(t, err)
+
+	assert.Equal(t, []TransactionCostSummary{
+		{Month: "2026-01", Reference: "example.com", ProductType: models.BillingProductTypeDomain, Action: models.BillingActionRenew,
+			Currency: models.CurrencyEUR, Count: 2, Price: "10.30", Tax: "0.01", Amount: "10.30"},
+		{Month: "2026-01", Reference: "example.org", ProductType: models.BillingProductTypeDomain, Action: models.BillingActionRenew,
+			Currency: models.CurrencyEUR, Count: 1, Price: "5.00", Tax: "0.01", Amount: "5.00"},
+		{Month: "2026-02", Reference: "example.com", ProductType: models.BillingProductTypeDomain, Action: models.BillingActionRenew,
+			Currency: models.CurrencyEUR, Count: 1, Price: "10.10", Tax: "0.01", Amount: "10.10"},
+	}, summaries)
+
+	_, err = SummarizeTransactions([]models.BillingTransaction{renewal("txn_6", "example.net", &feb, "ten", models.BillingStatusSucceeded)})
+	assert.Error(t, err)
+}
@@ -1,5 +1,12 @@
 package opusdns
 
+import (
+	"context"
+	"sync"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
 func cloneOptions[T any](opts *T) *T {
 	pageOpts := new(T)
 	if opts != nil {
@@ -7,3 +14,153 @@ func cloneOptions[T any](opts *T) *T {
 	}
 	return pageOpts
 }
+
+// pageLimits bounds automatic pagination. Zero fields are unlimited.
+type pageLimits struct {
+	maxPages int
+	maxItems int
+}
+
+// pageLimits returns the limits for one call: perCallPages and perCallItems
+// override MaxPages and MaxItems when positive and disable them when negative.
+func (c *Config) pageLimits(perCallPages, perCallItems int) pageLimits {
+	pick := func(perCall, configured int) int {
+		switch {
+		case perCall > 0:
+			return perCall
+		case perCall < 0:
+			return 0
+		default:
+			return configured
+		}
+	}
+	return pageLimits{maxPages: pick(perCallPages, c.MaxPages), maxItems: pick(perCallItems, c.MaxItems)}
+}
+
+// check returns a *TooManyResultsError if fetching page, or having fetched
+// items, would exceed the limits.
+func (l pageLimits) check(page, items int) error {
+	if l.maxPages > 0 && page > l.maxPages {
+		return &TooManyResultsError{Limit: "MaxPages", Max: l.maxPages}
+	}
+	if l.maxItems > 0 && items > l.maxItems {
+		return &TooManyResultsError{Limit: "MaxItems", Max: l.maxItems}
+	}
+	return nil
+}
+
+// pageFetcher retrieves one page of results.
+type pageFetcher[T any] func(ctx context.Context, page int) ([]T, models.Pagination, error)
+
+// fetchAllPages retrieves every page and returns the results in page order.
+// After page 1 reveals TotalPages, the remaining pages are fetched by up to
+// concurrency workers at once. With concurrency <= 1, or when the total is
+// unknown, pages are fetched one at a time until HasNextPage is false.
+// Fetching stops with a *TooManyResultsError once limits are exceeded, so a
+// HasNextPage that never turns false cannot loop forever.
+func fetchAllPages[T any](ctx context.Context, concurrency int, limits pageLimits, fetch pageFetcher[T]) ([]T, error) {
+	all, pagination, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.check(1, len(all)); err != nil {
+		return nil, err
+	}
+
+	next := 2
+	if concurrency > 1 && pagination.HasNextPage && pagination.TotalPages > 1 {
+		total := pagination.TotalPages
+		if err := limits.check(total, 0); err != nil {
+			return nil, err
+		}
+		pages, last, err := fetchPagesConcurrently(ctx, concurrency, 2, total, fetch)
+		if err != nil {
+			return nil, err
+		}
+		for _, results := range pages {
+			all = append(all, results...)
+		}
+		if err := limits.check(total, len(all)); err != nil {
+			return nil, err
+		}
+		pagination = last
+		next = total + 1
+	}
+
+	// Continue serially, which also picks up pages added while fetching.
+	for page := next; pagination.HasNextPage; page++ {
+		if err := limits.check(page, len(all)); err != nil {
+			return nil, err
+		}
+		var results []T
+		results, pagination, err = fetch(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, results...)
+		if err := limits.check(page, len(all)); err != nil {
+			return nil, err
+		}
+	}
+
+	return all, nil
+}
+
+// fetchPagesConcurrently fetches pages first..last with up to concurrency
+// workers. It returns the results indexed from first and the pagination of the
+// last page, or the first error encountered.
+func fetchPagesConcurrently[T any](ctx context.Context, concurrency, first, last int, fetch pageFetcher[T]) ([][]T, models.Pagination, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]T, last-first+1)
+	var lastPagination models.Pagination
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	work := make(chan int)
+
+	for w := 0; w < min(concurrency, len(pages)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range work {
+				results, pagination, err := fetch(ctx, page)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pages[page-first] = results
+				if page == last {
+					lastPagination = pagination
+				}
+			}
+		}()
+	}
+
+	for page := first; page <= last; page++ {
+		select {
+		case work <- page:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, models.Pagination{}, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, models.Pagination{}, err
+	}
+	return pages, lastPagination, nil
+}
@@ -0,0 +1,142 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSService_ListZones_Concurrent(t *testing.T) {
+	const totalPages = 7
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		_ = json.NewEncoder(w).Encode(models.ZoneListResponse{
+			Results: []models.Zone{{Name: "zone" + strconv.Itoa(page) + ".com"}},
+			Pagination: models.Pagination{
+				CurrentPage: page,
+				TotalPages:  totalPages,
+				HasNextPage: page < totalPages,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithListConcurrency(3))
+	require.NoError(t, err)
+
+	zones, err := client.DNS.ListZones(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, zones, totalPages)
+	for i, zone := range zones {
+		assert.Equal(t, "zone"+strconv.Itoa(i+1)+".com", zone.Name)
+	}
+	assert.LessOrEqual(t, maxInFlight, int32(3))
+	assert.Greater(t, maxInFlight, int32(1))
+}
+
+func TestFetchAllPages(t *testing.T) {
+	t.Run("continues past a growing total", func(t *testing.T) {
+		var calls int32
+		results, err := fetchAllPages(context.Background(), 4, pageLimits{}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			atomic.AddInt32(&calls, 1)
+			// Page 1 reports 3 pages, but a fourth appears while fetching.
+			total := 3
+			if page > 1 {
+				total = 4
+			}
+			return []int{page}, models.Pagination{TotalPages: total, HasNextPage: page < 4}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, results)
+		assert.Equal(t, int32(4), calls)
+	})
+
+	t.Run("falls back to serial without a total", func(t *testing.T) {
+		results, err := fetchAllPages(context.Background(), 4, pageLimits{}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			return []int{page}, models.Pagination{HasNextPage: page < 3}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, results)
+	})
+
+	t.Run("returns the first error", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := fetchAllPages(context.Background(), 3, pageLimits{}, func(ctx context.Context, page int) ([]int, models.Pagination, error) {
+			if page == 5 {
+				return nil, models.Pagination{}, boom
+			}
+			return []int{page}, models.Pagination{TotalPages: 20, HasNextPage: page < 20}, ctx.Err()
+		})
+		assert.ErrorIs(t, err, boom)
+	})
+
+	t.Run("stops at MaxPages", func(t *testing.T) {
+		var calls int32
+		_, err := fetchAllPages(context.Background(), 1, pageLimits{maxPages: 3}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			atomic.AddInt32(&calls, 1)
+			// A broken API that always reports another page.
+			return []int{page}, models.Pagination{HasNextPage: true}, nil
+		})
+		require.ErrorIs(t, err, ErrTooManyResults)
+		assert.Equal(t, int32(3), calls)
+
+		var tooMany *TooManyResultsError
+		require.ErrorAs(t, err, &tooMany)
+		assert.Equal(t, "MaxPages", tooMany.Limit)
+	})
+
+	t.Run("checks the total before fetching concurrently", func(t *testing.T) {
+		var calls int32
+		_, err := fetchAllPages(context.Background(), 4, pageLimits{maxPages: 5}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			atomic.AddInt32(&calls, 1)
+			return []int{page}, models.Pagination{TotalPages: 10, HasNextPage: page < 10}, nil
+		})
+		assert.ErrorIs(t, err, ErrTooManyResults)
+		assert.Equal(t, int32(1), calls)
+	})
+
+	t.Run("stops at MaxItems", func(t *testing.T) {
+		results, err := fetchAllPages(context.Background(), 1, pageLimits{maxItems: 4}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			return []int{page, page}, models.Pagination{HasNextPage: page < 2}, nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 4)
+
+		_, err = fetchAllPages(context.Background(), 1, pageLimits{maxItems: 3}, func(_ context.Context, page int) ([]int, models.Pagination, error) {
+			return []int{page, page}, models.Pagination{HasNextPage: page < 2}, nil
+		})
+		var tooMany *TooManyResultsError
+		require.ErrorAs(t, err, &tooMany)
+		assert.Equal(t, "MaxItems", tooMany.Limit)
+		assert.Equal(t, 3, tooMany.Max)
+	})
+}
+
+func TestConfig_pageLimits(t *testing.T) {
+	config := NewConfig(WithPaginationLimits(100, 500))
+	assert.Equal(t, pageLimits{maxPages: 100, maxItems: 500}, config.pageLimits(0, 0))
+	assert.Equal(t, pageLimits{maxPages: 2, maxItems: 0}, config.pageLimits(2, -1))
+	assert.Equal(t, pageLimits{maxPages: DefaultMaxPages}, NewConfig().pageLimits(0, 0))
+}
@@ -0,0 +1,324 @@
+package opusdns
+
+import (
+	"context"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// methodPermissions maps service methods, named "<Service>.<Method>" after the
+// Client field and method name, to the permission the API requires to call them.
+// Methods available to every authenticated key (such as Users.GetCurrentUser
+// and the public TLD catalog) and helpers needing the permissions of several
+// services (such as Export) are omitted.
+var methodPermissions = map[string]models.Permission{
+	"APIKeys.CreateAPIKey":                      "organization:manage",
+	"APIKeys.GetAPIKey":                         "organization:read",
+	"APIKeys.ListAPIKeys":                       "organization:read",
+	"APIKeys.ListAPIKeysPage":                   "organization:read",
+	"APIKeys.RevokeAPIKey":                      "organization:delete",
+	"Auth.IntrospectToken":                      "organization:read",
+	"Availability.CheckAvailability":            "domains:read",
+	"Availability.CheckSingleAvailability":      "domains:read",
+	"Availability.GetSuggestions":               "domains:read",
+	"Contacts.AttestContactVerification":        "contacts:manage",
+	"Contacts.BulkCreate":                       "contacts:manage",
+	"Contacts.CancelContactVerification":        "contacts:manage",
+	"Contacts.CreateContact":                    "contacts:manage",
+	"Contacts.CreateContactAttributeSet":        "contacts:manage",
+	"Contacts.DeleteContact":                    "contacts:delete",
+	"Contacts.DeleteContactAttributeSet":        "contacts:delete",
+	"Contacts.ExportCSV":                        "contacts:read",
+	"Contacts.FindDuplicates":                   "contacts:read",
+	"Contacts.FindMatching":                     "contacts:read",
+	"Contacts.GetContact":                       "contacts:read",
+	"Contacts.GetContactAttributeSet":           "contacts:read",
+	"Contacts.GetContactVerifications":          "contacts:read",
+	"Contacts.GetRegistryAttributes":            "contacts:read",
+	"Contacts.GetVerificationStatus":            "contacts:read",
+	"Contacts.ImportCSV":                        "contacts:manage",
+	"Contacts.LinkContactAttributeSet":          "contacts:manage",
+	"Contacts.Merge":                            "contacts:delete",
+	"Contacts.ListContactAttributeSets":         "contacts:read",
+	"Contacts.ListContactAttributeSetsPage":     "contacts:read",
+	"Contacts.ListContacts":                     "contacts:read",
+	"Contacts.ListContactsPage":                 "contacts:read",
+	"Contacts.RequestVerification":              "contacts:manage",
+	"Contacts.SetRegistryAttributes":            "contacts:manage",
+	"Contacts.UpdateContactAttributeSet":        "contacts:manage",
+	"Contacts.UpdateDisclosure":                 "contacts:manage",
+	"DNS.AXFR":                                  "dns:read",
+	"DNS.AddToRecordSet":                        "dns:manage",
+	"DNS.ApplyPlan":                             "dns:manage",
+	"DNS.AcquireZoneLock":                       "dns:manage",
+	"DNS.CreateZone":                            "dns:manage",
+	"DNS.DeleteRecord":                          "dns:manage",
+	"DNS.DeleteZone":                            "dns:delete",
+	"DNS.DisableDNSSEC":                         "dns:manage",
+	"DNS.EnableDNSSEC":                          "dns:manage",
+	"DNS.WaitForDNSSEC":                         "dns:read",
+	"DNS.ExportConfig":                          "dns:read",
+	"DNS.GetQueryStats":                         "dns:read",
+	"DNS.GetRRSet":                              "dns:read",
+	"DNS.GetSummary":                            "dns:read",
+	"DNS.FindZone":                              "dns:read",
+	"DNS.FindZoneForFQDN":                       "dns:read",
+	"DNS.GetZone":                               "dns:read",
+	"DNS.GetZoneStats":                          "dns:read",
+	"DNS.GetZoneWithOptions":                    "dns:read",
+	"DNS.KeepZoneLock":                          "dns:manage",
+	"DNS.ListRRSets":                            "dns:read",
+	"DNS.ListZones":                             "dns:read",
+	"DNS.ListZonesPage":                         "dns:read",
+	"DNS.PatchRRSets":                           "dns:manage",
+	"DNS.PatchRecords":                          "dns:manage",
+	"DNS.PatchRecordsWithRequest":               "dns:manage",
+	"DNS.PutRRSets":                             "dns:manage",
+	"DNS.PutRRSetsTemplate":                     "dns:manage",
+	"DNS.ReleaseZoneLock":                       "dns:manage",
+	"DNS.RemoveFromRecordSet":                   "dns:manage",
+	"DNS.RenewZoneLock":                         "dns:manage",
+	"DNS.RetransferZone":                        "dns:manage",
+	"DNS.SetRecordSet":                          "dns:manage",
+	"DNS.SetZoneTags":                           "tags:manage",
+	"DNS.SetZoneVanitySet":                      "dns:manage",
+	"DNS.UpdateZoneTransferSettings":            "dns:manage",
+	"DNS.UpsertMailRecords":                     "dns:manage",
+	"DNS.UpsertAlias":                           "dns:manage",
+	"DNS.UpsertRecord":                          "dns:manage",
+	"DNS.VerifyZoneLock":                        "dns:read",
+	"DomainForwards.CreateDomainForward":        "domain_forwards:manage",
+	"DomainForwards.CreateDomainForwardSet":     "domain_forwards:manage",
+	"DomainForwards.CreateWildcardRedirect":     "domain_forwards:manage",
+	"DomainForwards.DeleteDomainForward":        "domain_forwards:delete",
+	"DomainForwards.DeleteDomainForwardConfig":  "domain_forwards:manage",
+	"DomainForwards.DisableDomainForward":       "domain_forwards:manage",
+	"DomainForwards.EnableDomainForward":        "domain_forwards:manage",
+	"DomainForwards.GetBrowserStats":            "domain_forwards:read",
+	"DomainForwards.GetDomainForward":           "domain_forwards:read",
+	"DomainForwards.GetDomainForwardSet":        "domain_forwards:read",
+	"DomainForwards.GetGeoStats":                "domain_forwards:read",
+	"DomainForwards.GetMetrics":                 "domain_forwards:read",
+	"DomainForwards.GetPlatformStats":           "domain_forwards:read",
+	"DomainForwards.GetReferrerStats":           "domain_forwards:read",
+	"DomainForwards.GetStatusCodeStats":         "domain_forwards:read",
+	"DomainForwards.GetTimeSeries":              "domain_forwards:read",
+	"DomainForwards.GetUserAgentStats":          "domain_forwards:read",
+	"DomainForwards.ListDomainForwards":         "domain_forwards:read",
+	"DomainForwards.ListDomainForwardsByZone":   "domain_forwards:read",
+	"DomainForwards.ListDomainForwardsPage":     "domain_forwards:read",
+	"DomainForwards.PatchRedirects":             "domain_forwards:manage",
+	"DomainForwards.UpdateDomainForwardConfig":  "domain_forwards:manage",
+	"Domains.CancelTransfer":                    "domains:manage",
+	"Domains.CheckDelegation":                   "domains:read",
+	"Domains.CheckDomains":                      "domains:read",
+	"Domains.CreateDomain":                      "domains:manage",
+	"Domains.DeleteDNSSEC":                      "domains:manage",
+	"Domains.DeleteDomain":                      "domains:delete",
+	"Domains.DisableDNSSEC":                     "domains:manage",
+	"Domains.EnableDNSSEC":                      "domains:manage",
+	"Domains.EnableDNSSECAndPublishDS":          "domains:manage",
+	"Domains.GetDNSSEC":                         "domains:read",
+	"Domains.GetDomain":                         "domains:read",
+	"Domains.GetDomainWithOptions":              "domains:read",
+	"Domains.GetSummary":                        "domains:read",
+	"Domains.ListDomains":                       "domains:read",
+	"Domains.ListDomainsPage":                   "domains:read",
+	"Domains.MigrateNameservers":                "domains:manage",
+	"Domains.PutDNSSEC":                         "domains:manage",
+	"Domains.RegisterWithDefaults":              "domains:manage",
+	"Domains.RenewDomain":                       "domains:manage",
+	"Domains.SetTags":                           "tags:manage",
+	"Domains.SetupVanityNameservers":            "domains:manage",
+	"Domains.RestoreDomain":                     "domains:manage",
+	"Domains.TransferDomain":                    "domains:manage",
+	"Domains.UpdateDomain":                      "domains:manage",
+	"EmailForwards.CheckDNS":                    "dns:read",
+	"EmailForwards.CreateAlias":                 "email_forwards:manage",
+	"EmailForwards.CreateEmailForward":          "email_forwards:manage",
+	"EmailForwards.DeleteAlias":                 "email_forwards:manage",
+	"EmailForwards.DeleteEmailForward":          "email_forwards:delete",
+	"EmailForwards.DisableEmailForward":         "email_forwards:manage",
+	"EmailForwards.EnsureDNS":                   "dns:manage",
+	"EmailForwards.EnableEmailForward":          "email_forwards:manage",
+	"EmailForwards.GetEmailForward":             "email_forwards:read",
+	"EmailForwards.GetEmailForwardByHostname":   "email_forwards:read",
+	"EmailForwards.GetMetrics":                  "email_forwards:read",
+	"EmailForwards.ListEmailForwards":           "email_forwards:read",
+	"EmailForwards.ListEmailForwardsByZone":     "email_forwards:read",
+	"EmailForwards.ListEmailForwardsPage":       "email_forwards:read",
+	"EmailForwards.UpdateAlias":                 "email_forwards:manage",
+	"Events.AcknowledgeEvent":                   "events:manage",
+	"Events.Consume":                            "events:manage",
+	"Events.Export":                             "events:read",
+	"Events.ExportLogs":                         "audit_logs:read",
+	"Events.GetEvent":                           "events:read",
+	"Events.GetObjectLog":                       "audit_logs:read",
+	"Events.ListAllEmailForwardLogs":            "email_forwards:read",
+	"Events.ListEmailForwardLogs":               "email_forwards:read",
+	"Events.ListEmailForwardLogsByAlias":        "email_forwards:read",
+	"Events.ListEmailForwardLogsByAliasPage":    "email_forwards:read",
+	"Events.ListEmailForwardLogsPage":           "email_forwards:read",
+	"Events.ListEvents":                         "events:read",
+	"Events.ListEventsPage":                     "events:read",
+	"Events.ListObjectLogs":                     "audit_logs:read",
+	"Events.ListRequestHistory":                 "audit_logs:read",
+	"Hosts.CreateHost":                          "hosts:manage",
+	"Hosts.DeleteHost":                          "hosts:delete",
+	"Hosts.GetHost":                             "hosts:read",
+	"Hosts.UpdateHost":                          "hosts:manage",
+	"Jobs.CreateBatch":                          "jobs:manage",
+	"Jobs.DeleteBatch":                          "jobs:delete",
+	"Jobs.DeleteJob":                            "jobs:delete",
+	"Jobs.GetBatchStatus":                       "jobs:read",
+	"Jobs.GetJob":                               "jobs:read",
+	"Jobs.ListBatchJobs":                        "jobs:read",
+	"Jobs.ListBatchJobsPage":                    "jobs:read",
+	"Jobs.ListBatches":                          "jobs:read",
+	"Jobs.ListBatchesPage":                      "jobs:read",
+	"Jobs.PauseBatch":                           "jobs:manage",
+	"Jobs.PauseJob":                             "jobs:manage",
+	"Jobs.ResumeBatch":                          "jobs:manage",
+	"Jobs.ResumeJob":                            "jobs:manage",
+	"Jobs.RetryBatch":                           "jobs:manage",
+	"Jobs.RetryJob":                             "jobs:manage",
+	"Organizations.ConfirmPayment":              "billing:manage",
+	"Organizations.CreateIPRestriction":         "organization:manage",
+	"Organizations.CreateOrganization":          "organization:manage",
+	"Organizations.CreateRole":                  "users:manage",
+	"Organizations.DeleteIPRestriction":         "organization:delete",
+	"Organizations.DeleteOrganization":          "organization:delete",
+	"Organizations.DeleteRole":                  "users:delete",
+	"Organizations.ExportTransactions":          "billing:read",
+	"Organizations.GetAttributes":               "organization:read",
+	"Organizations.GetCurrentAttributes":        "organization:read",
+	"Organizations.GetIPRestriction":            "organization:read",
+	"Organizations.GetOrganization":             "organization:read",
+	"Organizations.GetPricing":                  "billing:read",
+	"Organizations.GetRole":                     "users:read",
+	"Organizations.GetTransaction":              "billing:read",
+	"Organizations.ListIPRestrictions":          "organization:read",
+	"Organizations.ListInvoices":                "billing:read",
+	"Organizations.ListOrganizations":           "organization:read",
+	"Organizations.ListOrganizationsPage":       "organization:read",
+	"Organizations.ListRolePermissions":         "users:read",
+	"Organizations.ListRoles":                   "users:read",
+	"Organizations.ListTransactions":            "billing:read",
+	"Organizations.ReconcileTransactions":       "billing:read",
+	"Organizations.TestIPRestriction":           "organization:read",
+	"Organizations.UpdateAttributes":            "organization:manage",
+	"Organizations.UpdateCurrentAttributes":     "organization:manage",
+	"Organizations.UpdateIPRestriction":         "organization:manage",
+	"Organizations.UpdateOrganization":          "organization:manage",
+	"Organizations.UpdateRole":                  "users:manage",
+	"Reports.CreateReport":                      "organization:read",
+	"Reports.DownloadReport":                    "organization:read",
+	"Reports.DownloadReportToWriter":            "organization:read",
+	"Reports.GetReport":                         "organization:read",
+	"Reports.ListReports":                       "organization:read",
+	"Reports.ListReportsPage":                   "organization:read",
+	"Tags.BulkUpdateObjects":                    "tags:manage",
+	"Tags.CreateTag":                            "tags:manage",
+	"Tags.DeleteTag":                            "tags:delete",
+	"Tags.GetTag":                               "tags:read",
+	"Tags.ListTags":                             "tags:read",
+	"Tags.ListTagsPage":                         "tags:read",
+	"Tags.ResolveLabels":                        "tags:read",
+	"Tags.SetObjectTags":                        "tags:manage",
+	"Tags.UpdateTag":                            "tags:manage",
+	"Tags.UpdateTagObjects":                     "tags:manage",
+	"Users.ConfirmTwoFactor":                    "users:manage",
+	"Users.CreateUser":                          "users:manage",
+	"Users.DeleteUser":                          "users:delete",
+	"Users.DisableTwoFactor":                    "users:manage",
+	"Users.EnableTwoFactor":                     "users:manage",
+	"Users.GetUser":                             "users:read",
+	"Users.GetUserPermissions":                  "users:read",
+	"Users.GetUserRole":                         "users:read",
+	"Users.GetUserWithAttributes":               "users:read",
+	"Users.ListSessions":                        "users:read",
+	"Users.ListUsers":                           "users:read",
+	"Users.ListUsersPage":                       "users:read",
+	"Users.RevokeSession":                       "users:manage",
+	"Users.SetUserRole":                         "users:manage",
+	"Users.UpdateUser":                          "users:manage",
+	"VanityNameservers.CheckSet":                "vanity_ns:read",
+	"VanityNameservers.ClearDefault":            "vanity_ns:manage",
+	"VanityNameservers.CreateSet":               "vanity_ns:manage",
+	"VanityNameservers.DeleteSet":               "vanity_ns:delete",
+	"VanityNameservers.GetSet":                  "vanity_ns:read",
+	"VanityNameservers.ListSets":                "vanity_ns:read",
+	"VanityNameservers.ListSetsPage":            "vanity_ns:read",
+	"VanityNameservers.ListZonesReferencingSet": "vanity_ns:read",
+	"VanityNameservers.RestoreSet":              "vanity_ns:manage",
+	"VanityNameservers.SetDefault":              "vanity_ns:manage",
+}
+
+// RequiredPermission returns the permission required to call a service method,
+// named "<Service>.<Method>" (e.g. "Domains.RenewDomain"). The boolean is false
+// when the method is unknown or requires no specific permission.
+func RequiredPermission(method string) (models.Permission, bool) {
+	p, ok := methodPermissions[method]
+	return p, ok
+}
+
+// RequiredPermissions returns the de-duplicated set of permissions required to
+// call the given service methods, in first-seen order. Unknown methods are ignored.
+func RequiredPermissions(methods ...string) []models.Permission {
+	seen := make(map[models.Permission]bool, len(methods))
+	var perms []models.Permission
+	for _, method := range methods {
+		p, ok := methodPermissions[method]
+		if !ok || seen[p] {
+			continue
+		}
+		seen[p] = true
+		perms = append(perms, p)
+	}
+	return perms
+}
+
+// Can reports whether the authenticated identity holds permission. It uses the
+// cached identity (see Client.Identity) and returns false if the identity
+// cannot be resolved.
+func (c *Client) Can(ctx context.Context, permission string) bool {
+	identity, err := c.Identity(ctx)
+	if err != nil {
+		return false
+	}
+	return identity.HasPermission(models.Permission(permission))
+}
+
+// CheckMethods verifies that the authenticated identity may call every given
+// service method before any work starts, so a planned batch can fail fast
+// instead of part-way through. It returns a *PermissionError listing the
+// missing permissions, or the error from resolving the identity.
+func (c *Client) CheckMethods(ctx context.Context, methods ...string) error {
+	return c.CheckPermissions(ctx, RequiredPermissions(methods...)...)
+}
+
+// CheckPermissions verifies that the authenticated identity holds every given
+// permission. It returns a *PermissionError listing the missing permissions,
+// or the error from resolving the identity.
+func (c *Client) CheckPermissions(ctx context.Context, permissions ...models.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	identity, err := c.Identity(ctx)
+	if err != nil {
+		return err
+	}
+
+	var missing []models.Permission
+	for _, p := range permissions {
+		if !identity.HasPermission(p) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return &PermissionError{Missing: missing}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package opusdns
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredPermission(t *testing.T) {
+	p, ok := RequiredPermission("Domains.RenewDomain")
+	assert.True(t, ok)
+	assert.Equal(t, models.Permission("domains:manage"), p)
+
+	p, ok = RequiredPermission("Domains.SetupVanityNameservers")
+	assert.True(t, ok)
+	assert.Equal(t, models.Permission("domains:manage"), p)
+
+	_, ok = RequiredPermission("Users.GetCurrentUser")
+	assert.False(t, ok)
+
+	perms := RequiredPermissions("Domains.ListDomains", "Domains.RenewDomain", "Domains.GetDomain", "Unknown.Method")
+	assert.Equal(t, []models.Permission{"domains:read", "domains:manage"}, perms)
+}
+
+func TestRequiredPermission_MethodsExist(t *testing.T) {
+	client := reflect.ValueOf(&Client{}).Elem()
+
+	for method := range methodPermissions {
+		service, name, ok := strings.Cut(method, ".")
+		require.True(t, ok, method)
+
+		field := client.FieldByName(service)
+		require.True(t, field.IsValid(), "no service %q for %s", service, method)
+
+		_, found := field.Type().MethodByName(name)
+		assert.True(t, found, "no method %s", method)
+	}
+}
+
+func TestClient_Can(t *testing.T) {
+	var lookups int32
+	server := newIdentityServer(t, &lookups, nil)
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.True(t, client.Can(ctx, "domains:read"))
+	assert.False(t, client.Can(ctx, "domains:manage"))
+
+	assert.NoError(t, client.CheckMethods(ctx, "Domains.ListDomains", "Users.GetCurrentUser"))
+
+	err = client.CheckMethods(ctx, "Domains.ListDomains", "Domains.RenewDomain", "Domains.DeleteDomain")
+	require.Error(t, err)
+	assert.True(t, IsForbiddenError(err))
+
+	var permErr *PermissionError
+	require.True(t, errors.As(err, &permErr))
+	assert.Equal(t, []models.Permission{"domains:manage", "domains:delete"}, permErr.Missing)
+	assert.Contains(t, err.Error(), "domains:manage, domains:delete")
+
+	assert.Equal(t, int32(1), lookups)
+}
@@ -0,0 +1,183 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// Policy is a guardrail consulted before every mutating request. Check
+// returns nil to allow the request, or an error to stop it from being sent;
+// the caller then gets a *PolicyViolationError. Policies are called
+// concurrently when the client is shared and must be safe for that.
+type Policy interface {
+	Check(ctx context.Context, m *Mutation) error
+}
+
+// PolicyFunc adapts a function to the Policy interface.
+type PolicyFunc func(ctx context.Context, m *Mutation) error
+
+// Check implements Policy.
+func (f PolicyFunc) Check(ctx context.Context, m *Mutation) error {
+	return f(ctx, m)
+}
+
+// Mutation describes a mutating request about to be sent.
+type Mutation struct {
+	// Method is the HTTP method.
+	Method string
+
+	// Path is the request path, including the API version prefix.
+	Path string
+
+	// Resource is the unescaped path below the API version, e.g.
+	// ["dns", "example.com", "rrsets"].
+	Resource []string
+
+	// Body is the request body as passed by the service, or nil.
+	Body interface{}
+}
+
+// DecodeBody decodes the JSON form of the request body into v. It returns
+// false if the request has no JSON body.
+func (m *Mutation) DecodeBody(v interface{}) (bool, error) {
+	if m.Body == nil {
+		return false, nil
+	}
+	data, err := json.Marshal(m.Body)
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkPolicies runs the configured policies against a mutating request.
+// Requests with a read-only method or marked ReadOnly are not checked.
+func (c *HTTPClient) checkPolicies(ctx context.Context, req *Request) error {
+	if len(c.config.Policies) == 0 || req.ReadOnly {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	m := &Mutation{Method: req.Method, Path: req.Path, Body: req.Body}
+	prefix := "/" + c.config.APIVersion + "/"
+	for _, seg := range strings.Split(strings.TrimPrefix(req.Path, prefix), "/") {
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			seg = unescaped
+		}
+		m.Resource = append(m.Resource, seg)
+	}
+
+	for _, p := range c.config.Policies {
+		err := p.Check(ctx, m)
+		if err == nil {
+			continue
+		}
+		var violation *PolicyViolationError
+		if !errors.As(err, &violation) {
+			violation = &PolicyViolationError{Reason: err.Error(), Err: err}
+		}
+		violation.Method = req.Method
+		violation.Path = req.Path
+		c.logf(ctx, "Policy violation: %s %s: %s", req.Method, req.Path, violation.Reason)
+		return violation
+	}
+	return nil
+}
+
+// DenyZoneDeletion returns a policy forbidding the deletion of zones for
+// which protected returns true, such as zones of production domains:
+//
+//	opusdns.DenyZoneDeletion(func(zone string) bool {
+//		return strings.HasSuffix(zone, ".prod.example.com")
+//	})
+func DenyZoneDeletion(protected func(zoneName string) bool) Policy {
+	return PolicyFunc(func(ctx context.Context, m *Mutation) error {
+		if m.Method != http.MethodDelete || len(m.Resource) != 2 || m.Resource[0] != "dns" {
+			return nil
+		}
+		zone := strings.TrimSuffix(m.Resource[1], ".")
+		if !protected(zone) {
+			return nil
+		}
+		return &PolicyViolationError{Rule: "deny_zone_deletion", Reason: fmt.Sprintf("zone %s is protected from deletion", zone)}
+	})
+}
+
+// MinTTL returns a policy rejecting DNS writes that set a TTL below seconds
+// on any record or RRset.
+func MinTTL(seconds int) Policy {
+	return PolicyFunc(func(ctx context.Context, m *Mutation) error {
+		if len(m.Resource) == 0 || m.Resource[0] != "dns" {
+			return nil
+		}
+		var body interface{}
+		if ok, err := m.DecodeBody(&body); !ok || err != nil {
+			return nil
+		}
+		if ttl, found := lowestTTL(body); found && ttl < seconds {
+			return &PolicyViolationError{Rule: "min_ttl", Reason: fmt.Sprintf("TTL %d is below the minimum of %d", ttl, seconds)}
+		}
+		return nil
+	})
+}
+
+// lowestTTL returns the smallest "ttl" value anywhere in a decoded JSON body.
+func lowestTTL(v interface{}) (int, bool) {
+	lowest, found := 0, false
+	consider := func(ttl int, ok bool) {
+		if ok && (!found || ttl < lowest) {
+			lowest, found = ttl, true
+		}
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if n, ok := child.(float64); ok && key == "ttl" {
+				consider(int(n), true)
+				continue
+			}
+			consider(lowestTTL(child))
+		}
+	case []interface{}:
+		for _, child := range v {
+			consider(lowestTTL(child))
+		}
+	}
+	return lowest, found
+}
+
+// RequireTransferLock returns a policy forbidding domain updates that remove
+// the clientTransferProhibited status.
+func RequireTransferLock() Policy {
+	return PolicyFunc(func(ctx context.Context, m *Mutation) error {
+		if m.Method != http.MethodPatch || len(m.Resource) != 2 || m.Resource[0] != "domains" {
+			return nil
+		}
+		var req models.DomainUpdateRequest
+		if ok, err := m.DecodeBody(&req); !ok || err != nil {
+			return nil
+		}
+		unlocks := req.Statuses != nil && !slices.Contains(req.Statuses, models.DomainClientStatusTransferProhibited)
+		if req.StatusChanges != nil && slices.Contains(req.StatusChanges.Remove, models.DomainClientStatusTransferProhibited) {
+			unlocks = true
+		}
+		if !unlocks {
+			return nil
+		}
+		return &PolicyViolationError{Rule: "require_transfer_lock", Reason: fmt.Sprintf("the transfer lock of %s must stay enabled", m.Resource[1])}
+	})
+}
@@ -0,0 +1,131 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyClient(t *testing.T, sent *[]string, opts ...Option) *Client {
+	t.Helper()
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*sent = append(*sent, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPatch:
+			if strings.HasPrefix(r.URL.Path, "/v1/domains/") {
+				_ = json.NewEncoder(w).Encode(models.Domain{Name: "example.com"})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com"})
+		}
+	}), opts...)
+}
+
+func TestDenyZoneDeletion(t *testing.T) {
+	var sent []string
+	client := newPolicyClient(t, &sent, WithPolicy(DenyZoneDeletion(func(zone string) bool {
+		return strings.HasSuffix(zone, ".prod.example.com")
+	})))
+	ctx := context.Background()
+
+	err := client.DNS.DeleteZone(ctx, "shop.prod.example.com.")
+	violation, ok := IsPolicyViolationError(err)
+	require.True(t, ok)
+	assert.True(t, errors.Is(err, ErrPolicyViolation))
+	assert.Equal(t, "deny_zone_deletion", violation.Rule)
+	assert.Equal(t, http.MethodDelete, violation.Method)
+	assert.Equal(t, "/v1/dns/shop.prod.example.com", violation.Path)
+	assert.Empty(t, sent)
+
+	require.NoError(t, client.DNS.DeleteZone(ctx, "shop.staging.example.com"))
+	_, err = client.DNS.GetZone(ctx, "shop.prod.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DELETE /v1/dns/shop.staging.example.com", "GET /v1/dns/shop.prod.example.com"}, sent)
+}
+
+func TestMinTTL(t *testing.T) {
+	var sent []string
+	client := newPolicyClient(t, &sent, WithPolicy(MinTTL(300)))
+	ctx := context.Background()
+
+	err := client.DNS.PatchRRSets(ctx, "example.com", []models.RRSetPatchOp{
+		{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "www", Type: models.RRSetTypeA, TTL: 3600, Records: []models.RecordCreate{{RData: "192.0.2.1"}}}},
+		{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "api", Type: models.RRSetTypeA, TTL: 60, Records: []models.RecordCreate{{RData: "192.0.2.2"}}}},
+	})
+	violation, ok := IsPolicyViolationError(err)
+	require.True(t, ok)
+	assert.Equal(t, "min_ttl", violation.Rule)
+	assert.Contains(t, violation.Reason, "TTL 60")
+	assert.Empty(t, sent)
+
+	require.NoError(t, client.DNS.UpsertRecord(ctx, "example.com", models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}))
+	assert.Len(t, sent, 1)
+}
+
+func TestRequireTransferLock(t *testing.T) {
+	var sent []string
+	client := newPolicyClient(t, &sent, WithPolicy(RequireTransferLock()))
+	ctx := context.Background()
+
+	_, err := client.Domains.UpdateDomain(ctx, "example.com", &models.DomainUpdateRequest{
+		StatusChanges: &models.StatusChanges{Remove: []models.DomainClientStatus{models.DomainClientStatusTransferProhibited}},
+	})
+	violation, ok := IsPolicyViolationError(err)
+	require.True(t, ok)
+	assert.Equal(t, "require_transfer_lock", violation.Rule)
+
+	_, err = client.Domains.UpdateDomain(ctx, "example.com", &models.DomainUpdateRequest{
+		Statuses: []models.DomainClientStatus{"clientUpdateProhibited"},
+	})
+	_, ok = IsPolicyViolationError(err)
+	assert.True(t, ok)
+	assert.Empty(t, sent)
+
+	renew := models.RenewalModeRenew
+	_, err = client.Domains.UpdateDomain(ctx, "example.com", &models.DomainUpdateRequest{RenewalMode: &renew})
+	require.NoError(t, err)
+	assert.Len(t, sent, 1)
+}
+
+func TestPolicyFunc(t *testing.T) {
+	var sent []string
+	denied := errors.New("changes are frozen")
+	var seen []*Mutation
+	client := newPolicyClient(t, &sent,
+		WithPolicy(PolicyFunc(func(ctx context.Context, m *Mutation) error {
+			seen = append(seen, m)
+			return nil
+		})),
+		WithPolicy(PolicyFunc(func(ctx context.Context, m *Mutation) error {
+			return denied
+		})),
+		WithDryRun(true),
+	)
+
+	_, err := client.DNS.CreateZone(context.Background(), &models.ZoneCreateRequest{Name: "example.com"})
+	violation, ok := IsPolicyViolationError(err)
+	require.True(t, ok, "policies run before dry-run mode")
+	assert.ErrorIs(t, err, denied)
+	assert.Empty(t, violation.Rule)
+	assert.Equal(t, "changes are frozen", violation.Reason)
+
+	require.Len(t, seen, 1)
+	assert.Equal(t, []string{"dns"}, seen[0].Resource)
+	var body models.ZoneCreateRequest
+	ok, err = seen[0].DecodeBody(&body)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", body.Name)
+	assert.Empty(t, sent)
+}
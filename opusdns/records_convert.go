@@ -0,0 +1,144 @@
+package opusdns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// TTLConflictPolicy decides the TTL of an RRset built by RecordsToRRSets when
+// its records carry different TTLs.
+type TTLConflictPolicy int
+
+const (
+	// TTLConflictError rejects records of one RRset with different TTLs.
+	TTLConflictError TTLConflictPolicy = iota
+
+	// TTLConflictLowest uses the lowest TTL of the RRset's records.
+	TTLConflictLowest
+
+	// TTLConflictHighest uses the highest TTL of the RRset's records.
+	TTLConflictHighest
+
+	// TTLConflictFirst uses the TTL of the RRset's first record.
+	TTLConflictFirst
+)
+
+// RecordsToRRSets groups records into RRsets by name and type, in the order
+// the RRsets first appear. Names are compared case-insensitively with "" and
+// "@" both meaning the apex, duplicate record data is dropped, and a record
+// set as protected keeps that flag. The policy resolves records of one RRset
+// with different TTLs; with TTLConflictError a *ValidationError is returned.
+func RecordsToRRSets(records []models.Record, policy TTLConflictPolicy) ([]models.RRSet, error) {
+	var rrsets []models.RRSet
+	index := map[string]int{}
+
+	for _, record := range records {
+		name := record.Name
+		if name == "" {
+			name = "@"
+		}
+		key := strings.ToLower(name) + " " + strings.ToUpper(string(record.Type))
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(rrsets)
+			rrsets = append(rrsets, models.RRSet{Name: name, Type: record.Type, TTL: record.TTL})
+			i = len(rrsets) - 1
+		}
+		rrset := &rrsets[i]
+
+		if record.TTL != rrset.TTL {
+			switch policy {
+			case TTLConflictLowest:
+				rrset.TTL = min(rrset.TTL, record.TTL)
+			case TTLConflictHighest:
+				rrset.TTL = max(rrset.TTL, record.TTL)
+			case TTLConflictFirst:
+			default:
+				return nil, &ValidationError{
+					Field:   "records",
+					Message: fmt.Sprintf("%s %s has conflicting TTLs %d and %d", name, record.Type, rrset.TTL, record.TTL),
+					Value:   record,
+				}
+			}
+		}
+
+		duplicate := false
+		for _, existing := range rrset.Records {
+			if existing.RData == record.RData {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			rrset.Records = append(rrset.Records, models.RecordData{RData: record.RData, Protected: record.Protected})
+		}
+	}
+
+	return rrsets, nil
+}
+
+// RRSetsToRecords flattens RRsets into one record per record data value,
+// for example to turn the RRsets of a zone into PatchRecords operations.
+func RRSetsToRecords(rrsets []models.RRSet) []models.Record {
+	var records []models.Record
+	for _, rrset := range rrsets {
+		for _, data := range rrset.Records {
+			records = append(records, models.Record{
+				Name:      rrset.Name,
+				Type:      rrset.Type,
+				TTL:       rrset.TTL,
+				RData:     data.RData,
+				Protected: data.Protected,
+			})
+		}
+	}
+	return records
+}
+
+// DiffRecords returns the PatchRecords operations that turn the current
+// records into the desired ones: an upsert for each desired record that is
+// missing or has a different TTL, and a remove for each current record that
+// is no longer desired. Names are compared like in RecordsToRRSets. Removes
+// come first so a record can be replaced within one atomic patch.
+func DiffRecords(current, desired []models.Record) []models.RecordOperation {
+	key := func(r models.Record) string {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		return strings.ToLower(name) + " " + strings.ToUpper(string(r.Type)) + " " + r.RData
+	}
+
+	existing := make(map[string]models.Record, len(current))
+	for _, record := range current {
+		existing[key(record)] = record
+	}
+	wanted := make(map[string]bool, len(desired))
+	for _, record := range desired {
+		wanted[key(record)] = true
+	}
+
+	var removes, upserts []models.RecordOperation
+	for _, record := range current {
+		if !wanted[key(record)] {
+			removes = append(removes, models.RecordOperation{Op: models.RecordOpRemove, Record: record})
+		}
+	}
+	seen := make(map[string]bool, len(desired))
+	for _, record := range desired {
+		k := key(record)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if old, ok := existing[k]; ok && old.TTL == record.TTL {
+			continue
+		}
+		upserts = append(upserts, models.RecordOperation{Op: models.RecordOpUpsert, Record: record})
+	}
+
+	return append(removes, upserts...)
+}
@@ -0,0 +1,89 @@
+package opusdns
+
+import (
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsToRRSets(t *testing.T) {
+	records := []models.Record{
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "", Type: models.RRSetTypeMX, TTL: 3600, RData: "10 mail.example.com."},
+		{Name: "WWW", Type: models.RRSetTypeA, TTL: 600, RData: "192.0.2.2"},
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "@", Type: models.RRSetTypeMX, TTL: 3600, RData: "20 backup.example.com.", Protected: true},
+	}
+
+	t.Run("conflict is an error by default", func(t *testing.T) {
+		_, err := RecordsToRRSets(records, TTLConflictError)
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+	})
+
+	tests := []struct {
+		policy TTLConflictPolicy
+		ttl    int
+	}{
+		{TTLConflictLowest, 300},
+		{TTLConflictHighest, 600},
+		{TTLConflictFirst, 300},
+	}
+	for _, tt := range tests {
+		rrsets, err := RecordsToRRSets(records, tt.policy)
+		require.NoError(t, err)
+		require.Len(t, rrsets, 2)
+
+		assert.Equal(t, "www", rrsets[0].Name)
+		assert.Equal(t, tt.ttl, rrsets[0].TTL)
+		assert.Equal(t, []models.RecordData{{RData: "192.0.2.1"}, {RData: "192.0.2.2"}}, rrsets[0].Records)
+
+		assert.Equal(t, "@", rrsets[1].Name)
+		assert.Equal(t, models.RRSetTypeMX, rrsets[1].Type)
+		require.Len(t, rrsets[1].Records, 2)
+		assert.True(t, rrsets[1].Records[1].Protected)
+	}
+}
+
+func TestRRSetsToRecords(t *testing.T) {
+	rrsets := []models.RRSet{
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordData{{RData: "192.0.2.1"}, {RData: "192.0.2.2"}}},
+		{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: `"v=spf1 -all"`, Protected: true}}},
+	}
+
+	records := RRSetsToRecords(rrsets)
+	assert.Equal(t, []models.Record{
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.2"},
+		{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, RData: `"v=spf1 -all"`, Protected: true},
+	}, records)
+
+	roundTrip, err := RecordsToRRSets(records, TTLConflictError)
+	require.NoError(t, err)
+	assert.Equal(t, rrsets, roundTrip)
+}
+
+func TestDiffRecords(t *testing.T) {
+	current := []models.Record{
+		{Name: "@", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "old", Type: models.RRSetTypeCNAME, TTL: 300, RData: "www.example.com."},
+	}
+	desired := []models.Record{
+		{Name: "", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"},
+		{Name: "WWW", Type: models.RRSetTypeA, TTL: 600, RData: "192.0.2.1"},
+		{Name: "new", Type: models.RRSetTypeCNAME, TTL: 300, RData: "www.example.com."},
+		{Name: "new", Type: models.RRSetTypeCNAME, TTL: 300, RData: "www.example.com."},
+	}
+
+	ops := DiffRecords(current, desired)
+	assert.Equal(t, []models.RecordOperation{
+		{Op: models.RecordOpRemove, Record: current[2]},
+		{Op: models.RecordOpUpsert, Record: desired[1]},
+		{Op: models.RecordOpUpsert, Record: desired[2]},
+	}, ops)
+
+	assert.Empty(t, DiffRecords(current, current))
+}
@@ -0,0 +1,99 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// RenderRecordTemplate resolves template variables such as {{ .LoadBalancerIP }}
+// or {{ .Env }} in a record name or record data value from vars. Referencing a
+// variable that is not in vars is an error, so a zone definition can never be
+// applied with a silently empty value. Text without "{{" is returned unchanged.
+func RenderRecordTemplate(text string, vars map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("record").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// RenderRRSets returns a copy of rrsets with template variables in each name
+// and record value resolved from vars (see RenderRecordTemplate). The first
+// failure is returned as a *ValidationError naming the offending field.
+func RenderRRSets(rrsets []models.RRSetCreate, vars map[string]string) ([]models.RRSetCreate, error) {
+	rendered := make([]models.RRSetCreate, len(rrsets))
+
+	for i, rrset := range rrsets {
+		name, err := RenderRecordTemplate(rrset.Name, vars)
+		if err != nil {
+			return nil, recordTemplateError(fmt.Sprintf("rrsets[%d].name", i), rrset.Name, err)
+		}
+
+		records := make([]models.RecordCreate, len(rrset.Records))
+		for j, record := range rrset.Records {
+			rdata, err := RenderRecordTemplate(record.RData, vars)
+			if err != nil {
+				return nil, recordTemplateError(fmt.Sprintf("rrsets[%d].records[%d].rdata", i, j), record.RData, err)
+			}
+			records[j] = models.RecordCreate{RData: rdata}
+		}
+
+		rendered[i] = models.RRSetCreate{Name: name, Type: rrset.Type, TTL: rrset.TTL, Records: records}
+	}
+
+	return rendered, nil
+}
+
+// RenderRecords returns a copy of records with template variables in each name
+// and record value resolved from vars (see RenderRecordTemplate).
+func RenderRecords(records []models.Record, vars map[string]string) ([]models.Record, error) {
+	rendered := make([]models.Record, len(records))
+
+	for i, record := range records {
+		name, err := RenderRecordTemplate(record.Name, vars)
+		if err != nil {
+			return nil, recordTemplateError(fmt.Sprintf("records[%d].name", i), record.Name, err)
+		}
+		rdata, err := RenderRecordTemplate(record.RData, vars)
+		if err != nil {
+			return nil, recordTemplateError(fmt.Sprintf("records[%d].rdata", i), record.RData, err)
+		}
+
+		record.Name = name
+		record.RData = rdata
+		rendered[i] = record
+	}
+
+	return rendered, nil
+}
+
+// PutRRSetsTemplate resolves template variables in rrsets from vars and then
+// replaces the zone's RRsets with the result, as PutRRSets does. Nothing is sent
+// if any variable cannot be resolved.
+func (s *DNSService) PutRRSetsTemplate(ctx context.Context, zoneName string, rrsets []models.RRSetCreate, vars map[string]string) error {
+	rendered, err := RenderRRSets(rrsets, vars)
+	if err != nil {
+		return err
+	}
+
+	return s.PutRRSets(ctx, zoneName, rendered)
+}
+
+// recordTemplateError wraps a template failure as a ValidationError.
+func recordTemplateError(field, text string, err error) error {
+	return &ValidationError{Field: field, Message: err.Error(), Value: text}
+}
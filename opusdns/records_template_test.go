@@ -0,0 +1,106 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderRecordTemplate(t *testing.T) {
+	vars := map[string]string{"Env": "staging", "LoadBalancerIP": "192.0.2.10"}
+
+	out, err := RenderRecordTemplate("api.{{ .Env }}", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "api.staging", out)
+
+	out, err = RenderRecordTemplate("v=spf1 -all", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v=spf1 -all", out)
+
+	_, err = RenderRecordTemplate("{{ .Region }}", vars)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"Region"`)
+
+	_, err = RenderRecordTemplate("{{ .Env", vars)
+	assert.Error(t, err)
+}
+
+func TestRenderRRSets(t *testing.T) {
+	rrsets := []models.RRSetCreate{
+		{Name: "www.{{ .Env }}", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordCreate{{RData: "{{ .LoadBalancerIP }}"}}},
+		{Name: "@", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordCreate{{RData: "\"env={{ .Env }}\""}}},
+	}
+
+	rendered, err := RenderRRSets(rrsets, map[string]string{"Env": "prod", "LoadBalancerIP": "198.51.100.7"})
+	require.NoError(t, err)
+	assert.Equal(t, "www.prod", rendered[0].Name)
+	assert.Equal(t, "198.51.100.7", rendered[0].Records[0].RData)
+	assert.Equal(t, "\"env=prod\"", rendered[1].Records[0].RData)
+
+	// The input is left untouched.
+	assert.Equal(t, "{{ .LoadBalancerIP }}", rrsets[0].Records[0].RData)
+
+	_, err = RenderRRSets(rrsets, map[string]string{"Env": "prod"})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "rrsets[0].records[0].rdata", validationErr.Field)
+}
+
+func TestRenderRecords(t *testing.T) {
+	rendered, err := RenderRecords([]models.Record{
+		{Name: "{{ .Env }}", Type: models.RRSetTypeCNAME, TTL: 300, RData: "lb.{{ .Env }}.example.net."},
+	}, map[string]string{"Env": "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", rendered[0].Name)
+	assert.Equal(t, "lb.staging.example.net.", rendered[0].RData)
+}
+
+func TestDNSService_PutRRSetsTemplate(t *testing.T) {
+	t.Run("renders before sending", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PUT", r.Method)
+			assert.Equal(t, "/v1/dns/example.com/rrsets", r.URL.Path)
+
+			var req models.RRSetUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Len(t, req.RRSets, 1)
+			assert.Equal(t, "192.0.2.10", req.RRSets[0].Records[0].RData)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		err = client.DNS.PutRRSetsTemplate(context.Background(), "example.com", []models.RRSetCreate{
+			{Name: "@", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordCreate{{RData: "{{ .LoadBalancerIP }}"}}},
+		}, map[string]string{"LoadBalancerIP": "192.0.2.10"})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown variable sends nothing", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		err = client.DNS.PutRRSetsTemplate(context.Background(), "example.com", []models.RRSetCreate{
+			{Name: "@", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordCreate{{RData: "{{ .LoadBalancerIP }}"}}},
+		}, nil)
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,67 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// NewRequest returns a request for an API endpoint the services do not wrap
+// yet, such as a beta API, to be sent with Do. A path without a leading "/"
+// is relative to the configured API version, so "dns/example.com" becomes
+// "/v1/dns/example.com"; a query string in path is moved to Request.Query.
+// body, if not nil, is sent as JSON.
+func (c *Client) NewRequest(method, path string, body interface{}) (*Request, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return nil, &ValidationError{Field: "method", Message: "method is required"}
+	}
+
+	u, err := url.Parse(path)
+	if err != nil || u.IsAbs() || u.Host != "" || u.Path == "" {
+		return nil, &ValidationError{Field: "path", Message: "must be an API path such as \"dns/example.com\"", Value: path}
+	}
+	// Request paths are escaped, like the ones the services build with url.PathEscape
+	reqPath := u.EscapedPath()
+	if !strings.HasPrefix(reqPath, "/") {
+		reqPath = c.http.BuildPath(reqPath)
+	}
+
+	req := &Request{Method: method, Path: reqPath, Body: body}
+	if u.RawQuery != "" {
+		req.Query = u.Query()
+	}
+	return req, nil
+}
+
+// Do sends a request built with NewRequest (or by hand) with the client's
+// authentication, retries, rate limiting and signing, and handles the
+// response like the services do: error statuses are returned as *APIError
+// (or a more specific error such as *MaintenanceError), and a successful
+// body is decoded as JSON into v, or copied to v if it is an io.Writer.
+// v may be nil to discard the body. The response is returned even with an
+// API error, so its headers can be inspected.
+func (c *Client) Do(ctx context.Context, req *Request, v interface{}) (*Response, error) {
+	if req == nil {
+		return nil, &ValidationError{Field: "request", Message: "request is required"}
+	}
+
+	resp, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	w, ok := v.(io.Writer)
+	if !ok {
+		return resp, c.http.DecodeResponse(resp, v)
+	}
+	if err := c.http.DecodeResponse(resp, nil); err != nil {
+		return resp, err
+	}
+	if _, err := w.Write(resp.Body); err != nil {
+		return resp, fmt.Errorf("opusdns: failed to write response of %s %s: %w", req.Method, req.Path, err)
+	}
+	return resp, nil
+}
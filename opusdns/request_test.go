@@ -0,0 +1,89 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_NewRequest(t *testing.T) {
+	client, err := NewClient(WithAPIKey("opk_test"))
+	require.NoError(t, err)
+
+	req, err := client.NewRequest("post", "dns/example.com/beta/analyze?depth=2", map[string]string{"mode": "full"})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/v1/dns/example.com/beta/analyze", req.Path)
+	assert.Equal(t, "2", req.Query.Get("depth"))
+	assert.Equal(t, map[string]string{"mode": "full"}, req.Body)
+
+	req, err = client.NewRequest(http.MethodGet, "/v2/beta/reports", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/v2/beta/reports", req.Path)
+	assert.Nil(t, req.Query)
+
+	req, err = client.NewRequest(http.MethodGet, "dns/a%2Fb", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/dns/a%2Fb", req.Path)
+
+	for _, path := range []string{"", "https://evil.example/v1/dns", "//evil.example/v1"} {
+		_, err = client.NewRequest(http.MethodGet, path, nil)
+		assert.True(t, IsValidationError(err), path)
+	}
+	_, err = client.NewRequest("", "dns", nil)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "opk_test", r.Header.Get("X-Api-Key"))
+		switch r.URL.Path {
+		case "/v1/beta/echo":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("X-Beta", "1")
+			_ = json.NewEncoder(w).Encode(map[string]string{"echo": body["value"]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"no such endpoint"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	req, err := client.NewRequest(http.MethodPost, "beta/echo", map[string]string{"value": "hi"})
+	require.NoError(t, err)
+
+	var result struct {
+		Echo string `json:"echo"`
+	}
+	resp, err := client.Do(ctx, req, &result)
+	require.NoError(t, err)
+	assert.Equal(t, "hi", result.Echo)
+	assert.Equal(t, "1", resp.Headers.Get("X-Beta"))
+
+	var buf bytes.Buffer
+	_, err = client.Do(ctx, req, &buf)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"echo":"hi"}`, buf.String())
+
+	req, err = client.NewRequest(http.MethodGet, "beta/missing", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(ctx, req, &buf)
+	assert.True(t, IsNotFoundError(err))
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = client.Do(ctx, nil, nil)
+	assert.True(t, errors.As(err, new(*ValidationError)))
+}
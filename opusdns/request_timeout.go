@@ -0,0 +1,65 @@
+package opusdns
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// requestTimeoutKey and overallTimeoutKey are the context keys for the
+// per-call timeout overrides.
+type (
+	requestTimeoutKey struct{}
+	overallTimeoutKey struct{}
+)
+
+// ContextWithRequestTimeout returns a context that bounds each attempt of the
+// calls made with it by timeout instead of Config.HTTPTimeout, so slow
+// operations such as domain transfers or bulk availability checks can be
+// given more time than record updates. Unlike a context deadline, it applies
+// afresh to every retry. A timeout of 0 removes the limit.
+func ContextWithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// ContextWithOverallTimeout returns a context that bounds the calls made with
+// it, including retries and rate-limit waits, by timeout instead of
+// Config.OverallTimeout. A timeout of 0 removes the limit.
+func ContextWithOverallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, overallTimeoutKey{}, timeout)
+}
+
+// requestTimeout returns the time limit of one attempt of a call with ctx.
+func (c *HTTPClient) requestTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.attemptTimeout
+}
+
+// overallTimeout returns the time limit of a whole call with ctx.
+func (c *HTTPClient) overallTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(overallTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.config.OverallTimeout
+}
+
+// withRequestTimeout returns the context for one attempt of a call with ctx.
+func (c *HTTPClient) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := c.requestTimeout(ctx); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// cancelOnClose releases an attempt's context when a streamed body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
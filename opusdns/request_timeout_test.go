@@ -0,0 +1,75 @@
+package opusdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithRequestTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"name": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithHTTPTimeout(20*time.Millisecond),
+		WithMaxRetries(1),
+		WithRetryWait(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	// Each attempt times out under the client timeout and is retried.
+	_, err = client.DNS.GetZone(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(2), attempts.Load())
+
+	// A longer per-call timeout lets the same call succeed.
+	attempts.Store(0)
+	zone, err := client.DNS.GetZone(ContextWithRequestTimeout(context.Background(), time.Second), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Equal(t, int32(1), attempts.Load())
+
+	// Streams are bounded until the body is closed.
+	body, err := client.http.Stream(ContextWithRequestTimeout(context.Background(), time.Second), &Request{Method: http.MethodGet, Path: "/v1/dns/example.com"})
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.JSONEq(t, `{"name": "example.com"}`, string(data))
+}
+
+func TestContextWithOverallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithMaxRetries(100),
+		WithRetryWait(20*time.Millisecond, 20*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.DNS.GetZone(ContextWithOverallTimeout(context.Background(), 100*time.Millisecond), "example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+}
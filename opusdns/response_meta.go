@@ -0,0 +1,145 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResponseMeta is the HTTP metadata of an API response.
+type ResponseMeta struct {
+	// Method and Path identify the request.
+	Method string
+	Path   string
+
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// RequestID is the request ID to quote in support tickets (from the
+	// X-Request-ID header).
+	RequestID string
+
+	// RateLimit is the rate-limit quota reported by the response, or nil if
+	// it reported none.
+	RateLimit *RateLimit
+
+	// Header holds all response headers.
+	Header http.Header
+
+	// Body is the raw response body, decompressed, from which the typed
+	// result was decoded. It holds API fields the models do not have yet and
+	// must not be modified. It is nil for streamed responses.
+	Body json.RawMessage
+}
+
+// Decode decodes the raw response body into v, such as a map or a struct
+// with just the fields the models are missing:
+//
+//	var extra struct {
+//		NewField string `json:"new_field"`
+//	}
+//	err := meta.Decode(&extra)
+func (m *ResponseMeta) Decode(v interface{}) error {
+	if len(m.Body) == 0 {
+		return fmt.Errorf("opusdns: response to %s %s has no body", m.Method, m.Path)
+	}
+	if err := json.Unmarshal(m.Body, v); err != nil {
+		return fmt.Errorf("opusdns: failed to decode response to %s %s: %w", m.Method, m.Path, err)
+	}
+	return nil
+}
+
+// RateLimit is a rate-limit quota reported in response headers.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is when the window resets (zero if not reported).
+	Reset time.Time
+}
+
+// responseHandlerKey is the context key for the per-call response handler.
+type responseHandlerKey struct{}
+
+// ContextWithResponseHandler returns a context that calls fn with the
+// metadata of every HTTP response received by the calls made with it. A call
+// can receive several responses: one per retried attempt, and one per page
+// for methods fetching all pages. fn may be called from several goroutines
+// if the context is shared by concurrent calls.
+func ContextWithResponseHandler(ctx context.Context, fn func(*ResponseMeta)) context.Context {
+	return context.WithValue(ctx, responseHandlerKey{}, fn)
+}
+
+// WithResponse runs call with a context recording the response metadata and
+// returns its results with the metadata of the last response received, or
+// nil if no response was received:
+//
+//	zones, meta, err := opusdns.WithResponse(ctx, func(ctx context.Context) ([]models.Zone, error) {
+//		return client.DNS.ListZones(ctx, nil)
+//	})
+//	log.Printf("request_id=%s remaining=%d", meta.RequestID, meta.RateLimit.Remaining)
+func WithResponse[T any](ctx context.Context, call func(context.Context) (T, error)) (T, *ResponseMeta, error) {
+	var mu sync.Mutex
+	var last *ResponseMeta
+	ctx = ContextWithResponseHandler(ctx, func(meta *ResponseMeta) {
+		mu.Lock()
+		defer mu.Unlock()
+		last = meta
+	})
+
+	result, err := call(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return result, last, err
+}
+
+// reportResponse passes the metadata of a response to the handler of ctx,
+// if any. body is nil for streamed responses.
+func reportResponse(ctx context.Context, req *Request, statusCode int, header http.Header, body []byte) {
+	fn, ok := ctx.Value(responseHandlerKey{}).(func(*ResponseMeta))
+	if !ok || fn == nil {
+		return
+	}
+
+	fn(&ResponseMeta{
+		Method:     req.Method,
+		Path:       req.Path,
+		StatusCode: statusCode,
+		RequestID:  header.Get("X-Request-ID"),
+		RateLimit:  parseRateLimit(header, time.Now()),
+		Header:     header,
+		Body:       body,
+	})
+}
+
+// parseRateLimit reads the RateLimit-* headers, or the older X-RateLimit-*
+// headers. Reset is either seconds until the reset or, for large values, a
+// Unix timestamp.
+func parseRateLimit(header http.Header, now time.Time) *RateLimit {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+
+		rl := &RateLimit{Remaining: remaining}
+		rl.Limit, _ = strconv.Atoi(header.Get(prefix + "Limit"))
+		if reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); err == nil {
+			if reset > 1_000_000_000 {
+				rl.Reset = time.Unix(reset, 0)
+			} else {
+				rl.Reset = now.Add(time.Duration(reset) * time.Second)
+			}
+		}
+		return rl
+	}
+	return nil
+}
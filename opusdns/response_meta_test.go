@@ -0,0 +1,113 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResponse(t *testing.T) {
+	reset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	page := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		w.Header().Set("X-Request-ID", "req_"+strconv.Itoa(page))
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(100-page))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		_ = json.NewEncoder(w).Encode(models.ZoneListResponse{
+			Results:    []models.Zone{{Name: "zone" + strconv.Itoa(page) + ".com"}},
+			Pagination: models.Pagination{HasNextPage: page < 2},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zones, meta, err := WithResponse(context.Background(), func(ctx context.Context) ([]models.Zone, error) {
+		return client.DNS.ListZones(ctx, nil)
+	})
+	require.NoError(t, err)
+	assert.Len(t, zones, 2)
+
+	require.NotNil(t, meta)
+	assert.Equal(t, http.MethodGet, meta.Method)
+	assert.Equal(t, "/v1/dns", meta.Path)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "req_2", meta.RequestID)
+	assert.Equal(t, &RateLimit{Limit: 100, Remaining: 98, Reset: reset.Local()}, meta.RateLimit)
+}
+
+func TestResponseMeta_Body(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"example.com","dnssec_status":"enabled","anycast_pool":"eu-2"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zone, meta, err := WithResponse(context.Background(), func(ctx context.Context) (*models.Zone, error) {
+		return client.DNS.GetZone(ctx, "example.com")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSSECStatusEnabled, zone.DNSSECStatus)
+
+	var extra struct {
+		AnycastPool string `json:"anycast_pool"`
+	}
+	require.NoError(t, meta.Decode(&extra))
+	assert.Equal(t, "eu-2", extra.AnycastPool)
+	assert.JSONEq(t, `{"name":"example.com","dnssec_status":"enabled","anycast_pool":"eu-2"}`, string(meta.Body))
+
+	assert.Error(t, (&ResponseMeta{Method: http.MethodGet, Path: "/v1/dns"}).Decode(&extra))
+}
+
+func TestContextWithResponseHandler(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Request-ID", "req_"+strconv.Itoa(attempts))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithRetryWait(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	var metas []*ResponseMeta
+	ctx := ContextWithResponseHandler(context.Background(), func(m *ResponseMeta) { metas = append(metas, m) })
+	_, err = client.DNS.GetZone(ctx, "example.com")
+	require.Error(t, err)
+
+	require.Len(t, metas, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, metas[0].StatusCode)
+	assert.Equal(t, "req_2", metas[1].RequestID)
+	assert.Equal(t, http.StatusNotFound, metas[1].StatusCode)
+	assert.Nil(t, metas[1].RateLimit)
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	header.Set("RateLimit-Limit", "50")
+	header.Set("RateLimit-Remaining", "0")
+	header.Set("RateLimit-Reset", "30")
+	assert.Equal(t, &RateLimit{Limit: 50, Remaining: 0, Reset: now.Add(30 * time.Second)}, parseRateLimit(header, now))
+
+	assert.Nil(t, parseRateLimit(http.Header{}, now))
+}
@@ -0,0 +1,123 @@
+package opusdns
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// APIKeysService provides methods for managing the organization's API keys.
+type APIKeysService struct {
+	client *Client
+}
+
+// ListAPIKeys retrieves all API keys with automatic pagination.
+func (s *APIKeysService) ListAPIKeys(ctx context.Context, opts *models.ListAPIKeysOptions) ([]models.OrganizationCredential, error) {
+	var all []models.OrganizationCredential
+	page := 1
+
+	for {
+		pageOpts := cloneOptions(opts)
+		pageOpts.Page = page
+		if pageOpts.PageSize == 0 {
+			pageOpts.PageSize = DefaultPageSize
+		}
+
+		resp, err := s.ListAPIKeysPage(ctx, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Results...)
+
+		if !resp.Pagination.HasNextPage {
+			break
+		}
+		page++
+	}
+
+	return all, nil
+}
+
+// ListAPIKeysPage retrieves a single page of API keys.
+func (s *APIKeysService) ListAPIKeysPage(ctx context.Context, opts *models.ListAPIKeysOptions) (*models.OrganizationCredentialListResponse, error) {
+	path := s.client.http.BuildPath("auth", "client_credentials")
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.PageSize > 0 {
+			query.Set("page_size", strconv.Itoa(opts.PageSize))
+		}
+		if opts.Status != "" {
+			query.Set("status", string(opts.Status))
+		}
+	}
+
+	resp, err := s.client.http.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.OrganizationCredentialListResponse
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAPIKey retrieves an API key by ID. The secret is not included.
+func (s *APIKeysService) GetAPIKey(ctx context.Context, keyID models.OrganizationCredentialID) (*models.OrganizationCredential, error) {
+	path := s.client.http.BuildPath("auth", "client_credentials", string(keyID))
+
+	resp, err := s.client.http.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var credential models.OrganizationCredential
+	if err := s.client.http.DecodeResponse(resp, &credential); err != nil {
+		return nil, err
+	}
+
+	return &credential, nil
+}
+
+// CreateAPIKey creates an API key. The returned APIKey secret is only
+// available in this response, so it must be stored right away.
+func (s *APIKeysService) CreateAPIKey(ctx context.Context, req *models.APIKeyCreateRequest) (*models.APIKeyCreated, error) {
+	if req == nil {
+		req = &models.APIKeyCreateRequest{}
+	}
+
+	path := s.client.http.BuildPath("auth", "client_credentials")
+
+	resp, err := s.client.http.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var created models.APIKeyCreated
+	if err := s.client.http.DecodeResponse(resp, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// RevokeAPIKey revokes an API key. Requests made with it fail from then on.
+func (s *APIKeysService) RevokeAPIKey(ctx context.Context, keyID models.OrganizationCredentialID) error {
+	path := s.client.http.BuildPath("auth", "client_credentials", string(keyID))
+
+	resp, err := s.client.http.Delete(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, nil)
+}
@@ -0,0 +1,88 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeysService_ListAPIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1/auth/client_credentials", r.URL.Path)
+		assert.Equal(t, "active", r.URL.Query().Get("status"))
+
+		page := r.URL.Query().Get("page")
+		_ = json.NewEncoder(w).Encode(models.OrganizationCredentialListResponse{
+			Results:    []models.OrganizationCredential{{APIKeyID: models.OrganizationCredentialID("key_" + page)}},
+			Pagination: models.Pagination{HasNextPage: page == "1"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	keys, err := client.APIKeys.ListAPIKeys(context.Background(), &models.ListAPIKeysOptions{
+		Status: models.OrganizationCredentialStatusActive,
+	})
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, models.OrganizationCredentialID("key_2"), keys[1].APIKeyID)
+}
+
+func TestAPIKeysService_CreateAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/auth/client_credentials", r.URL.Path)
+
+		var req models.APIKeyCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.NotNil(t, req.APIKeyName)
+		assert.Equal(t, "ci", *req.APIKeyName)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"api_key_id":"key_new","api_key_name":"ci","status":"active","api_key":"opk_secret"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	name := "ci"
+	created, err := client.APIKeys.CreateAPIKey(context.Background(), &models.APIKeyCreateRequest{APIKeyName: &name})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrganizationCredentialID("key_new"), created.APIKeyID)
+	assert.Equal(t, models.OrganizationCredentialStatusActive, created.Status)
+	assert.Equal(t, "opk_secret", created.APIKey)
+}
+
+func TestAPIKeysService_GetAndRevokeAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/auth/client_credentials/key_1", r.URL.Path)
+		switch r.Method {
+		case "GET":
+			_ = json.NewEncoder(w).Encode(models.OrganizationCredential{APIKeyID: "key_1"})
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	key, err := client.APIKeys.GetAPIKey(context.Background(), "key_1")
+	require.NoError(t, err)
+	assert.Equal(t, models.OrganizationCredentialID("key_1"), key.APIKeyID)
+
+	require.NoError(t, client.APIKeys.RevokeAPIKey(context.Background(), "key_1"))
+}
@@ -2,6 +2,7 @@ package opusdns
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/opusdns/opusdns-go-client/models"
 )
@@ -28,3 +29,32 @@ func (s *AuthService) IntrospectAPIKey(ctx context.Context) (*models.Organizatio
 
 	return &credential, nil
 }
+
+// IntrospectToken describes any API key or access token of the organization,
+// such as one found in a leak, including whether it is still active and the
+// session or API key to revoke. It only reads, so it is sent even in dry-run
+// mode, is not checked by policies and is not audited.
+func (s *AuthService) IntrospectToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	if token == "" {
+		return nil, &ValidationError{Field: "token", Message: "token is required"}
+	}
+
+	path := s.client.http.BuildPath("auth", "token", "introspect")
+
+	resp, err := s.client.http.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     path,
+		Body:     &models.TokenIntrospectionRequest{Token: token},
+		ReadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.TokenIntrospection
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
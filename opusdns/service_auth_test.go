@@ -1,8 +1,10 @@
 package opusdns
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -33,3 +35,56 @@ func TestAuthService_IntrospectAPIKey(t *testing.T) {
 	assert.Equal(t, "admin", *cred.Role)
 	assert.Equal(t, models.OrganizationCredentialStatusActive, cred.Status)
 }
+
+func TestAuthService_IntrospectToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/auth/token/introspect", r.URL.Path)
+
+		var req models.TokenIntrospectionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Token != "opk_leaked" {
+			_ = json.NewEncoder(w).Encode(models.TokenIntrospection{Active: false})
+			return
+		}
+		keyID := models.OrganizationCredentialID("apikey_1")
+		_ = json.NewEncoder(w).Encode(models.TokenIntrospection{Active: true, TokenType: models.TokenTypeAPIKey, APIKeyID: &keyID})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	info, err := client.Auth.IntrospectToken(context.Background(), "opk_leaked")
+	require.NoError(t, err)
+	assert.True(t, info.Active)
+	assert.Equal(t, models.TokenTypeAPIKey, info.TokenType)
+	require.NotNil(t, info.APIKeyID)
+	assert.Equal(t, "apikey_1", string(*info.APIKeyID))
+
+	info, err = client.Auth.IntrospectToken(context.Background(), "opk_revoked")
+	require.NoError(t, err)
+	assert.False(t, info.Active)
+
+	_, err = client.Auth.IntrospectToken(context.Background(), "")
+	assert.True(t, IsValidationError(err))
+
+	t.Run("read-only", func(t *testing.T) {
+		var journal bytes.Buffer
+		client, err := NewClient(
+			WithAPIKey("opk_test"),
+			WithAPIEndpoint(server.URL),
+			WithDryRun(true),
+			WithAuditWriter(&journal),
+			WithPolicy(PolicyFunc(func(ctx context.Context, m *Mutation) error {
+				return errors.New("changes are frozen")
+			})),
+		)
+		require.NoError(t, err)
+
+		info, err := client.Auth.IntrospectToken(context.Background(), "opk_leaked")
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Empty(t, journal.String())
+	})
+}
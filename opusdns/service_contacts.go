@@ -2,8 +2,11 @@ package opusdns
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
@@ -81,6 +84,9 @@ func (s *ContactsService) ListContactsPage(ctx context.Context, opts *models.Lis
 		if opts.Country != "" {
 			query.Set("country", opts.Country)
 		}
+		if opts.PostalCode != "" {
+			query.Set("postal_code", opts.PostalCode)
+		}
 		if opts.Verified != nil {
 			query.Set("verified", strconv.FormatBool(*opts.Verified))
 		}
@@ -142,6 +148,54 @@ func (s *ContactsService) CreateContact(ctx context.Context, req *models.Contact
 	return &contact, nil
 }
 
+// ContactBulkCreateFailure describes a contact that BulkCreate could not create.
+type ContactBulkCreateFailure struct {
+	// Index is the position of the request in the input slice.
+	Index int
+
+	// Request is the create request that failed.
+	Request models.ContactCreateRequest
+
+	// Err is the error returned by the API for this request.
+	Err error
+}
+
+// ContactBulkCreateResult reports the outcome of BulkCreate.
+type ContactBulkCreateResult struct {
+	// Created contains the contacts that were created, in input order.
+	Created []models.Contact
+
+	// Failed contains the requests that could not be created.
+	Failed []ContactBulkCreateFailure
+}
+
+// BulkCreate creates each contact in reqs, continuing past individual failures.
+// Failures are reported per request in the result rather than aborting the run.
+// A non-nil error is only returned when ctx is done; the result then holds the
+// contacts processed so far.
+func (s *ContactsService) BulkCreate(ctx context.Context, reqs []models.ContactCreateRequest) (*ContactBulkCreateResult, error) {
+	result := &ContactBulkCreateResult{}
+
+	for i := range reqs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		contact, err := s.CreateContact(ctx, &reqs[i])
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return result, ctxErr
+			}
+			result.Failed = append(result.Failed, ContactBulkCreateFailure{Index: i, Request: reqs[i], Err: err})
+			continue
+		}
+
+		result.Created = append(result.Created, *contact)
+	}
+
+	return result, nil
+}
+
 // DeleteContact deletes a contact.
 func (s *ContactsService) DeleteContact(ctx context.Context, contactID models.ContactID) error {
 	path := s.client.http.BuildPath("contacts", string(contactID))
@@ -154,6 +208,105 @@ func (s *ContactsService) DeleteContact(ctx context.Context, contactID models.Co
 	return s.client.http.DecodeResponse(resp, nil)
 }
 
+// UpdateDisclosure updates which fields of a contact are publicly disclosed.
+// Fields left nil in req.DiscloseFields inherit the contact-level Disclose setting.
+func (s *ContactsService) UpdateDisclosure(ctx context.Context, contactID models.ContactID, req *models.ContactDisclosureUpdateRequest) (*models.Contact, error) {
+	path := s.client.http.BuildPath("contacts", string(contactID), "disclosure")
+
+	resp, err := s.client.http.Patch(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var contact models.Contact
+	if err := s.client.http.DecodeResponse(resp, &contact); err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+// GetRegistryAttributes retrieves the registry attributes set on a contact for a TLD.
+func (s *ContactsService) GetRegistryAttributes(ctx context.Context, contactID models.ContactID, tld string) (*models.ContactRegistryAttributes, error) {
+	tld = normalizeTLD(tld)
+	path := s.client.http.BuildPath("contacts", string(contactID), "attributes", url.PathEscape(tld))
+
+	resp, err := s.client.http.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.ContactRegistryAttributes
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SetRegistryAttributes replaces the registry attributes set on a contact for a TLD.
+// The attributes are first validated against the TLD's attribute definitions
+// (see ValidateRegistryAttributes); TLDs that publish no definitions are not
+// validated client-side.
+func (s *ContactsService) SetRegistryAttributes(ctx context.Context, contactID models.ContactID, tld string, attrs map[models.RegistryHandleAttributeType]string) (*models.ContactRegistryAttributes, error) {
+	tld = normalizeTLD(tld)
+	if tld == "" {
+		return nil, &ValidationError{Field: "tld", Message: "TLD is required"}
+	}
+
+	details, err := s.client.TLDs.GetTLD(ctx, tld)
+	if err != nil {
+		return nil, err
+	}
+	if len(details.AttributeDefinitions) > 0 {
+		if err := ValidateRegistryAttributes(details.AttributeDefinitions, attrs); err != nil {
+			return nil, err
+		}
+	}
+
+	path := s.client.http.BuildPath("contacts", string(contactID), "attributes", url.PathEscape(tld))
+
+	resp, err := s.client.http.Put(ctx, path, &models.ContactRegistryAttributesUpdateRequest{Attributes: attrs})
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.ContactRegistryAttributes
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ValidateRegistryAttributes checks attrs against a TLD's attribute definitions:
+// every key must be defined, required attributes must be present and non-empty,
+// and enum-typed attributes must use one of the allowed values.
+func ValidateRegistryAttributes(defs []models.ContactAttributeDefinition, attrs map[models.RegistryHandleAttributeType]string) error {
+	byKey := make(map[models.RegistryHandleAttributeType]models.ContactAttributeDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	for key, value := range attrs {
+		def, ok := byKey[key]
+		if !ok {
+			return &ValidationError{Field: string(key), Message: "attribute is not defined for this TLD"}
+		}
+		if len(def.Values) > 0 && !slices.Contains(def.Values, value) {
+			return &ValidationError{Field: string(key), Message: fmt.Sprintf("must be one of %s", strings.Join(def.Values, ", ")), Value: value}
+		}
+	}
+
+	for _, def := range defs {
+		if def.Required && attrs[def.Key] == "" {
+			return &ValidationError{Field: string(def.Key), Message: "attribute is required for this TLD"}
+		}
+	}
+
+	return nil
+}
+
 // RequestVerification initiates email verification for a contact.
 func (s *ContactsService) RequestVerification(ctx context.Context, contactID models.ContactID) (*models.ContactVerification, error) {
 	path := s.client.http.BuildPath("contacts", string(contactID), "verification")
@@ -376,3 +376,146 @@ func TestContactsService_GetVerificationStatus(t *testing.T) {
 	assert.Equal(t, models.ContactID("contact_123"), verification.ContactID)
 	assert.Equal(t, models.EmailVerificationVerified, verification.Status)
 }
+
+func TestContactsService_BulkCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v1/contacts", r.URL.Path)
+
+		var req models.ContactCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Email == "bad@example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid phone"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(models.Contact{ContactID: "contact_" + models.ContactID(req.FirstName), Email: req.Email})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	result, err := client.Contacts.BulkCreate(context.Background(), []models.ContactCreateRequest{
+		{FirstName: "a", Email: "a@example.com"},
+		{FirstName: "b", Email: "bad@example.com"},
+		{FirstName: "c", Email: "c@example.com"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 2)
+	assert.Equal(t, models.ContactID("contact_a"), result.Created[0].ContactID)
+	assert.Equal(t, models.ContactID("contact_c"), result.Created[1].ContactID)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, 1, result.Failed[0].Index)
+	assert.Equal(t, "bad@example.com", result.Failed[0].Request.Email)
+	assert.ErrorIs(t, result.Failed[0].Err, ErrBadRequest)
+}
+
+func TestContactsService_RegistryAttributes(t *testing.T) {
+	defs := []models.ContactAttributeDefinition{
+		{Key: models.RegistryAttrDEContactType, Type: "enum", Values: []string{"PERSON", "ORG"}, Required: true},
+		{Key: models.RegistryAttrDNSBEType, Type: "string"},
+	}
+
+	newServer := func(t *testing.T, puts *int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "GET" && r.URL.Path == "/v1/tlds/de":
+				_ = json.NewEncoder(w).Encode(models.TLDDetails{TLD: models.TLD{Name: "de", AttributeDefinitions: defs}})
+			case r.URL.Path == "/v1/contacts/contact_1/attributes/de":
+				var attrs map[models.RegistryHandleAttributeType]string
+				if r.Method == "PUT" {
+					*puts++
+					var req models.ContactRegistryAttributesUpdateRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					attrs = req.Attributes
+				} else {
+					attrs = map[models.RegistryHandleAttributeType]string{models.RegistryAttrDEContactType: "ORG"}
+				}
+				_ = json.NewEncoder(w).Encode(models.ContactRegistryAttributes{ContactID: "contact_1", TLD: "de", Attributes: attrs})
+			default:
+				t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			}
+		}))
+	}
+
+	t.Run("sets and reads back attributes", func(t *testing.T) {
+		puts := 0
+		server := newServer(t, &puts)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Contacts.SetRegistryAttributes(context.Background(), "contact_1", ".DE", map[models.RegistryHandleAttributeType]string{
+			models.RegistryAttrDEContactType: "PERSON",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, puts)
+		assert.Equal(t, "PERSON", result.Attributes[models.RegistryAttrDEContactType])
+
+		current, err := client.Contacts.GetRegistryAttributes(context.Background(), "contact_1", "de")
+		require.NoError(t, err)
+		assert.Equal(t, "ORG", current.Attributes[models.RegistryAttrDEContactType])
+	})
+
+	t.Run("rejects invalid attributes before writing", func(t *testing.T) {
+		puts := 0
+		server := newServer(t, &puts)
+		defer server.Close()
+
+		client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+		require.NoError(t, err)
+
+		_, err = client.Contacts.SetRegistryAttributes(context.Background(), "contact_1", "de", map[models.RegistryHandleAttributeType]string{
+			models.RegistryAttrDEContactType: "ROBOT",
+		})
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+
+		_, err = client.Contacts.SetRegistryAttributes(context.Background(), "contact_1", "de", map[models.RegistryHandleAttributeType]string{
+			models.RegistryAttrDNSBEType: "x",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "required")
+		assert.Equal(t, 0, puts)
+	})
+}
+
+func TestContactsService_UpdateDisclosure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/v1/contacts/contact_1/disclosure", r.URL.Path)
+
+		var raw map[string]map[string]bool
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		assert.Equal(t, map[string]bool{"email": true, "address": false}, raw["disclose_fields"])
+
+		_ = json.NewEncoder(w).Encode(models.Contact{
+			ContactID: "contact_1",
+			Disclose:  false,
+			DiscloseFields: &models.ContactDiscloseFields{
+				Email:   models.BoolPtr(true),
+				Address: models.BoolPtr(false),
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	contact, err := client.Contacts.UpdateDisclosure(context.Background(), "contact_1", &models.ContactDisclosureUpdateRequest{
+		DiscloseFields: models.ContactDiscloseFields{
+			Email:   models.BoolPtr(true),
+			Address: models.BoolPtr(false),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, contact.IsDisclosed(models.ContactDiscloseEmail))
+	assert.False(t, contact.IsDisclosed(models.ContactDiscloseAddress))
+	assert.False(t, contact.IsDisclosed(models.ContactDiscloseName))
+}
@@ -2,6 +2,9 @@ package opusdns
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -13,14 +16,23 @@ import (
 // DNSService provides methods for managing DNS zones and records.
 type DNSService struct {
 	client *Client
+
+	zones zoneCache // see FindZoneForFQDN
 }
 
 // ListZones retrieves all DNS zones with automatic pagination.
+// Pages are fetched in parallel when Config.ListConcurrency is above 1.
+// It fails with ErrTooManyResults beyond Config.MaxPages or Config.MaxItems.
 func (s *DNSService) ListZones(ctx context.Context, opts *models.ListZonesOptions) ([]models.Zone, error) {
-	var allZones []models.Zone
-	page := 1
-
-	for {
+	opts, err := s.resolveTagLabels(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	limits := s.client.Config.pageLimits(0, 0)
+	if opts != nil {
+		limits = s.client.Config.pageLimits(opts.MaxPages, opts.MaxItems)
+	}
+	return fetchAllPages(ctx, s.client.Config.ListConcurrency, limits, func(ctx context.Context, page int) ([]models.Zone, models.Pagination, error) {
 		pageOpts := cloneOptions(opts)
 		pageOpts.Page = page
 		if pageOpts.PageSize == 0 {
@@ -29,22 +41,19 @@ func (s *DNSService) ListZones(ctx context.Context, opts *models.ListZonesOption
 
 		resp, err := s.ListZonesPage(ctx, pageOpts)
 		if err != nil {
-			return nil, err
-		}
-
-		allZones = append(allZones, resp.Results...)
-
-		if !resp.Pagination.HasNextPage {
-			break
+			return nil, models.Pagination{}, err
 		}
-		page++
-	}
-
-	return allZones, nil
+		return resp.Results, resp.Pagination, nil
+	})
 }
 
 // ListZonesPage retrieves a single page of DNS zones.
 func (s *DNSService) ListZonesPage(ctx context.Context, opts *models.ListZonesOptions) (*models.ZoneListResponse, error) {
+	opts, err := s.resolveTagLabels(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("dns")
 
 	query := url.Values{}
@@ -114,7 +123,10 @@ func (s *DNSService) GetZone(ctx context.Context, name string) (*models.Zone, er
 	return s.GetZoneWithOptions(ctx, name, nil)
 }
 
-// GetZoneWithOptions retrieves a specific zone by name with optional response expansions.
+// GetZoneWithOptions retrieves a specific zone by name with optional response
+// expansions and RRset selection. Set IncludeRRSets to false to skip the records
+// of large zones, or use Types and NameFilter to fetch only the RRsets needed.
+// The selection is sent to the API and also applied to the response.
 func (s *DNSService) GetZoneWithOptions(ctx context.Context, name string, opts *models.GetZoneOptions) (*models.Zone, error) {
 	name = strings.TrimSuffix(name, ".")
 	path := s.client.http.BuildPath("dns", url.PathEscape(name))
@@ -124,6 +136,15 @@ func (s *DNSService) GetZoneWithOptions(ctx context.Context, name string, opts *
 		for _, include := range opts.Include {
 			query.Add("include", string(include))
 		}
+		if opts.IncludeRRSets != nil {
+			query.Set("include_rrsets", strconv.FormatBool(*opts.IncludeRRSets))
+		}
+		for _, rrtype := range opts.Types {
+			query.Add("rrset_type", string(rrtype))
+		}
+		if opts.NameFilter != "" {
+			query.Set("rrset_name", opts.NameFilter)
+		}
 	}
 
 	resp, err := s.client.http.Get(ctx, path, query)
@@ -136,17 +157,207 @@ func (s *DNSService) GetZoneWithOptions(ctx context.Context, name string, opts *
 		return nil, err
 	}
 
+	if opts != nil {
+		if opts.IncludeRRSets != nil && !*opts.IncludeRRSets {
+			zone.RRSets = nil
+		}
+		if len(opts.Types) > 0 || opts.NameFilter != "" {
+			filtered := zone.RRSets[:0]
+			for _, rrset := range zone.RRSets {
+				if opts.MatchesRRSet(rrset) {
+					filtered = append(filtered, rrset)
+				}
+			}
+			zone.RRSets = filtered
+		}
+	}
+
 	return &zone, nil
 }
 
+// findZonePageSize is the page size FindZone lists zones with. If more zones
+// share the suffix, FindZone probes the candidates instead.
+const findZonePageSize = 100
+
+// FindZone returns the most specific zone containing fqdn, without its
+// records. It lists the zones ending in the last two labels of fqdn in one
+// request and picks the longest that is fqdn itself or a parent of it, so a
+// name at a zone apex finds that zone and a name in a delegated subzone finds
+// the subzone rather than its parent. If the matching zones do not fit in one
+// page, or the API rejects the suffix filter, it falls back to probing each
+// candidate with GetZone, longest first. It returns an error matching
+// ErrZoneNotFound if no zone contains fqdn.
+func (s *DNSService) FindZone(ctx context.Context, fqdn string) (*models.Zone, error) {
+	zone, _, err := s.locateZone(ctx, fqdn)
+	if zone != nil {
+		zone.RRSets = nil
+	}
+	return zone, err
+}
+
+// FindZoneForFQDN returns the most specific zone containing fqdn, with its
+// records. The zone is found like FindZone and then fetched, so a lookup
+// usually costs two requests.
+//
+// With Config.ZoneCacheTTL set, probing remembers which candidates are not
+// zones for that long, so looking up many names in the same zones (such as
+// ACME challenges for a batch of certificates) stays cheap when probing is
+// needed.
+func (s *DNSService) FindZoneForFQDN(ctx context.Context, fqdn string) (*models.Zone, error) {
+	zone, fetched, err := s.locateZone(ctx, fqdn)
+	if err != nil || fetched {
+		return zone, err
+	}
+	return s.GetZone(ctx, zone.Name)
+}
+
+// locateZone implements FindZone and reports whether the zone was fetched
+// with its records, which is the case when it was found by probing.
+func (s *DNSService) locateZone(ctx context.Context, fqdn string) (*models.Zone, bool, error) {
+	candidates := zoneCandidates(fqdn)
+	if len(candidates) == 0 {
+		return nil, false, fmt.Errorf("%w: %s", ErrZoneNotFound, fqdn)
+	}
+
+	resp, err := s.ListZonesPage(ctx, &models.ListZonesOptions{
+		Suffix:   candidates[len(candidates)-1],
+		PageSize: findZonePageSize,
+	})
+	switch {
+	case err == nil && !resp.Pagination.HasNextPage:
+		for _, candidate := range candidates {
+			for _, zone := range resp.Results {
+				if strings.EqualFold(strings.TrimSuffix(zone.Name, "."), candidate) {
+					zone.Name = candidate
+					return &zone, false, nil
+				}
+			}
+		}
+		return nil, false, fmt.Errorf("%w: %s", ErrZoneNotFound, fqdn)
+	case err == nil, errors.Is(err, ErrBadRequest), IsNotFoundError(err):
+		zone, err := s.findZoneCached(ctx, fqdn)
+		return zone, true, err
+	default:
+		return nil, false, err
+	}
+}
+
+// findZone implements FindZoneForFQDN on top of dns.GetZone, so services
+// calling it honour a replaced DNS implementation.
+func findZone(ctx context.Context, dns DNSAPI, fqdn string) (*models.Zone, error) {
+	for _, candidate := range zoneCandidates(fqdn) {
+		zone, err := dns.GetZone(ctx, candidate)
+		if IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		zone.Name = candidate
+		return zone, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrZoneNotFound, fqdn)
+}
+
+// zoneCandidates returns the zone names that may contain fqdn, longest first:
+// fqdn itself and each parent with at least two labels.
+func zoneCandidates(fqdn string) []string {
+	fqdn = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(fqdn), "."))
+	if fqdn == "" {
+		return nil
+	}
+
+	labels := strings.Split(fqdn, ".")
+	candidates := make([]string, 0, len(labels))
+	for i := 0; i < len(labels)-1; i++ {
+		candidates = append(candidates, strings.Join(labels[i:], "."))
+	}
+	return candidates
+}
+
+// ListRRSets returns the RRsets of a zone that pass the given filters. The name
+// prefix and types are sent to the API so that only matching RRsets are
+// downloaded; the TTL range and search are applied to the response.
+func (s *DNSService) ListRRSets(ctx context.Context, zoneName string, opts *models.ListRRSetsOptions) ([]models.RRSet, error) {
+	zoneOpts := &models.GetZoneOptions{}
+	if opts != nil {
+		zoneOpts.Types = opts.Types
+		if opts.NamePrefix != "" {
+			zoneOpts.NameFilter = opts.NamePrefix + "*"
+		}
+	}
+
+	zone, err := s.GetZoneWithOptions(ctx, zoneName, zoneOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	rrsets := make([]models.RRSet, 0, len(zone.RRSets))
+	for _, rrset := range zone.RRSets {
+		if opts.Matches(rrset) {
+			rrsets = append(rrsets, rrset)
+		}
+	}
+
+	return rrsets, nil
+}
+
+// GetRRSet retrieves a single RRset by relative name ("@" for the apex) and
+// type without downloading the rest of the zone. It returns ErrNotFound if the
+// zone has no such RRset.
+func (s *DNSService) GetRRSet(ctx context.Context, zoneName, name string, rrtype models.RRSetType) (*models.RRSet, error) {
+	if name == "" {
+		name = "@"
+	}
+
+	zone, err := s.GetZoneWithOptions(ctx, zoneName, &models.GetZoneOptions{
+		Types:      []models.RRSetType{rrtype},
+		NameFilter: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rrset := findRRSet(zone, name, rrtype)
+	if rrset == nil {
+		return nil, ErrNotFound
+	}
+
+	return rrset, nil
+}
+
 // CreateZone creates a new DNS zone.
+// The zone name and the names of any initial RRsets are checked with
+// ValidateZoneName and ValidateRecordName before the request is sent, and a
+// CNAME at the apex is rejected in favour of ALIAS. A
+// secondary zone (Kind ZoneKindSecondary) needs Transfer settings naming its
+// primary servers and may not have initial RRsets.
 func (s *DNSService) CreateZone(ctx context.Context, req *models.ZoneCreateRequest) (*models.Zone, error) {
+	if req == nil {
+		return nil, &ValidationError{Field: "request", Message: "request is required"}
+	}
+	if err := validateZoneName("name", req.Name); err != nil {
+		return nil, err
+	}
+	for i, rrset := range req.RRSets {
+		if err := validateRecordName(fmt.Sprintf("rrsets[%d].name", i), rrset.Name, req.Name); err != nil {
+			return nil, err
+		}
+		if err := validateApexCNAME(fmt.Sprintf("rrsets[%d].name", i), rrset.Name, rrset.Type, req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateZoneKind(req); err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("dns")
 
 	resp, err := s.client.http.Post(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
+	s.forgetZone(req.Name)
 
 	var zone models.Zone
 	if err := s.client.http.DecodeResponse(resp, &zone); err != nil {
@@ -186,8 +397,92 @@ func (s *DNSService) GetSummary(ctx context.Context) (*models.ZoneSummary, error
 	return &summary, nil
 }
 
+// GetZoneStats returns record counts by type, the DNSSEC state and the
+// creation and last-modified times of a zone. The API does not report query
+// counts, so they are not included.
+func (s *DNSService) GetZoneStats(ctx context.Context, zoneName string) (*models.ZoneStats, error) {
+	zone, err := s.GetZone(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := zone.Stats()
+	return &stats, nil
+}
+
+// GetQueryStats retrieves the query analytics of a zone: aggregate counts,
+// queries over time, the most queried names and the response codes. An error
+// matching ErrNotFound is returned if analytics are not available for the zone.
+func (s *DNSService) GetQueryStats(ctx context.Context, zoneName string, opts *models.DNSQueryStatsOptions) (*models.DNSQueryStats, error) {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	stats := &models.DNSQueryStats{Zone: zoneName}
+
+	if err := s.getQueryMetrics(ctx, zoneName, "", opts, &stats.Metrics); err != nil {
+		return nil, err
+	}
+
+	var timeSeries struct {
+		Results []models.TimeSeriesBucket `json:"results"`
+	}
+	if err := s.getQueryMetrics(ctx, zoneName, "time-series", opts, &timeSeries); err != nil {
+		return nil, err
+	}
+	stats.TimeSeries = timeSeries.Results
+
+	var names struct {
+		Results []models.DNSQueryNameBucket `json:"results"`
+	}
+	if err := s.getQueryMetrics(ctx, zoneName, "names", opts, &names); err != nil {
+		return nil, err
+	}
+	stats.TopNames = names.Results
+
+	var rcodes struct {
+		Results []models.DNSResponseCodeBucket `json:"results"`
+	}
+	if err := s.getQueryMetrics(ctx, zoneName, "response-codes", opts, &rcodes); err != nil {
+		return nil, err
+	}
+	stats.ResponseCodes = rcodes.Results
+
+	return stats, nil
+}
+
+// getQueryMetrics fetches a zone query metrics endpoint ("" for the aggregate) into target.
+func (s *DNSService) getQueryMetrics(ctx context.Context, zoneName, endpoint string, opts *models.DNSQueryStatsOptions, target interface{}) error {
+	parts := []string{"dns", url.PathEscape(zoneName), "metrics"}
+	if endpoint != "" {
+		parts = append(parts, endpoint)
+	}
+	path := s.client.http.BuildPath(parts...)
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.TimeRange != "" {
+			query.Set("time_range", string(opts.TimeRange))
+		}
+		if opts.Limit > 0 && endpoint == "names" {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	resp, err := s.client.http.Get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, target)
+}
+
 // PutRRSets replaces all resource record sets for a zone.
+// A CNAME at the zone apex is rejected; use ALIAS there.
 func (s *DNSService) PutRRSets(ctx context.Context, zoneName string, rrsets []models.RRSetCreate) error {
+	for i, rrset := range rrsets {
+		if err := validateApexCNAME(fmt.Sprintf("rrsets[%d].name", i), rrset.Name, rrset.Type, zoneName); err != nil {
+			return err
+		}
+	}
+
 	zoneName = strings.TrimSuffix(zoneName, ".")
 	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "rrsets")
 
@@ -202,7 +497,21 @@ func (s *DNSService) PutRRSets(ctx context.Context, zoneName string, rrsets []mo
 }
 
 // PatchRRSets applies multiple RRset operations atomically.
+// With ExpectedSerial set, the patch is only applied if the zone is still at
+// that serial; otherwise an error matching ErrConflictVersion is returned and
+// nothing is changed. Re-read the zone and recompute the operations to retry.
+//
+// Upserting a CNAME at the zone apex is rejected; use ALIAS there.
 func (s *DNSService) PatchRRSets(ctx context.Context, zoneName string, ops []models.RRSetPatchOp) error {
+	for i, op := range ops {
+		if op.Op == models.RecordOpRemove {
+			continue
+		}
+		if err := validateApexCNAME(fmt.Sprintf("ops[%d].rrset.name", i), op.RRSet.Name, op.RRSet.Type, zoneName); err != nil {
+			return err
+		}
+	}
+
 	zoneName = strings.TrimSuffix(zoneName, ".")
 	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "rrsets")
 
@@ -216,23 +525,102 @@ func (s *DNSService) PatchRRSets(ctx context.Context, zoneName string, ops []mod
 	return s.client.http.DecodeResponse(resp, nil)
 }
 
-// PatchRecords applies multiple record operations atomically.
+// PatchRecords applies multiple record operations.
+// TXT record data may be given as a plain string or as quoted character
+// strings; either way it is sent split into 255-byte strings.
+// Use PatchRecordsWithRequest to require that all operations apply or none do,
+// or to override record protection. Failed operations are reported as a
+// *BatchError.
 func (s *DNSService) PatchRecords(ctx context.Context, zoneName string, ops []models.RecordOperation) error {
+	_, err := s.PatchRecordsWithRequest(ctx, zoneName, &models.RecordPatchRequest{Ops: ops})
+	return err
+}
+
+// PatchRecordsWithRequest applies a record patch request. With Atomic set, the
+// API is asked to apply all operations in one transaction; the result reports
+// whether it confirmed doing so, since a server without transaction support
+// may apply the operations one by one.
+//
+// Upserting a CNAME at the zone apex is rejected with a *ValidationError
+// before the request is sent; use an ALIAS record there (see UpsertAlias).
+//
+// If operations fail, for example because of invalid record data or because
+// they target protected records without AllowProtected, a *BatchError lists
+// each failed operation with its index and reason; it matches
+// ErrRecordProtected for protected records. When the API applied the other
+// operations, the result is returned along with the error.
+func (s *DNSService) PatchRecordsWithRequest(ctx context.Context, zoneName string, req *models.RecordPatchRequest) (*models.RecordPatchResult, error) {
+	if req == nil {
+		return nil, &ValidationError{Field: "request", Message: "request is required"}
+	}
+	for i, op := range req.Ops {
+		if op.Op == models.RecordOpRemove {
+			continue
+		}
+		if err := validateApexCNAME(fmt.Sprintf("ops[%d].record.name", i), op.Record.Name, op.Record.Type, zoneName); err != nil {
+			return nil, err
+		}
+	}
+
 	zoneName = strings.TrimSuffix(zoneName, ".")
 	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "records")
 
-	req := models.RecordPatchRequest{Ops: ops}
+	body := *req
+	body.Ops = chunkTXTRecords(req.Ops)
 
-	resp, err := s.client.http.Patch(ctx, path, req)
+	var headers http.Header
+	if req.ExpectedSerial != 0 {
+		headers = http.Header{"If-Match": {fmt.Sprintf("%q", strconv.FormatInt(req.ExpectedSerial, 10))}}
+	}
+
+	resp, err := s.client.http.Do(ctx, &Request{Method: http.MethodPatch, Path: path, Body: body, Headers: headers})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return s.client.http.DecodeResponse(resp, nil)
+	var result models.RecordPatchResult
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if batchErr := newBatchError(apiErr, nil, req.Ops); batchErr != nil {
+				return nil, batchErr
+			}
+		}
+		return nil, err
+	}
+
+	if batchErr := newBatchError(nil, result.Rejected, req.Ops); batchErr != nil {
+		result.Rejected = batchErr.Failed
+		return &result, batchErr
+	}
+
+	return &result, nil
+}
+
+// chunkTXTRecords returns ops with the data of TXT records split into quoted
+// 255-byte character strings (see models.TXTData), so long values such as
+// DKIM keys are accepted. ops itself is not modified.
+func chunkTXTRecords(ops []models.RecordOperation) []models.RecordOperation {
+	out := make([]models.RecordOperation, len(ops))
+	for i, op := range ops {
+		if op.Record.Type == models.RRSetTypeTXT {
+			op.Record.RData = op.Record.TXT().RData()
+		}
+		out[i] = op
+	}
+	return out
 }
 
 // UpsertRecord creates or updates a single DNS record.
+// The zone and record names are validated like in CreateZone.
 func (s *DNSService) UpsertRecord(ctx context.Context, zoneName string, record models.Record) error {
+	if err := validateZoneName("zone", zoneName); err != nil {
+		return err
+	}
+	if err := validateRecordName("record.name", record.Name, zoneName); err != nil {
+		return err
+	}
+
 	return s.PatchRecords(ctx, zoneName, []models.RecordOperation{
 		{Op: models.RecordOpUpsert, Record: record},
 	})
@@ -302,3 +690,19 @@ func (s *DNSService) DisableDNSSEC(ctx context.Context, zoneName string) (*model
 
 	return &changes, nil
 }
+
+// resolveTagLabels returns opts with its Tags labels resolved into TagIDs,
+// so pages of one listing share a single tag lookup.
+func (s *DNSService) resolveTagLabels(ctx context.Context, opts *models.ListZonesOptions) (*models.ListZonesOptions, error) {
+	if opts == nil || len(opts.Tags) == 0 {
+		return opts, nil
+	}
+	ids, err := tagFilterIDs(ctx, s.client.Tags, models.TagTypeZone, opts.TagIDs, opts.Tags)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *opts
+	resolved.TagIDs = ids
+	resolved.Tags = nil
+	return &resolved, nil
+}
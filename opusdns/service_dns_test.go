@@ -3,9 +3,12 @@ package opusdns
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
 	"github.com/stretchr/testify/assert"
@@ -134,6 +137,151 @@ func TestDNSService_GetZone(t *testing.T) {
 	assert.Equal(t, "example.com", zone.Name)
 }
 
+func TestDNSService_FindZone(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/v1/dns":
+			var zones []models.Zone
+			for _, name := range []string{"example.com", "sub.example.com", "badexample.com", "example.net"} {
+				if strings.HasSuffix(name, r.URL.Query().Get("suffix")) {
+					zones = append(zones, models.Zone{Name: name + "."})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: zones})
+		case "/v1/dns/sub.example.com":
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "sub.example.com", RRSets: []models.RRSet{{Name: "www", Type: models.RRSetTypeA}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		fqdn string
+		zone string
+	}{
+		{fqdn: "example.com", zone: "example.com"},
+		{fqdn: "_acme-challenge.Example.COM.", zone: "example.com"},
+		{fqdn: "www.sub.example.com", zone: "sub.example.com"},
+		{fqdn: "sub.example.com", zone: "sub.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fqdn, func(t *testing.T) {
+			requested = nil
+			zone, err := client.DNS.FindZone(ctx, tt.fqdn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.zone, zone.Name)
+			assert.Equal(t, []string{"/v1/dns?page_size=100&suffix=example.com"}, requested)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.DNS.FindZone(ctx, "www.example.org")
+		assert.ErrorIs(t, err, ErrZoneNotFound)
+	})
+
+	t.Run("with records", func(t *testing.T) {
+		requested = nil
+		zone, err := client.DNS.FindZoneForFQDN(ctx, "www.sub.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "sub.example.com", zone.Name)
+		assert.Len(t, zone.RRSets, 1)
+		assert.Equal(t, []string{"/v1/dns?page_size=100&suffix=example.com", "/v1/dns/sub.example.com?"}, requested)
+	})
+}
+
+func TestDNSService_FindZone_MoreThanOnePage(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/v1/dns":
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Pagination: models.Pagination{HasNextPage: true}})
+		case "/v1/dns/www.example.co.uk":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.co.uk", RRSets: []models.RRSet{{Name: "www", Type: models.RRSetTypeA}}})
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zone, err := client.DNS.FindZone(context.Background(), "www.example.co.uk")
+	require.NoError(t, err)
+	assert.Equal(t, "example.co.uk", zone.Name)
+	assert.Empty(t, zone.RRSets)
+	assert.Equal(t, []string{"/v1/dns", "/v1/dns/www.example.co.uk", "/v1/dns/example.co.uk"}, requested)
+}
+
+func TestDNSService_FindZoneForFQDN_Probing(t *testing.T) {
+	zones := map[string]bool{"example.com": true, "sub.example.com": true}
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/dns" {
+			// An API without the suffix filter: probe instead.
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/v1/dns/")
+		requested = append(requested, name)
+		if name == "broken.example.org" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !zones[name] {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"zone not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: name})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	tests := []struct {
+		fqdn      string
+		zone      string
+		requested []string
+	}{
+		{fqdn: "example.com", zone: "example.com", requested: []string{"example.com"}},
+		{fqdn: "_acme-challenge.Example.COM.", zone: "example.com", requested: []string{"_acme-challenge.example.com", "example.com"}},
+		{fqdn: "www.sub.example.com", zone: "sub.example.com", requested: []string{"www.sub.example.com", "sub.example.com"}},
+		{fqdn: "sub.example.com", zone: "sub.example.com", requested: []string{"sub.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fqdn, func(t *testing.T) {
+			requested = nil
+			zone, err := client.DNS.FindZoneForFQDN(context.Background(), tt.fqdn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.zone, zone.Name)
+			assert.Equal(t, tt.requested, requested)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		requested = nil
+		_, err := client.DNS.FindZoneForFQDN(context.Background(), "www.example.net")
+		assert.ErrorIs(t, err, ErrZoneNotFound)
+		assert.Equal(t, []string{"www.example.net", "example.net"}, requested)
+	})
+
+	t.Run("stops on other errors", func(t *testing.T) {
+		_, err := client.DNS.FindZoneForFQDN(context.Background(), "broken.example.org")
+		assert.True(t, IsForbiddenError(err))
+	})
+}
+
 func TestDNSService_CreateZone(t *testing.T) {
 	t.Run("creates empty zone", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -422,6 +570,179 @@ func TestDNSService_GetZoneWithOptions(t *testing.T) {
 	assert.Equal(t, "example.com", zone.Name)
 }
 
+func TestDNSService_GetZoneWithOptions_RRSetSelection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("include_rrsets") == "false" {
+			assert.Empty(t, query["rrset_type"])
+		} else {
+			assert.Equal(t, []string{"TXT"}, query["rrset_type"])
+			assert.Equal(t, "_acme-challenge*", query.Get("rrset_name"))
+		}
+
+		// Respond with everything, as a server ignoring the selection would.
+		_ = json.NewEncoder(w).Encode(models.Zone{
+			Name: "example.com",
+			RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeTXT},
+				{Name: "_acme-challenge", Type: models.RRSetTypeTXT},
+				{Name: "_acme-challenge.www", Type: models.RRSetTypeTXT},
+				{Name: "_acme-challenge", Type: models.RRSetTypeCNAME},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	zone, err := client.DNS.GetZoneWithOptions(context.Background(), "example.com", &models.GetZoneOptions{
+		Types:      []models.RRSetType{models.RRSetTypeTXT},
+		NameFilter: "_acme-challenge*",
+	})
+	require.NoError(t, err)
+	require.Len(t, zone.RRSets, 2)
+	assert.Equal(t, "_acme-challenge", zone.RRSets[0].Name)
+	assert.Equal(t, "_acme-challenge.www", zone.RRSets[1].Name)
+
+	zone, err = client.DNS.GetZoneWithOptions(context.Background(), "example.com", &models.GetZoneOptions{
+		IncludeRRSets: models.BoolPtr(false),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Empty(t, zone.RRSets)
+}
+
+func TestDNSService_ListRRSets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/dns/example.com", r.URL.Path)
+		assert.Equal(t, []string{"TXT"}, r.URL.Query()["rrset_type"])
+		assert.Equal(t, "_acme*", r.URL.Query().Get("rrset_name"))
+
+		_ = json.NewEncoder(w).Encode(models.Zone{
+			Name: "example.com",
+			RRSets: []models.RRSet{
+				{Name: "_acme-challenge", Type: models.RRSetTypeTXT, TTL: 60, Records: []models.RecordData{{RData: `"token-a"`}}},
+				{Name: "_acme-challenge.www", Type: models.RRSetTypeTXT, TTL: 3600, Records: []models.RecordData{{RData: `"token-b"`}}},
+				{Name: "_acme-challenge.api", Type: models.RRSetTypeTXT, TTL: 120, Records: []models.RecordData{{RData: `"other"`}}},
+				{Name: "www", Type: models.RRSetTypeA, TTL: 60},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	rrsets, err := client.DNS.ListRRSets(context.Background(), "example.com", &models.ListRRSetsOptions{
+		NamePrefix: "_acme",
+		Types:      []models.RRSetType{models.RRSetTypeTXT},
+		MaxTTL:     300,
+		Search:     "TOKEN",
+	})
+	require.NoError(t, err)
+	require.Len(t, rrsets, 1)
+	assert.Equal(t, "_acme-challenge", rrsets[0].Name)
+}
+
+func TestDNSService_GetRRSet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"TXT"}, r.URL.Query()["rrset_type"])
+
+		rrsets := []models.RRSet{}
+		if r.URL.Query().Get("rrset_name") == "_acme-challenge" {
+			rrsets = append(rrsets, models.RRSet{
+				Name:    "_acme-challenge",
+				Type:    models.RRSetTypeTXT,
+				TTL:     60,
+				Records: []models.RecordData{{RData: `"token"`}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(models.Zone{Name: "example.com", RRSets: rrsets})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	rrset, err := client.DNS.GetRRSet(context.Background(), "example.com", "_acme-challenge", models.RRSetTypeTXT)
+	require.NoError(t, err)
+	assert.Equal(t, `"token"`, rrset.Records[0].RData)
+
+	_, err = client.DNS.GetRRSet(context.Background(), "example.com", "missing", models.RRSetTypeTXT)
+	assert.True(t, IsNotFoundError(err))
+}
+
+func TestDNSService_GetZoneStats(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/dns/example.com", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(models.Zone{
+			Name:         "example.com",
+			DNSSECStatus: models.DNSSECStatusEnabled,
+			UpdatedOn:    &updated,
+			RRSets: []models.RRSet{
+				{Name: "@", Type: models.RRSetTypeA, Records: []models.RecordData{{RData: "192.0.2.1"}, {RData: "192.0.2.2"}}},
+				{Name: "www", Type: models.RRSetTypeA, Records: []models.RecordData{{RData: "192.0.2.1"}}},
+				{Name: "@", Type: models.RRSetTypeMX, Records: []models.RecordData{{RData: "10 mail.example.com."}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	stats, err := client.DNS.GetZoneStats(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", stats.Zone)
+	assert.Equal(t, models.DNSSECStatusEnabled, stats.DNSSECStatus)
+	assert.Equal(t, 3, stats.RRSets)
+	assert.Equal(t, 4, stats.Records)
+	assert.Equal(t, map[models.RRSetType]int{models.RRSetTypeA: 3, models.RRSetTypeMX: 1}, stats.RecordsByType)
+	require.NotNil(t, stats.UpdatedOn)
+	assert.True(t, updated.Equal(*stats.UpdatedOn))
+}
+
+func TestDNSService_GetQueryStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "7d", r.URL.Query().Get("time_range"))
+
+		switch r.URL.Path {
+		case "/v1/dns/example.com/metrics":
+			_, _ = w.Write([]byte(`{"total_queries":1200,"queries_per_second":0.002}`))
+		case "/v1/dns/example.com/metrics/time-series":
+			_, _ = w.Write([]byte(`{"results":[{"timestamp":"2026-10-01T00:00:00Z","total":700},{"timestamp":"2026-10-02T00:00:00Z","total":500}]}`))
+		case "/v1/dns/example.com/metrics/names":
+			assert.Equal(t, "5", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"results":[{"key":"www","total":900},{"key":"@","total":300}]}`))
+		case "/v1/dns/example.com/metrics/response-codes":
+			_, _ = w.Write([]byte(`{"results":[{"key":"NOERROR","total":1150},{"key":"NXDOMAIN","total":50}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	stats, err := client.DNS.GetQueryStats(context.Background(), "example.com.", &models.DNSQueryStatsOptions{
+		TimeRange: models.TimeRange7D,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", stats.Zone)
+	assert.Equal(t, 1200, stats.Metrics.TotalQueries)
+	require.Len(t, stats.TimeSeries, 2)
+	assert.Equal(t, 700, stats.TimeSeries[0].Total)
+	require.Len(t, stats.TopNames, 2)
+	assert.Equal(t, "www", stats.TopNames[0].Key)
+	require.Len(t, stats.ResponseCodes, 2)
+	assert.Equal(t, "NXDOMAIN", stats.ResponseCodes[1].Key)
+}
+
 func TestDNSService_GetSummary(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -476,6 +797,188 @@ func TestDNSService_PatchRecords(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDNSService_PatchRecordsWithRequest_Atomic(t *testing.T) {
+	honored := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		assert.Equal(t, true, raw["atomic"])
+		assert.Len(t, raw["ops"], 3)
+
+		if !honored {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"atomic":true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	req := &models.RecordPatchRequest{
+		Atomic: true,
+		Ops: []models.RecordOperation{
+			{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+			{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeAAAA, TTL: 300, RData: "2001:db8::1"}},
+			{Op: models.RecordOpRemove, Record: models.Record{Name: "www", Type: models.RRSetTypeCNAME, TTL: 300, RData: "old.example.com."}},
+		},
+	}
+
+	result, err := client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", req)
+	require.NoError(t, err)
+	assert.True(t, result.Atomic)
+
+	honored = false
+	result, err = client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", req)
+	require.NoError(t, err)
+	assert.False(t, result.Atomic)
+
+	_, err = client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", nil)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestDNSService_PatchRecordsWithRequest_ExpectedSerial(t *testing.T) {
+	const serial = 2024061502
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		assert.NotContains(t, raw, "expected_serial")
+
+		switch r.Header.Get("If-Match") {
+		case "":
+			_, _ = w.Write([]byte(`{"atomic":false}`))
+		case `"2024061502"`:
+			_, _ = w.Write([]byte(`{"atomic":true}`))
+		default:
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"message":"zone serial mismatch"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ops := []models.RecordOperation{
+		{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+	}
+
+	result, err := client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", &models.RecordPatchRequest{Ops: ops, ExpectedSerial: serial})
+	require.NoError(t, err)
+	assert.True(t, result.Atomic)
+
+	_, err = client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", &models.RecordPatchRequest{Ops: ops})
+	require.NoError(t, err)
+
+	_, err = client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", &models.RecordPatchRequest{Ops: ops, ExpectedSerial: serial - 1})
+	assert.True(t, IsConflictVersionError(err))
+	assert.True(t, IsConflictError(err))
+	assert.ErrorIs(t, err, ErrConflictVersion)
+}
+
+func TestDNSService_PatchRecordsWithRequest_Protected(t *testing.T) {
+	partial := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+
+		if raw["allow_protected"] == true {
+			_, _ = w.Write([]byte(`{"atomic":false}`))
+			return
+		}
+		if partial {
+			_, _ = w.Write([]byte(`{"atomic":false,"rejected":[{"index":1,"error_code":"record_protected","message":"record is protected"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error_code":"record_protected","message":"batch touches protected records","details":{"rejected":[{"index":0,"error_code":"record_protected"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ops := []models.RecordOperation{
+		{Op: models.RecordOpUpsert, Record: models.Record{Name: "www", Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1"}},
+		{Op: models.RecordOpRemove, Record: models.Record{Name: "@", Type: models.RRSetTypeMX, TTL: 300, RData: "10 mail.example.com."}},
+	}
+
+	// The API applied the first operation and rejected the second.
+	result, err := client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", &models.RecordPatchRequest{Ops: ops})
+	require.Error(t, err)
+	assert.True(t, IsRecordProtectedError(err))
+	require.NotNil(t, result)
+	require.Len(t, result.Rejected, 1)
+	assert.Equal(t, ops[1], result.Rejected[0].Operation)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Nil(t, batchErr.APIError)
+	assert.True(t, batchErr.Applied)
+	assert.Contains(t, err.Error(), "remove @ MX")
+
+	// The whole batch was rejected.
+	partial = false
+	err = client.DNS.PatchRecords(context.Background(), "example.com", ops)
+	assert.True(t, IsRecordProtectedError(err))
+	assert.True(t, IsConflictError(err))
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Failed, 1)
+	assert.Equal(t, ops[0], batchErr.Failed[0].Operation)
+
+	result, err = client.DNS.PatchRecordsWithRequest(context.Background(), "example.com", &models.RecordPatchRequest{Ops: ops, AllowProtected: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Rejected)
+}
+
+func TestDNSService_PatchRecords_BatchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.RecordPatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if len(req.Ops) < 50 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"detail":[
+			{"loc":["body","ops",41,"record","rdata"],"msg":"value is not a valid IPv4 address","type":"value_error"},
+			{"loc":["body","ops",7,"record","ttl"],"msg":"ensure this value is greater than 0","type":"value_error"},
+			{"loc":["body","atomic"],"msg":"not a boolean","type":"type_error"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ops := make([]models.RecordOperation, 50)
+	for i := range ops {
+		ops[i] = models.RecordOperation{Op: models.RecordOpUpsert, Record: models.Record{
+			Name: fmt.Sprintf("host%d", i), Type: models.RRSetTypeA, TTL: 300, RData: "192.0.2.1",
+		}}
+	}
+
+	err = client.DNS.PatchRecords(context.Background(), "example.com", ops)
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.False(t, batchErr.Applied)
+	assert.False(t, IsRecordProtectedError(err))
+
+	require.Len(t, batchErr.Failed, 2)
+	assert.Equal(t, 7, batchErr.Failed[0].Index)
+	assert.Equal(t, ops[7], batchErr.Failed[0].Operation)
+	assert.Equal(t, "record.ttl: ensure this value is greater than 0", batchErr.Failed[0].Message)
+	assert.Equal(t, 41, batchErr.Failed[1].Index)
+	assert.Contains(t, err.Error(), "2 of 50 record operation(s) failed: ops[7] upsert host7 A")
+
+	assert.Equal(t, []models.RecordOperation{ops[7], ops[41]}, batchErr.FailedOps())
+	assert.Len(t, batchErr.PassedOps(), 48)
+
+	// Apply the valid part of the batch on its own.
+	require.NoError(t, client.DNS.PatchRecords(context.Background(), "example.com", batchErr.PassedOps()))
+}
+
 func TestDNSService_ListZonesPage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -504,3 +1007,29 @@ func TestDNSService_ListZonesPage(t *testing.T) {
 	assert.True(t, resp.Pagination.HasNextPage)
 	assert.Equal(t, 2, resp.Pagination.CurrentPage)
 }
+
+func TestDNSService_UpsertRecord_TXTChunking(t *testing.T) {
+	value := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjAN", 40)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.RecordPatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(t, req.Ops, 1)
+
+		rdata := req.Ops[0].Record.RData
+		assert.Equal(t, `"`+value[:255]+`" "`+value[255:]+`"`, rdata)
+		assert.Equal(t, models.TXTData(value), req.Ops[0].Record.TXT())
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	for _, rdata := range []string{value, `"` + value + `"`} {
+		record := models.Record{Name: "s1._domainkey", Type: models.RRSetTypeTXT, TTL: 3600, RData: rdata}
+		require.NoError(t, client.DNS.UpsertRecord(context.Background(), "example.com", record))
+		assert.Equal(t, rdata, record.RData)
+	}
+}
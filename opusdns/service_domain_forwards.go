@@ -226,6 +226,29 @@ func (s *DomainForwardsService) PatchRedirects(ctx context.Context, req *models.
 	return s.client.http.DecodeResponse(resp, nil)
 }
 
+// CreateWildcardRedirect creates or updates a wildcard redirect for a hostname
+// and protocol. Requests matching RequestSubdomain (e.g. "*") below the hostname
+// are redirected to the target.
+func (s *DomainForwardsService) CreateWildcardRedirect(ctx context.Context, hostname string, protocol models.HttpProtocol, req *models.WildcardHttpRedirectRequest) error {
+	subdomain := req.RequestSubdomain
+
+	return s.PatchRedirects(ctx, &models.DomainForwardPatchOps{
+		Ops: []models.DomainForwardPatchOp{{
+			Op: models.PatchOpUpsert,
+			Redirect: models.HttpRedirectUpsert{
+				RequestProtocol:  protocol,
+				RequestHostname:  hostname,
+				RequestPath:      req.RequestPath,
+				RequestSubdomain: &subdomain,
+				TargetProtocol:   req.TargetProtocol,
+				TargetHostname:   req.TargetHostname,
+				TargetPath:       req.TargetPath,
+				RedirectCode:     req.RedirectCode,
+			},
+		}},
+	})
+}
+
 // ListDomainForwardsByZone retrieves domain forwards for a specific DNS zone.
 func (s *DomainForwardsService) ListDomainForwardsByZone(ctx context.Context, zoneName string) ([]models.DomainForward, error) {
 	path := s.client.http.BuildPath("dns", url.PathEscape(zoneName), "domain-forwards")
@@ -251,7 +274,83 @@ func (s *DomainForwardsService) ListDomainForwardsByZone(ctx context.Context, zo
 
 // GetMetrics retrieves aggregate domain-forward metrics.
 func (s *DomainForwardsService) GetMetrics(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardMetrics, error) {
-	path := s.client.http.BuildPath("domain-forwards", "metrics")
+	var result models.DomainForwardMetrics
+	if err := s.getMetrics(ctx, "", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTimeSeries retrieves domain-forward visits over time.
+func (s *DomainForwardsService) GetTimeSeries(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardTimeSeriesResponse, error) {
+	var result models.DomainForwardTimeSeriesResponse
+	if err := s.getMetrics(ctx, "time-series", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGeoStats retrieves domain-forward visits by country.
+func (s *DomainForwardsService) GetGeoStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardGeoStatsResponse, error) {
+	var result models.DomainForwardGeoStatsResponse
+	if err := s.getMetrics(ctx, "geo", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBrowserStats retrieves domain-forward visits by browser.
+func (s *DomainForwardsService) GetBrowserStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardBrowserStatsResponse, error) {
+	var result models.DomainForwardBrowserStatsResponse
+	if err := s.getMetrics(ctx, "browser", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPlatformStats retrieves domain-forward visits by platform.
+func (s *DomainForwardsService) GetPlatformStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardPlatformStatsResponse, error) {
+	var result models.DomainForwardPlatformStatsResponse
+	if err := s.getMetrics(ctx, "platform", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetReferrerStats retrieves domain-forward visits by referrer.
+func (s *DomainForwardsService) GetReferrerStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardReferrerStatsResponse, error) {
+	var result models.DomainForwardReferrerStatsResponse
+	if err := s.getMetrics(ctx, "referrer", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetStatusCodeStats retrieves domain-forward responses by HTTP status code.
+func (s *DomainForwardsService) GetStatusCodeStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardStatusCodeStatsResponse, error) {
+	var result models.DomainForwardStatusCodeStatsResponse
+	if err := s.getMetrics(ctx, "status-code", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetUserAgentStats retrieves domain-forward visits by user agent.
+func (s *DomainForwardsService) GetUserAgentStats(ctx context.Context, opts *models.DomainForwardMetricsOptions) (*models.DomainForwardUserAgentStatsResponse, error) {
+	var result models.DomainForwardUserAgentStatsResponse
+	if err := s.getMetrics(ctx, "user-agent", opts, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// getMetrics fetches a domain-forward metrics endpoint ("" for the aggregate) into target.
+func (s *DomainForwardsService) getMetrics(ctx context.Context, endpoint string, opts *models.DomainForwardMetricsOptions, target interface{}) error {
+	parts := []string{"domain-forwards", "metrics"}
+	if endpoint != "" {
+		parts = append(parts, endpoint)
+	}
+	path := s.client.http.BuildPath(parts...)
 
 	query := url.Values{}
 	if opts != nil {
@@ -274,13 +373,8 @@ func (s *DomainForwardsService) GetMetrics(ctx context.Context, opts *models.Dom
 
 	resp, err := s.client.http.Get(ctx, path, query)
 	if err != nil {
-		return nil, err
-	}
-
-	var result models.DomainForwardMetrics
-	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
-		return nil, err
+		return err
 	}
 
-	return &result, nil
+	return s.client.http.DecodeResponse(resp, target)
 }
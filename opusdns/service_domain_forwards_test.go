@@ -202,6 +202,46 @@ func TestDomainForwardsService_PatchRedirects(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDomainForwardsService_CreateWildcardRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/v1/domain-forwards", r.URL.Path)
+
+		var req struct {
+			Ops []struct {
+				Op       models.PatchOp            `json:"op"`
+				Redirect models.HttpRedirectUpsert `json:"redirect"`
+			} `json:"ops"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		require.Len(t, req.Ops, 1)
+		assert.Equal(t, models.PatchOpUpsert, req.Ops[0].Op)
+
+		redirect := req.Ops[0].Redirect
+		assert.Equal(t, models.HttpProtocolHTTPS, redirect.RequestProtocol)
+		assert.Equal(t, "example.com", redirect.RequestHostname)
+		require.NotNil(t, redirect.RequestSubdomain)
+		assert.Equal(t, "*", *redirect.RequestSubdomain)
+		assert.Equal(t, "dest.com", redirect.TargetHostname)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	err = client.DomainForwards.CreateWildcardRedirect(context.Background(), "example.com", models.HttpProtocolHTTPS, &models.WildcardHttpRedirectRequest{
+		RequestPath:      "/",
+		RequestSubdomain: "*",
+		TargetProtocol:   models.HttpProtocolHTTPS,
+		TargetHostname:   "dest.com",
+		TargetPath:       "/",
+		RedirectCode:     models.RedirectCodePermanent,
+	})
+	require.NoError(t, err)
+}
+
 func TestDomainForwardsService_ListDomainForwards(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -401,3 +441,66 @@ func TestDomainForwardsService_GetMetrics(t *testing.T) {
 	assert.Equal(t, 100, metrics.TotalVisits)
 	assert.Equal(t, 5, metrics.ConfiguredForwards)
 }
+
+func TestDomainForwardsService_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "example.com", r.URL.Query().Get("hostname"))
+		assert.Equal(t, "30d", r.URL.Query().Get("time_range"))
+
+		switch r.URL.Path {
+		case "/v1/domain-forwards/metrics/time-series":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardTimeSeriesResponse{Results: []models.TimeSeriesBucket{{Total: 7}}})
+		case "/v1/domain-forwards/metrics/geo":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardGeoStatsResponse{Results: []models.GeoStatsBucket{{Key: "DE", Total: 4}}})
+		case "/v1/domain-forwards/metrics/browser":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardBrowserStatsResponse{Results: []models.BrowserStatsBucket{{Key: "Firefox", Total: 3, Unique: 2}}})
+		case "/v1/domain-forwards/metrics/platform":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardPlatformStatsResponse{Results: []models.PlatformStatsBucket{{Key: "Linux", Total: 2}}})
+		case "/v1/domain-forwards/metrics/referrer":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardReferrerStatsResponse{Results: []models.ReferrerStatsBucket{{Key: "google.com", Total: 5}}})
+		case "/v1/domain-forwards/metrics/status-code":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardStatusCodeStatsResponse{Results: []models.StatusCodeStatsBucket{{Key: "301", Total: 9}}})
+		case "/v1/domain-forwards/metrics/user-agent":
+			_ = json.NewEncoder(w).Encode(models.DomainForwardUserAgentStatsResponse{Results: []models.UserAgentStatsBucket{{Key: "curl/8.0", Total: 1}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	opts := &models.DomainForwardMetricsOptions{Hostname: "example.com", TimeRange: models.TimeRange30D}
+	forwards := client.DomainForwards
+
+	series, err := forwards.GetTimeSeries(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 7, series.Results[0].Total)
+
+	geo, err := forwards.GetGeoStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "DE", geo.Results[0].Key)
+
+	browsers, err := forwards.GetBrowserStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, browsers.Results[0].Unique)
+
+	platforms, err := forwards.GetPlatformStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "Linux", platforms.Results[0].Key)
+
+	referrers, err := forwards.GetReferrerStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 5, referrers.Results[0].Total)
+
+	codes, err := forwards.GetStatusCodeStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "301", codes.Results[0].Key)
+
+	agents, err := forwards.GetUserAgentStats(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "curl/8.0", agents.Results[0].Key)
+}
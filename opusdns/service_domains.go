@@ -15,11 +15,18 @@ type DomainsService struct {
 }
 
 // ListDomains retrieves all domains with automatic pagination.
+// Pages are fetched in parallel when Config.ListConcurrency is above 1.
+// It fails with ErrTooManyResults beyond Config.MaxPages or Config.MaxItems.
 func (s *DomainsService) ListDomains(ctx context.Context, opts *models.ListDomainsOptions) ([]models.Domain, error) {
-	var allDomains []models.Domain
-	page := 1
-
-	for {
+	opts, err := s.resolveTagLabels(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	limits := s.client.Config.pageLimits(0, 0)
+	if opts != nil {
+		limits = s.client.Config.pageLimits(opts.MaxPages, opts.MaxItems)
+	}
+	return fetchAllPages(ctx, s.client.Config.ListConcurrency, limits, func(ctx context.Context, page int) ([]models.Domain, models.Pagination, error) {
 		pageOpts := cloneOptions(opts)
 		pageOpts.Page = page
 		if pageOpts.PageSize == 0 {
@@ -28,22 +35,19 @@ func (s *DomainsService) ListDomains(ctx context.Context, opts *models.ListDomai
 
 		resp, err := s.ListDomainsPage(ctx, pageOpts)
 		if err != nil {
-			return nil, err
+			return nil, models.Pagination{}, err
 		}
-
-		allDomains = append(allDomains, resp.Results...)
-
-		if !resp.Pagination.HasNextPage {
-			break
-		}
-		page++
-	}
-
-	return allDomains, nil
+		return resp.Results, resp.Pagination, nil
+	})
 }
 
 // ListDomainsPage retrieves a single page of domains.
 func (s *DomainsService) ListDomainsPage(ctx context.Context, opts *models.ListDomainsOptions) (*models.DomainListResponse, error) {
+	opts, err := s.resolveTagLabels(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("domains")
 
 	query := url.Values{}
@@ -396,3 +400,19 @@ func (s *DomainsService) CheckDomains(ctx context.Context, domains []string) (*m
 
 	return &result, nil
 }
+
+// resolveTagLabels returns opts with its Tags labels resolved into TagIDs,
+// so pages of one listing share a single tag lookup.
+func (s *DomainsService) resolveTagLabels(ctx context.Context, opts *models.ListDomainsOptions) (*models.ListDomainsOptions, error) {
+	if opts == nil || len(opts.Tags) == 0 {
+		return opts, nil
+	}
+	ids, err := tagFilterIDs(ctx, s.client.Tags, models.TagTypeDomain, opts.TagIDs, opts.Tags)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *opts
+	resolved.TagIDs = ids
+	resolved.Tags = nil
+	return &resolved, nil
+}
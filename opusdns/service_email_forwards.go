@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
@@ -98,6 +99,25 @@ func (s *EmailForwardsService) GetEmailForward(ctx context.Context, emailForward
 	return &emailForward, nil
 }
 
+// GetEmailForwardByHostname retrieves the email forward of a hostname. It
+// returns ErrNotFound if the hostname has no email forwarding.
+func (s *EmailForwardsService) GetEmailForwardByHostname(ctx context.Context, hostname string) (*models.EmailForward, error) {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+
+	forwards, err := s.ListEmailForwards(ctx, &models.ListEmailForwardsOptions{Search: hostname})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range forwards {
+		if strings.EqualFold(forwards[i].Hostname, hostname) {
+			return &forwards[i], nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
 // CreateEmailForward creates email forwarding for a hostname.
 func (s *EmailForwardsService) CreateEmailForward(ctx context.Context, req *models.EmailForwardCreateRequest) (*models.EmailForward, error) {
 	path := s.client.http.BuildPath("email-forwards")
@@ -152,7 +172,24 @@ func (s *EmailForwardsService) DisableEmailForward(ctx context.Context, emailFor
 }
 
 // CreateAlias creates a new email alias.
+//
+// Malformed aliases or destination addresses fail with a *ValidationError
+// before any request is made. The alias is then validated against the email
+// forward: a *ValidationError is returned for an alias that already exists
+// (including a second catch-all), a catch-all forwarding to its own hostname,
+// or when the hostname already has Config.MaxEmailForwardAliases aliases.
 func (s *EmailForwardsService) CreateAlias(ctx context.Context, emailForwardID models.EmailForwardID, req *models.EmailForwardAliasCreate) (*models.EmailForwardAlias, error) {
+	if err := checkAliasCreate(req); err != nil {
+		return nil, err
+	}
+	forward, err := s.GetEmailForward(ctx, emailForwardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAliasCreate(forward, req, s.client.Config.MaxEmailForwardAliases); err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("email-forwards", string(emailForwardID), "aliases")
 
 	resp, err := s.client.http.Post(ctx, path, req)
@@ -169,7 +206,21 @@ func (s *EmailForwardsService) CreateAlias(ctx context.Context, emailForwardID m
 }
 
 // UpdateAlias updates an email alias.
+//
+// The new destinations are validated like those of CreateAlias before the
+// request is sent.
 func (s *EmailForwardsService) UpdateAlias(ctx context.Context, emailForwardID models.EmailForwardID, aliasID models.EmailForwardAliasID, req *models.EmailForwardAliasUpdate) (*models.EmailForwardAlias, error) {
+	if err := checkAliasUpdate(req); err != nil {
+		return nil, err
+	}
+	forward, err := s.GetEmailForward(ctx, emailForwardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAliasUpdate(forward, aliasID, req); err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("email-forwards", string(emailForwardID), "aliases", string(aliasID))
 
 	resp, err := s.client.http.Put(ctx, path, req)
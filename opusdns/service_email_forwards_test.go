@@ -182,6 +182,11 @@ func TestEmailForwardsService_DisableEmailForward(t *testing.T) {
 
 func TestEmailForwardsService_CreateAlias(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/v1/email-forwards/email_forward_123", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(models.EmailForward{EmailForwardID: "email_forward_123", Hostname: "example.org"})
+			return
+		}
 		assert.Equal(t, "POST", r.Method)
 		assert.Equal(t, "/v1/email-forwards/email_forward_123/aliases", r.URL.Path)
 
@@ -219,6 +224,15 @@ func TestEmailForwardsService_CreateAlias(t *testing.T) {
 
 func TestEmailForwardsService_UpdateAlias(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/v1/email-forwards/email_forward_123", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(models.EmailForward{
+				EmailForwardID: "email_forward_123",
+				Hostname:       "example.org",
+				Aliases:        []models.EmailForwardAlias{{EmailForwardAliasID: "email_forward_alias_456", Alias: "info"}},
+			})
+			return
+		}
 		assert.Equal(t, "PUT", r.Method)
 		assert.Equal(t, "/v1/email-forwards/email_forward_123/aliases/email_forward_alias_456", r.URL.Path)
 
@@ -349,3 +363,28 @@ func TestEmailForwardsService_GetMetrics(t *testing.T) {
 	assert.Equal(t, 42, metrics.TotalLogs)
 	assert.Equal(t, 40, metrics.ByStatus[models.EmailForwardLogStatusDelivered])
 }
+
+func TestEmailForwardsService_GetEmailForwardByHostname(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/email-forwards", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("search"), "example.com")
+
+		_ = json.NewEncoder(w).Encode(models.EmailForwardListResponse{
+			Results: []models.EmailForward{
+				{EmailForwardID: "email_forward_sub", Hostname: "mail.example.com"},
+				{EmailForwardID: "email_forward_123", Hostname: "example.com"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	forward, err := client.EmailForwards.GetEmailForwardByHostname(context.Background(), "Example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, models.EmailForwardID("email_forward_123"), forward.EmailForwardID)
+
+	_, err = client.EmailForwards.GetEmailForwardByHostname(context.Background(), "example.com.evil")
+	assert.True(t, IsNotFoundError(err))
+}
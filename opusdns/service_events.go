@@ -75,6 +75,12 @@ func (s *EventsService) ListEventsPage(ctx context.Context, opts *models.ListEve
 		if opts.ObjectID != "" {
 			query.Set("object_id", opts.ObjectID)
 		}
+		if opts.CreatedAfter != nil {
+			query.Set("created_after", opts.CreatedAfter.Format(time.RFC3339))
+		}
+		if opts.CreatedBefore != nil {
+			query.Set("created_before", opts.CreatedBefore.Format(time.RFC3339))
+		}
 	}
 
 	resp, err := s.client.http.Get(ctx, path, query)
@@ -259,28 +265,93 @@ func (s *EventsService) ListRequestHistory(ctx context.Context, opts *models.Lis
 	return &result, nil
 }
 
-// ListEmailForwardLogs retrieves email forward logs for a specific email forward.
+// ListEmailForwardLogs retrieves the first page of email forward logs for a specific email forward.
+// Use ListEmailForwardLogsPage to filter, sort or page through the logs.
 func (s *EventsService) ListEmailForwardLogs(ctx context.Context, emailForwardID models.EmailForwardID) (*models.EmailForwardLogListResponse, error) {
-	path := s.client.http.BuildPath("archive", "email-forward-logs", string(emailForwardID))
+	return s.ListEmailForwardLogsPage(ctx, emailForwardID, nil)
+}
 
-	resp, err := s.client.http.Get(ctx, path, nil)
-	if err != nil {
-		return nil, err
-	}
+// ListAllEmailForwardLogs retrieves all email forward logs for a specific email forward
+// matching opts, with automatic pagination.
+func (s *EventsService) ListAllEmailForwardLogs(ctx context.Context, emailForwardID models.EmailForwardID, opts *models.ListEmailForwardLogsOptions) ([]models.EmailForwardLog, error) {
+	var all []models.EmailForwardLog
+	page := 1
 
-	var result models.EmailForwardLogListResponse
-	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
-		return nil, err
+	for {
+		pageOpts := cloneOptions(opts)
+		pageOpts.Page = page
+		if pageOpts.PageSize == 0 {
+			pageOpts.PageSize = DefaultPageSize
+		}
+
+		resp, err := s.ListEmailForwardLogsPage(ctx, emailForwardID, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Results...)
+
+		if !resp.Pagination.HasNextPage {
+			break
+		}
+		page++
 	}
 
-	return &result, nil
+	return all, nil
+}
+
+// ListEmailForwardLogsPage retrieves a single page of email forward logs for a specific email forward.
+func (s *EventsService) ListEmailForwardLogsPage(ctx context.Context, emailForwardID models.EmailForwardID, opts *models.ListEmailForwardLogsOptions) (*models.EmailForwardLogListResponse, error) {
+	path := s.client.http.BuildPath("archive", "email-forward-logs", string(emailForwardID))
+	return s.listEmailForwardLogs(ctx, path, opts)
 }
 
-// ListEmailForwardLogsByAlias retrieves email forward logs for a specific alias.
+// ListEmailForwardLogsByAlias retrieves the first page of email forward logs for a specific alias.
+// Use ListEmailForwardLogsByAliasPage to filter, sort or page through the logs.
 func (s *EventsService) ListEmailForwardLogsByAlias(ctx context.Context, aliasID models.EmailForwardAliasID) (*models.EmailForwardLogListResponse, error) {
+	return s.ListEmailForwardLogsByAliasPage(ctx, aliasID, nil)
+}
+
+// ListEmailForwardLogsByAliasPage retrieves a single page of email forward logs for a specific alias.
+func (s *EventsService) ListEmailForwardLogsByAliasPage(ctx context.Context, aliasID models.EmailForwardAliasID, opts *models.ListEmailForwardLogsOptions) (*models.EmailForwardLogListResponse, error) {
 	path := s.client.http.BuildPath("archive", "email-forward-logs", "aliases", string(aliasID))
+	return s.listEmailForwardLogs(ctx, path, opts)
+}
 
-	resp, err := s.client.http.Get(ctx, path, nil)
+// listEmailForwardLogs fetches one page of email forward logs from path.
+func (s *EventsService) listEmailForwardLogs(ctx context.Context, path string, opts *models.ListEmailForwardLogsOptions) (*models.EmailForwardLogListResponse, error) {
+	query := url.Values{}
+	if opts != nil {
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.PageSize > 0 {
+			query.Set("page_size", strconv.Itoa(opts.PageSize))
+		}
+		if opts.SortBy != "" {
+			query.Set("sort_by", string(opts.SortBy))
+		}
+		if opts.SortOrder != "" {
+			query.Set("sort_order", string(opts.SortOrder))
+		}
+		if opts.Status != "" {
+			query.Set("final_status", string(opts.Status))
+		}
+		if opts.SenderEmail != "" {
+			query.Set("sender_email", opts.SenderEmail)
+		}
+		if opts.RecipientEmail != "" {
+			query.Set("recipient_email", opts.RecipientEmail)
+		}
+		if opts.StartTime != nil {
+			query.Set("start_time", opts.StartTime.Format(time.RFC3339))
+		}
+		if opts.EndTime != nil {
+			query.Set("end_time", opts.EndTime.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.client.http.Get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
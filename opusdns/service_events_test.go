@@ -248,6 +248,47 @@ func TestEventsService_ListEmailForwardLogs(t *testing.T) {
 	assert.Equal(t, models.EmailForwardLogStatusDelivered, resp.Results[0].FinalStatus)
 }
 
+func TestEventsService_ListAllEmailForwardLogs(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v1/archive/email-forward-logs/ef_1", r.URL.Path)
+
+		q := r.URL.Query()
+		assert.Equal(t, "HARD-BOUNCE", q.Get("final_status"))
+		assert.Equal(t, "sender@example.com", q.Get("sender_email"))
+		assert.Equal(t, "created_on", q.Get("sort_by"))
+		assert.Equal(t, "desc", q.Get("sort_order"))
+		assert.Equal(t, "2024-05-01T00:00:00Z", q.Get("start_time"))
+		assert.Equal(t, "50", q.Get("page_size"))
+
+		page := q.Get("page")
+		_ = json.NewEncoder(w).Encode(models.EmailForwardLogListResponse{
+			Results:    []models.EmailForwardLog{{LogID: "log_" + page, FinalStatus: models.EmailForwardLogStatusHardBounce}},
+			Pagination: models.Pagination{HasNextPage: page == "1"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	logs, err := client.Events.ListAllEmailForwardLogs(context.Background(), "ef_1", &models.ListEmailForwardLogsOptions{
+		PageSize:    50,
+		SortBy:      models.EmailForwardLogSortByCreatedOn,
+		SortOrder:   models.SortDesc,
+		Status:      models.EmailForwardLogStatusHardBounce,
+		SenderEmail: "sender@example.com",
+		StartTime:   &start,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "log_2", logs[1].LogID)
+	assert.Equal(t, 2, requests)
+}
+
 func TestEventsService_ListEmailForwardLogsByAlias(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -360,7 +360,13 @@ func (s *OrganizationsService) UpdateCurrentAttributes(ctx context.Context, req
 }
 
 // GetAttributes retrieves organization attributes.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) GetAttributes(ctx context.Context, orgID models.OrganizationID) (*models.OrganizationAttributesResponse, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", "attributes", string(orgID))
 
 	resp, err := s.client.http.Get(ctx, path, nil)
@@ -377,7 +383,13 @@ func (s *OrganizationsService) GetAttributes(ctx context.Context, orgID models.O
 }
 
 // UpdateAttributes updates organization attributes.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) UpdateAttributes(ctx context.Context, orgID models.OrganizationID, req *models.OrganizationAttributeUpdateRequest) (*models.OrganizationAttributesResponse, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", "attributes", string(orgID))
 
 	resp, err := s.client.http.Patch(ctx, path, req)
@@ -394,7 +406,13 @@ func (s *OrganizationsService) UpdateAttributes(ctx context.Context, orgID model
 }
 
 // ListTransactions retrieves billing transactions for an organization.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) ListTransactions(ctx context.Context, orgID models.OrganizationID, opts *models.ListTransactionsOptions) (*models.BillingTransactionListResponse, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", string(orgID), "transactions")
 
 	query := url.Values{}
@@ -442,7 +460,13 @@ func (s *OrganizationsService) ListTransactions(ctx context.Context, orgID model
 }
 
 // GetTransaction retrieves a specific transaction by ID.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) GetTransaction(ctx context.Context, orgID models.OrganizationID, transactionID models.BillingTransactionID) (*models.BillingTransaction, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", string(orgID), "transactions", string(transactionID))
 
 	resp, err := s.client.http.Get(ctx, path, nil)
@@ -459,7 +483,13 @@ func (s *OrganizationsService) GetTransaction(ctx context.Context, orgID models.
 }
 
 // ListInvoices retrieves invoices for an organization.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) ListInvoices(ctx context.Context, orgID models.OrganizationID) (*models.InvoiceListResponse, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", string(orgID), "billing", "invoices")
 
 	resp, err := s.client.http.Get(ctx, path, nil)
@@ -476,7 +506,13 @@ func (s *OrganizationsService) ListInvoices(ctx context.Context, orgID models.Or
 }
 
 // GetPricing retrieves pricing for a specific product type.
+// If orgID is empty, the organization of the authenticated identity is used.
 func (s *OrganizationsService) GetPricing(ctx context.Context, orgID models.OrganizationID, productType string) (*models.ProductPricing, error) {
+	orgID, err := s.client.resolveOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.client.http.BuildPath("organizations", string(orgID), "pricing", "product-type", url.PathEscape(productType))
 
 	resp, err := s.client.http.Get(ctx, path, nil)
@@ -491,3 +527,25 @@ func (s *OrganizationsService) GetPricing(ctx context.Context, orgID models.Orga
 
 	return &pricing, nil
 }
+
+// ConfirmPayment confirms payment for an operation that failed with a
+// PaymentRequiredError and completes it. token is the error's ContinuationToken.
+func (s *OrganizationsService) ConfirmPayment(ctx context.Context, token string) (*models.PaymentConfirmation, error) {
+	if token == "" {
+		return nil, &ValidationError{Field: "token", Message: "continuation token is required"}
+	}
+
+	path := s.client.http.BuildPath("organizations", "payments", "confirm")
+
+	resp, err := s.client.http.Post(ctx, path, &models.PaymentConfirmRequest{ContinuationToken: token})
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PaymentConfirmation
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -622,3 +622,49 @@ func TestOrganizationsService_ListOrganizationsPage(t *testing.T) {
 	require.Len(t, resp.Results, 1)
 	assert.Equal(t, "Example", resp.Results[0].Name)
 }
+
+func TestOrganizationsService_ConfirmPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/domains":
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"error_code":"insufficient_funds","message":"wallet balance too low","details":{"amount":12.5,"currency":"EUR","continuation_token":"pay_123"}}`))
+		case "/v1/organizations/payments/confirm":
+			assert.Equal(t, "POST", r.Method)
+			var req models.PaymentConfirmRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "pay_123", req.ContinuationToken)
+
+			_, _ = w.Write([]byte(`{"status":"succeeded","amount":"12.50","currency":"EUR","result":{"name":"example.com"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Domains.CreateDomain(context.Background(), &models.DomainCreateRequest{Name: "example.com"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrPaymentRequired)
+
+	payErr, ok := IsPaymentRequiredError(err)
+	require.True(t, ok)
+	assert.Equal(t, "12.5", payErr.Amount)
+	assert.Equal(t, models.CurrencyEUR, payErr.Currency)
+	assert.Equal(t, "pay_123", payErr.ContinuationToken)
+	assert.Equal(t, "opusdns: payment required: 12.5 EUR (wallet balance too low)", payErr.Error())
+
+	apiErr, ok := IsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, "insufficient_funds", apiErr.ErrorCode)
+
+	confirmation, err := client.Organizations.ConfirmPayment(context.Background(), payErr.ContinuationToken)
+	require.NoError(t, err)
+	assert.Equal(t, models.BillingStatusSucceeded, confirmation.Status)
+	assert.JSONEq(t, `{"name":"example.com"}`, string(confirmation.Result))
+
+	_, err = client.Organizations.ConfirmPayment(context.Background(), "")
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,42 @@
+package opusdns
+
+import (
+	"context"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// StatusService provides methods for querying the platform status page.
+type StatusService struct {
+	client *Client
+}
+
+// GetStatus retrieves the overall platform status with the unresolved
+// incidents and the maintenance windows in progress or scheduled.
+func (s *StatusService) GetStatus(ctx context.Context) (*models.PlatformStatus, error) {
+	path := s.client.http.BuildPath("status")
+
+	resp, err := s.client.http.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PlatformStatus
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeferReason fetches the status and reports why bulk operations should be
+// postponed, or "" if it is a good time to proceed. See
+// models.PlatformStatus.DeferReason.
+func (s *StatusService) DeferReason(ctx context.Context, margin time.Duration) (string, error) {
+	status, err := s.GetStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+	return status.DeferReason(time.Now(), margin), nil
+}
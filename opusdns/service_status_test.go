@@ -0,0 +1,97 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusService_GetStatus(t *testing.T) {
+	starts := time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1/status", r.URL.Path)
+
+		_ = json.NewEncoder(w).Encode(models.PlatformStatus{
+			Status: models.PlatformStatusOperational,
+			Maintenances: []models.MaintenanceWindow{
+				{MaintenanceID: "mnt_1", Name: "Database upgrade", Status: models.MaintenanceStatusScheduled, StartsOn: starts, EndsOn: starts.Add(time.Hour)},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	status, err := client.Status.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.Status.IsOperational())
+	require.Len(t, status.Maintenances, 1)
+	assert.Equal(t, starts, status.Maintenances[0].StartsOn)
+
+	reason, err := client.Status.DeferReason(context.Background(), 15*time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	reason, err = client.Status.DeferReason(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, reason, `maintenance "Database upgrade" starts at`)
+}
+
+func TestPlatformStatus_DeferReason(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	window := func(status models.MaintenanceStatus, start, end time.Duration) models.MaintenanceWindow {
+		return models.MaintenanceWindow{Name: "upgrade", Status: status, StartsOn: now.Add(start), EndsOn: now.Add(end)}
+	}
+
+	tests := []struct {
+		name   string
+		status models.PlatformStatus
+		want   string
+	}{
+		{name: "operational", status: models.PlatformStatus{Status: models.PlatformStatusOperational}},
+		{name: "degraded", status: models.PlatformStatus{Status: models.PlatformStatusDegraded}, want: "platform status is degraded_performance"},
+		{
+			name:   "unresolved incident",
+			status: models.PlatformStatus{Status: models.PlatformStatusOperational, Incidents: []models.Incident{{Name: "Slow API", Status: models.IncidentStatusMonitoring, Impact: models.IncidentImpactMinor}}},
+			want:   `incident "Slow API" is monitoring`,
+		},
+		{
+			name:   "incident without impact",
+			status: models.PlatformStatus{Incidents: []models.Incident{{Name: "Notice", Status: models.IncidentStatusIdentified, Impact: models.IncidentImpactNone}}},
+		},
+		{
+			name:   "maintenance in progress",
+			status: models.PlatformStatus{Maintenances: []models.MaintenanceWindow{window(models.MaintenanceStatusInProgress, -time.Hour, time.Hour)}},
+			want:   `maintenance "upgrade" is in progress until 2024-03-01T13:00:00Z`,
+		},
+		{
+			name:   "maintenance within margin",
+			status: models.PlatformStatus{Maintenances: []models.MaintenanceWindow{window(models.MaintenanceStatusScheduled, 20*time.Minute, time.Hour)}},
+			want:   `maintenance "upgrade" starts at 2024-03-01T12:20:00Z`,
+		},
+		{
+			name:   "maintenance after margin",
+			status: models.PlatformStatus{Maintenances: []models.MaintenanceWindow{window(models.MaintenanceStatusScheduled, 2*time.Hour, 3*time.Hour)}},
+		},
+		{
+			name:   "completed maintenance",
+			status: models.PlatformStatus{Maintenances: []models.MaintenanceWindow{window(models.MaintenanceStatusCompleted, -time.Hour, time.Hour)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.DeferReason(now, 30*time.Minute))
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
 )
@@ -11,6 +14,9 @@ import (
 // TLDsService provides methods for accessing TLD information.
 type TLDsService struct {
 	client *Client
+
+	mu      sync.Mutex
+	pricing map[string]cachedTLD // see ComparePricing
 }
 
 // ListTLDs retrieves all available TLDs.
@@ -108,6 +114,78 @@ func (s *TLDsService) GetPortfolio(ctx context.Context) (*models.TLDPortfolio, e
 	return &portfolio, nil
 }
 
+// ListPriceChanges retrieves all upcoming TLD price changes with automatic pagination.
+func (s *TLDsService) ListPriceChanges(ctx context.Context, opts *models.ListTLDPriceChangesOptions) ([]models.TLDPriceChange, error) {
+	var all []models.TLDPriceChange
+	page := 1
+
+	for {
+		pageOpts := cloneOptions(opts)
+		pageOpts.Page = page
+		if pageOpts.PageSize == 0 {
+			pageOpts.PageSize = DefaultPageSize
+		}
+
+		resp, err := s.ListPriceChangesPage(ctx, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Results...)
+
+		if !resp.Pagination.HasNextPage {
+			break
+		}
+		page++
+	}
+
+	return all, nil
+}
+
+// ListPriceChangesPage retrieves a single page of upcoming TLD price changes.
+func (s *TLDsService) ListPriceChangesPage(ctx context.Context, opts *models.ListTLDPriceChangesOptions) (*models.TLDPriceChangeListResponse, error) {
+	path := s.client.http.BuildPath("tlds", "price-changes")
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.PageSize > 0 {
+			query.Set("page_size", strconv.Itoa(opts.PageSize))
+		}
+		for _, tld := range opts.TLDs {
+			query.Add("tld", normalizeTLD(tld))
+		}
+		for _, action := range opts.Actions {
+			query.Add("action", string(action))
+		}
+		if opts.EffectiveAfter != nil {
+			query.Set("effective_after", opts.EffectiveAfter.Format(time.RFC3339))
+		}
+		if opts.EffectiveBefore != nil {
+			query.Set("effective_before", opts.EffectiveBefore.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.client.http.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.TLDPriceChangeListResponse
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// normalizeTLD trims whitespace and a leading dot from a TLD and lower-cases it.
+func normalizeTLD(tld string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), "."))
+}
+
 // AvailabilityService provides methods for checking domain availability.
 type AvailabilityService struct {
 	client *Client
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/opusdns/opusdns-go-client/models"
 	"github.com/stretchr/testify/assert"
@@ -101,6 +102,50 @@ func TestTLDsService_GetPortfolio(t *testing.T) {
 	assert.Equal(t, "com", portfolio.TLDs[0].Name)
 }
 
+func TestTLDsService_ListPriceChanges(t *testing.T) {
+	effective := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	page := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1/tlds/price-changes", r.URL.Path)
+		assert.Equal(t, []string{"com", "io"}, r.URL.Query()["tld"])
+		assert.Equal(t, []string{"renew"}, r.URL.Query()["action"])
+
+		change := models.TLDPriceChange{
+			TLD:           "com",
+			Action:        models.BillingActionRenew,
+			OldPrice:      "10.00",
+			NewPrice:      "11.50",
+			Currency:      models.CurrencyUSD,
+			EffectiveDate: effective,
+		}
+		if page == 2 {
+			change.TLD = "io"
+		}
+		_ = json.NewEncoder(w).Encode(models.TLDPriceChangeListResponse{
+			Results:    []models.TLDPriceChange{change},
+			Pagination: models.Pagination{HasNextPage: page == 1, CurrentPage: page},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	changes, err := client.TLDs.ListPriceChanges(context.Background(), &models.ListTLDPriceChangesOptions{
+		TLDs:    []string{"com", ".io"},
+		Actions: []models.BillingTransactionAction{models.BillingActionRenew},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "com", changes[0].TLD)
+	assert.Equal(t, "11.50", changes[0].NewPrice)
+	assert.True(t, effective.Equal(changes[0].EffectiveDate))
+	assert.Equal(t, "io", changes[1].TLD)
+}
+
 func TestAvailabilityService_CheckAvailability(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/opusdns/opusdns-go-client/models"
 )
@@ -219,3 +220,82 @@ func (s *UsersService) DeleteUser(ctx context.Context, userID models.UserID) err
 
 	return s.client.http.DecodeResponse(resp, nil)
 }
+
+// ListSessions retrieves the active sign-in sessions of a user.
+func (s *UsersService) ListSessions(ctx context.Context, userID models.UserID) ([]models.UserSession, error) {
+	path := s.client.http.BuildPath("users", string(userID), "sessions")
+
+	resp, err := s.client.http.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.UserSessionListResponse
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
+
+// RevokeSession signs a user out of a session, invalidating its access tokens.
+func (s *UsersService) RevokeSession(ctx context.Context, userID models.UserID, sessionID models.UserSessionID) error {
+	path := s.client.http.BuildPath("users", string(userID), "sessions", string(sessionID))
+
+	resp, err := s.client.http.Delete(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, nil)
+}
+
+// EnableTwoFactor starts two-factor authentication enrollment for a user and
+// returns the TOTP secret and provisioning URI to load into an authenticator.
+// Two-factor authentication is enforced once ConfirmTwoFactor succeeds.
+func (s *UsersService) EnableTwoFactor(ctx context.Context, userID models.UserID) (*models.TwoFactorEnrollment, error) {
+	path := s.client.http.BuildPath("users", string(userID), "two-factor")
+
+	resp, err := s.client.http.Post(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.TwoFactorEnrollment
+	if err := s.client.http.DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ConfirmTwoFactor completes a pending enrollment with a current one-time
+// code from the authenticator.
+func (s *UsersService) ConfirmTwoFactor(ctx context.Context, userID models.UserID, code string) error {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) < 6 || len(code) > 8 || strings.Trim(code, "0123456789") != "" {
+		return &ValidationError{Field: "code", Message: "must be a 6 to 8 digit one-time code", Value: code}
+	}
+
+	path := s.client.http.BuildPath("users", string(userID), "two-factor", "confirm")
+
+	resp, err := s.client.http.Post(ctx, path, &models.TwoFactorConfirmRequest{Code: code})
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, nil)
+}
+
+// DisableTwoFactor turns off two-factor authentication for a user, or
+// cancels a pending enrollment.
+func (s *UsersService) DisableTwoFactor(ctx context.Context, userID models.UserID) error {
+	path := s.client.http.BuildPath("users", string(userID), "two-factor")
+
+	resp, err := s.client.http.Delete(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	return s.client.http.DecodeResponse(resp, nil)
+}
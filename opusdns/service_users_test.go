@@ -294,3 +294,77 @@ func TestUsersService_ListUsersPage(t *testing.T) {
 	require.Len(t, resp.Results, 1)
 	assert.Equal(t, "user@example.com", resp.Results[0].Email)
 }
+
+func TestUsersService_TwoFactor(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v1/users/usr_123/two-factor":
+			if r.Method == http.MethodPost {
+				_ = json.NewEncoder(w).Encode(models.TwoFactorEnrollment{
+					Secret:          "JBSWY3DPEHPK3PXP",
+					ProvisioningURI: "otpauth://totp/OpusDNS:alice?secret=JBSWY3DPEHPK3PXP&issuer=OpusDNS",
+					RecoveryCodes:   []string{"a1b2-c3d4"},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/users/usr_123/two-factor/confirm":
+			var req models.TwoFactorConfirmRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			assert.Equal(t, "123456", req.Code)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	enrollment, err := client.Users.EnableTwoFactor(ctx, "usr_123")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", enrollment.Secret)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/")
+
+	require.NoError(t, client.Users.ConfirmTwoFactor(ctx, "usr_123", "123 456"))
+	require.NoError(t, client.Users.DisableTwoFactor(ctx, "usr_123"))
+
+	err = client.Users.ConfirmTwoFactor(ctx, "usr_123", "12ab56")
+	assert.True(t, IsValidationError(err))
+
+	assert.Equal(t, []string{
+		"POST /v1/users/usr_123/two-factor",
+		"POST /v1/users/usr_123/two-factor/confirm",
+		"DELETE /v1/users/usr_123/two-factor",
+	}, requests)
+}
+
+func TestUsersService_Sessions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/users/usr_123/sessions":
+			_ = json.NewEncoder(w).Encode(models.UserSessionListResponse{Results: []models.UserSession{
+				{SessionID: "session_1", UserID: "usr_123", IPAddress: models.StringPtr("203.0.113.9")},
+			}})
+		case "DELETE /v1/users/usr_123/sessions/session_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	sessions, err := client.Users.ListSessions(context.Background(), "usr_123")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "203.0.113.9", *sessions[0].IPAddress)
+
+	require.NoError(t, client.Users.RevokeSession(context.Background(), "usr_123", sessions[0].SessionID))
+}
@@ -2,6 +2,7 @@ package opusdns
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strconv"
 
@@ -122,7 +123,12 @@ func (s *VanityNameserversService) DeleteSet(ctx context.Context, setID models.V
 func (s *VanityNameserversService) CheckSet(ctx context.Context, setID models.VanityNameserverSetID) (*models.VanityNsCheckResponse, error) {
 	path := s.client.http.BuildPath("vanity-nameserver-sets", "check")
 
-	resp, err := s.client.http.Post(ctx, path, &models.VanityNsCheckRequest{SetID: setID})
+	resp, err := s.client.http.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     path,
+		Body:     &models.VanityNsCheckRequest{SetID: setID},
+		ReadOnly: true,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,66 @@
+package opusdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSignature(t *testing.T) {
+	sig := RequestSignature([]byte("secret"), "POST", "/v1/dns?page=2", "1767225600", []byte(`{"name":"example.com"}`))
+	assert.Len(t, sig, 64)
+	assert.Equal(t, sig, RequestSignature([]byte("secret"), "POST", "/v1/dns?page=2", "1767225600", []byte(`{"name":"example.com"}`)))
+	assert.NotEqual(t, sig, RequestSignature([]byte("secret"), "POST", "/v1/dns?page=3", "1767225600", []byte(`{"name":"example.com"}`)))
+	assert.NotEqual(t, sig, RequestSignature([]byte("other"), "POST", "/v1/dns?page=2", "1767225600", []byte(`{"name":"example.com"}`)))
+}
+
+func TestRequestSigner(t *testing.T) {
+	// The server's clock is an hour ahead and it rejects timestamps more than
+	// a minute off
+	skew := time.Hour
+	var timestamps []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().Add(skew)
+		w.Header().Set("Date", now.UTC().Format(http.TimeFormat))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp := r.Header.Get(SignatureTimestampHeader)
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		require.NoError(t, err)
+		timestamps = append(timestamps, ts)
+
+		if r.Header.Get(SignatureHeader) != RequestSignature([]byte("secret"), r.Method, r.URL.RequestURI(), timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if time.Unix(ts, 0).Sub(now).Abs() > time.Minute {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithMaxRetries(0),
+		WithRetryWait(time.Millisecond, time.Millisecond), WithRequestSigner("secret"))
+	require.NoError(t, err)
+
+	_, err = client.http.Post(context.Background(), "/v1/dns", map[string]string{"name": "example.com"})
+	require.NoError(t, err)
+	require.Len(t, timestamps, 2)
+
+	// Later requests use the corrected clock right away
+	_, err = client.http.Get(context.Background(), "/v1/dns", nil)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 3)
+
+	assert.False(t, newRequestSigner(NewConfig(), systemClock{}).observe(http.Header{"Date": {time.Now().UTC().Format(http.TimeFormat)}}))
+}
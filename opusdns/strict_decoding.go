@@ -0,0 +1,185 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// jsonUnmarshalerType is the type of json.Unmarshaler.
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// checkSchema reports, once per type and field, where a successfully decoded
+// response body does not match the type it was decoded into. ctx is the
+// context of the call that returned the body and may be nil.
+func (c *HTTPClient) checkSchema(ctx context.Context, body []byte, target interface{}) {
+	t := reflect.TypeOf(target)
+	if t == nil {
+		return
+	}
+
+	unknown, missing := schemaDrift(body, t)
+	for _, path := range unknown {
+		c.warnSchemaDrift(ctx, t, "unknown field", path)
+	}
+	for _, path := range missing {
+		c.warnSchemaDrift(ctx, t, "missing field", path)
+	}
+}
+
+// warnSchemaDrift logs a schema drift warning unless it was logged before.
+// Without a configured logger the warning goes to the standard log package,
+// which writes to stderr, rather than to the program's stdout.
+func (c *HTTPClient) warnSchemaDrift(ctx context.Context, t reflect.Type, kind, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	msg := fmt.Sprintf("schema drift decoding %s: %s %q", t, kind, path)
+	if _, seen := c.schemaWarnings.LoadOrStore(msg, true); seen {
+		return
+	}
+
+	if logger := c.logger(ctx); logger != nil {
+		logger.Printf("[opusdns] warning: %s", msg)
+	} else {
+		log.Printf("[opusdns] warning: %s", msg)
+	}
+}
+
+// schemaDrift compares a JSON document with the Go type it is decoded into.
+// It returns the paths of object keys no field decodes (unknown) and of
+// fields without omitempty that are absent (missing), such as
+// "results[].anycast_pool". Like json.Decoder.DisallowUnknownFields it
+// detects fields the type does not cover, but it reports all of them, not
+// just the first. Types with their own UnmarshalJSON are not inspected.
+func schemaDrift(data []byte, t reflect.Type) (unknown, missing []string) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	add := func(list *[]string, path string) {
+		if !seen[path] {
+			seen[path] = true
+			*list = append(*list, path)
+		}
+	}
+
+	var walk func(v interface{}, t reflect.Type, path string)
+	walk = func(v interface{}, t reflect.Type, path string) {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if v == nil || t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+			return
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return
+			}
+			fields := jsonFields(t)
+			for key, value := range obj {
+				f, ok := fields[key]
+				if !ok {
+					// encoding/json also matches names case-insensitively
+					for name, candidate := range fields {
+						if strings.EqualFold(name, key) {
+							f, ok = candidate, true
+							break
+						}
+					}
+				}
+				if !ok {
+					add(&unknown, joinPath(path, key))
+					continue
+				}
+				walk(value, f.typ, joinPath(path, key))
+			}
+			for name, f := range fields {
+				if _, ok := obj[name]; !ok && !f.omitEmpty {
+					add(&missing, joinPath(path, name))
+				}
+			}
+		case reflect.Slice, reflect.Array:
+			items, ok := v.([]interface{})
+			if !ok {
+				return
+			}
+			for _, item := range items {
+				walk(item, t.Elem(), path+"[]")
+			}
+		case reflect.Map:
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return
+			}
+			for _, value := range obj {
+				walk(value, t.Elem(), path+"{}")
+			}
+		}
+	}
+	walk(doc, t, "")
+
+	sort.Strings(unknown)
+	sort.Strings(missing)
+	return unknown, missing
+}
+
+// jsonField is a struct field as seen by encoding/json.
+type jsonField struct {
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields returns the JSON fields of struct type t by name, including
+// those of embedded structs.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for n, ef := range jsonFields(embedded) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = ef
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = jsonField{typ: f.Type, omitEmpty: strings.Contains(opts, "omitempty")}
+	}
+	return fields
+}
+
+// joinPath appends key to a JSON path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
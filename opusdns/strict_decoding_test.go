@@ -0,0 +1,111 @@
+package opusdns
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDrift(t *testing.T) {
+	type inner struct {
+		Name string `json:"name"`
+		TTL  int    `json:"ttl,omitempty"`
+	}
+	type base struct {
+		ID string `json:"id"`
+	}
+	type outer struct {
+		base
+		Items   []inner          `json:"items"`
+		ByName  map[string]inner `json:"by_name,omitempty"`
+		Created time.Time        `json:"created_on,omitempty"`
+		Skipped string           `json:"-"`
+	}
+
+	data := []byte(`{
+		"id": "1",
+		"items": [{"name": "a", "weight": 1}, {"NAME": "b"}, {"ttl": 60}],
+		"by_name": {"a": {"name": "a", "extra": true}},
+		"created_on": {"unexpected": "object"},
+		"anycast_pool": "eu"
+	}`)
+
+	unknown, missing := schemaDrift(data, reflect.TypeOf(&outer{}))
+	assert.Equal(t, []string{"anycast_pool", "by_name{}.extra", "items[].weight"}, unknown)
+	assert.Equal(t, []string{"items[].name"}, missing)
+
+	unknown, missing = schemaDrift([]byte(`{"id": "1", "items": null}`), reflect.TypeOf(outer{}))
+	assert.Empty(t, unknown)
+	assert.Empty(t, missing)
+}
+
+func TestHTTPClient_StrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "example.com", "anycast_pool": "eu"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithLogger(logger), WithStrictDecoding(true))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		zone, err := client.DNS.GetZone(context.Background(), "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com", zone.Name)
+	}
+	assert.Equal(t, []string{
+		`[opusdns] warning: schema drift decoding models.Zone: unknown field "anycast_pool"`,
+	}, logger.lines)
+
+	logger.lines = nil
+	client, err = NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithLogger(logger))
+	require.NoError(t, err)
+	_, err = client.DNS.GetZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Empty(t, logger.lines)
+}
+
+func TestHTTPClient_StrictDecoding_LoggerSelection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "example.com", "anycast_pool": "eu"}`))
+	}))
+	defer server.Close()
+
+	global, scoped := &recordingLogger{}, &recordingLogger{}
+	client, err := NewClient(
+		WithAPIKey("opk_test"),
+		WithAPIEndpoint(server.URL),
+		WithStrictDecoding(true),
+		WithLogger(global),
+		WithContextLogger(func(ctx context.Context) Logger {
+			if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+				return l
+			}
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	_, err = client.DNS.GetZone(context.WithValue(context.Background(), loggerKey{}, scoped), "example.com")
+	require.NoError(t, err)
+	assert.Len(t, scoped.lines, 1)
+	assert.Empty(t, global.lines)
+
+	var stderr bytes.Buffer
+	log.SetOutput(&stderr)
+	defer log.SetOutput(os.Stderr)
+	client, err = NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL), WithStrictDecoding(true))
+	require.NoError(t, err)
+	_, err = client.DNS.GetZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Contains(t, stderr.String(), `[opusdns] warning: schema drift decoding models.Zone: unknown field "anycast_pool"`)
+}
@@ -0,0 +1,110 @@
+package opusdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// ResolveLabels returns the IDs of the tags of tagType with the given labels,
+// in order. Labels match case-insensitively; an unknown label is an error
+// matching ErrNotFound.
+func (s *TagsService) ResolveLabels(ctx context.Context, tagType models.TagType, labels []string) ([]models.TagID, error) {
+	return lookupTagLabels(ctx, s, tagType, labels, false)
+}
+
+// SetObjectTags replaces the tags of objects (domain or zone names, or
+// contact IDs) with the tags of tagType labelled labels, creating the tags
+// that do not exist yet. An empty labels removes all tags from the objects.
+func (s *TagsService) SetObjectTags(ctx context.Context, tagType models.TagType, objects []string, labels []string) error {
+	if len(objects) == 0 {
+		return &ValidationError{Field: "objects", Message: "at least one object is required"}
+	}
+	ids, err := lookupTagLabels(ctx, s, tagType, labels, true)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.BulkUpdateObjects(ctx, &models.BulkObjectTagChanges{
+		Type:    tagType,
+		Objects: objects,
+		Replace: &ids,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Unresolved) > 0 {
+		return fmt.Errorf("opusdns: set tags: %w: %s", ErrNotFound, strings.Join(resp.Unresolved, ", "))
+	}
+	return nil
+}
+
+// lookupTagLabels maps labels to tag IDs with a single tag listing, creating
+// the missing tags if create is set.
+func lookupTagLabels(ctx context.Context, tags TagsAPI, tagType models.TagType, labels []string, create bool) ([]models.TagID, error) {
+	ids := make([]models.TagID, 0, len(labels))
+	if len(labels) == 0 {
+		return ids, nil
+	}
+
+	existing, err := tags.ListTags(ctx, &models.ListTagsOptions{TagTypes: []models.TagType{tagType}})
+	if err != nil {
+		return nil, err
+	}
+	byLabel := make(map[string]models.TagID, len(existing))
+	for _, t := range existing {
+		if t.Type == tagType {
+			byLabel[strings.ToLower(t.Label)] = t.TagID
+		}
+	}
+
+	seen := make(map[models.TagID]bool, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, &ValidationError{Field: "labels", Message: "tag labels must not be empty"}
+		}
+		id, ok := byLabel[strings.ToLower(label)]
+		if !ok {
+			if !create {
+				return nil, fmt.Errorf("opusdns: %w: no %s tag labelled %q", ErrNotFound, strings.ToLower(string(tagType)), label)
+			}
+			tag, err := tags.CreateTag(ctx, &models.TagCreateRequest{Label: label, Type: tagType})
+			if err != nil {
+				return nil, fmt.Errorf("opusdns: create tag %q: %w", label, err)
+			}
+			id = tag.TagID
+			byLabel[strings.ToLower(label)] = id
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// tagFilterIDs returns ids extended with the IDs of the tags labelled
+// labels, for the Tags filter of the list options.
+func tagFilterIDs(ctx context.Context, tags TagsAPI, tagType models.TagType, ids []models.TagID, labels []string) ([]models.TagID, error) {
+	resolved, err := tags.ResolveLabels(ctx, tagType, labels)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]models.TagID(nil), ids...), resolved...), nil
+}
+
+// SetTags replaces the tags of a domain with the domain tags labelled
+// labels, creating missing tags. Use it to segment a portfolio (by brand,
+// client or environment) and ListDomainsOptions.Tags to list a segment.
+func (s *DomainsService) SetTags(ctx context.Context, domainName string, labels []string) error {
+	return s.client.Tags.SetObjectTags(ctx, models.TagTypeDomain, []string{domainName}, labels)
+}
+
+// SetZoneTags replaces the tags of a zone with the zone tags labelled
+// labels, creating missing tags.
+func (s *DNSService) SetZoneTags(ctx context.Context, zoneName string, labels []string) error {
+	return s.client.Tags.SetObjectTags(ctx, models.TagTypeZone, []string{zoneName}, labels)
+}
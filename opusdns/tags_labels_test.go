@@ -0,0 +1,162 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tagLabelServer fakes the tags API with a fixed set of existing tags and
+// records created tags, bulk updates and the tag_ids of domain and zone
+// listings.
+type tagLabelServer struct {
+	mu        sync.Mutex
+	tags      []models.Tag
+	created   []models.TagCreateRequest
+	bulk      []models.BulkObjectTagChanges
+	filters   [][]string
+	tagLists  int
+	unresolve []string
+}
+
+func (f *tagLabelServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/tags":
+			f.tagLists++
+			_ = json.NewEncoder(w).Encode(models.TagListResponse{Results: f.tags})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/tags":
+			var req models.TagCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.created = append(f.created, req)
+			tag := models.Tag{TagID: models.TagID("tag_new_" + req.Label), Label: req.Label, Type: req.Type}
+			f.tags = append(f.tags, tag)
+			_ = json.NewEncoder(w).Encode(tag)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/tags/objects":
+			var req models.BulkObjectTagChanges
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.bulk = append(f.bulk, req)
+			_ = json.NewEncoder(w).Encode(models.ObjectTagChangesResponse{Unresolved: f.unresolve})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains":
+			f.filters = append(f.filters, r.URL.Query()["tag_ids"])
+			pagination := models.Pagination{HasNextPage: r.URL.Query().Get("page") == "1"}
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{{Name: "example.com"}}, Pagination: pagination})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns":
+			f.filters = append(f.filters, r.URL.Query()["tag_ids"])
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: []models.Zone{{Name: "example.com"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func existingTags() []models.Tag {
+	return []models.Tag{
+		{TagID: "tag_brand", Label: "Brand-A", Type: models.TagTypeDomain},
+		{TagID: "tag_prod", Label: "production", Type: models.TagTypeDomain},
+		{TagID: "tag_zone_prod", Label: "production", Type: models.TagTypeZone},
+	}
+}
+
+func TestTagsService_ResolveLabels(t *testing.T) {
+	fake := &tagLabelServer{tags: existingTags()}
+	client := newTestClient(t, fake.handler(t))
+
+	ids, err := client.Tags.ResolveLabels(context.Background(), models.TagTypeDomain, []string{"Production", "brand-a", "production"})
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagID{"tag_prod", "tag_brand"}, ids)
+
+	_, err = client.Tags.ResolveLabels(context.Background(), models.TagTypeDomain, []string{"staging"})
+	assert.True(t, IsNotFoundError(err))
+	assert.Empty(t, fake.created)
+}
+
+func TestDomainsService_SetTags(t *testing.T) {
+	t.Run("creates missing tags and replaces the domain's tags", func(t *testing.T) {
+		fake := &tagLabelServer{tags: existingTags()}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.Domains.SetTags(context.Background(), "example.com", []string{"production", "client-x"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.TagCreateRequest{{Label: "client-x", Type: models.TagTypeDomain}}, fake.created)
+		require.Len(t, fake.bulk, 1)
+		assert.Equal(t, models.TagTypeDomain, fake.bulk[0].Type)
+		assert.Equal(t, []string{"example.com"}, fake.bulk[0].Objects)
+		require.NotNil(t, fake.bulk[0].Replace)
+		assert.Equal(t, []models.TagID{"tag_prod", "tag_new_client-x"}, *fake.bulk[0].Replace)
+	})
+
+	t.Run("no labels clears the tags", func(t *testing.T) {
+		fake := &tagLabelServer{tags: existingTags()}
+		client := newTestClient(t, fake.handler(t))
+
+		require.NoError(t, client.Domains.SetTags(context.Background(), "example.com", nil))
+		require.Len(t, fake.bulk, 1)
+		require.NotNil(t, fake.bulk[0].Replace)
+		assert.Empty(t, *fake.bulk[0].Replace)
+		assert.Zero(t, fake.tagLists)
+	})
+
+	t.Run("unknown domain", func(t *testing.T) {
+		fake := &tagLabelServer{tags: existingTags(), unresolve: []string{"missing.com"}}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.Domains.SetTags(context.Background(), "missing.com", []string{"production"})
+		assert.True(t, IsNotFoundError(err))
+	})
+
+	t.Run("empty label", func(t *testing.T) {
+		fake := &tagLabelServer{tags: existingTags()}
+		client := newTestClient(t, fake.handler(t))
+
+		err := client.Domains.SetTags(context.Background(), "example.com", []string{" "})
+		assert.True(t, IsValidationError(err))
+		assert.Empty(t, fake.bulk)
+	})
+}
+
+func TestDNSService_SetZoneTags(t *testing.T) {
+	fake := &tagLabelServer{tags: existingTags()}
+	client := newTestClient(t, fake.handler(t))
+
+	require.NoError(t, client.DNS.SetZoneTags(context.Background(), "example.com", []string{"production"}))
+	require.Len(t, fake.bulk, 1)
+	assert.Equal(t, models.TagTypeZone, fake.bulk[0].Type)
+	assert.Equal(t, []models.TagID{"tag_zone_prod"}, *fake.bulk[0].Replace)
+}
+
+func TestListDomains_TagLabels(t *testing.T) {
+	fake := &tagLabelServer{tags: existingTags()}
+	client := newTestClient(t, fake.handler(t))
+
+	opts := &models.ListDomainsOptions{TagIDs: []models.TagID{"tag_other"}, Tags: []string{"Brand-A"}}
+	domains, err := client.Domains.ListDomains(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Len(t, domains, 2)
+
+	assert.Equal(t, 1, fake.tagLists, "labels are resolved once per listing")
+	assert.Equal(t, [][]string{{"tag_other", "tag_brand"}, {"tag_other", "tag_brand"}}, fake.filters)
+	assert.Equal(t, []string{"Brand-A"}, opts.Tags, "caller's options are not modified")
+
+	_, err = client.Domains.ListDomainsPage(context.Background(), &models.ListDomainsOptions{Tags: []string{"nope"}})
+	assert.True(t, IsNotFoundError(err))
+}
+
+func TestListZones_TagLabels(t *testing.T) {
+	fake := &tagLabelServer{tags: existingTags()}
+	client := newTestClient(t, fake.handler(t))
+
+	_, err := client.DNS.ListZones(context.Background(), &models.ListZonesOptions{Tags: []string{"production"}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"tag_zone_prod"}}, fake.filters)
+}
@@ -0,0 +1,117 @@
+package opusdns
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+)
+
+// DefaultPricingCacheTTL is how long ComparePricing reuses the details it
+// fetched for a TLD.
+const DefaultPricingCacheTTL = time.Hour
+
+// cachedTLD is a TLD's details as fetched by ComparePricing.
+type cachedTLD struct {
+	details *models.TLDDetails // nil if the TLD does not exist
+	fetched time.Time
+}
+
+// ComparePricing returns the price of action for each of tlds, cheapest
+// first. Prices in different currencies are not converted: the result is
+// grouped by currency code and sorted by price within each currency. TLDs
+// without a price for the action, including unknown TLDs, come last with an
+// empty Price.
+//
+// Supported actions are create, renew, transfer and restore. TLD details are
+// cached on the service for DefaultPricingCacheTTL, so repeated comparisons
+// do not refetch them.
+func (s *TLDsService) ComparePricing(ctx context.Context, tlds []string, action models.BillingTransactionAction) ([]models.TLDPrice, error) {
+	switch action {
+	case models.BillingActionCreate, models.BillingActionRenew, models.BillingActionTransfer, models.BillingActionRestore:
+	default:
+		return nil, &ValidationError{Field: "action", Message: "must be create, renew, transfer or restore", Value: action}
+	}
+
+	seen := map[string]bool{}
+	prices := make([]models.TLDPrice, 0, len(tlds))
+	for _, tld := range tlds {
+		tld = normalizeTLD(tld)
+		if tld == "" || seen[tld] {
+			continue
+		}
+		seen[tld] = true
+
+		details, err := s.cachedTLD(ctx, tld)
+		if err != nil {
+			return nil, err
+		}
+
+		price := models.TLDPrice{TLD: tld, Action: action}
+		if details != nil && details.Pricing != nil {
+			pricing := details.Pricing
+			price.Currency = pricing.Currency
+			price.Premium = pricing.PremiumPricing
+			switch action {
+			case models.BillingActionCreate:
+				price.Price = pricing.RegisterPrice
+			case models.BillingActionRenew:
+				price.Price = pricing.RenewPrice
+			case models.BillingActionTransfer:
+				price.Price = pricing.TransferPrice
+			case models.BillingActionRestore:
+				price.Price = pricing.RestorePrice
+			}
+		}
+		prices = append(prices, price)
+	}
+
+	sort.SliceStable(prices, func(i, j int) bool {
+		a, aOK := new(big.Rat).SetString(prices[i].Price)
+		b, bOK := new(big.Rat).SetString(prices[j].Price)
+		if aOK != bOK {
+			return aOK
+		}
+		if !aOK {
+			return prices[i].TLD < prices[j].TLD
+		}
+		if prices[i].Currency != prices[j].Currency {
+			return prices[i].Currency < prices[j].Currency
+		}
+		if c := a.Cmp(b); c != 0 {
+			return c < 0
+		}
+		return prices[i].TLD < prices[j].TLD
+	})
+
+	return prices, nil
+}
+
+// cachedTLD returns the details of tld from the pricing cache, fetching them
+// if missing or stale. It returns nil details for a TLD that does not exist.
+func (s *TLDsService) cachedTLD(ctx context.Context, tld string) (*models.TLDDetails, error) {
+	s.mu.Lock()
+	entry, ok := s.pricing[tld]
+	s.mu.Unlock()
+	if ok && time.Since(entry.fetched) < DefaultPricingCacheTTL {
+		return entry.details, nil
+	}
+
+	details, err := s.GetTLD(ctx, tld)
+	if IsNotFoundError(err) {
+		details, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pricing == nil {
+		s.pricing = map[string]cachedTLD{}
+	}
+	s.pricing[tld] = cachedTLD{details: details, fetched: time.Now()}
+	return details, nil
+}
@@ -0,0 +1,67 @@
+package opusdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLDsService_ComparePricing(t *testing.T) {
+	pricing := map[string]*models.TLDPricing{
+		"com": {RegisterPrice: "10.50", RenewPrice: "12.00", Currency: models.CurrencyEUR},
+		"net": {RegisterPrice: "9.75", RenewPrice: "13.00", Currency: models.CurrencyEUR},
+		"io":  {RegisterPrice: "35.00", Currency: models.CurrencyEUR, PremiumPricing: true},
+		"ch":  {RegisterPrice: "8.00", Currency: models.CurrencyCHF},
+		"org": {RegisterPrice: "10.5", Currency: models.CurrencyEUR},
+	}
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tld := strings.TrimPrefix(r.URL.Path, "/v1/tlds/")
+		requests[tld]++
+		p, ok := pricing[tld]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.TLDDetails{TLD: models.TLD{Name: tld, Pricing: p}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("opk_test"), WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	prices, err := client.TLDs.ComparePricing(ctx, []string{".com", "io", "NET", "org", "ch", "nosuch", "com"}, models.BillingActionCreate)
+	require.NoError(t, err)
+
+	var order []string
+	for _, p := range prices {
+		order = append(order, p.TLD)
+	}
+	assert.Equal(t, []string{"ch", "net", "com", "org", "io", "nosuch"}, order)
+	assert.Equal(t, "9.75", prices[1].Price)
+	assert.True(t, prices[4].Premium)
+	assert.Empty(t, prices[5].Price)
+
+	t.Run("cached", func(t *testing.T) {
+		prices, err := client.TLDs.ComparePricing(ctx, []string{"com", "net", "io"}, models.BillingActionRenew)
+		require.NoError(t, err)
+		assert.Equal(t, "com", prices[0].TLD)
+		assert.Equal(t, "io", prices[2].TLD, "no renew price sorts last")
+		assert.Equal(t, 1, requests["com"])
+		assert.Equal(t, 1, requests["nosuch"])
+	})
+
+	t.Run("rejects unsupported action", func(t *testing.T) {
+		_, err := client.TLDs.ComparePricing(ctx, []string{"com"}, models.BillingActionTrade)
+		assert.True(t, IsValidationError(err))
+	})
+}
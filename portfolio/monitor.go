@@ -0,0 +1,330 @@
+// Package portfolio provides helpers that watch and report on a domain
+// portfolio through an opusdns.Client.
+package portfolio
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+)
+
+// Default monitor settings.
+const (
+	// DefaultExpiringWithin is the default expiry window.
+	DefaultExpiringWithin = 30 * 24 * time.Hour
+
+	// DefaultInterval is the default time between checks in Run.
+	DefaultInterval = time.Hour
+)
+
+// AlertKind identifies the kind of alert emitted by a Monitor.
+type AlertKind string
+
+const (
+	// AlertExpiring is emitted for a domain inside the expiry window that will not auto-renew.
+	AlertExpiring AlertKind = "expiring"
+
+	// AlertExpiringAutoRenew is emitted for a domain inside the expiry window that is set to auto-renew.
+	AlertExpiringAutoRenew AlertKind = "expiring_auto_renew"
+
+	// AlertExpired is emitted for a domain whose expiry date has passed.
+	AlertExpired AlertKind = "expired"
+
+	// AlertRenewed is emitted when the renew policy renewed a domain.
+	AlertRenewed AlertKind = "renewed"
+
+	// AlertRenewFailed is emitted when the renew policy failed to renew a domain.
+	AlertRenewFailed AlertKind = "renew_failed"
+)
+
+// Alert is a single portfolio finding.
+type Alert struct {
+	// Kind is the kind of alert.
+	Kind AlertKind
+
+	// Domain is the domain the alert is about. For AlertRenewed it reflects the renewed domain.
+	Domain models.Domain
+
+	// ExpiresOn is the domain's expiry date at the time of the check.
+	ExpiresOn time.Time
+
+	// ExpiresIn is the time left until ExpiresOn (negative once expired).
+	ExpiresIn time.Duration
+
+	// DaysLeft is the number of calendar days until the expiry date in the
+	// registry's timezone (0 on the day of expiry). Unlike ExpiresIn divided
+	// by 24 hours, it does not report 0 for a domain expiring tomorrow.
+	DaysLeft int
+
+	// Err is the renewal error for AlertRenewFailed.
+	Err error
+}
+
+// RenewPolicy configures automatic renewal of expiring domains.
+// Only domains that are not set to auto-renew are renewed by the monitor.
+type RenewPolicy struct {
+	// Within renews domains expiring within this duration. Zero uses the monitor's expiry window.
+	Within time.Duration
+
+	// Period is the renewal period in years (defaults to 1).
+	Period int
+
+	// Match optionally restricts which domains are renewed.
+	Match func(models.Domain) bool
+}
+
+// Option configures a Monitor.
+type Option func(*Monitor)
+
+// WithExpiringWithin sets the expiry window. Domains expiring within it are reported.
+func WithExpiringWithin(d time.Duration) Option {
+	return func(m *Monitor) {
+		m.expiringWithin = d
+	}
+}
+
+// WithInterval sets the time between checks in Run.
+func WithInterval(d time.Duration) Option {
+	return func(m *Monitor) {
+		m.interval = d
+	}
+}
+
+// WithAlertHandler sets a callback invoked for each alert, in order.
+func WithAlertHandler(fn func(Alert)) Option {
+	return func(m *Monitor) {
+		m.onAlert = fn
+	}
+}
+
+// WithErrorHandler sets a callback invoked when a check in Run fails.
+// Run keeps going after a failed check.
+func WithErrorHandler(fn func(error)) Option {
+	return func(m *Monitor) {
+		m.onError = fn
+	}
+}
+
+// WithRenewPolicy enables automatic renewal under policy.
+func WithRenewPolicy(policy RenewPolicy) Option {
+	return func(m *Monitor) {
+		m.renew = &policy
+	}
+}
+
+// WithListOptions sets base filters (such as TLD or tags) used when listing domains.
+// The expiry filter is always set by the monitor.
+func WithListOptions(opts *models.ListDomainsOptions) Option {
+	return func(m *Monitor) {
+		m.listOpts = opts
+	}
+}
+
+// Monitor periodically checks a domain portfolio for expiring domains and
+// emits alerts. Each alert is emitted once per domain, kind and expiry date
+// for the lifetime of the monitor, so repeated checks only report changes.
+type Monitor struct {
+	client         *opusdns.Client
+	expiringWithin time.Duration
+	interval       time.Duration
+	onAlert        func(Alert)
+	onError        func(error)
+	renew          *RenewPolicy
+	listOpts       *models.ListDomainsOptions
+	now            func() time.Time
+
+	mu   sync.Mutex
+	seen map[alertKey]bool
+}
+
+type alertKey struct {
+	domain    models.DomainID
+	kind      AlertKind
+	expiresOn time.Time
+}
+
+// NewMonitor creates a Monitor for the client's domain portfolio.
+func NewMonitor(client *opusdns.Client, opts ...Option) *Monitor {
+	m := &Monitor{
+		client:         client,
+		expiringWithin: DefaultExpiringWithin,
+		interval:       DefaultInterval,
+		now:            time.Now,
+		seen:           make(map[alertKey]bool),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Check runs a single pass over the portfolio and returns the alerts it
+// emitted. Domains without an expiry date are skipped.
+func (m *Monitor) Check(ctx context.Context) ([]Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	window := m.expiringWithin
+	if m.renew != nil && m.renew.Within > window {
+		window = m.renew.Within
+	}
+
+	opts := models.ListDomainsOptions{}
+	if m.listOpts != nil {
+		opts = *m.listOpts
+	}
+	expiresBefore := now.Add(window)
+	opts.ExpiresBefore = &expiresBefore
+
+	domains, err := m.client.Domains.ListDomains(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []Alert
+	for _, domain := range domains {
+		if domain.ExpiresOn == nil {
+			continue
+		}
+		alerts = append(alerts, m.evaluate(ctx, domain, now)...)
+	}
+
+	return alerts, nil
+}
+
+// evaluate produces the new alerts for a single domain, renewing it first when the policy applies.
+func (m *Monitor) evaluate(ctx context.Context, domain models.Domain, now time.Time) []Alert {
+	expiresOn := *domain.ExpiresOn
+	expiresIn := expiresOn.Sub(now)
+
+	var alerts []Alert
+	emit := func(alert Alert) {
+		key := alertKey{domain: domain.DomainID, kind: alert.Kind, expiresOn: expiresOn}
+		if m.seen[key] {
+			return
+		}
+		m.seen[key] = true
+		alerts = append(alerts, alert)
+		if m.onAlert != nil {
+			m.onAlert(alert)
+		}
+	}
+
+	base := Alert{
+		Domain:    domain,
+		ExpiresOn: expiresOn,
+		ExpiresIn: expiresIn,
+		DaysLeft:  models.RegistryDate(now).DaysUntil(models.RegistryDate(expiresOn)),
+	}
+
+	if expiresIn <= 0 {
+		base.Kind = AlertExpired
+		emit(base)
+		return alerts
+	}
+
+	if m.shouldRenew(domain, expiresIn) {
+		renewed, err := m.renewDomain(ctx, domain)
+		if err != nil {
+			failed := base
+			failed.Kind = AlertRenewFailed
+			failed.Err = err
+			emit(failed)
+		} else {
+			ok := base
+			ok.Kind = AlertRenewed
+			ok.Domain = *renewed
+			emit(ok)
+			return alerts
+		}
+	}
+
+	if expiresIn > m.expiringWithin {
+		return alerts
+	}
+	if domain.RenewalMode.IsAutoRenew() {
+		base.Kind = AlertExpiringAutoRenew
+	} else {
+		base.Kind = AlertExpiring
+	}
+	emit(base)
+
+	return alerts
+}
+
+// shouldRenew reports whether the renew policy applies to domain.
+func (m *Monitor) shouldRenew(domain models.Domain, expiresIn time.Duration) bool {
+	if m.renew == nil || domain.RenewalMode.IsAutoRenew() {
+		return false
+	}
+	within := m.renew.Within
+	if within == 0 {
+		within = m.expiringWithin
+	}
+	if expiresIn > within {
+		return false
+	}
+	return m.renew.Match == nil || m.renew.Match(domain)
+}
+
+// renewDomain renews domain under the policy's period.
+func (m *Monitor) renewDomain(ctx context.Context, domain models.Domain) (*models.Domain, error) {
+	period := m.renew.Period
+	if period <= 0 {
+		period = 1
+	}
+	return m.client.Domains.RenewDomain(ctx, domain.Name, &models.DomainRenewRequest{
+		Period:            period,
+		CurrentExpiryDate: domain.ExpiresOn,
+	})
+}
+
+// Run checks the portfolio immediately and then every interval until ctx is
+// done, and returns ctx.Err(). Failed checks are passed to the error handler.
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx); err != nil && ctx.Err() == nil && m.onError != nil {
+			m.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Watch runs the monitor in a goroutine and delivers alerts on the returned
+// channel, which is closed once ctx is done. Any alert handler set with
+// WithAlertHandler is still invoked before the alert is sent.
+func (m *Monitor) Watch(ctx context.Context) <-chan Alert {
+	ch := make(chan Alert)
+	handler := m.onAlert
+
+	m.mu.Lock()
+	m.onAlert = func(alert Alert) {
+		if handler != nil {
+			handler(alert)
+		}
+		select {
+		case ch <- alert:
+		case <-ctx.Done():
+		}
+	}
+	m.mu.Unlock()
+
+	go func() {
+		defer close(ch)
+		_ = m.Run(ctx)
+	}()
+
+	return ch
+}
@@ -0,0 +1,161 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMonitor(t *testing.T, handler http.HandlerFunc, opts ...Option) *Monitor {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	m := NewMonitor(client, opts...)
+	m.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return m
+}
+
+func TestMonitor_Check(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expired := now.Add(-24 * time.Hour)
+	soon := now.Add(10 * 24 * time.Hour)
+
+	var renewed []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains":
+			assert.Equal(t, now.Add(DefaultExpiringWithin).Format(time.RFC3339), r.URL.Query().Get("expires_before"))
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+				{DomainID: "domain_1", Name: "gone.com", ExpiresOn: &expired, RenewalMode: models.RenewalModeExpire},
+				{DomainID: "domain_2", Name: "auto.com", ExpiresOn: &soon, RenewalMode: models.RenewalModeRenew},
+				{DomainID: "domain_3", Name: "manual.com", ExpiresOn: &soon, RenewalMode: models.RenewalModeExpire},
+				{DomainID: "domain_4", Name: "unknown.com"},
+			}})
+		case r.Method == http.MethodPost:
+			renewed = append(renewed, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(models.Domain{DomainID: "domain_3", Name: "manual.com"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	var handled []AlertKind
+	m := newTestMonitor(t, handler, WithAlertHandler(func(a Alert) { handled = append(handled, a.Kind) }))
+
+	alerts, err := m.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, AlertExpired, alerts[0].Kind)
+	assert.Equal(t, AlertExpiringAutoRenew, alerts[1].Kind)
+	assert.Equal(t, AlertExpiring, alerts[2].Kind)
+	assert.Equal(t, "manual.com", alerts[2].Domain.Name)
+	assert.Equal(t, 10*24*time.Hour, alerts[2].ExpiresIn)
+	assert.Equal(t, 10, alerts[2].DaysLeft)
+	assert.Equal(t, []AlertKind{AlertExpired, AlertExpiringAutoRenew, AlertExpiring}, handled)
+	assert.Empty(t, renewed)
+
+	// A second pass over unchanged data reports nothing new.
+	alerts, err = m.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestMonitor_CheckRenewPolicy(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.Add(5 * 24 * time.Hour)
+	later := soon.AddDate(1, 0, 0)
+
+	var renewBody models.DomainRenewRequest
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains":
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+				{DomainID: "domain_1", Name: "keep.com", ExpiresOn: &soon, RenewalMode: models.RenewalModeExpire},
+				{DomainID: "domain_2", Name: "skip.net", ExpiresOn: &soon, RenewalMode: models.RenewalModeExpire},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/domains/keep.com/renew":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&renewBody))
+			_ = json.NewEncoder(w).Encode(models.Domain{DomainID: "domain_1", Name: "keep.com", ExpiresOn: &later})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	m := newTestMonitor(t, handler, WithRenewPolicy(RenewPolicy{
+		Within: 7 * 24 * time.Hour,
+		Period: 2,
+		Match:  func(d models.Domain) bool { return d.Name == "keep.com" },
+	}))
+
+	alerts, err := m.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	assert.Equal(t, AlertRenewed, alerts[0].Kind)
+	assert.Equal(t, later, *alerts[0].Domain.ExpiresOn)
+	assert.Equal(t, 2, renewBody.Period)
+	require.NotNil(t, renewBody.CurrentExpiryDate)
+	assert.True(t, soon.Equal(*renewBody.CurrentExpiryDate))
+
+	assert.Equal(t, AlertExpiring, alerts[1].Kind)
+	assert.Equal(t, "skip.net", alerts[1].Domain.Name)
+}
+
+func TestMonitor_Watch(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.Add(24 * time.Hour)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+			{DomainID: "domain_1", Name: "example.com", ExpiresOn: &soon},
+		}})
+	}
+
+	m := newTestMonitor(t, handler, WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	alerts := m.Watch(ctx)
+
+	alert := <-alerts
+	assert.Equal(t, AlertExpiring, alert.Kind)
+	assert.Equal(t, "example.com", alert.Domain.Name)
+
+	cancel()
+	_, open := <-alerts
+	assert.False(t, open)
+}
+
+func TestMonitor_CheckDaysLeftAcrossMidnight(t *testing.T) {
+	// 23:00 UTC with an expiry at 00:30 UTC the next day: 1.5 hours, but the
+	// domain expires on the next registry day.
+	now := time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC)
+	expires := time.Date(2026, 1, 2, 0, 30, 0, 0, time.UTC)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+			{DomainID: "domain_1", Name: "tomorrow.com", ExpiresOn: &expires, RenewalMode: models.RenewalModeExpire},
+		}})
+	}
+
+	m := newTestMonitor(t, handler)
+	m.now = func() time.Time { return now }
+
+	alerts, err := m.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, 90*time.Minute, alerts[0].ExpiresIn)
+	assert.Equal(t, 1, alerts[0].DaysLeft)
+}
@@ -0,0 +1,247 @@
+package portfolio
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+)
+
+// ReportFormat is the output format of WriteReport.
+type ReportFormat string
+
+const (
+	// ReportFormatCSV writes one CSV row per domain with a header row.
+	ReportFormatCSV ReportFormat = "csv"
+
+	// ReportFormatXLSX writes an Excel workbook with one sheet.
+	ReportFormatXLSX ReportFormat = "xlsx"
+
+	// ReportFormatJSON writes a JSON array of ReportRow.
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// reportHeader is the header row of the CSV and XLSX reports.
+var reportHeader = []string{
+	"name", "expires_on", "auto_renew", "transfer_lock", "nameservers",
+	"dns_hosted", "dnssec", "registrant_id", "registrant", "registrant_email",
+}
+
+// ReportRow is one domain of a portfolio report.
+type ReportRow struct {
+	// Name is the domain name.
+	Name string `json:"name"`
+
+	// ExpiresOn is when the domain expires.
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+
+	// AutoRenew reports whether the domain renews automatically.
+	AutoRenew bool `json:"auto_renew"`
+
+	// TransferLock reports whether transfers are prohibited.
+	TransferLock bool `json:"transfer_lock"`
+
+	// Nameservers are the hostnames the domain is delegated to.
+	Nameservers []string `json:"nameservers"`
+
+	// DNSHosted reports whether OpusDNS hosts a zone for the domain.
+	DNSHosted bool `json:"dns_hosted"`
+
+	// DNSSEC is the DNSSEC status of the hosted zone, empty if not hosted.
+	DNSSEC models.DNSSECStatus `json:"dnssec,omitempty"`
+
+	// RegistrantID is the registrant contact, if any.
+	RegistrantID models.ContactID `json:"registrant_id,omitempty"`
+
+	// Registrant is the registrant's name and organization.
+	Registrant string `json:"registrant,omitempty"`
+
+	// RegistrantEmail is the registrant's email address.
+	RegistrantEmail string `json:"registrant_email,omitempty"`
+}
+
+// BuildReport lists the domains matching opts (all if nil) and joins them
+// with the DNS zones and contacts of the account into one row per domain, in
+// the order listed. It makes one paginated sweep each over domains, zones and
+// contacts rather than a request per domain.
+func BuildReport(ctx context.Context, client *opusdns.Client, opts *models.ListDomainsOptions) ([]ReportRow, error) {
+	domains, err := client.Domains.ListDomains(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("portfolio: list domains: %w", err)
+	}
+	zones, err := client.DNS.ListZones(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("portfolio: list zones: %w", err)
+	}
+	contacts, err := client.Contacts.ListContacts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("portfolio: list contacts: %w", err)
+	}
+
+	zonesByName := make(map[string]models.Zone, len(zones))
+	for _, z := range zones {
+		zonesByName[strings.ToLower(strings.TrimSuffix(z.Name, "."))] = z
+	}
+	contactsByID := make(map[models.ContactID]models.Contact, len(contacts))
+	for _, c := range contacts {
+		contactsByID[c.ContactID] = c
+	}
+
+	rows := make([]ReportRow, 0, len(domains))
+	for _, d := range domains {
+		row := ReportRow{
+			Name:         d.Name,
+			ExpiresOn:    d.ExpiresOn,
+			AutoRenew:    d.RenewalMode.IsAutoRenew(),
+			TransferLock: d.TransferLock,
+			Nameservers:  make([]string, 0, len(d.Nameservers)),
+		}
+		for _, ns := range d.Nameservers {
+			row.Nameservers = append(row.Nameservers, ns.Hostname)
+		}
+		if z, ok := zonesByName[strings.ToLower(d.Name)]; ok {
+			row.DNSHosted = true
+			row.DNSSEC = z.DNSSECStatus
+		}
+		for _, dc := range d.Contacts {
+			if dc.ContactType != models.DomainContactTypeRegistrant {
+				continue
+			}
+			row.RegistrantID = dc.ContactID
+			if c, ok := contactsByID[dc.ContactID]; ok {
+				row.Registrant = contactName(c)
+				row.RegistrantEmail = c.Email
+			}
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// contactName returns "First Last (Org)", leaving out missing parts.
+func contactName(c models.Contact) string {
+	name := strings.TrimSpace(c.FirstName + " " + c.LastName)
+	if c.Org != nil && *c.Org != "" {
+		if name == "" {
+			return *c.Org
+		}
+		name += " (" + *c.Org + ")"
+	}
+	return name
+}
+
+// WriteReport writes rows to w in the given format (CSV if empty).
+func WriteReport(w io.Writer, rows []ReportRow, format ReportFormat) error {
+	switch format {
+	case "", ReportFormatCSV:
+		return writeReportCSV(w, rows)
+	case ReportFormatXLSX:
+		return writeReportXLSX(w, rows)
+	case ReportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	default:
+		return fmt.Errorf("unknown report format %q: must be csv, xlsx or json", format)
+	}
+}
+
+// record formats the row as strings matching reportHeader.
+func (r ReportRow) record() []string {
+	expires := ""
+	if r.ExpiresOn != nil {
+		expires = r.ExpiresOn.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		r.Name,
+		expires,
+		strconv.FormatBool(r.AutoRenew),
+		strconv.FormatBool(r.TransferLock),
+		strings.Join(r.Nameservers, " "),
+		strconv.FormatBool(r.DNSHosted),
+		string(r.DNSSEC),
+		string(r.RegistrantID),
+		r.Registrant,
+		r.RegistrantEmail,
+	}
+}
+
+func writeReportCSV(w io.Writer, rows []ReportRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// xlsxParts are the fixed parts of a one-sheet workbook.
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Domains" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// writeReportXLSX writes a minimal workbook with the report as inline-string
+// cells, so it needs no spreadsheet library.
+func writeReportXLSX(w io.Writer, rows []ReportRow) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeXLSXRow(&sb, reportHeader)
+	for _, r := range rows {
+		writeXLSXRow(&sb, r.record())
+	}
+	sb.WriteString(`</sheetData></worksheet>`)
+	if _, err := io.WriteString(f, sb.String()); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeXLSXRow appends a row of inline-string cells.
+func writeXLSXRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("<row>")
+	for _, c := range cells {
+		sb.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(sb, []byte(c))
+		sb.WriteString("</t></is></c>")
+	}
+	sb.WriteString("</row>")
+}
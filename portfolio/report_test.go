@@ -0,0 +1,132 @@
+package portfolio
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReport(t *testing.T) {
+	expires := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	org := "Example GmbH"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/domains":
+			_ = json.NewEncoder(w).Encode(models.DomainListResponse{Results: []models.Domain{
+				{
+					Name: "example.com", ExpiresOn: &expires, RenewalMode: models.RenewalModeRenew, TransferLock: true,
+					Nameservers: []models.Nameserver{{Hostname: "ns1.opusdns.net"}, {Hostname: "ns2.opusdns.net"}},
+					Contacts: []models.DomainContact{
+						{ContactID: "contact_admin", ContactType: models.DomainContactTypeAdmin},
+						{ContactID: "contact_1", ContactType: models.DomainContactTypeRegistrant},
+					},
+				},
+				{Name: "external.org", RenewalMode: models.RenewalModeExpire, Nameservers: []models.Nameserver{{Hostname: "ns.other.net"}}},
+			}})
+		case "/v1/dns":
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: []models.Zone{
+				{Name: "example.com", DNSSECStatus: models.DNSSECStatusEnabled},
+			}})
+		case "/v1/contacts":
+			_ = json.NewEncoder(w).Encode(models.ContactListResponse{Results: []models.Contact{
+				{ContactID: "contact_1", FirstName: "Erika", LastName: "Mustermann", Org: &org, Email: "erika@example.com"},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+
+	rows, err := BuildReport(context.Background(), client, nil)
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, ReportRow{
+		Name:            "example.com",
+		ExpiresOn:       rows[0].ExpiresOn,
+		AutoRenew:       true,
+		TransferLock:    true,
+		Nameservers:     []string{"ns1.opusdns.net", "ns2.opusdns.net"},
+		DNSHosted:       true,
+		DNSSEC:          models.DNSSECStatusEnabled,
+		RegistrantID:    "contact_1",
+		Registrant:      "Erika Mustermann (Example GmbH)",
+		RegistrantEmail: "erika@example.com",
+	}, rows[0])
+	require.NotNil(t, rows[0].ExpiresOn)
+	assert.True(t, expires.Equal(*rows[0].ExpiresOn))
+	assert.Equal(t, ReportRow{Name: "external.org", Nameservers: []string{"ns.other.net"}}, rows[1])
+}
+
+func TestWriteReport(t *testing.T) {
+	expires := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []ReportRow{{
+		Name: "example.com", ExpiresOn: &expires, AutoRenew: true,
+		Nameservers: []string{"ns1.opusdns.net", "ns2.opusdns.net"}, Registrant: "A & B <Ltd>",
+	}}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteReport(&buf, rows, ReportFormatCSV))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, reportHeader, records[0])
+		assert.Equal(t, []string{"example.com", "2027-03-01T12:00:00Z", "true", "false", "ns1.opusdns.net ns2.opusdns.net", "false", "", "", "A & B <Ltd>", ""}, records[1])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteReport(&buf, rows, ReportFormatJSON))
+
+		var decoded []ReportRow
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Len(t, decoded, 1)
+		assert.Equal(t, "example.com", decoded[0].Name)
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteReport(&buf, rows, ReportFormatXLSX))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		var names []string
+		var sheet []byte
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				sheet, err = io.ReadAll(rc)
+				require.NoError(t, err)
+				_ = rc.Close()
+			}
+		}
+		assert.Contains(t, names, "[Content_Types].xml")
+		assert.Contains(t, names, "xl/workbook.xml")
+		assert.Contains(t, string(sheet), "<t xml:space=\"preserve\">A &amp; B &lt;Ltd&gt;</t>")
+		assert.Equal(t, 2, bytes.Count(sheet, []byte("<row>")))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Error(t, WriteReport(io.Discard, rows, "pdf"))
+	})
+}
@@ -0,0 +1,123 @@
+package externaldns
+
+import "strings"
+
+// The types below mirror those of sigs.k8s.io/external-dns (endpoint.Endpoint,
+// plan.Changes and endpoint.DomainFilter) field by field and with the same
+// JSON encoding, so this package does not depend on external-dns and its
+// Kubernetes dependencies. Converting between the two is a matter of copying
+// the fields, or of encoding to JSON as the webhook does.
+
+// Endpoint is a DNS name with its targets, the unit external-dns plans with.
+// It corresponds to one RRset.
+type Endpoint struct {
+	// DNSName is the fully qualified name, without a trailing dot.
+	DNSName string `json:"dnsName,omitempty"`
+
+	// Targets are the record data values. TXT targets are the logical values,
+	// without quotes, and host names in targets carry no trailing dot.
+	Targets []string `json:"targets,omitempty"`
+
+	// RecordType is the record type, such as "A" or "CNAME".
+	RecordType string `json:"recordType,omitempty"`
+
+	// SetIdentifier distinguishes endpoints of the same name and type for
+	// routing policies. OpusDNS has no routing policies, so it is unused.
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+
+	// RecordTTL is the TTL in seconds (0 for the client's default TTL).
+	RecordTTL int64 `json:"recordTTL,omitempty"`
+
+	// Labels are the external-dns labels, such as the owner.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ProviderSpecific are provider-specific properties, ignored by OpusDNS.
+	ProviderSpecific []ProviderSpecificProperty `json:"providerSpecific,omitempty"`
+}
+
+// ProviderSpecificProperty is a provider-specific property of an Endpoint.
+type ProviderSpecificProperty struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Changes are the endpoints external-dns wants created, updated and deleted.
+// UpdateOld and UpdateNew hold the current and desired state of the same
+// endpoints.
+type Changes struct {
+	Create    []*Endpoint `json:"create,omitempty"`
+	UpdateOld []*Endpoint `json:"updateOld,omitempty"`
+	UpdateNew []*Endpoint `json:"updateNew,omitempty"`
+	Delete    []*Endpoint `json:"delete,omitempty"`
+}
+
+// DomainFilter lists the domains a provider manages. A name matches if it
+// equals or is a subdomain of an included domain and of no excluded one; an
+// empty Include matches every name.
+type DomainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Match reports whether name is matched by the filter.
+func (f DomainFilter) Match(name string) bool {
+	for _, d := range f.Exclude {
+		if inDomain(name, d) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, d := range f.Include {
+		if inDomain(name, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether the filter may match names inside zone, that is
+// whether zone is matched or contains an included domain.
+func (f DomainFilter) overlaps(zone string) bool {
+	if f.Match(zone) {
+		return true
+	}
+	for _, d := range f.Include {
+		if inDomain(d, zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerLabel is the key of the owner in external-dns ownership records.
+const ownerLabel = "external-dns/owner"
+
+// ParseOwner parses the value of an external-dns ownership TXT record, such as
+// "heritage=external-dns,external-dns/owner=default". It returns the owner ID,
+// and false if value is not an ownership record. Surrounding quotes are
+// ignored.
+func ParseOwner(value string) (string, bool) {
+	value = strings.Trim(value, `"`)
+	if !strings.HasPrefix(value, "heritage=external-dns,") {
+		return "", false
+	}
+	for _, label := range strings.Split(value, ",") {
+		if k, v, ok := strings.Cut(label, "="); ok && k == ownerLabel {
+			return v, true
+		}
+	}
+	return "", true
+}
+
+// normalizeName lowercases name and removes a trailing dot.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// inDomain reports whether name equals or is a subdomain of domain.
+func inDomain(name, domain string) bool {
+	name, domain = normalizeName(name), normalizeName(domain)
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
@@ -0,0 +1,29 @@
+package externaldns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainFilter_Match(t *testing.T) {
+	filter := DomainFilter{Include: []string{"example.com"}, Exclude: []string{"internal.example.com"}}
+
+	assert.True(t, filter.Match("example.com"))
+	assert.True(t, filter.Match("WWW.example.com."))
+	assert.False(t, filter.Match("badexample.com"))
+	assert.False(t, filter.Match("db.internal.example.com"))
+	assert.True(t, DomainFilter{}.Match("example.org"))
+
+	assert.True(t, DomainFilter{Include: []string{"www.example.com"}}.overlaps("example.com"))
+	assert.False(t, DomainFilter{Include: []string{"www.example.com"}}.overlaps("example.org"))
+}
+
+func TestParseOwner(t *testing.T) {
+	owner, ok := ParseOwner(`"heritage=external-dns,external-dns/owner=default,external-dns/resource=ingress/default/web"`)
+	assert.True(t, ok)
+	assert.Equal(t, "default", owner)
+
+	_, ok = ParseOwner("v=spf1 -all")
+	assert.False(t, ok)
+}
@@ -0,0 +1,380 @@
+// Package externaldns implements an external-dns provider on top of
+// opusdns.DNSService, so Kubernetes clusters running external-dns can manage
+// OpusDNS records.
+//
+// Provider has the method set of the external-dns provider.Provider
+// interface (Records, ApplyChanges, AdjustEndpoints and GetDomainFilter) on
+// types mirroring those of external-dns. External-dns runs out-of-tree
+// providers as webhooks, which NewWebhook serves.
+package externaldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+)
+
+// supportedTypes are the record types managed through external-dns.
+var supportedTypes = map[models.RRSetType]bool{
+	models.RRSetTypeA:     true,
+	models.RRSetTypeAAAA:  true,
+	models.RRSetTypeCNAME: true,
+	models.RRSetTypeMX:    true,
+	models.RRSetTypeNS:    true,
+	models.RRSetTypeSRV:   true,
+	models.RRSetTypeTXT:   true,
+}
+
+// ErrNotOwned is returned by ApplyChanges for a change to a record that is
+// not owned by the provider's owner ID.
+var ErrNotOwned = errors.New("externaldns: record is not owned by this instance")
+
+// Provider is an external-dns provider backed by an opusdns.Client. Each
+// endpoint is one RRset; the changes of one ApplyChanges call are applied
+// with one atomic RRset patch per zone.
+type Provider struct {
+	client    *opusdns.Client
+	filter    DomainFilter
+	ownerID   string
+	txtPrefix string
+}
+
+// Option configures a Provider.
+type Option func(*Provider)
+
+// WithDomainFilter limits the provider to the zones and names matched by
+// filter, which should match the --domain-filter given to external-dns.
+func WithDomainFilter(filter DomainFilter) Option {
+	return func(p *Provider) {
+		p.filter = filter
+	}
+}
+
+// WithOwnerID makes ApplyChanges refuse to update or delete records that are
+// not owned by ownerID according to the ownership TXT records of the
+// external-dns TXT registry. External-dns does not plan such changes itself,
+// so this guards against clusters misconfigured with a shared owner ID or
+// without a registry overwriting each other's records.
+func WithOwnerID(ownerID string) Option {
+	return func(p *Provider) {
+		p.ownerID = ownerID
+	}
+}
+
+// WithTXTPrefix sets the --txt-prefix given to external-dns, used to find
+// ownership records for WithOwnerID.
+func WithTXTPrefix(prefix string) Option {
+	return func(p *Provider) {
+		p.txtPrefix = prefix
+	}
+}
+
+// NewProvider creates a provider managing the zones of client.
+func NewProvider(client *opusdns.Client, opts ...Option) *Provider {
+	p := &Provider{client: client}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// GetDomainFilter returns the domains the provider manages.
+func (p *Provider) GetDomainFilter() DomainFilter {
+	return p.filter
+}
+
+// Records returns the endpoints of all managed zones. The SOA record, the
+// NS records at zone apexes and record types external-dns does not manage are
+// left out.
+func (p *Provider) Records(ctx context.Context) ([]*Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*Endpoint{}
+	for _, name := range zones {
+		zone, err := p.client.DNS.GetZone(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("externaldns: failed to get zone %s: %w", name, err)
+		}
+		endpoints = append(endpoints, p.zoneEndpoints(zone)...)
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints normalizes the desired endpoints to the form Records
+// returns, so that external-dns does not plan changes for records that are
+// already up to date: names are lowercased, a zero TTL becomes the client's
+// default TTL, TXT targets are unquoted and host names lose their trailing
+// dot. Endpoints of unsupported record types are dropped.
+func (p *Provider) AdjustEndpoints(endpoints []*Endpoint) ([]*Endpoint, error) {
+	adjusted := make([]*Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		rrtype := models.RRSetType(strings.ToUpper(ep.RecordType))
+		if !supportedTypes[rrtype] {
+			continue
+		}
+
+		a := *ep
+		a.DNSName = normalizeName(ep.DNSName)
+		a.RecordType = string(rrtype)
+		if a.RecordTTL <= 0 {
+			a.RecordTTL = int64(p.client.DefaultTTL())
+		}
+		a.Targets = make([]string, len(ep.Targets))
+		for i, t := range ep.Targets {
+			a.Targets[i] = fromRData(rrtype, t)
+		}
+		sort.Strings(a.Targets)
+		adjusted = append(adjusted, &a)
+	}
+	return adjusted, nil
+}
+
+// ApplyChanges applies the changes with one atomic RRset patch per zone,
+// removals first. Zones are patched independently, so when one fails the
+// others are still applied; the failures are joined in the returned error.
+func (p *Provider) ApplyChanges(ctx context.Context, changes *Changes) error {
+	if changes == nil {
+		return nil
+	}
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	if p.ownerID != "" {
+		if err := p.checkOwnership(ctx, changes); err != nil {
+			return err
+		}
+	}
+
+	ops := map[string][]models.RRSetPatchOp{}
+	add := func(op models.RecordPatchOp, ep *Endpoint) error {
+		zone := zoneFor(zones, ep.DNSName)
+		if zone == "" {
+			return fmt.Errorf("externaldns: no managed zone contains %s", ep.DNSName)
+		}
+		ops[zone] = append(ops[zone], models.RRSetPatchOp{Op: op, RRSet: p.rrset(zone, ep)})
+		return nil
+	}
+
+	updated := map[string]bool{}
+	for _, ep := range changes.UpdateNew {
+		updated[endpointKey(ep)] = true
+	}
+	var errs []error
+	for _, ep := range changes.Delete {
+		errs = append(errs, add(models.RecordOpRemove, ep))
+	}
+	for _, ep := range changes.UpdateOld {
+		// An upsert replaces the RRset, so only renamed or retyped endpoints need removing
+		if !updated[endpointKey(ep)] {
+			errs = append(errs, add(models.RecordOpRemove, ep))
+		}
+	}
+	for _, ep := range append(append([]*Endpoint{}, changes.Create...), changes.UpdateNew...) {
+		errs = append(errs, add(models.RecordOpUpsert, ep))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(ops))
+	for zone := range ops {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	for _, zone := range names {
+		if err := p.client.DNS.PatchRRSets(ctx, zone, ops[zone]); err != nil {
+			errs = append(errs, fmt.Errorf("externaldns: failed to apply changes to zone %s: %w", zone, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// zones returns the names of the zones overlapping the domain filter.
+func (p *Provider) zones(ctx context.Context) ([]string, error) {
+	zones, err := p.client.DNS.ListZones(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("externaldns: failed to list zones: %w", err)
+	}
+
+	var names []string
+	for _, z := range zones {
+		if name := normalizeName(z.Name); p.filter.overlaps(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// zoneEndpoints converts the managed RRsets of zone to endpoints.
+func (p *Provider) zoneEndpoints(zone *models.Zone) []*Endpoint {
+	zoneName := normalizeName(zone.Name)
+
+	var endpoints []*Endpoint
+	for _, rrset := range zone.RRSets {
+		if !supportedTypes[rrset.Type] {
+			continue
+		}
+		name := absoluteName(rrset.Name, zoneName)
+		if (rrset.Type == models.RRSetTypeNS && name == zoneName) || !p.filter.Match(name) {
+			continue
+		}
+
+		ep := &Endpoint{DNSName: name, RecordType: string(rrset.Type), RecordTTL: int64(rrset.TTL)}
+		for _, r := range rrset.Records {
+			ep.Targets = append(ep.Targets, fromRData(rrset.Type, r.RData))
+		}
+		sort.Strings(ep.Targets)
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
+
+// rrset converts an endpoint to an RRset of zone.
+func (p *Provider) rrset(zone string, ep *Endpoint) models.RRSetPatch {
+	rrtype := models.RRSetType(strings.ToUpper(ep.RecordType))
+	ttl := int(ep.RecordTTL)
+	if ttl <= 0 {
+		ttl = p.client.DefaultTTL()
+	}
+
+	rrset := models.RRSetPatch{Name: relativeName(ep.DNSName, zone), Type: rrtype, TTL: ttl}
+	for _, t := range ep.Targets {
+		rrset.Records = append(rrset.Records, models.RecordCreate{RData: toRData(rrtype, t)})
+	}
+	return rrset
+}
+
+// checkOwnership returns an error matching ErrNotOwned for each updated or
+// deleted endpoint not owned by the provider's owner ID. An ownership record
+// is owned if it names the owner ID; another endpoint is owned if one of its
+// ownership records, named like the endpoint or in the "<type>-<name>" format
+// of newer external-dns versions (after the TXT prefix), names the owner ID.
+func (p *Provider) checkOwnership(ctx context.Context, changes *Changes) error {
+	current, err := p.Records(ctx)
+	if err != nil {
+		return err
+	}
+	owners := map[string][]string{}
+	for _, ep := range current {
+		if ep.RecordType != string(models.RRSetTypeTXT) {
+			continue
+		}
+		for _, t := range ep.Targets {
+			if owner, ok := ParseOwner(t); ok {
+				owners[ep.DNSName] = append(owners[ep.DNSName], owner)
+			}
+		}
+	}
+
+	var errs []error
+	for _, ep := range append(append([]*Endpoint{}, changes.UpdateOld...), changes.Delete...) {
+		if !p.owned(ep, owners) {
+			errs = append(errs, fmt.Errorf("%w: %s %s", ErrNotOwned, ep.RecordType, ep.DNSName))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// owned reports whether ep is owned by the provider's owner ID.
+func (p *Provider) owned(ep *Endpoint, owners map[string][]string) bool {
+	if strings.EqualFold(ep.RecordType, string(models.RRSetTypeTXT)) {
+		for _, t := range ep.Targets {
+			if owner, ok := ParseOwner(t); ok {
+				return owner == p.ownerID
+			}
+		}
+	}
+
+	name := normalizeName(ep.DNSName)
+	prefix := strings.ToLower(p.txtPrefix)
+	for _, candidate := range []string{
+		prefix + name,
+		prefix + strings.ToLower(ep.RecordType) + "-" + name,
+	} {
+		for _, owner := range owners[candidate] {
+			if owner == p.ownerID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// zoneFor returns the most specific of zones containing name, or "".
+func zoneFor(zones []string, name string) string {
+	var best string
+	for _, zone := range zones {
+		if inDomain(name, zone) && len(zone) > len(best) {
+			best = zone
+		}
+	}
+	return best
+}
+
+// endpointKey identifies the RRset of an endpoint.
+func endpointKey(ep *Endpoint) string {
+	return normalizeName(ep.DNSName) + " " + strings.ToUpper(ep.RecordType)
+}
+
+// absoluteName returns the fully qualified form of an RRset name of zone.
+func absoluteName(name, zone string) string {
+	switch {
+	case name == "" || name == "@":
+		return zone
+	case strings.HasSuffix(name, "."):
+		return normalizeName(name)
+	default:
+		return strings.ToLower(name) + "." + zone
+	}
+}
+
+// relativeName returns name relative to zone, "@" for the apex.
+func relativeName(name, zone string) string {
+	name = normalizeName(name)
+	if name == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+// hostTarget reports whether the targets of rrtype end in a host name.
+func hostTarget(rrtype models.RRSetType) bool {
+	switch rrtype {
+	case models.RRSetTypeCNAME, models.RRSetTypeMX, models.RRSetTypeNS, models.RRSetTypeSRV:
+		return true
+	}
+	return false
+}
+
+// fromRData converts record data to an external-dns target.
+func fromRData(rrtype models.RRSetType, rdata string) string {
+	switch {
+	case rrtype == models.RRSetTypeTXT:
+		return models.ParseTXTData(rdata).String()
+	case hostTarget(rrtype):
+		return strings.TrimSuffix(rdata, ".")
+	}
+	return rdata
+}
+
+// toRData converts an external-dns target to record data.
+func toRData(rrtype models.RRSetType, target string) string {
+	switch {
+	case rrtype == models.RRSetTypeTXT:
+		return models.ParseTXTData(target).RData()
+	case hostTarget(rrtype) && !strings.HasSuffix(target, "."):
+		return target + "."
+	}
+	return target
+}
@@ -0,0 +1,183 @@
+package externaldns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/opusdns/opusdns-go-client/opusdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDNS fakes the zone endpoints used by the provider.
+type fakeDNS struct {
+	mu      sync.Mutex
+	zones   map[string][]models.RRSet
+	patches map[string][]models.RRSetPatchOp
+}
+
+func (f *fakeDNS) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/dns":
+			var zones []models.Zone
+			for name := range f.zones {
+				zones = append(zones, models.Zone{Name: name + "."})
+			}
+			_ = json.NewEncoder(w).Encode(models.ZoneListResponse{Results: zones})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/dns/"):
+			name := strings.TrimPrefix(r.URL.Path, "/v1/dns/")
+			_ = json.NewEncoder(w).Encode(models.Zone{Name: name + ".", RRSets: f.zones[name]})
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/rrsets"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/dns/"), "/rrsets")
+			var req models.RRSetPatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if f.patches == nil {
+				f.patches = map[string][]models.RRSetPatchOp{}
+			}
+			f.patches[name] = append(f.patches[name], req.Ops...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func newTestProvider(t *testing.T, fake *fakeDNS, opts ...Option) *Provider {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	client, err := opusdns.NewClient(opusdns.WithAPIKey("opk_test"), opusdns.WithAPIEndpoint(server.URL))
+	require.NoError(t, err)
+	return NewProvider(client, opts...)
+}
+
+func testZones() map[string][]models.RRSet {
+	return map[string][]models.RRSet{
+		"example.com": {
+			{Name: "@", Type: models.RRSetTypeSOA, TTL: 3600, Records: []models.RecordData{{RData: "ns1.opusdns.net. hostmaster.example.com. 1 7200 900 1209600 3600"}}},
+			{Name: "@", Type: models.RRSetTypeNS, TTL: 86400, Records: []models.RecordData{{RData: "ns1.opusdns.net."}}},
+			{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordData{{RData: "192.0.2.2"}, {RData: "192.0.2.1"}}},
+			{Name: "api", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordData{{RData: "lb.example.net."}}},
+			{Name: "a-www", Type: models.RRSetTypeTXT, TTL: 300, Records: []models.RecordData{{RData: `"heritage=external-dns,external-dns/owner=cluster-a"`}}},
+			{Name: "api", Type: models.RRSetTypeTXT, TTL: 300, Records: []models.RecordData{{RData: `"heritage=external-dns,external-dns/owner=cluster-b"`}}},
+			{Name: "@", Type: models.RRSetTypeCAA, TTL: 3600, Records: []models.RecordData{{RData: `0 issue "letsencrypt.org"`}}},
+		},
+		"example.org": {
+			{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordData{{RData: "198.51.100.1"}}},
+		},
+	}
+}
+
+func TestProvider_Records(t *testing.T) {
+	t.Run("converts managed RRsets", func(t *testing.T) {
+		p := newTestProvider(t, &fakeDNS{zones: testZones()}, WithDomainFilter(DomainFilter{Include: []string{"example.com"}}))
+
+		endpoints, err := p.Records(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []*Endpoint{
+			{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"192.0.2.1", "192.0.2.2"}},
+			{DNSName: "api.example.com", RecordType: "CNAME", RecordTTL: 300, Targets: []string{"lb.example.net"}},
+			{DNSName: "a-www.example.com", RecordType: "TXT", RecordTTL: 300, Targets: []string{"heritage=external-dns,external-dns/owner=cluster-a"}},
+			{DNSName: "api.example.com", RecordType: "TXT", RecordTTL: 300, Targets: []string{"heritage=external-dns,external-dns/owner=cluster-b"}},
+		}, endpoints)
+	})
+
+	t.Run("filters names inside a zone", func(t *testing.T) {
+		p := newTestProvider(t, &fakeDNS{zones: testZones()}, WithDomainFilter(DomainFilter{Include: []string{"www.example.org"}}))
+
+		endpoints, err := p.Records(context.Background())
+		require.NoError(t, err)
+		require.Len(t, endpoints, 1)
+		assert.Equal(t, "www.example.org", endpoints[0].DNSName)
+	})
+}
+
+func TestProvider_AdjustEndpoints(t *testing.T) {
+	p := newTestProvider(t, &fakeDNS{})
+
+	adjusted, err := p.AdjustEndpoints([]*Endpoint{
+		{DNSName: "WWW.Example.com.", RecordType: "a", Targets: []string{"192.0.2.1"}},
+		{DNSName: "www.example.com", RecordType: "TXT", RecordTTL: 300, Targets: []string{`"heritage=external-dns,external-dns/owner=default"`}},
+		{DNSName: "api.example.com", RecordType: "CNAME", RecordTTL: 300, Targets: []string{"lb.example.net."}},
+		{DNSName: "example.com", RecordType: "CAA", Targets: []string{`0 issue "letsencrypt.org"`}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Endpoint{
+		{DNSName: "www.example.com", RecordType: "A", RecordTTL: opusdns.DefaultTTL, Targets: []string{"192.0.2.1"}},
+		{DNSName: "www.example.com", RecordType: "TXT", RecordTTL: 300, Targets: []string{"heritage=external-dns,external-dns/owner=default"}},
+		{DNSName: "api.example.com", RecordType: "CNAME", RecordTTL: 300, Targets: []string{"lb.example.net"}},
+	}, adjusted)
+}
+
+func TestProvider_ApplyChanges(t *testing.T) {
+	t.Run("patches each zone", func(t *testing.T) {
+		fake := &fakeDNS{zones: testZones()}
+		p := newTestProvider(t, fake)
+
+		err := p.ApplyChanges(context.Background(), &Changes{
+			Create:    []*Endpoint{{DNSName: "new.example.org", RecordType: "TXT", RecordTTL: 60, Targets: []string{"hello"}}},
+			UpdateOld: []*Endpoint{{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"192.0.2.1", "192.0.2.2"}}},
+			UpdateNew: []*Endpoint{{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"192.0.2.3"}}},
+			Delete:    []*Endpoint{{DNSName: "api.example.com", RecordType: "CNAME", RecordTTL: 300, Targets: []string{"lb.example.net"}}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]models.RRSetPatchOp{
+			"example.com": {
+				{Op: models.RecordOpRemove, RRSet: models.RRSetPatch{Name: "api", Type: models.RRSetTypeCNAME, TTL: 300, Records: []models.RecordCreate{{RData: "lb.example.net."}}}},
+				{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "www", Type: models.RRSetTypeA, TTL: 300, Records: []models.RecordCreate{{RData: "192.0.2.3"}}}},
+			},
+			"example.org": {
+				{Op: models.RecordOpUpsert, RRSet: models.RRSetPatch{Name: "new", Type: models.RRSetTypeTXT, TTL: 60, Records: []models.RecordCreate{{RData: `"hello"`}}}},
+			},
+		}, fake.patches)
+	})
+
+	t.Run("rejects names outside managed zones", func(t *testing.T) {
+		fake := &fakeDNS{zones: testZones()}
+		p := newTestProvider(t, fake)
+
+		err := p.ApplyChanges(context.Background(), &Changes{
+			Create: []*Endpoint{{DNSName: "www.example.net", RecordType: "A", Targets: []string{"192.0.2.1"}}},
+		})
+		assert.ErrorContains(t, err, "no managed zone contains www.example.net")
+		assert.Empty(t, fake.patches)
+	})
+
+	t.Run("enforces ownership", func(t *testing.T) {
+		fake := &fakeDNS{zones: testZones()}
+		p := newTestProvider(t, fake, WithOwnerID("cluster-a"))
+
+		// www is owned through the "a-www" record of newer external-dns versions
+		err := p.ApplyChanges(context.Background(), &Changes{
+			Delete: []*Endpoint{{DNSName: "www.example.com", RecordType: "A", Targets: []string{"192.0.2.1", "192.0.2.2"}}},
+		})
+		require.NoError(t, err)
+
+		err = p.ApplyChanges(context.Background(), &Changes{
+			Delete: []*Endpoint{
+				{DNSName: "api.example.com", RecordType: "CNAME", Targets: []string{"lb.example.net"}},
+				{DNSName: "api.example.com", RecordType: "TXT", Targets: []string{"heritage=external-dns,external-dns/owner=cluster-b"}},
+			},
+		})
+		assert.True(t, errors.Is(err, ErrNotOwned))
+		assert.ErrorContains(t, err, "CNAME api.example.com")
+		assert.ErrorContains(t, err, "TXT api.example.com")
+		assert.Len(t, fake.patches["example.com"], 1)
+	})
+}
@@ -0,0 +1,102 @@
+package externaldns
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// MediaType is the media type of the external-dns webhook protocol.
+const MediaType = "application/external.dns.webhook+json;version=1"
+
+// Webhook serves a Provider over the external-dns webhook protocol, for
+// external-dns started with --provider=webhook. It is usually run as a
+// sidecar listening on localhost:8888, external-dns's default webhook URL:
+//
+//	GET  /                 negotiation; returns the domain filter
+//	GET  /records          Records
+//	POST /records          ApplyChanges
+//	POST /adjustendpoints  AdjustEndpoints
+//	GET  /healthz          liveness
+type Webhook struct {
+	provider *Provider
+	mux      *http.ServeMux
+}
+
+// NewWebhook creates a webhook handler for provider.
+func NewWebhook(provider *Provider) *Webhook {
+	w := &Webhook{provider: provider, mux: http.NewServeMux()}
+	w.mux.HandleFunc("/", w.negotiate)
+	w.mux.HandleFunc("/records", w.records)
+	w.mux.HandleFunc("/adjustendpoints", w.adjustEndpoints)
+	w.mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	return w
+}
+
+// ServeHTTP implements http.Handler.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mux.ServeHTTP(rw, r)
+}
+
+func (w *Webhook) negotiate(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(rw, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(rw, w.provider.GetDomainFilter())
+}
+
+func (w *Webhook) records(rw http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		endpoints, err := w.provider.Records(r.Context())
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(rw, endpoints)
+	case http.MethodPost:
+		var changes Changes
+		if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := w.provider.ApplyChanges(r.Context(), &changes); err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (w *Webhook) adjustEndpoints(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var endpoints []*Endpoint
+	if err := json.NewDecoder(r.Body).Decode(&endpoints); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	adjusted, err := w.provider.AdjustEndpoints(endpoints)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(rw, adjusted)
+}
+
+// writeJSON writes v as a webhook response.
+func writeJSON(rw http.ResponseWriter, v any) {
+	rw.Header().Set("Content-Type", MediaType)
+	rw.Header().Set("Vary", "Content-Type")
+	_ = json.NewEncoder(rw).Encode(v)
+}
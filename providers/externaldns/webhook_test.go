@@ -0,0 +1,63 @@
+package externaldns
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opusdns/opusdns-go-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	fake := &fakeDNS{zones: testZones()}
+	webhook := NewWebhook(newTestProvider(t, fake, WithDomainFilter(DomainFilter{Include: []string{"example.org"}})))
+
+	serve := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Accept", MediaType)
+		rec := httptest.NewRecorder()
+		webhook.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("negotiates", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MediaType, rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"include":["example.org"]}`, rec.Body.String())
+	})
+
+	t.Run("lists records", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/records", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"dnsName":"www.example.org","recordType":"A","recordTTL":300,"targets":["198.51.100.1"]}]`, rec.Body.String())
+	})
+
+	t.Run("applies changes", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/records", Changes{
+			Delete: []*Endpoint{{DNSName: "www.example.org", RecordType: "A", Targets: []string{"198.51.100.1"}}},
+		})
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		require.Len(t, fake.patches["example.org"], 1)
+		assert.Equal(t, models.RecordOpRemove, fake.patches["example.org"][0].Op)
+	})
+
+	t.Run("adjusts endpoints", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/adjustendpoints", []*Endpoint{{DNSName: "WWW.example.org", RecordType: "A", RecordTTL: 60, Targets: []string{"198.51.100.2"}}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"dnsName":"www.example.org","recordType":"A","recordTTL":60,"targets":["198.51.100.2"]}]`, rec.Body.String())
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "/records", nil).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/unknown", nil).Code)
+	})
+}